@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+var (
+	// emailAddressRE matches an email address in rendered text.
+	emailAddressRE = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// bracketedEmailRE matches a "<email>" as written after a name in
+	// Co-authored-by trailers and git identities.
+	bracketedEmailRE = regexp.MustCompile(`\s*<([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})>`)
+)
+
+// emailAnonymizer replaces email addresses with names for --anonymize. The
+// user's own addresses become the configured name, other known developers
+// their name from the developers table, and unknown addresses initials
+// derived from the address.
+type emailAnonymizer struct {
+	names map[string]string // lower-cased email -> replacement
+}
+
+// newEmailAnonymizer builds the email-to-name mapping from the developers
+// table and the profile. A database error leaves only the profile's
+// mapping, so anonymizing never fails; unknown addresses still become
+// initials.
+func newEmailAnonymizer(ctx context.Context, dbRepo *db.SQLRepository, cfg *config.Config) *emailAnonymizer {
+	a := &emailAnonymizer{names: make(map[string]string)}
+	userName := strings.TrimSpace(cfg.GetEffectiveUserName())
+
+	if dbRepo != nil {
+		devs, err := dbRepo.ListDevelopers(ctx)
+		if err != nil {
+			VerboseLog("Warning: failed to load developers for anonymizing: %v", err)
+		}
+		for _, dev := range devs {
+			name := strings.TrimSpace(dev.Name)
+			if dev.IsCurrentUser && userName != "" {
+				name = userName
+			}
+			if name == "" || strings.Contains(name, "@") {
+				continue
+			}
+			a.names[strings.ToLower(dev.Email)] = name
+		}
+	}
+	if email := strings.TrimSpace(cfg.GetEffectiveUserEmail()); email != "" && userName != "" {
+		a.names[strings.ToLower(email)] = userName
+	}
+	return a
+}
+
+// Name returns what email is shown as.
+func (a *emailAnonymizer) Name(email string) string {
+	if name, ok := a.names[strings.ToLower(strings.TrimSpace(email))]; ok {
+		return name
+	}
+	return emailInitials(email)
+}
+
+// Replace rewrites every email address in text. "Name <email>" becomes just
+// "Name" when the address maps to that same name.
+func (a *emailAnonymizer) Replace(text string) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range bracketedEmailRE.FindAllStringSubmatchIndex(text, -1) {
+		name := a.Name(text[m[2]:m[3]])
+		before := strings.ToLower(strings.TrimSpace(text[:m[0]]))
+		sb.WriteString(text[last:m[0]])
+		if !strings.HasSuffix(before, strings.ToLower(name)) {
+			sb.WriteString(" <" + name + ">")
+		}
+		last = m[1]
+	}
+	sb.WriteString(text[last:])
+	return emailAddressRE.ReplaceAllStringFunc(sb.String(), a.Name)
+}
+
+// emailInitials abbreviates an address by its local part, e.g.
+// "jane.doe@example.com" -> "J.D." and "jdoe@example.com" -> "J.". A GitHub
+// noreply address uses the username instead.
+func emailInitials(email string) string {
+	if username := extractGitHubUsername(email); username != "" {
+		email = username
+	}
+	local, _, _ := strings.Cut(email, "@")
+	parts := strings.FieldsFunc(local, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	var sb strings.Builder
+	for _, part := range parts {
+		sb.WriteRune(unicode.ToUpper([]rune(part)[0]))
+		sb.WriteString(".")
+	}
+	if sb.Len() == 0 {
+		return "(anonymous)"
+	}
+	return sb.String()
+}
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+// defaultBotAuthorPatterns match the author emails of common dependency and
+// CI bots.
+var defaultBotAuthorPatterns = []string{
+	`\[bot\]@`,
+	`^bot@renovateapp\.com$`,
+}
+
+// defaultBotMessagePatterns match commit messages written by dependency and
+// CI bots, which can end up under the user's identity after a rebase.
+var defaultBotMessagePatterns = []string{
+	`^Bump \S+ from \S+ to \S+`,
+	`^(chore|build)\(deps(-dev)?\): (bump|update) `,
+	`^Update dependency \S+ to `,
+}
+
+// botFilter flags commits authored or generated by bots.
+type botFilter struct {
+	authors  []*regexp.Regexp
+	messages []*regexp.Regexp
+}
+
+// newBotFilter compiles the built-in patterns plus the active profile's.
+func newBotFilter(cfg *config.Config) (*botFilter, error) {
+	authorPatterns, messagePatterns := cfg.GetBotFilters()
+	authors, err := compileBotPatterns(append(append([]string{}, defaultBotAuthorPatterns...), authorPatterns...))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bot author pattern: %w", err)
+	}
+	messages, err := compileBotPatterns(append(append([]string{}, defaultBotMessagePatterns...), messagePatterns...))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bot message pattern: %w", err)
+	}
+	return &botFilter{authors: authors, messages: messages}, nil
+}
+
+func compileBotPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// isBot reports whether a commit's author email or subject line matches a
+// bot pattern.
+func (f *botFilter) isBot(authorEmail, message string) bool {
+	if f == nil {
+		return false
+	}
+	for _, re := range f.authors {
+		if re.MatchString(authorEmail) {
+			return true
+		}
+	}
+	subject := strings.TrimSpace(strings.SplitN(strings.TrimSpace(message), "\n", 2)[0])
+	for _, re := range f.messages {
+		if re.MatchString(subject) {
+			return true
+		}
+	}
+	return false
+}
+
+var botFiltersMessage bool
+
+var profileBotFiltersCmd = &cobra.Command{
+	Use:   "bot-filters",
+	Short: "Show the patterns that mark commits as bot commits",
+	Long: `Show and edit the patterns that mark commits as bot commits.
+
+Patterns are case-insensitive regular expressions matched against the author
+email, or against the commit subject with --message. Commits that match are
+flagged during ingest and left out of worklogs unless --include-bots is used.
+Built-in patterns cover dependabot, renovate and CI skip markers.
+
+Examples:
+  devlog profile bot-filters                                 # List patterns
+  devlog profile bot-filters add 'ci@mycompany\.com'         # Match an author
+  devlog profile bot-filters add --message '^Release v\d+'   # Match a subject
+  devlog profile bot-filters remove 'ci@mycompany\.com'`,
+	Args: cobra.NoArgs,
+	RunE: runProfileBotFilters,
+}
+
+var profileBotFiltersAddCmd = &cobra.Command{
+	Use:   "add <pattern>",
+	Short: "Add a bot filter pattern to the active profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileBotFiltersAdd,
+}
+
+var profileBotFiltersRemoveCmd = &cobra.Command{
+	Use:   "remove <pattern>",
+	Short: "Remove a bot filter pattern from the active profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileBotFiltersRemove,
+}
+
+func init() {
+	profileCmd.AddCommand(profileBotFiltersCmd)
+	profileBotFiltersCmd.AddCommand(profileBotFiltersAddCmd)
+	profileBotFiltersCmd.AddCommand(profileBotFiltersRemoveCmd)
+
+	for _, c := range []*cobra.Command{profileBotFiltersAddCmd, profileBotFiltersRemoveCmd} {
+		c.Flags().BoolVar(&botFiltersMessage, "message", false, "Match the commit subject instead of the author email")
+	}
+}
+
+func runProfileBotFilters(cmd *cobra.Command, args []string) error {
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	authorPatterns, messagePatterns := cfg.GetBotFilters()
+
+	printPatterns := func(label string, builtin, custom []string) {
+		fmt.Println()
+		titleColor.Printf("  %s\n", label)
+		for _, p := range builtin {
+			dimColor.Printf("    %s (built-in)\n", p)
+		}
+		for _, p := range custom {
+			infoColor.Printf("    %s\n", p)
+		}
+	}
+
+	fmt.Println()
+	titleColor.Printf("  Bot filters for '%s'\n", cfg.GetActiveProfileName())
+	dimColor.Println("  " + strings.Repeat("─", 40))
+	printPatterns("Author email", defaultBotAuthorPatterns, authorPatterns)
+	printPatterns("Commit subject", defaultBotMessagePatterns, messagePatterns)
+	fmt.Println()
+	return nil
+}
+
+func runProfileBotFiltersAdd(cmd *cobra.Command, args []string) error {
+	pattern := args[0]
+	if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return updateBotFilters(pattern, "Added", func(patterns []string) ([]string, error) {
+		for _, p := range patterns {
+			if p == pattern {
+				return nil, fmt.Errorf("pattern %q is already configured", pattern)
+			}
+		}
+		return append(patterns, pattern), nil
+	})
+}
+
+func runProfileBotFiltersRemove(cmd *cobra.Command, args []string) error {
+	pattern := args[0]
+	return updateBotFilters(pattern, "Removed", func(patterns []string) ([]string, error) {
+		for i, p := range patterns {
+			if p == pattern {
+				return append(patterns[:i:i], patterns[i+1:]...), nil
+			}
+		}
+		return nil, fmt.Errorf("pattern %q is not configured (see 'devlog profile bot-filters')", pattern)
+	})
+}
+
+// updateBotFilters applies edit to the author or message pattern list chosen
+// by --message and saves the config.
+func updateBotFilters(pattern, verb string, edit func([]string) ([]string, error)) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.EnsureDefaultProfile(); err != nil {
+		return fmt.Errorf("failed to ensure default profile: %w", err)
+	}
+	profileName := cfg.GetActiveProfileName()
+	authorPatterns, messagePatterns := cfg.GetBotFilters()
+
+	kind := "author"
+	if botFiltersMessage {
+		kind = "message"
+		messagePatterns, err = edit(messagePatterns)
+	} else {
+		authorPatterns, err = edit(authorPatterns)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.SetBotFilters(profileName, authorPatterns, messagePatterns); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	successColor := color.New(color.FgHiGreen)
+	dimColor := color.New(color.FgHiBlack)
+	fmt.Println()
+	successColor.Printf("  ✓ %s %s pattern %q for profile '%s'\n", verb, kind, pattern, profileName)
+	dimColor.Println("  Applies to commits ingested from now on")
+	fmt.Println()
+	return nil
+}
+
+// clearStaleBotFlags unflags stored commits that no longer match any bot
+// pattern, e.g. after a pattern is removed from the profile or the
+// defaults, so they show up in worklogs again. It returns how many were
+// cleared.
+func clearStaleBotFlags(ctx context.Context, dbRepo *db.SQLRepository, bots *botFilter, codebaseID string) int {
+	commits, err := dbRepo.GetBotCommits(ctx, codebaseID)
+	if err != nil {
+		VerboseLog("Warning: failed to load bot commits: %v", err)
+		return 0
+	}
+	cleared := 0
+	for _, c := range commits {
+		if bots.isBot(c.AuthorEmail, c.Message) {
+			continue
+		}
+		if err := dbRepo.ClearCommitBotFlag(ctx, codebaseID, c.Hash); err != nil {
+			VerboseLog("Warning: failed to unflag %s: %v", c.Hash[:8], err)
+			continue
+		}
+		cleared++
+	}
+	return cleared
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+func defaultBotFilter(t *testing.T) *botFilter {
+	t.Helper()
+	authors, err := compileBotPatterns(defaultBotAuthorPatterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages, err := compileBotPatterns(defaultBotMessagePatterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &botFilter{authors: authors, messages: messages}
+}
+
+func TestDefaultBotFilter(t *testing.T) {
+	bots := defaultBotFilter(t)
+	tests := []struct {
+		email, message string
+		want           bool
+	}{
+		{"49699333+dependabot[bot]@users.noreply.github.com", "Bump lodash from 4.17.20 to 4.17.21", true},
+		{"me@x.com", "chore(deps): bump golang.org/x/net to v0.48.0", true},
+		{"me@x.com", "Update dependency react to v19", true},
+		{"me@x.com", "docs: fix typo in README [skip ci]", false},
+		{"me@x.com", "[ci skip] tweak contributing guide", false},
+		{"me@x.com", "feat: add search command", false},
+	}
+	for _, tt := range tests {
+		if got := bots.isBot(tt.email, tt.message); got != tt.want {
+			t.Errorf("isBot(%q, %q) = %v, want %v", tt.email, tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestClearStaleBotFlags(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	const profile = "bots-test"
+	dbRepo, err := db.GetRepositoryForProfile(profile)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	t.Cleanup(func() { db.CloseDB(profile) })
+	ctx := context.Background()
+
+	if err := dbRepo.UpsertCodebase(ctx, &db.Codebase{ID: "cb", Path: "/tmp/repo", Name: "repo"}); err != nil {
+		t.Fatalf("upsert codebase: %v", err)
+	}
+	// Both were flagged when [skip ci] was a default pattern.
+	for _, c := range []*db.Commit{
+		{ID: "c1", Hash: "aaaaaaaa1", AuthorEmail: "me@x.com", Message: "docs: typo [skip ci]"},
+		{ID: "c2", Hash: "bbbbbbbb2", AuthorEmail: "renovate[bot]@users.noreply.github.com", Message: "chore: lock file [skip ci]"},
+	} {
+		c.CodebaseID = "cb"
+		c.CommittedAt = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+		c.IsBot = true
+		if err := dbRepo.UpsertCommit(ctx, c); err != nil {
+			t.Fatalf("upsert %s: %v", c.Hash, err)
+		}
+	}
+
+	if cleared := clearStaleBotFlags(ctx, dbRepo, defaultBotFilter(t), "cb"); cleared != 1 {
+		t.Errorf("cleared %d flags, want 1", cleared)
+	}
+	human, err := dbRepo.GetCommitByHash(ctx, "cb", "aaaaaaaa1")
+	if err != nil || human == nil || human.IsBot {
+		t.Errorf("human commit = %+v, %v; want is_bot false", human, err)
+	}
+	bot, err := dbRepo.GetCommitByHash(ctx, "cb", "bbbbbbbb2")
+	if err != nil || bot == nil || !bot.IsBot {
+		t.Errorf("bot commit = %+v, %v; want is_bot kept", bot, err)
+	}
+}
@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
+	"github.com/ishaan812/devlog/internal/llm"
+	"github.com/ishaan812/devlog/internal/prompts"
+)
+
+var (
+	changelogOutput      string
+	changelogNoLLM       bool
+	changelogProvider    string
+	changelogModel       string
+	changelogIncludeBots bool
+)
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog <from>..<to>",
+	Short: "Generate release notes for the commits between two refs",
+	Long: `Generate user-facing release notes from the commits between two git refs,
+grouped by commit type (features, fixes, ...), as markdown for a release.
+
+Unlike worklog, this covers everyone's commits and is bounded by refs rather
+than dates. Commit types and summaries come from ingest where the commits
+have been ingested; otherwise the conventional-commit prefix is used.
+Merges and bot commits are left out.
+
+The <to> ref defaults to HEAD, so "v1.2.0.." and "v1.2.0" both mean
+everything since v1.2.0.
+
+Examples:
+  devlog changelog v1.2.0..v1.3.0           # Notes for a tagged release
+  devlog changelog v1.3.0..                 # Unreleased changes since v1.3.0
+  devlog changelog v1.2.0..v1.3.0 -o notes.md  # Write to a file
+  devlog changelog v1.2.0..v1.3.0 --no-llm  # Commit subjects grouped by type`,
+	Args: cobra.ExactArgs(1),
+	RunE: runChangelog,
+}
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+
+	changelogCmd.Flags().StringVarP(&changelogOutput, "output", "o", "", "Write the notes to a file instead of stdout")
+	changelogCmd.Flags().BoolVar(&changelogNoLLM, "no-llm", false, "List commit subjects by type without LLM rewriting")
+	changelogCmd.Flags().StringVar(&changelogProvider, "provider", "", "LLM provider for the notes")
+	changelogCmd.Flags().StringVar(&changelogModel, "model", "", "LLM model to use")
+	changelogCmd.Flags().BoolVar(&changelogIncludeBots, "include-bots", false, "Include commits flagged by bot filters")
+}
+
+// changelogSections lists release-note sections in order, with the commit
+// types each collects. Types not listed go under "Other Changes".
+var changelogSections = []struct {
+	Title string
+	Types []string
+}{
+	{"Features", []string{"feat"}},
+	{"Fixes", []string{"fix", "revert"}},
+	{"Performance", []string{"perf"}},
+	{"Documentation", []string{"docs"}},
+}
+
+const changelogOtherSection = "Other Changes"
+
+// changelogCommit is one commit as release notes see it.
+type changelogCommit struct {
+	Hash    string
+	Subject string
+	Summary string
+	Type    string
+	URL     string
+}
+
+func runChangelog(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	dimColor := color.New(color.FgHiBlack)
+	successColor := color.New(color.FgHiGreen)
+
+	fromRev, toRev, err := parseChangelogRange(args[0])
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w\n\nRun 'devlog onboard' to set up your configuration", err)
+	}
+
+	absPath, err := filepath.Abs(".")
+	if err != nil {
+		return fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	repo, err := git.OpenRepo(absPath)
+	if err != nil {
+		return err
+	}
+
+	hashes, err := repo.GetCommitsBetween(fromRev, toRev)
+	if err != nil {
+		return fmt.Errorf("failed to list commits in %s..%s: %w", fromRev, toRev, err)
+	}
+
+	commits, err := loadChangelogCommits(ctx, repo, absPath, hashes)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		dimColor.Printf("  No commits to report between %s and %s.\n", fromRev, toRev)
+		return nil
+	}
+
+	release := toRev
+	if toRev == "HEAD" {
+		release = "Unreleased"
+	}
+
+	var notes string
+	if !changelogNoLLM {
+		client, err := createLLMClient(cfg, changelogProvider, changelogModel)
+		if err != nil {
+			return fmt.Errorf("failed to create LLM client: %w\n\nTo list commits without rewriting them, use: --no-llm", err)
+		}
+		dimColor.Fprintf(os.Stderr, "  Writing release notes for %d commits...\n", len(commits))
+		notes, err = generateChangelogNotes(ctx, client, lookupProjectContext(ctx, absPath), fmt.Sprintf("%s (changes since %s)", release, fromRev), commits)
+		if err != nil {
+			color.New(color.FgYellow).Fprintf(os.Stderr, "  LLM release notes unavailable, listing commits instead: %v\n", err)
+		}
+	}
+	if notes == "" {
+		notes = renderChangelogSections(commits)
+	}
+
+	markdown := fmt.Sprintf("## %s\n\n%s\n\n**Full range:** `%s..%s` (%d commits)\n", release, strings.TrimSpace(notes), fromRev, toRev, len(commits))
+
+	if changelogOutput == "" {
+		fmt.Print(markdown)
+		return nil
+	}
+	if err := os.WriteFile(changelogOutput, []byte(markdown), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	successColor.Printf("  Release notes written to %s\n", changelogOutput)
+	return nil
+}
+
+// parseChangelogRange splits "from..to" into its refs. A missing <to>
+// means HEAD.
+func parseChangelogRange(arg string) (from, to string, err error) {
+	if strings.Contains(arg, "...") {
+		return "", "", fmt.Errorf("symmetric ranges (a...b) are not supported; use <from>..<to>")
+	}
+	from, to, _ = strings.Cut(arg, "..")
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" {
+		return "", "", fmt.Errorf("missing <from> ref in %q (expected <from>..<to>)", arg)
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+	return from, to, nil
+}
+
+// loadChangelogCommits builds the release-note view of each commit, using
+// ingested data when the repository has been ingested. Merges and bot
+// commits are dropped.
+func loadChangelogCommits(ctx context.Context, repo *git.Repository, absPath string, hashes []string) ([]changelogCommit, error) {
+	var dbRepo *db.SQLRepository
+	var codebase *db.Codebase
+	if r, err := db.GetRepository(); err == nil {
+		if cb, err := r.GetCodebaseByPath(ctx, absPath); err == nil && cb != nil {
+			dbRepo, codebase = r, cb
+		}
+	}
+	browseURL := git.BrowseURL(repo.RemoteURL())
+
+	var commits []changelogCommit
+	for _, hash := range hashes {
+		gc, err := repo.GetCommit(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit %s: %w", hash[:7], err)
+		}
+		if gc.NumParents() > 1 {
+			continue
+		}
+		c := changelogCommit{
+			Hash:    hash,
+			Subject: strings.TrimSpace(strings.SplitN(strings.TrimSpace(gc.Message), "\n", 2)[0]),
+			Type:    conventionalCommitType(gc.Message),
+			URL:     git.CommitURL(browseURL, hash),
+		}
+		if codebase != nil {
+			if stored, err := dbRepo.GetCommitByHash(ctx, codebase.ID, hash); err == nil && stored != nil {
+				if stored.IsBot && !changelogIncludeBots {
+					continue
+				}
+				c.Summary = stored.Summary
+				if stored.CommitType != "" {
+					c.Type = stored.CommitType
+				}
+			}
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// changelogSectionTitle returns the release-note section for a commit type.
+func changelogSectionTitle(commitType string) string {
+	for _, section := range changelogSections {
+		for _, t := range section.Types {
+			if t == commitType {
+				return section.Title
+			}
+		}
+	}
+	return changelogOtherSection
+}
+
+// groupChangelogCommits splits commits into sections, in section order,
+// leaving out empty ones.
+func groupChangelogCommits(commits []changelogCommit) (titles []string, groups map[string][]changelogCommit) {
+	groups = make(map[string][]changelogCommit)
+	for _, c := range commits {
+		title := changelogSectionTitle(c.Type)
+		groups[title] = append(groups[title], c)
+	}
+	for _, section := range changelogSections {
+		if len(groups[section.Title]) > 0 {
+			titles = append(titles, section.Title)
+		}
+	}
+	if len(groups[changelogOtherSection]) > 0 {
+		titles = append(titles, changelogOtherSection)
+	}
+	return titles, groups
+}
+
+// renderChangelogSections lists commit subjects under their sections, with
+// conventional prefixes removed.
+func renderChangelogSections(commits []changelogCommit) string {
+	titles, groups := groupChangelogCommits(commits)
+	var sb strings.Builder
+	for _, title := range titles {
+		sb.WriteString(fmt.Sprintf("### %s\n\n", title))
+		for _, c := range groups[title] {
+			subject := conventionalPrefixRE.ReplaceAllString(c.Subject, "")
+			ref := fmt.Sprintf("`%s`", c.Hash[:7])
+			if c.URL != "" {
+				ref = fmt.Sprintf("[%s](%s)", c.Hash[:7], c.URL)
+			}
+			sb.WriteString(fmt.Sprintf("- %s (%s)\n", subject, ref))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// generateChangelogNotes asks the LLM to rewrite the grouped commits as
+// user-facing release notes.
+func generateChangelogNotes(ctx context.Context, client llm.Client, projectContext, release string, commits []changelogCommit) (string, error) {
+	titles, groups := groupChangelogCommits(commits)
+	var sb strings.Builder
+	for _, title := range titles {
+		sb.WriteString(fmt.Sprintf("%s:\n", title))
+		for _, c := range groups[title] {
+			sb.WriteString(fmt.Sprintf("- %s %s\n", c.Hash[:7], c.Subject))
+			if c.Summary != "" {
+				sb.WriteString(fmt.Sprintf("  Summary: %s\n", truncate(strings.Join(strings.Fields(c.Summary), " "), 400)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	llmCtx, cancel := withLLMTimeout(ctx)
+	defer cancel()
+	notes, err := client.Complete(llmCtx, prompts.BuildChangelogPrompt(projectContext, release, sb.String()))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(notes), nil
+}
@@ -127,7 +127,7 @@ func runClear(cmd *cobra.Command, args []string) error {
 	dimColor.Println("  Clearing data...")
 
 	tables := []string{
-		"worklog_entries", "file_changes", "ingest_cursors", "commits", "branches",
+		"worklog_entries", "file_changes", "commit_coauthors", "ingest_cursors", "commits", "branches",
 		"file_indexes", "folders", "codebases", "developers",
 	}
 
@@ -137,8 +137,10 @@ func runClear(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to clear table %s: %w", table, err)
 		}
 	}
-	if _, err := database.Exec("CHECKPOINT"); err != nil {
-		return fmt.Errorf("failed to checkpoint database: %w", err)
+	if storage, _ := cfg.GetProfileStorage(profileName); storage == config.StorageDuckDB {
+		if _, err := database.Exec("CHECKPOINT"); err != nil {
+			return fmt.Errorf("failed to checkpoint database: %w", err)
+		}
 	}
 
 	fmt.Println()
@@ -7,7 +7,6 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -98,7 +97,7 @@ func runCommitMessage(cmd *cobra.Command, args []string) error {
 
 	prompt := prompts.BuildCommitMessagePrompt(projectContext, diff)
 
-	llmCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	llmCtx, cancel := withLLMTimeout(ctx)
 	defer cancel()
 
 	result, err := client.Complete(llmCtx, prompt)
@@ -144,6 +143,11 @@ func createCommitClient(cfg *config.Config) (llm.Client, error) {
 	if selectedModel == "" {
 		selectedModel = cfg.GetEffectiveModel()
 	}
+	// A keychain: or secrets: reference that fails to resolve would
+	// otherwise surface as a confusing authentication error from the API.
+	if err := cfg.CheckSecretReferences(selectedProvider); err != nil {
+		return nil, fmt.Errorf("failed to resolve %s credentials: %w", selectedProvider, err)
+	}
 	llmCfg := llm.Config{Provider: llm.Provider(selectedProvider), Model: selectedModel}
 	switch llmCfg.Provider {
 	case llm.ProviderOpenAI:
@@ -160,6 +164,14 @@ func createCommitClient(cfg *config.Config) (llm.Client, error) {
 		llmCfg.AWSAccessKeyID = cfg.GetEffectiveAWSAccessKeyID()
 		llmCfg.AWSSecretAccessKey = cfg.GetEffectiveAWSSecretAccessKey()
 		llmCfg.AWSRegion = cfg.GetEffectiveAWSRegion()
+	case llm.ProviderAzureOpenAI:
+		llmCfg.APIKey = cfg.GetEffectiveAPIKey("azure")
+		llmCfg.AzureEndpoint = cfg.GetEffectiveAzureEndpoint()
+		llmCfg.AzureDeployment = cfg.GetEffectiveAzureDeployment()
+		llmCfg.AzureAPIVersion = cfg.GetEffectiveAzureAPIVersion()
+		if llmCfg.Model == "" {
+			llmCfg.Model = llmCfg.AzureDeployment
+		}
 	case llm.ProviderOllama:
 		if url := cfg.GetEffectiveOllamaBaseURL(); url != "" {
 			llmCfg.BaseURL = url
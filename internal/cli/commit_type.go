@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
+	"github.com/ishaan812/devlog/internal/llm"
+	"github.com/ishaan812/devlog/internal/prompts"
+)
+
+// commitTypeLabels maps each known commit type to its singular and plural
+// human-readable label.
+var commitTypeLabels = map[string][2]string{
+	"feat":     {"feature", "features"},
+	"fix":      {"fix", "fixes"},
+	"refactor": {"refactor", "refactors"},
+	"perf":     {"performance change", "performance changes"},
+	"docs":     {"docs change", "docs changes"},
+	"test":     {"test change", "test changes"},
+	"build":    {"build change", "build changes"},
+	"ci":       {"CI change", "CI changes"},
+	"style":    {"style change", "style changes"},
+	"chore":    {"chore", "chores"},
+	"revert":   {"revert", "reverts"},
+	"merge":    {"merge", "merges"},
+}
+
+// commitTypeAliases normalizes common non-standard prefixes.
+var commitTypeAliases = map[string]string{
+	"feature": "feat",
+	"bugfix":  "fix",
+	"hotfix":  "fix",
+	"doc":     "docs",
+	"tests":   "test",
+	"deps":    "chore",
+}
+
+var conventionalTypeRE = regexp.MustCompile(`^([a-zA-Z]+)(\([^)]*\))?!?:\s`)
+
+// conventionalCommitType returns the type from a conventional-commit prefix
+// ("feat(api): ...") or a git revert message, or "" if there is none.
+func conventionalCommitType(message string) string {
+	subject := strings.TrimSpace(strings.SplitN(strings.TrimSpace(message), "\n", 2)[0])
+	if strings.HasPrefix(subject, `Revert "`) {
+		return "revert"
+	}
+	m := conventionalTypeRE.FindStringSubmatch(subject)
+	if m == nil {
+		return ""
+	}
+	return normalizeCommitType(m[1])
+}
+
+// normalizeCommitType maps a raw type to a known one, or "" if unknown.
+func normalizeCommitType(raw string) string {
+	t := strings.ToLower(strings.TrimSpace(raw))
+	if alias, ok := commitTypeAliases[t]; ok {
+		t = alias
+	}
+	if _, ok := commitTypeLabels[t]; !ok {
+		return ""
+	}
+	return t
+}
+
+// classifyCommitType infers a commit's type: merges and conventional prefixes
+// first, then the LLM (when a client is given), then a file-based heuristic.
+func classifyCommitType(client llm.Client, message string, parentCount int, fileChanges []*db.FileChange) string {
+	if parentCount > 1 {
+		return "merge"
+	}
+	if t := conventionalCommitType(message); t != "" {
+		return t
+	}
+	if client != nil && len(fileChanges) > 0 {
+		t, err := classifyCommitTypeWithLLM(client, message, fileChanges)
+		if err == nil && t != "" {
+			return t
+		}
+		VerboseLog("Warning: LLM commit classification failed, using heuristic: %v", err)
+	}
+	return inferCommitTypeFromFiles(fileChanges)
+}
+
+func classifyCommitTypeWithLLM(client llm.Client, message string, fileChanges []*db.FileChange) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("Commit message: ")
+	sb.WriteString(strings.TrimSpace(message))
+	sb.WriteString("\n\nFiles changed:\n")
+	for _, fc := range fileChanges {
+		sb.WriteString(fmt.Sprintf("- %s (%s): +%d/-%d\n", fc.FilePath, fc.ChangeType, fc.Additions, fc.Deletions))
+	}
+
+	ctx, cancel := withLLMTimeout(context.Background())
+	defer cancel()
+	result, err := client.Complete(ctx, prompts.BuildCommitClassifyPrompt(sb.String()))
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(strings.Trim(strings.TrimSpace(result), "`\"'."))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty classification")
+	}
+	t := normalizeCommitType(strings.Trim(fields[0], "`\"'.:,"))
+	if t == "" {
+		return "", fmt.Errorf("unknown commit type %q", fields[0])
+	}
+	return t, nil
+}
+
+// inferCommitTypeFromFiles falls back to the same path/diff-shape heuristic
+// used by 'devlog suggest-message'.
+func inferCommitTypeFromFiles(fileChanges []*db.FileChange) string {
+	if len(fileChanges) == 0 {
+		return "chore"
+	}
+	changes := make([]git.WorkingChange, 0, len(fileChanges))
+	for _, fc := range fileChanges {
+		changes = append(changes, git.WorkingChange{
+			Path:       fc.FilePath,
+			ChangeType: fc.ChangeType,
+			Additions:  fc.Additions,
+			Deletions:  fc.Deletions,
+		})
+	}
+	return detectChangeType(changes)
+}
+
+// commitTypeCount is the number of commits of one type.
+type commitTypeCount struct {
+	Type  string
+	Count int
+}
+
+// countCommitTypes tallies commit types, most frequent first. Unclassified
+// commits (ingested before classification existed) are skipped.
+func countCommitTypes(commits []commitData) []commitTypeCount {
+	counts := make(map[string]int)
+	for _, c := range commits {
+		if c.CommitType != "" {
+			counts[c.CommitType]++
+		}
+	}
+	result := make([]commitTypeCount, 0, len(counts))
+	for t, n := range counts {
+		result = append(result, commitTypeCount{Type: t, Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+// formatCommitTypeBreakdown renders commit types as "3 features, 2 fixes".
+func formatCommitTypeBreakdown(commits []commitData) string {
+	var parts []string
+	for _, tc := range countCommitTypes(commits) {
+		label, ok := commitTypeLabels[tc.Type]
+		if !ok {
+			label = [2]string{tc.Type, tc.Type}
+		}
+		if tc.Count == 1 {
+			parts = append(parts, fmt.Sprintf("1 %s", label[0]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d %s", tc.Count, label[1]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
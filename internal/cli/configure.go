@@ -237,6 +237,10 @@ func configureLLMProvider(cfg *config.Config, reader *bufio.Reader) error {
 		if err := configureBedrock(cfg, reader); err != nil {
 			return err
 		}
+	case constants.ProviderAzureOpenAI:
+		if err := configureAzureOpenAI(cfg, reader); err != nil {
+			return err
+		}
 	}
 
 	fmt.Println()
@@ -81,7 +81,7 @@ func runConsole(cmd *cobra.Command, args []string) error {
 		}
 
 		// Load weeks
-		weeks, err := dbRepo.ListWorklogWeeks(ctx, cb.ID, profileName)
+		weeks, err := dbRepo.ListWorklogWeeks(ctx, cb.ID, profileName, cfg.GetWeekStart())
 		if err != nil {
 			weeks = nil // Non-fatal, just won't show weeks
 		}
@@ -109,7 +109,7 @@ func runConsole(cmd *cobra.Command, args []string) error {
 		}
 
 		// Load months
-		months, err := dbRepo.ListWorklogMonths(ctx, cb.ID, profileName)
+		months, err := dbRepo.ListWorklogMonths(ctx, cb.ID, profileName, cfg.GetWeekStart())
 		if err != nil {
 			months = nil // Non-fatal, just won't show months
 		}
@@ -153,6 +153,7 @@ func runConsole(cmd *cobra.Command, args []string) error {
 			Months:      tuiMonths,
 			CommitCount: int(commitCount),
 			IsIngested:  commitCount > 0,
+			Missing:     tui.IsRepoPathMissing(cb.Path),
 		})
 	}
 
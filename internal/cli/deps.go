@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+var depsCmd = &cobra.Command{
+	Use:   "deps <file>",
+	Short: "Show what a file imports and what imports it",
+	Long: `Show a file's imports and the indexed files that import it.
+
+Imports are extracted from source during 'devlog ingest' indexing (Go,
+JavaScript/TypeScript, Python, C/C++ and JVM languages). Imports that resolve
+inside the repository are listed first; reverse lookups match Go package
+directories, relative JS/TS and Python imports, and dotted module names.
+
+Examples:
+  devlog deps internal/db/repository.go
+  devlog deps src/utils/format.ts`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeps,
+}
+
+func init() {
+	rootCmd.AddCommand(depsCmd)
+}
+
+func runDeps(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+
+	absFile, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	codebase, err := findCodebaseForFile(ctx, dbRepo, absFile)
+	if err != nil {
+		return err
+	}
+	relPath, err := filepath.Rel(codebase.Path, absFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	file, err := dbRepo.GetFileIndexByPath(ctx, codebase.ID, relPath)
+	if err != nil {
+		return fmt.Errorf("failed to get file index: %w", err)
+	}
+	if file == nil {
+		return fmt.Errorf("%s is not indexed. Run 'devlog ingest' first", relPath)
+	}
+	dependents, err := dbRepo.GetFileDependents(ctx, codebase.ID, relPath)
+	if err != nil {
+		return fmt.Errorf("failed to get dependents: %w", err)
+	}
+
+	indexed, err := dbRepo.GetFilesByCodebase(ctx, codebase.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get indexed files: %w", err)
+	}
+	var local, external []string
+	for _, dep := range file.Dependencies {
+		if isLocalDependency(dep, indexed) {
+			local = append(local, dep)
+		} else {
+			external = append(external, dep)
+		}
+	}
+
+	fmt.Println()
+	titleColor.Printf("  %s\n", relPath)
+	if file.Purpose != "" {
+		dimColor.Printf("  %s\n", file.Purpose)
+	}
+	dimColor.Println("  " + strings.Repeat("─", 40))
+
+	fmt.Println()
+	titleColor.Printf("  Imports (%d)\n", len(file.Dependencies))
+	if len(file.Dependencies) == 0 {
+		dimColor.Println("    No imports recorded")
+	}
+	for _, dep := range local {
+		infoColor.Printf("    %s\n", dep)
+	}
+	for _, dep := range external {
+		dimColor.Printf("    %s\n", dep)
+	}
+
+	fmt.Println()
+	titleColor.Printf("  Imported by (%d)\n", len(dependents))
+	if len(dependents) == 0 {
+		dimColor.Println("    No indexed files import this file")
+	}
+	for _, dep := range dependents {
+		infoColor.Printf("    %s\n", dep)
+	}
+	fmt.Println()
+	return nil
+}
+
+// findCodebaseForFile walks up from absFile to the nearest ingested codebase.
+func findCodebaseForFile(ctx context.Context, dbRepo *db.SQLRepository, absFile string) (*db.Codebase, error) {
+	for dir := filepath.Dir(absFile); ; dir = filepath.Dir(dir) {
+		codebase, err := dbRepo.GetCodebaseByPath(ctx, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get codebase: %w", err)
+		}
+		if codebase != nil {
+			return codebase, nil
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+	return nil, fmt.Errorf("%s is not inside an ingested repository. Run 'devlog ingest' first", absFile)
+}
+
+// isLocalDependency reports whether dep resolves to a file in the index.
+func isLocalDependency(dep string, files []db.FileIndex) bool {
+	for _, f := range files {
+		if db.DependencyRefersTo(dep, f.Path) {
+			return true
+		}
+	}
+	return false
+}
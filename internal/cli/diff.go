@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
+	"github.com/ishaan812/devlog/internal/prompts"
+)
+
+var diffStagedOnly bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Summarize your uncommitted changes",
+	Long: `Summarize the uncommitted changes in the current repository.
+
+Uses an LLM to describe what changed in plain English and to suggest a
+commit message, using the same commit analysis as 'devlog ingest'.
+Nothing is committed; the result is printed to stdout.
+
+Examples:
+  devlog diff                  # Staged + unstaged changes
+  devlog diff --staged-only    # Only staged changes`,
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().BoolVarP(&diffStagedOnly, "staged-only", "s", false, "Only summarize staged changes")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w\n\nRun 'devlog onboard' to set up your configuration", err)
+	}
+
+	absPath, err := filepath.Abs(".")
+	if err != nil {
+		return fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	repo, err := git.OpenRepo(absPath)
+	if err != nil {
+		return err
+	}
+
+	changes, err := repo.WorkingTreeChanges(diffStagedOnly)
+	if err != nil {
+		return fmt.Errorf("failed to read working changes: %w", err)
+	}
+	if len(changes) == 0 {
+		if diffStagedOnly {
+			fmt.Println("No staged changes found. Stage changes with 'git add' first.")
+		} else {
+			fmt.Println("No changes found. Working tree is clean.")
+		}
+		return nil
+	}
+
+	fileChanges := make([]*db.FileChange, 0, len(changes))
+	var patches []string
+	for _, c := range changes {
+		fileChanges = append(fileChanges, &db.FileChange{
+			ID:         uuid.New().String(),
+			FilePath:   c.Path,
+			ChangeType: c.ChangeType,
+			Additions:  c.Additions,
+			Deletions:  c.Deletions,
+			Patch:      c.Patch,
+		})
+		if c.Patch != "" {
+			patches = append(patches, c.Patch)
+		}
+	}
+
+	projectContext := lookupProjectContext(ctx, absPath)
+	filePurposes := lookupFilePurposes(ctx, absPath)
+
+	client, err := createLLMClient(cfg, "", cfg.GetCommitSummaryModel())
+	if err != nil {
+		return fmt.Errorf("failed to create LLM client: %w\n\nRun 'devlog onboard' to configure your LLM provider", err)
+	}
+
+	dimColor.Printf("  Analyzing %d changed file(s)...\n", len(changes))
+
+	description, err := generateCommitSummary(client, "(uncommitted working changes)", fileChanges, projectContext, filePurposes, cfg.GetCommitSummaryMaxWords())
+	if err != nil {
+		return fmt.Errorf("failed to summarize changes: %w", err)
+	}
+
+	llmCtx, cancel := withLLMTimeout(ctx)
+	defer cancel()
+	message, err := client.Complete(llmCtx, prompts.BuildCommitMessagePrompt(projectContext, strings.Join(patches, "\n")))
+	if err != nil {
+		return fmt.Errorf("failed to generate commit message: %w", err)
+	}
+
+	fmt.Println()
+	titleColor.Println("  Changed Files")
+	for _, fc := range fileChanges {
+		infoColor.Printf("  %-7s %s", fc.ChangeType, fc.FilePath)
+		dimColor.Printf(" (+%d/-%d)\n", fc.Additions, fc.Deletions)
+	}
+
+	fmt.Println()
+	titleColor.Println("  What Changed")
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+
+	fmt.Println()
+	titleColor.Println("  Suggested Commit Message")
+	for _, line := range strings.Split(strings.TrimSpace(message), "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// lookupProjectContext returns the ingested codebase summary and tech stack
+// for a path, if any.
+func lookupProjectContext(ctx context.Context, absPath string) string {
+	if dbRepo, err := db.GetRepository(); err == nil {
+		if codebase, err := dbRepo.GetCodebaseByPath(ctx, absPath); err == nil && codebase != nil {
+			return getProjectContext(codebase)
+		}
+	}
+	return "(No project context available)"
+}
+
+// lookupFilePurposes returns the indexed file purposes for the codebase at
+// absPath, or nil if it has not been indexed.
+func lookupFilePurposes(ctx context.Context, absPath string) map[string]string {
+	if dbRepo, err := db.GetRepository(); err == nil {
+		if codebase, err := dbRepo.GetCodebaseByPath(ctx, absPath); err == nil && codebase != nil {
+			return loadFilePurposes(ctx, dbRepo, codebase.ID)
+		}
+	}
+	return nil
+}
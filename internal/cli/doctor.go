@@ -0,0 +1,331 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/llm"
+)
+
+// doctorLLMTimeout caps the provider check, so a hung endpoint does not
+// stall the whole report for the profile's full llm_timeout_seconds.
+const doctorLLMTimeout = 30 * time.Second
+
+var doctorSkipLLM bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check devlog's setup and suggest fixes",
+	Long: `Run a health check of devlog's environment and print a checklist with a
+hint for each problem found. Run it first when something is wrong.
+
+Checks:
+  - ~/.devlog exists and is writable, and the config file can be read
+  - an old ~/.devlog/devlog.db has been migrated to the default profile
+  - the active profile's database opens and its schema is up to date
+  - the ingest lock is free, held by a running ingest, or stale
+  - the LLM provider answers a short request
+  - git is installed
+
+The exit status is non-zero if any check fails; warnings do not count.
+
+Examples:
+  devlog doctor                 # Run every check
+  devlog doctor --skip-llm      # Skip the request to the LLM provider
+  devlog doctor --profile work  # Check another profile`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorSkipLLM, "skip-llm", false, "Skip the request to the LLM provider")
+}
+
+// doctorStatus is the outcome of one doctor check.
+type doctorStatus int
+
+const (
+	doctorOK doctorStatus = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorReport prints check results as they complete and counts failures.
+type doctorReport struct {
+	failures int
+	warnings int
+}
+
+// add prints one check result, with its hint (if any) on the next line.
+func (r *doctorReport) add(status doctorStatus, name, detail, hint string) {
+	switch status {
+	case doctorOK:
+		successColor.Print("  ✓ ")
+	case doctorWarn:
+		r.warnings++
+		promptColor.Print("  ! ")
+	case doctorFail:
+		r.failures++
+		errorColor.Print("  ✗ ")
+	}
+	infoColor.Printf("%-14s", name)
+	dimColor.Printf(" %s\n", detail)
+	if hint != "" && status != doctorOK {
+		dimColor.Printf("    → %s\n", hint)
+	}
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	report := &doctorReport{}
+
+	fmt.Println()
+	titleColor.Println("  devlog doctor")
+	fmt.Println()
+
+	cfg := doctorCheckConfig(report)
+	doctorCheckMigration(report)
+
+	profileName := "default"
+	if cfg != nil {
+		// --profile only switches the database elsewhere; here the LLM and
+		// lock checks should use its settings too.
+		if profileFlag != "" && cfg.Profiles[profileFlag] != nil {
+			cfg.ActiveProfile = profileFlag
+		}
+		if name := cfg.GetActiveProfileName(); name != "" {
+			profileName = name
+		}
+	}
+	doctorCheckDatabase(report, cfg, profileName)
+	doctorCheckIngestLock(report, cfg)
+	if doctorSkipLLM {
+		dimColor.Printf("  - %-14s skipped (--skip-llm)\n", "LLM provider")
+	} else {
+		doctorCheckLLM(report, cfg)
+	}
+	doctorCheckGit(report)
+
+	fmt.Println()
+	switch {
+	case report.failures > 0:
+		errorColor.Printf("  %d check(s) failed", report.failures)
+		if report.warnings > 0 {
+			dimColor.Printf(", %d warning(s)", report.warnings)
+		}
+		fmt.Println()
+		fmt.Println()
+		// The checklist already explains the failures; the error only sets
+		// the exit status.
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return fmt.Errorf("%d doctor check(s) failed", report.failures)
+	case report.warnings > 0:
+		promptColor.Printf("  All checks passed with %d warning(s)\n\n", report.warnings)
+	default:
+		successColor.Println("  All checks passed")
+		fmt.Println()
+	}
+	return nil
+}
+
+// doctorCheckConfig checks that ~/.devlog is writable and the config file
+// parses. It returns the loaded config, or nil if it cannot be read.
+func doctorCheckConfig(report *doctorReport) *config.Config {
+	devlogDir := config.GetDevlogDir()
+	if err := os.MkdirAll(devlogDir, 0755); err != nil {
+		report.add(doctorFail, "Data dir", fmt.Sprintf("%s cannot be created: %v", devlogDir, err),
+			"Check the permissions of your home directory")
+	} else if probe, err := os.CreateTemp(devlogDir, ".doctor-*"); err != nil {
+		report.add(doctorFail, "Data dir", fmt.Sprintf("%s is not writable: %v", devlogDir, err),
+			fmt.Sprintf("Fix its ownership, e.g. sudo chown -R $USER %s", devlogDir))
+	} else {
+		probe.Close()
+		os.Remove(probe.Name())
+		report.add(doctorOK, "Data dir", devlogDir, "")
+	}
+
+	configPath := config.GetConfigPath()
+	cfg, err := config.Load()
+	if err != nil {
+		report.add(doctorFail, "Config", err.Error(),
+			fmt.Sprintf("Fix or move aside %s (or the repo's .devlog/config.json), then run 'devlog onboard'", configPath))
+		return nil
+	}
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		report.add(doctorWarn, "Config", fmt.Sprintf("%s does not exist yet", configPath), "Run 'devlog onboard' to set up devlog")
+		return cfg
+	}
+	if profileFlag != "" && (cfg.Profiles == nil || cfg.Profiles[profileFlag] == nil) {
+		report.add(doctorFail, "Config", fmt.Sprintf("profile '%s' not found", profileFlag), "List profiles with 'devlog profile list'")
+		return cfg
+	}
+	detail := configPath
+	if name := cfg.GetActiveProfileName(); name != "" {
+		detail += fmt.Sprintf(" (profile: %s)", name)
+	}
+	report.add(doctorOK, "Config", detail, "")
+	return cfg
+}
+
+// doctorCheckMigration reports an old single-profile database that has not
+// been moved into profiles/default.
+func doctorCheckMigration(report *doctorReport) {
+	oldDBPath := filepath.Join(config.GetDevlogDir(), "devlog.db")
+	if _, err := os.Stat(oldDBPath); os.IsNotExist(err) {
+		report.add(doctorOK, "Migration", "no pre-profile database to migrate", "")
+		return
+	}
+	if _, err := os.Stat(config.GetProfileDBPath("default")); err == nil {
+		report.add(doctorWarn, "Migration", fmt.Sprintf("%s was left behind; the default profile already has a database", oldDBPath),
+			"If nothing in it is needed, delete it; otherwise move it over profiles/default/devlog.db")
+		return
+	}
+	if err := config.MigrateOldDB(); err != nil {
+		report.add(doctorFail, "Migration", fmt.Sprintf("%s could not be migrated: %v", oldDBPath, err),
+			"Move it to profiles/default/devlog.db under ~/.devlog by hand")
+		return
+	}
+	report.add(doctorOK, "Migration", fmt.Sprintf("moved %s to the default profile", oldDBPath), "")
+}
+
+// doctorCheckDatabase opens the profile's database, which also applies any
+// pending schema migrations.
+func doctorCheckDatabase(report *doctorReport, cfg *config.Config, profileName string) {
+	if cfg != nil && cfg.Profiles != nil && cfg.Profiles[profileName] == nil {
+		report.add(doctorWarn, "Database", fmt.Sprintf("profile '%s' does not exist yet", profileName), "Run any devlog command, or 'devlog onboard', to create it")
+		return
+	}
+	dbPath := config.GetProfileDBPath(profileName)
+	postgres := false
+	if cfg != nil {
+		storage, _ := cfg.GetProfileStorage(profileName)
+		postgres = storage == config.StoragePostgres
+	}
+	if postgres {
+		dbPath = "postgres"
+	} else if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		report.add(doctorWarn, "Database", fmt.Sprintf("%s does not exist yet", dbPath), "Run 'devlog ingest' in a repository to create it")
+		return
+	}
+	dbRepo, err := db.GetRepositoryForProfile(profileName)
+	if err != nil {
+		hint := "Make sure no other devlog process is running, then retry"
+		if postgres {
+			hint = "Check the profile's postgres_url (or DEVLOG_POSTGRES_URL) and that the server is reachable"
+		} else if strings.Contains(err.Error(), "duckdb") || strings.Contains(err.Error(), "dlopen") {
+			hint = "The DuckDB library failed to load; reinstall devlog for this platform"
+		}
+		report.add(doctorFail, "Database", fmt.Sprintf("%s: %v", dbPath, err), hint)
+		return
+	}
+	codebases, err := dbRepo.GetAllCodebases(context.Background())
+	if err != nil {
+		report.add(doctorFail, "Database", fmt.Sprintf("%s opened but could not be queried: %v", dbPath, err),
+			"The database may be corrupt; back it up and re-run 'devlog ingest'")
+		return
+	}
+	report.add(doctorOK, "Database", fmt.Sprintf("%s, schema up to date, repositories: %d", dbPath, len(codebases)), "")
+}
+
+// doctorCheckIngestLock reports whether an ingest holds the lock and
+// whether the lock is stale.
+func doctorCheckIngestLock(report *doctorReport, cfg *config.Config) {
+	lockPath := ingestLockPath()
+	lock, err := readIngestLock(lockPath)
+	if err != nil {
+		report.add(doctorWarn, "Ingest lock", fmt.Sprintf("%s is unreadable: %v", lockPath, err), "Run 'devlog ingest --force-unlock' to remove it")
+		return
+	}
+	if lock == nil {
+		report.add(doctorOK, "Ingest lock", "free", "")
+		return
+	}
+	maxAge := time.Duration(config.DefaultIngestLockMaxAgeMinutes) * time.Minute
+	if cfg != nil {
+		maxAge = cfg.GetIngestLockMaxAge()
+	}
+	if ingestLockIsStale(lock, maxAge) {
+		report.add(doctorWarn, "Ingest lock", fmt.Sprintf("stale lock held by %s", describeIngestLock(lock)),
+			"The next ingest takes it over; 'devlog ingest --force-unlock' removes it now")
+		return
+	}
+	report.add(doctorOK, "Ingest lock", fmt.Sprintf("held by a running ingest (%s)", describeIngestLock(lock)), "")
+}
+
+// doctorCheckLLM sends the provider a one-word request.
+func doctorCheckLLM(report *doctorReport, cfg *config.Config) {
+	if cfg == nil {
+		report.add(doctorFail, "LLM provider", "not checked: config could not be read", "")
+		return
+	}
+	provider := cfg.GetEffectiveProvider()
+	if provider == "" {
+		report.add(doctorFail, "LLM provider", "no provider configured", "Run 'devlog onboard' to choose one")
+		return
+	}
+	model := cfg.GetEffectiveModel()
+	if provider == string(llm.ProviderOllama) {
+		model = cfg.GetEffectiveOllamaModel()
+	}
+	label := provider
+	if model != "" {
+		label += "/" + model
+	}
+
+	if err := cfg.CheckSecretReferences(provider); err != nil {
+		report.add(doctorFail, "LLM provider", fmt.Sprintf("%s: %v", label, err), "Store the key in the keychain or "+config.GetSecretsPath()+", or fix the reference in the profile")
+		return
+	}
+	client, err := createLLMClient(cfg, "", "")
+	if err != nil {
+		report.add(doctorFail, "LLM provider", fmt.Sprintf("%s: %v", label, err), "Set the missing credentials with 'devlog onboard' or 'devlog models set'")
+		return
+	}
+	timeout := cfg.GetLLMTimeout()
+	if timeout > doctorLLMTimeout {
+		timeout = doctorLLMTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Complete(ctx, "Reply with the single word OK."); err != nil {
+		hint := "Check the API key, model name and network access ('devlog models show')"
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			hint = "The provider did not answer in time; check the URL and network, or raise llm_timeout_seconds"
+		case provider == string(llm.ProviderOllama):
+			hint = fmt.Sprintf("Make sure Ollama is running ('ollama serve') and the model is pulled ('ollama pull %s')", model)
+		}
+		report.add(doctorFail, "LLM provider", fmt.Sprintf("%s: %v", label, err), hint)
+		return
+	}
+	report.add(doctorOK, "LLM provider", fmt.Sprintf("%s answered in %s", label, time.Since(start).Round(10*time.Millisecond)), "")
+}
+
+// doctorCheckGit checks that the git binary is available. Ingest reads
+// repositories directly, but hooks and 'devlog commit' run git.
+func doctorCheckGit(report *doctorReport) {
+	path, err := exec.LookPath("git")
+	if err != nil {
+		report.add(doctorWarn, "Git", "git not found in PATH", "Install git; hooks and 'devlog commit' need it")
+		return
+	}
+	out, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		report.add(doctorWarn, "Git", fmt.Sprintf("%s --version failed: %v", path, err), "Reinstall git")
+		return
+	}
+	report.add(doctorOK, "Git", strings.TrimSpace(string(out)), "")
+}
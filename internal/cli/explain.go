@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+var (
+	explainRepo  string
+	explainNoLLM bool
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <hash>",
+	Short: "Show everything devlog knows about one commit",
+	Long: `Show an ingested commit in full: its message, stored summary, branch, how
+devlog classified it (your commit, merge-sync, bot, conventional type) and the
+files it changed with their line counts. The drill-down companion to
+'devlog log'.
+
+The hash may be abbreviated. It is looked up in the current repository when
+run inside an ingested one, otherwise in every repository in the profile.
+If the commit has no summary yet, one is generated and saved.
+
+Examples:
+  devlog explain abc1234              # Explain a commit
+  devlog explain abc1234 --repo api   # Look in another repository
+  devlog explain abc1234 --no-llm     # Don't generate a missing summary`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExplain,
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+
+	explainCmd.Flags().StringVar(&explainRepo, "repo", "", "Repository name or path (default: current repository, else all)")
+	explainCmd.Flags().BoolVar(&explainNoLLM, "no-llm", false, "Don't generate a summary for a commit that has none")
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	hashColor := color.New(color.FgYellow)
+	addColor := color.New(color.FgGreen)
+	delColor := color.New(color.FgRed)
+
+	prefix := strings.ToLower(strings.TrimSpace(args[0]))
+	if len(prefix) < 4 || strings.Trim(prefix, "0123456789abcdef") != "" {
+		return fmt.Errorf("invalid commit hash %q (need at least 4 hex characters)", args[0])
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	loc := getProfileTimezone(cfg)
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	codebaseID := ""
+	if explainRepo != "" {
+		codebase, err := findCodebaseByRef(ctx, dbRepo, explainRepo)
+		if err != nil {
+			return err
+		}
+		codebaseID = codebase.ID
+	} else if codebasePath, err := filepath.Abs("."); err == nil {
+		if codebase, err := dbRepo.GetCodebaseByPath(ctx, codebasePath); err == nil && codebase != nil {
+			codebaseID = codebase.ID
+		}
+	}
+
+	matches, err := dbRepo.FindCommitsByHashPrefix(ctx, codebaseID, prefix, 10)
+	if err != nil {
+		return fmt.Errorf("failed to look up commit: %w", err)
+	}
+	switch {
+	case len(matches) == 0:
+		return fmt.Errorf("no ingested commit matches %s\n\nRun `devlog ingest` if it is new, or pass --repo", prefix)
+	case len(matches) > 1:
+		var sb strings.Builder
+		for _, c := range matches {
+			sb.WriteString(fmt.Sprintf("\n  %s  %s", c.Hash[:12], commitSubject(c.Message)))
+		}
+		return fmt.Errorf("commit hash %s is ambiguous; use more characters:%s", prefix, sb.String())
+	}
+	commit := matches[0]
+
+	codebase, err := dbRepo.GetCodebaseByID(ctx, commit.CodebaseID)
+	if err != nil {
+		return fmt.Errorf("failed to load repository: %w", err)
+	}
+	branchName := ""
+	if commit.BranchID != "" {
+		if branch, err := dbRepo.GetBranchByID(ctx, commit.BranchID); err == nil && branch != nil {
+			branchName = branch.Name
+		}
+	}
+	fileChanges, err := dbRepo.GetFileChangesByCommit(ctx, commit.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load file changes: %w", err)
+	}
+
+	summary := strings.TrimSpace(commit.Summary)
+	generated := false
+	if summary == "" && !explainNoLLM && len(fileChanges) > 0 {
+		summary = explainCommitSummary(ctx, dbRepo, cfg, codebase, &commit, fileChanges)
+		generated = summary != ""
+	}
+
+	fmt.Println()
+	titleColor.Print("  Commit ")
+	hashColor.Println(commit.Hash)
+	fmt.Println()
+	if codebase != nil {
+		infoColor.Printf("  Repository:     %s\n", codebase.Name)
+	}
+	if branchName != "" {
+		if commit.IsOnDefaultBranch {
+			infoColor.Printf("  Branch:         %s (default)\n", branchName)
+		} else {
+			infoColor.Printf("  Branch:         %s\n", branchName)
+		}
+	}
+	infoColor.Printf("  Author:         %s\n", commit.AuthorEmail)
+	infoColor.Printf("  Date:           %s\n", commit.CommittedAt.In(loc).Format("Mon Jan 2 2006 15:04"))
+	infoColor.Printf("  Classification: %s\n", strings.Join(commitClassification(commit), ", "))
+	if len(commit.Parents) > 0 {
+		short := make([]string, len(commit.Parents))
+		for i, p := range commit.Parents {
+			short[i] = p[:min(12, len(p))]
+		}
+		infoColor.Printf("  Parents:        %s\n", strings.Join(short, ", "))
+	}
+	if commit.RevertsHash != "" {
+		infoColor.Printf("  Reverts:        %s\n", commit.RevertsHash[:min(12, len(commit.RevertsHash))])
+	}
+
+	fmt.Println()
+	titleColor.Println("  Message")
+	for _, line := range strings.Split(strings.TrimRight(commit.Message, "\n"), "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+
+	fmt.Println()
+	if generated {
+		titleColor.Print("  Summary ")
+		dimColor.Println("(generated now)")
+	} else {
+		titleColor.Println("  Summary")
+	}
+	if summary == "" {
+		dimColor.Println("    No summary stored.")
+	} else {
+		for _, line := range strings.Split(summary, "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+
+	fmt.Println()
+	additions, deletions := 0, 0
+	for _, fc := range fileChanges {
+		additions += fc.Additions
+		deletions += fc.Deletions
+	}
+	titleColor.Printf("  Files changed (%d, +%d/-%d)\n", len(fileChanges), additions, deletions)
+	if len(fileChanges) == 0 {
+		dimColor.Println("    No file changes recorded.")
+	}
+	for _, fc := range fileChanges {
+		dimColor.Printf("    %-7s ", fc.ChangeType)
+		addColor.Printf("%6s", fmt.Sprintf("+%d", fc.Additions))
+		delColor.Printf(" %6s", fmt.Sprintf("-%d", fc.Deletions))
+		fmt.Printf("  %s\n", fc.FilePath)
+	}
+	fmt.Println()
+	return nil
+}
+
+// commitClassification describes how ingest classified a commit.
+func commitClassification(c db.Commit) []string {
+	var labels []string
+	if c.IsUserCommit {
+		labels = append(labels, "your commit")
+	} else {
+		labels = append(labels, "someone else's commit")
+	}
+	if c.ParentCount > 1 {
+		labels = append(labels, fmt.Sprintf("merge of %d parents", c.ParentCount))
+	}
+	if c.IsMergeSync {
+		labels = append(labels, "merge-sync (left out of line totals)")
+	}
+	if c.IsBot {
+		labels = append(labels, "bot (hidden from worklogs)")
+	}
+	if c.IsSigned {
+		labels = append(labels, "signed (not verified)")
+	}
+	if c.CommitType != "" {
+		labels = append(labels, "type "+c.CommitType)
+	}
+	return labels
+}
+
+// explainCommitSummary generates and saves a summary for a commit that has
+// none, the way ingest would have. It returns "" if that fails.
+func explainCommitSummary(ctx context.Context, dbRepo *db.SQLRepository, cfg *config.Config, codebase *db.Codebase, commit *db.Commit, fileChanges []db.FileChange) string {
+	fcPtrs := make([]*db.FileChange, len(fileChanges))
+	for i := range fileChanges {
+		fcPtrs[i] = &fileChanges[i]
+	}
+	summary := terseCommitSummary(commit.Message, fcPtrs, cfg.GetCommitSummaryMinChurn())
+	if summary == "" {
+		client, err := createLLMClient(cfg, "", cfg.GetCommitSummaryModel())
+		if err != nil {
+			VerboseLog("Warning: failed to create LLM client: %v", err)
+			return ""
+		}
+		projectCtx, filePurposes := "", map[string]string(nil)
+		if codebase != nil {
+			projectCtx = codebase.Summary
+			filePurposes = loadFilePurposes(ctx, dbRepo, codebase.ID)
+		}
+		color.New(color.FgHiBlack).Println("\n  Generating a summary...")
+		summary, err = generateCommitSummary(client, commit.Message, fcPtrs, projectCtx, filePurposes, cfg.GetCommitSummaryMaxWords())
+		if err != nil {
+			VerboseLog("Warning: failed to generate summary: %v", err)
+			return ""
+		}
+	}
+	summary = strings.TrimSpace(summary)
+	if err := dbRepo.UpdateCommitSummary(ctx, commit.ID, summary); err != nil {
+		VerboseLog("Warning: failed to save summary: %v", err)
+	}
+	return summary
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/ishaan812/devlog/internal/config"
 	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
 	"github.com/ishaan812/devlog/internal/tui"
 )
 
@@ -25,6 +26,11 @@ var (
 	obsidianRootFolder string
 	obsidianDryRun     bool
 	obsidianForce      bool
+	obsidianShowDiff   bool
+
+	obsidianDailyNotes        bool
+	obsidianDailyNotesPath    string
+	obsidianDailyNotesHeading string
 )
 
 var exportCmd = &cobra.Command{
@@ -32,7 +38,8 @@ var exportCmd = &cobra.Command{
 	Short: "Export cached worklogs to external formats",
 	Long: `Export cached worklog entries to external destinations.
 
-Use subcommands to export in specific formats (for example Obsidian).`,
+Use subcommands to export in specific formats (for example Obsidian, or a
+weekly digest email).`,
 }
 
 var exportObsidianCmd = &cobra.Command{
@@ -46,7 +53,23 @@ This command exports cached entries only:
   - monthly summaries (month_summary)
 
 It writes only new/changed entries by default using export signatures.
-Use --force to rewrite all files.`,
+Use --force to rewrite all files, and --dry-run --show-diff to preview
+exactly what would change in each file.
+
+DevLog's content in each note sits between <!-- devlog:begin --> and
+<!-- devlog:end --> markers. Re-exports replace only that region and the
+front matter keys devlog writes, so notes you add above or below the
+markers, and front matter keys such as aliases or extra tags, are kept.
+
+With --daily-notes, each day's worklog is written into your existing daily
+notes instead, inside a marked section under a configurable heading. The
+note is created if it does not exist, and only the marked section is ever
+rewritten. Weekly and monthly summaries are not exported in this mode.
+
+Examples:
+  devlog export obsidian --vault ~/Notes
+  devlog export obsidian --daily-notes --daily-notes-path "Journal/{{date:YYYY-MM-DD}}.md"
+  devlog export obsidian --daily-notes --daily-notes-heading "## Code"`,
 	RunE: runExportObsidian,
 }
 
@@ -73,6 +96,10 @@ func init() {
 	exportObsidianCmd.PersistentFlags().StringVar(&obsidianRootFolder, "root", "", "Root folder inside vault (default: DevLog)")
 	exportObsidianCmd.PersistentFlags().BoolVar(&obsidianDryRun, "dry-run", false, "Show what would be exported without writing files")
 	exportObsidianCmd.PersistentFlags().BoolVar(&obsidianForce, "force", false, "Rewrite all entries even if already exported")
+	exportObsidianCmd.PersistentFlags().BoolVar(&obsidianShowDiff, "show-diff", false, "With --dry-run, print a diff of each file that would change")
+	exportObsidianCmd.PersistentFlags().BoolVar(&obsidianDailyNotes, "daily-notes", false, "Write each day's worklog into your Obsidian daily notes")
+	exportObsidianCmd.PersistentFlags().StringVar(&obsidianDailyNotesPath, "daily-notes-path", "", "Daily note path template inside the vault (default: {{date:YYYY-MM-DD}}.md; saved)")
+	exportObsidianCmd.PersistentFlags().StringVar(&obsidianDailyNotesHeading, "daily-notes-heading", "", "Heading for devlog's section in daily notes (default: \"## DevLog\"; saved)")
 }
 
 type obsidianExportContext struct {
@@ -81,10 +108,15 @@ type obsidianExportContext struct {
 	codebase    *db.Codebase
 	repoPath    string
 	repoName    string
+	repoURL     string // https browse URL of the origin remote, if known
 	profileName string
 	vaultPath   string
 	rootFolder  string
 	loc         *time.Location
+
+	dailyNotes        bool
+	dailyNotesPath    string
+	dailyNotesHeading string
 }
 
 type obsidianExportItem struct {
@@ -94,6 +126,7 @@ type obsidianExportItem struct {
 	Signature    string
 	RelativePath string
 	Markdown     string
+	SectionID    string // set for daily notes: Markdown replaces only this marked section
 }
 
 type obsidianExportSummary struct {
@@ -110,6 +143,9 @@ type obsidianExportSummary struct {
 
 func runExportObsidian(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
+	if obsidianShowDiff && !obsidianDryRun {
+		return fmt.Errorf("--show-diff can only be used with --dry-run")
+	}
 	exportCtx, err := resolveObsidianExportContext(true, true)
 	if err != nil {
 		return err
@@ -190,13 +226,17 @@ func runExportObsidianStatus(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Printf("Vault path: %s\n", exportCtx.vaultPath)
 	}
-	fmt.Printf("Root folder: %s\n", exportCtx.rootFolder)
+	if exportCtx.dailyNotes {
+		fmt.Printf("Daily notes: %s\n", exportCtx.dailyNotesPath)
+	} else {
+		fmt.Printf("Root folder: %s\n", exportCtx.rootFolder)
+	}
 	fmt.Printf("Cached entries: %d\n", summary.Scanned)
 	fmt.Printf("Up-to-date exports: %d\n", summary.SkippedUnchanged)
 	fmt.Printf("Pending export diffs: %d\n", summary.PendingInDryRun)
 	printStatusTypeBreakdown(summary)
 
-	for _, t := range []string{"day_updates", "week_summary", "month_summary"} {
+	for _, t := range []string{"day_updates", "daily_note", "week_summary", "month_summary"} {
 		if ts, ok := summary.LastExportedAtByTy[t]; ok && !ts.IsZero() {
 			fmt.Printf("Last %s export: %s\n", t, ts.Format(time.RFC3339))
 		}
@@ -216,6 +256,8 @@ func runExportObsidianConfig(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Vault path: %s\n", exportCtx.vaultPath)
 	}
 	fmt.Printf("Root folder: %s\n", exportCtx.rootFolder)
+	fmt.Printf("Daily notes path: %s\n", exportCtx.dailyNotesPath)
+	fmt.Printf("Daily notes heading: %s\n", exportCtx.dailyNotesHeading)
 	return nil
 }
 
@@ -245,11 +287,19 @@ func resolveObsidianExportContext(requireVault bool, persistSettings bool) (*obs
 	saved := cfg.GetObsidianVault(profileName, repoPath)
 	resolvedVault := ""
 	resolvedRoot := "Devlog"
+	resolvedNotesPath := defaultDailyNotesPath
+	resolvedNotesHeading := defaultDailyNotesHeading
 	if saved != nil {
 		resolvedVault = saved.VaultPath
 		if strings.TrimSpace(saved.RootFolder) != "" {
 			resolvedRoot = strings.TrimSpace(saved.RootFolder)
 		}
+		if saved.DailyNotesPath != "" {
+			resolvedNotesPath = saved.DailyNotesPath
+		}
+		if saved.DailyNotesHeading != "" {
+			resolvedNotesHeading = saved.DailyNotesHeading
+		}
 	}
 
 	if strings.TrimSpace(obsidianVaultPath) != "" {
@@ -262,6 +312,12 @@ func resolveObsidianExportContext(requireVault bool, persistSettings bool) (*obs
 	if strings.TrimSpace(obsidianRootFolder) != "" {
 		resolvedRoot = strings.TrimSpace(obsidianRootFolder)
 	}
+	if strings.TrimSpace(obsidianDailyNotesPath) != "" {
+		resolvedNotesPath = strings.TrimSpace(obsidianDailyNotesPath)
+	}
+	if strings.TrimSpace(obsidianDailyNotesHeading) != "" {
+		resolvedNotesHeading = strings.TrimSpace(obsidianDailyNotesHeading)
+	}
 
 	if persistSettings && strings.TrimSpace(resolvedVault) != "" && (strings.TrimSpace(obsidianVaultPath) != "" || strings.TrimSpace(obsidianRootFolder) != "") {
 		if err := cfg.SaveObsidianVault(profileName, repoPath, resolvedVault, resolvedRoot); err != nil {
@@ -271,6 +327,17 @@ func resolveObsidianExportContext(requireVault bool, persistSettings bool) (*obs
 			return nil, fmt.Errorf("failed to save config: %w", err)
 		}
 	}
+	if persistSettings && strings.TrimSpace(resolvedVault) != "" && (strings.TrimSpace(obsidianDailyNotesPath) != "" || strings.TrimSpace(obsidianDailyNotesHeading) != "") {
+		if err := cfg.SaveObsidianVault(profileName, repoPath, resolvedVault, resolvedRoot); err != nil {
+			return nil, fmt.Errorf("failed to save obsidian settings: %w", err)
+		}
+		if err := cfg.SaveObsidianDailyNotes(profileName, repoPath, resolvedNotesPath, resolvedNotesHeading); err != nil {
+			return nil, fmt.Errorf("failed to save obsidian settings: %w", err)
+		}
+		if err := cfg.Save(); err != nil {
+			return nil, fmt.Errorf("failed to save config: %w", err)
+		}
+	}
 
 	if requireVault && strings.TrimSpace(resolvedVault) == "" {
 		if term.IsTerminal(int(os.Stdin.Fd())) {
@@ -309,10 +376,15 @@ func resolveObsidianExportContext(requireVault bool, persistSettings bool) (*obs
 		codebase:    codebase,
 		repoPath:    repoPath,
 		repoName:    codebase.Name,
+		repoURL:     git.BrowseURL(codebase.RemoteURL),
 		profileName: profileName,
 		vaultPath:   resolvedVault,
 		rootFolder:  resolvedRoot,
 		loc:         loc,
+
+		dailyNotes:        obsidianDailyNotes,
+		dailyNotesPath:    resolvedNotesPath,
+		dailyNotesHeading: resolvedNotesHeading,
 	}, nil
 }
 
@@ -355,9 +427,22 @@ func buildObsidianExportItems(ctx context.Context, exportCtx *obsidianExportCont
 		})
 
 		date := dayEntries[0].EntryDate.In(exportCtx.loc)
+		if exportCtx.dailyNotes {
+			content := renderDailyNoteSection(exportCtx, dayEntries)
+			items = append(items, obsidianExportItem{
+				EntryType:    "daily_note",
+				EntryDate:    date,
+				BranchID:     "",
+				Signature:    computeExportSignature("daily_note", date, "", dayEntries, content),
+				RelativePath: expandDailyNotePath(exportCtx.dailyNotesPath, date),
+				Markdown:     content,
+				SectionID:    dailyNoteSectionID(exportCtx),
+			})
+			continue
+		}
 		relPath := filepath.Join(exportBasePath(exportCtx), "daily", date.Format("2006"), date.Format("01"), date.Format("2006-01-02")+".md")
 		content := renderDailyObsidianMarkdown(exportCtx, date, dayEntries)
-		sig := computeExportSignature("day_updates", date, "", dayEntries, content)
+		sig := computeExportSignature("day_updates", date, "", dayEntries, noteBody(content))
 
 		items = append(items, obsidianExportItem{
 			EntryType:    "day_updates",
@@ -369,6 +454,10 @@ func buildObsidianExportItems(ctx context.Context, exportCtx *obsidianExportCont
 		})
 	}
 
+	if exportCtx.dailyNotes {
+		return items, nil
+	}
+
 	sort.Slice(weekly, func(i, j int) bool {
 		return weekly[i].EntryDate.Before(weekly[j].EntryDate)
 	})
@@ -376,7 +465,7 @@ func buildObsidianExportItems(ctx context.Context, exportCtx *obsidianExportCont
 		weekStart := e.EntryDate.In(exportCtx.loc)
 		relPath := filepath.Join(exportBasePath(exportCtx), "weekly", weekStart.Format("2006"), weekRangeNoteID(weekStart)+".md")
 		content := renderWeeklyObsidianMarkdown(exportCtx, weekStart, e)
-		sig := computeExportSignature("week_summary", weekStart, "", []db.WorklogEntry{e}, content)
+		sig := computeExportSignature("week_summary", weekStart, "", []db.WorklogEntry{e}, noteBody(content))
 		items = append(items, obsidianExportItem{
 			EntryType:    "week_summary",
 			EntryDate:    weekStart,
@@ -394,7 +483,7 @@ func buildObsidianExportItems(ctx context.Context, exportCtx *obsidianExportCont
 		monthStart := e.EntryDate.In(exportCtx.loc)
 		relPath := filepath.Join(exportBasePath(exportCtx), "monthly", monthStart.Format("2006"), monthStart.Format("2006-01")+".md")
 		content := renderMonthlyObsidianMarkdown(exportCtx, monthStart, e)
-		sig := computeExportSignature("month_summary", monthStart, "", []db.WorklogEntry{e}, content)
+		sig := computeExportSignature("month_summary", monthStart, "", []db.WorklogEntry{e}, noteBody(content))
 		items = append(items, obsidianExportItem{
 			EntryType:    "month_summary",
 			EntryDate:    monthStart,
@@ -439,14 +528,23 @@ func applyObsidianExport(ctx context.Context, exportCtx *obsidianExportContext,
 		if dryRun {
 			summary.PendingInDryRun++
 			summary.ByTypePending[item.EntryType]++
+			if obsidianShowDiff {
+				if err := printObsidianExportDiff(exportCtx, item); err != nil {
+					return nil, err
+				}
+			}
 			continue
 		}
 
 		outPath := filepath.Join(exportCtx.vaultPath, item.RelativePath)
+		_, content, _, err := mergeExportedFile(outPath, item)
+		if err != nil {
+			return nil, err
+		}
 		if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
 			return nil, fmt.Errorf("failed to create export directory %s: %w", filepath.Dir(outPath), err)
 		}
-		if err := os.WriteFile(outPath, []byte(item.Markdown), 0644); err != nil {
+		if err := os.WriteFile(outPath, []byte(content), 0644); err != nil {
 			return nil, fmt.Errorf("failed to write exported file %s: %w", outPath, err)
 		}
 
@@ -475,7 +573,7 @@ func applyObsidianExport(ctx context.Context, exportCtx *obsidianExportContext,
 }
 
 func renderDailyObsidianMarkdown(exportCtx *obsidianExportContext, date time.Time, entries []db.WorklogEntry) string {
-	weekStart := getWeekStart(date, exportCtx.loc)
+	weekStart := getWeekStart(date, exportCtx.loc, exportCtx.cfg.GetWeekStart())
 	weekRef := weekRangeNoteID(weekStart)
 	monthRef := date.In(exportCtx.loc).Format("2006-01")
 
@@ -485,6 +583,7 @@ func renderDailyObsidianMarkdown(exportCtx *obsidianExportContext, date time.Tim
 	sb.WriteString(fmt.Sprintf("date: %s\n", date.Format("2006-01-02")))
 	sb.WriteString(fmt.Sprintf("profile: %s\n", exportCtx.profileName))
 	sb.WriteString(fmt.Sprintf("repo: %s\n", exportCtx.repoName))
+	writeObsidianRepoURL(&sb, exportCtx)
 	sb.WriteString(fmt.Sprintf("week: %s\n", weekStart.Format("2006-01-02")))
 	sb.WriteString(fmt.Sprintf("month: %s\n", monthRef))
 	sb.WriteString("tags:\n")
@@ -492,6 +591,9 @@ func renderDailyObsidianMarkdown(exportCtx *obsidianExportContext, date time.Tim
 	sb.WriteString("  - daily\n")
 	sb.WriteString("---\n\n")
 	sb.WriteString(fmt.Sprintf("# Daily Worklog - %s\n\n", date.Format("Monday, January 2, 2006")))
+	if exportCtx.repoURL != "" {
+		sb.WriteString(fmt.Sprintf("Repository: [%s](%s)\n", exportCtx.repoName, exportCtx.repoURL))
+	}
 	sb.WriteString(fmt.Sprintf("Week: [[%s]]\n", weekRef))
 	sb.WriteString(fmt.Sprintf("Month: [[%s]]\n\n", monthRef))
 	sb.WriteString("---\n\n")
@@ -520,6 +622,7 @@ func renderWeeklyObsidianMarkdown(exportCtx *obsidianExportContext, weekStart ti
 	sb.WriteString(fmt.Sprintf("week_end: %s\n", weekEnd.Format("2006-01-02")))
 	sb.WriteString(fmt.Sprintf("profile: %s\n", exportCtx.profileName))
 	sb.WriteString(fmt.Sprintf("repo: %s\n", exportCtx.repoName))
+	writeObsidianRepoURL(&sb, exportCtx)
 	sb.WriteString("tags:\n")
 	sb.WriteString("  - worklog\n")
 	sb.WriteString("  - weekly\n")
@@ -542,6 +645,7 @@ func renderMonthlyObsidianMarkdown(exportCtx *obsidianExportContext, monthStart
 	sb.WriteString(fmt.Sprintf("month: %s\n", monthRef))
 	sb.WriteString(fmt.Sprintf("profile: %s\n", exportCtx.profileName))
 	sb.WriteString(fmt.Sprintf("repo: %s\n", exportCtx.repoName))
+	writeObsidianRepoURL(&sb, exportCtx)
 	sb.WriteString("tags:\n")
 	sb.WriteString("  - worklog\n")
 	sb.WriteString("  - monthly\n")
@@ -553,6 +657,14 @@ func renderMonthlyObsidianMarkdown(exportCtx *obsidianExportContext, monthStart
 	return strings.TrimSpace(sb.String()) + "\n"
 }
 
+// writeObsidianRepoURL adds the repository's web address to a note's
+// front matter when the origin remote is known.
+func writeObsidianRepoURL(sb *strings.Builder, exportCtx *obsidianExportContext) {
+	if exportCtx.repoURL != "" {
+		sb.WriteString(fmt.Sprintf("repo_url: %s\n", exportCtx.repoURL))
+	}
+}
+
 func computeExportSignature(entryType string, entryDate time.Time, branchID string, entries []db.WorklogEntry, renderedMarkdown string) string {
 	parts := []string{
 		entryType,
@@ -627,15 +739,11 @@ func exportEntryIsCurrent(exportCtx *obsidianExportContext, item obsidianExportI
 	if state.FilePath != item.RelativePath {
 		return false
 	}
-	targetPath := filepath.Join(exportCtx.vaultPath, item.RelativePath)
-	if _, err := os.Stat(targetPath); err != nil {
-		return false
-	}
-	return true
+	return managedSectionIsCurrent(filepath.Join(exportCtx.vaultPath, item.RelativePath), item)
 }
 
 func printTypeBreakdown(summary *obsidianExportSummary) {
-	for _, t := range []string{"day_updates", "week_summary", "month_summary"} {
+	for _, t := range []string{"day_updates", "daily_note", "week_summary", "month_summary"} {
 		scanned := summary.ByTypeScanned[t]
 		if scanned == 0 {
 			continue
@@ -646,7 +754,7 @@ func printTypeBreakdown(summary *obsidianExportSummary) {
 }
 
 func printStatusTypeBreakdown(summary *obsidianExportSummary) {
-	for _, t := range []string{"day_updates", "week_summary", "month_summary"} {
+	for _, t := range []string{"day_updates", "daily_note", "week_summary", "month_summary"} {
 		scanned := summary.ByTypeScanned[t]
 		if scanned == 0 {
 			continue
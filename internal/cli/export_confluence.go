@@ -0,0 +1,528 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/yuin/goldmark"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/term"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
+)
+
+var (
+	confluenceBaseURL  string
+	confluenceSpace    string
+	confluenceParentID string
+	confluenceUser     string
+	confluenceRepoPath string
+	confluenceDryRun   bool
+	confluenceForce    bool
+)
+
+// confluenceStatePrefix keeps Confluence export state apart from Obsidian's
+// in worklog_export_state.
+const confluenceStatePrefix = "confluence:"
+
+var exportConfluenceCmd = &cobra.Command{
+	Use:   "confluence",
+	Short: "Export cached worklogs to Confluence pages",
+	Long: `Export cached worklog entries (daily logs, weekly and monthly summaries) as
+Confluence pages under a parent page, using the Confluence REST API.
+
+Each entry becomes one page, converted from markdown to Confluence's storage
+format. Pages are matched by title in the space, so re-exports update the
+existing page rather than creating a new one. Like the Obsidian export, only
+new or changed entries are sent unless --force is given.
+
+The site, space, parent page and username given as flags are saved to the
+profile. The API token is read from DEVLOG_CONFLUENCE_TOKEN, or from
+"confluence.api_token" in the profile config; when neither is set you are
+asked for it and it is saved to the profile. With a username the token is
+sent as basic auth (Confluence Cloud API tokens); without one it is sent as
+a bearer token (Data Center personal access tokens).
+
+Examples:
+  devlog export confluence --base-url https://acme.atlassian.net/wiki --space ENG --parent-id 12345 --user me@acme.com
+  devlog export confluence                  # Reuse the saved site, space and parent page
+  devlog export confluence --dry-run        # Print the storage format that would be sent
+  devlog export confluence --force          # Update every page`,
+	Args: cobra.NoArgs,
+	RunE: runExportConfluence,
+}
+
+func init() {
+	exportCmd.AddCommand(exportConfluenceCmd)
+
+	exportConfluenceCmd.Flags().StringVar(&confluenceBaseURL, "base-url", "", "Confluence base URL, e.g. https://acme.atlassian.net/wiki (saved)")
+	exportConfluenceCmd.Flags().StringVar(&confluenceSpace, "space", "", "Space key to create pages in (saved)")
+	exportConfluenceCmd.Flags().StringVar(&confluenceParentID, "parent-id", "", "ID of the page to create pages under (saved)")
+	exportConfluenceCmd.Flags().StringVar(&confluenceUser, "user", "", "Username or email for basic auth (saved)")
+	exportConfluenceCmd.Flags().StringVar(&confluenceRepoPath, "repo", ".", "Repository path to export from")
+	exportConfluenceCmd.Flags().BoolVar(&confluenceDryRun, "dry-run", false, "Print the pages that would be exported without calling Confluence")
+	exportConfluenceCmd.Flags().BoolVar(&confluenceForce, "force", false, "Update all pages even if already exported")
+}
+
+// confluencePage is one cached worklog entry rendered as a Confluence page.
+type confluencePage struct {
+	EntryType string
+	EntryDate time.Time
+	Title     string
+	Storage   string
+	Signature string
+}
+
+func runExportConfluence(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	dimColor := color.New(color.FgHiBlack)
+	successColor := color.New(color.FgHiGreen)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := saveConfluenceSettings(cfg); err != nil {
+		return err
+	}
+	confluenceCfg := cfg.GetConfluenceConfig()
+	if confluenceCfg == nil || confluenceCfg.BaseURL == "" {
+		return fmt.Errorf("no Confluence site configured; pass --base-url (and --space, --parent-id)")
+	}
+	if confluenceCfg.SpaceKey == "" {
+		return fmt.Errorf("no Confluence space configured; pass --space")
+	}
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	repoPath, err := filepath.Abs(confluenceRepoPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve repo path: %w", err)
+	}
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to look up codebase: %w", err)
+	}
+	if codebase == nil {
+		return fmt.Errorf("no indexed repository found at %s\n\nRun `devlog ingest %s` first", repoPath, repoPath)
+	}
+	profileName := cfg.GetActiveProfileName()
+
+	pages, err := buildConfluencePages(ctx, cfg, dbRepo, codebase, profileName, confluenceCfg)
+	if err != nil {
+		return err
+	}
+	if len(pages) == 0 {
+		fmt.Println("No cached worklog entries found to export.")
+		fmt.Println("Run `devlog worklog --days <n>` first to populate the cache.")
+		return nil
+	}
+
+	var client *confluenceClient
+	if !confluenceDryRun {
+		token, err := resolveConfluenceToken(cfg)
+		if err != nil {
+			return err
+		}
+		client = &confluenceClient{
+			baseURL:  strings.TrimRight(confluenceCfg.BaseURL, "/"),
+			username: confluenceCfg.Username,
+			token:    token,
+			http:     &http.Client{Timeout: 30 * time.Second},
+		}
+	}
+
+	var exported, unchanged, pending int
+	for _, page := range pages {
+		entryType := confluenceStatePrefix + page.EntryType
+		state, err := dbRepo.GetWorklogExportState(ctx, codebase.ID, profileName, entryType, page.EntryDate, "")
+		if err != nil {
+			return fmt.Errorf("failed to read export state: %w", err)
+		}
+		if state != nil && state.Signature == page.Signature && !confluenceForce {
+			unchanged++
+			continue
+		}
+
+		if confluenceDryRun {
+			pending++
+			fmt.Printf("=== %s ===\n%s\n\n", page.Title, page.Storage)
+			continue
+		}
+
+		pageID, created, err := client.upsertPage(ctx, confluenceCfg.SpaceKey, confluenceCfg.ParentID, page.Title, page.Storage)
+		if err != nil {
+			return fmt.Errorf("failed to export %q: %w", page.Title, err)
+		}
+		verb := "Updated"
+		if created {
+			verb = "Created"
+		}
+		dimColor.Printf("  %s %s\n", verb, page.Title)
+
+		state = &db.WorklogExportState{
+			ID:          exportStateID(codebase.ID, profileName, entryType, page.EntryDate, ""),
+			CodebaseID:  codebase.ID,
+			ProfileName: profileName,
+			EntryType:   entryType,
+			EntryDate:   page.EntryDate,
+			Signature:   page.Signature,
+			FilePath:    pageID,
+			ExportedAt:  time.Now(),
+		}
+		if err := dbRepo.UpsertWorklogExportState(ctx, state); err != nil {
+			return fmt.Errorf("failed to save export state: %w", err)
+		}
+		exported++
+	}
+
+	if confluenceDryRun {
+		fmt.Printf("Dry run complete for space: %s\n", confluenceCfg.SpaceKey)
+		fmt.Printf("Scanned %d entries, would export %d, unchanged %d\n", len(pages), pending, unchanged)
+		return nil
+	}
+	successColor.Printf("  Export complete: %s (space %s)\n", confluenceCfg.BaseURL, confluenceCfg.SpaceKey)
+	fmt.Printf("  Scanned %d entries, exported %d, unchanged %d\n", len(pages), exported, unchanged)
+	return nil
+}
+
+// saveConfluenceSettings stores the Confluence flags on the active profile.
+func saveConfluenceSettings(cfg *config.Config) error {
+	if confluenceBaseURL == "" && confluenceSpace == "" && confluenceParentID == "" && confluenceUser == "" {
+		return nil
+	}
+	profile := cfg.GetActiveProfile()
+	if profile == nil {
+		return fmt.Errorf("no active profile; run 'devlog onboard' first")
+	}
+	if profile.Confluence == nil {
+		profile.Confluence = &config.ConfluenceConfig{}
+	}
+	if confluenceBaseURL != "" {
+		profile.Confluence.BaseURL = strings.TrimRight(strings.TrimSpace(confluenceBaseURL), "/")
+	}
+	if confluenceSpace != "" {
+		profile.Confluence.SpaceKey = strings.TrimSpace(confluenceSpace)
+	}
+	if confluenceParentID != "" {
+		profile.Confluence.ParentID = strings.TrimSpace(confluenceParentID)
+	}
+	if confluenceUser != "" {
+		profile.Confluence.Username = strings.TrimSpace(confluenceUser)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save Confluence settings: %w", err)
+	}
+	return nil
+}
+
+// resolveConfluenceToken returns the saved API token, asking for one and
+// saving it to the profile when none is set and stdin is a terminal.
+func resolveConfluenceToken(cfg *config.Config) (string, error) {
+	if token := cfg.GetEffectiveConfluenceToken(); token != "" {
+		return token, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("no Confluence API token; set DEVLOG_CONFLUENCE_TOKEN or confluence.api_token in the profile config")
+	}
+	fmt.Print("  Confluence API token: ")
+	raw, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read API token: %w", err)
+	}
+	token := strings.TrimSpace(string(raw))
+	if token == "" {
+		return "", fmt.Errorf("no Confluence API token given")
+	}
+	cfg.GetActiveProfile().Confluence.APIToken = token
+	if err := cfg.Save(); err != nil {
+		return "", fmt.Errorf("failed to save Confluence settings: %w", err)
+	}
+	return token, nil
+}
+
+// buildConfluencePages renders every cached entry for the repository as a
+// page: one per day (all branches together), week and month.
+func buildConfluencePages(ctx context.Context, cfg *config.Config, dbRepo *db.SQLRepository, codebase *db.Codebase, profileName string, target *config.ConfluenceConfig) ([]confluencePage, error) {
+	entries, err := dbRepo.ListWorklogEntriesForExport(ctx, codebase.ID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cached worklog entries: %w", err)
+	}
+	loc := getProfileTimezone(cfg)
+	repoURL := git.BrowseURL(codebase.RemoteURL)
+
+	dailyByDate := make(map[string][]db.WorklogEntry)
+	var pages []confluencePage
+	addPage := func(entryType string, date time.Time, title string, group []db.WorklogEntry, markdown string) error {
+		storage, err := renderConfluenceStorage(markdown)
+		if err != nil {
+			return fmt.Errorf("failed to render %q: %w", title, err)
+		}
+		// The target is part of the signature so that pointing the export
+		// at another space or parent sends every page again.
+		sig := computeExportSignature(entryType, date, "", group, strings.Join([]string{target.BaseURL, target.SpaceKey, target.ParentID, title, storage}, "\n"))
+		pages = append(pages, confluencePage{EntryType: entryType, EntryDate: date, Title: title, Storage: storage, Signature: sig})
+		return nil
+	}
+
+	for _, e := range entries {
+		switch e.EntryType {
+		case "day_updates":
+			dateKey := e.EntryDate.In(loc).Format("2006-01-02")
+			dailyByDate[dateKey] = append(dailyByDate[dateKey], e)
+		case "week_summary":
+			weekStart := e.EntryDate.In(loc)
+			title := fmt.Sprintf("%s: Weekly Summary %s", codebase.Name, weekRangeNoteID(weekStart))
+			markdown := confluenceRepoLine(codebase.Name, repoURL) + strings.TrimSpace(e.Content)
+			if err := addPage("week_summary", weekStart, title, []db.WorklogEntry{e}, markdown); err != nil {
+				return nil, err
+			}
+		case "month_summary":
+			monthStart := e.EntryDate.In(loc)
+			title := fmt.Sprintf("%s: Monthly Summary %s", codebase.Name, monthStart.Format("2006-01"))
+			markdown := confluenceRepoLine(codebase.Name, repoURL) + strings.TrimSpace(e.Content)
+			if err := addPage("month_summary", monthStart, title, []db.WorklogEntry{e}, markdown); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, dayEntries := range dailyByDate {
+		sort.Slice(dayEntries, func(i, j int) bool {
+			return dayEntries[i].BranchName < dayEntries[j].BranchName
+		})
+		date := dayEntries[0].EntryDate.In(loc)
+		var sb strings.Builder
+		sb.WriteString(confluenceRepoLine(codebase.Name, repoURL))
+		for _, entry := range dayEntries {
+			branch := strings.TrimSpace(entry.BranchName)
+			if branch == "" {
+				branch = "unknown"
+			}
+			sb.WriteString(fmt.Sprintf("## Branch: %s\n\n", branch))
+			sb.WriteString(strings.TrimSpace(entry.Content))
+			sb.WriteString("\n\n")
+		}
+		title := fmt.Sprintf("%s: Worklog %s", codebase.Name, date.Format("2006-01-02"))
+		if err := addPage("day_updates", date, title, dayEntries, sb.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		if !pages[i].EntryDate.Equal(pages[j].EntryDate) {
+			return pages[i].EntryDate.Before(pages[j].EntryDate)
+		}
+		return pages[i].EntryType < pages[j].EntryType
+	})
+	return pages, nil
+}
+
+// confluenceRepoLine is the markdown line naming the repository at the top
+// of each page.
+func confluenceRepoLine(repoName, repoURL string) string {
+	if repoURL != "" {
+		return fmt.Sprintf("Repository: [%s](%s)\n\n", repoName, repoURL)
+	}
+	return fmt.Sprintf("Repository: %s\n\n", repoName)
+}
+
+// renderConfluenceStorage converts markdown to Confluence storage format:
+// XHTML, with code blocks as code macros and task list checkboxes as
+// plain characters, since storage format has no <input> element. Raw HTML
+// in the markdown is dropped.
+func renderConfluenceStorage(markdown string) (string, error) {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(
+			html.WithXHTML(),
+			renderer.WithNodeRenderers(util.Prioritized(confluenceNodeRenderer{}, 100)),
+		),
+	)
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(markdown), &buf); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// confluenceNodeRenderer overrides goldmark's HTML output for the nodes
+// that storage format represents differently.
+type confluenceNodeRenderer struct{}
+
+func (confluenceNodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(gast.KindFencedCodeBlock, renderConfluenceCodeBlock)
+	reg.Register(gast.KindCodeBlock, renderConfluenceCodeBlock)
+	reg.Register(extast.KindTaskCheckBox, renderConfluenceTaskCheckBox)
+}
+
+func renderConfluenceCodeBlock(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkContinue, nil
+	}
+	_, _ = w.WriteString(`<ac:structured-macro ac:name="code">`)
+	if fenced, ok := node.(*gast.FencedCodeBlock); ok {
+		if lang := fenced.Language(source); len(lang) > 0 {
+			_, _ = w.WriteString(`<ac:parameter ac:name="language">`)
+			_, _ = w.Write(util.EscapeHTML(lang))
+			_, _ = w.WriteString(`</ac:parameter>`)
+		}
+	}
+	var code bytes.Buffer
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		code.Write(line.Value(source))
+	}
+	// "]]>" would end the CDATA section early, so it is split across two.
+	body := strings.ReplaceAll(code.String(), "]]>", "]]]]><![CDATA[>")
+	_, _ = w.WriteString("<ac:plain-text-body><![CDATA[" + body + "]]></ac:plain-text-body></ac:structured-macro>\n")
+	return gast.WalkSkipChildren, nil
+}
+
+func renderConfluenceTaskCheckBox(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkContinue, nil
+	}
+	if node.(*extast.TaskCheckBox).IsChecked {
+		_, _ = w.WriteString("&#9745; ")
+	} else {
+		_, _ = w.WriteString("&#9744; ")
+	}
+	return gast.WalkContinue, nil
+}
+
+// confluenceClient is a minimal client for the Confluence content REST API.
+type confluenceClient struct {
+	baseURL  string
+	username string
+	token    string
+	http     *http.Client
+}
+
+type confluenceContent struct {
+	ID      string `json:"id"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+}
+
+// upsertPage updates the page titled title in space, or creates it under
+// parentID when there is none. It returns the page's ID and whether the
+// page was created.
+func (c *confluenceClient) upsertPage(ctx context.Context, space, parentID, title, storage string) (string, bool, error) {
+	existing, err := c.findPage(ctx, space, title)
+	if err != nil {
+		return "", false, err
+	}
+
+	payload := map[string]any{
+		"type":  "page",
+		"title": title,
+		"space": map[string]string{"key": space},
+		"body": map[string]any{
+			"storage": map[string]string{"value": storage, "representation": "storage"},
+		},
+	}
+	var result confluenceContent
+	if existing == nil {
+		if parentID != "" {
+			payload["ancestors"] = []map[string]string{{"id": parentID}}
+		}
+		if err := c.do(ctx, http.MethodPost, "/rest/api/content", payload, &result); err != nil {
+			return "", false, err
+		}
+		return result.ID, true, nil
+	}
+
+	payload["id"] = existing.ID
+	payload["version"] = map[string]int{"number": existing.Version.Number + 1}
+	if err := c.do(ctx, http.MethodPut, "/rest/api/content/"+url.PathEscape(existing.ID), payload, &result); err != nil {
+		return "", false, err
+	}
+	return existing.ID, false, nil
+}
+
+// findPage returns the page titled title in space, or nil.
+func (c *confluenceClient) findPage(ctx context.Context, space, title string) (*confluenceContent, error) {
+	query := url.Values{}
+	query.Set("spaceKey", space)
+	query.Set("title", title)
+	query.Set("type", "page")
+	query.Set("expand", "version")
+	var result struct {
+		Results []confluenceContent `json:"results"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/rest/api/content?"+query.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Results) == 0 {
+		return nil, nil
+	}
+	return &result.Results[0], nil
+}
+
+func (c *confluenceClient) do(ctx context.Context, method, path string, payload, out any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("confluence request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read confluence response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("confluence returned %s: %s", resp.Status, truncate(strings.TrimSpace(string(respBody)), 300))
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to parse confluence response: %w", err)
+		}
+	}
+	return nil
+}
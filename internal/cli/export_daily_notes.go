@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+const (
+	defaultDailyNotesPath    = "{{date:YYYY-MM-DD}}.md"
+	defaultDailyNotesHeading = "## DevLog"
+)
+
+var dailyNoteDateRE = regexp.MustCompile(`\{\{date(?::([^}]*))?\}\}`)
+
+// momentDateTokens maps the Moment.js tokens Obsidian uses in daily note
+// formats to Go layouts, longest first so "YYYY" wins over "YY".
+var momentDateTokens = []struct{ token, layout string }{
+	{"YYYY", "2006"},
+	{"YY", "06"},
+	{"MMMM", "January"},
+	{"MMM", "Jan"},
+	{"MM", "01"},
+	{"M", "1"},
+	{"DD", "02"},
+	{"D", "2"},
+	{"dddd", "Monday"},
+	{"ddd", "Mon"},
+}
+
+// expandDailyNotePath fills {{date}} and {{date:FORMAT}} placeholders in a
+// daily note path template. A bare {{date}} uses Obsidian's default
+// YYYY-MM-DD format.
+func expandDailyNotePath(template string, date time.Time) string {
+	return dailyNoteDateRE.ReplaceAllStringFunc(template, func(match string) string {
+		format := dailyNoteDateRE.FindStringSubmatch(match)[1]
+		if format == "" {
+			format = "YYYY-MM-DD"
+		}
+		return formatMomentDate(format, date)
+	})
+}
+
+// formatMomentDate formats date with a Moment.js-style format string. Each
+// token is formatted on its own so literal characters are never mistaken
+// for parts of a Go layout.
+func formatMomentDate(format string, date time.Time) string {
+	var sb strings.Builder
+	for i := 0; i < len(format); {
+		matched := false
+		for _, t := range momentDateTokens {
+			if strings.HasPrefix(format[i:], t.token) {
+				sb.WriteString(date.Format(t.layout))
+				i += len(t.token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			sb.WriteByte(format[i])
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// dailyNoteSectionID identifies this profile and repo's section inside a
+// shared daily note, so several repos can write to the same note.
+func dailyNoteSectionID(exportCtx *obsidianExportContext) string {
+	return sanitizePathComponent(exportCtx.profileName) + "/" + sanitizePathComponent(exportCtx.repoName)
+}
+
+func renderDailyNoteSection(exportCtx *obsidianExportContext, entries []db.WorklogEntry) string {
+	var sb strings.Builder
+	sb.WriteString(exportCtx.dailyNotesHeading)
+	sb.WriteString("\n\n")
+	if exportCtx.repoURL != "" {
+		sb.WriteString(fmt.Sprintf("Repository: [%s](%s)\n\n", exportCtx.repoName, exportCtx.repoURL))
+	} else {
+		sb.WriteString(fmt.Sprintf("Repository: %s\n\n", exportCtx.repoName))
+	}
+	for _, entry := range entries {
+		branch := strings.TrimSpace(entry.BranchName)
+		if branch == "" {
+			branch = "unknown"
+		}
+		sb.WriteString(fmt.Sprintf("**Branch: %s**\n\n", branch))
+		sb.WriteString(strings.TrimSpace(entry.Content))
+		sb.WriteString("\n\n")
+	}
+	return strings.TrimSpace(sb.String())
+}
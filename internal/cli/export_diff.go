@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// exportDiffContext is the number of unchanged lines shown around each change.
+const exportDiffContext = 3
+
+// printObsidianExportDiff prints a unified diff between the file currently in
+// the vault and what exporting item would write there.
+func printObsidianExportDiff(exportCtx *obsidianExportContext, item obsidianExportItem) error {
+	dimColor := color.New(color.FgHiBlack)
+	addColor := color.New(color.FgGreen)
+	delColor := color.New(color.FgRed)
+	hunkColor := color.New(color.FgCyan)
+
+	before, after, exists, err := mergeExportedFile(filepath.Join(exportCtx.vaultPath, item.RelativePath), item)
+	if err != nil {
+		return err
+	}
+
+	fromName := "a/" + item.RelativePath
+	if !exists {
+		fromName = "/dev/null"
+	}
+	patch := unifiedDiff(fromName, "b/"+item.RelativePath, before, after, exportDiffContext)
+	if patch == "" {
+		dimColor.Printf("%s: file contents unchanged\n\n", item.RelativePath)
+		return nil
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			dimColor.Println(line)
+		case strings.HasPrefix(line, "@@"):
+			hunkColor.Println(line)
+		case strings.HasPrefix(line, "+"):
+			addColor.Println(line)
+		case strings.HasPrefix(line, "-"):
+			delColor.Println(line)
+		default:
+			fmt.Println(line)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// unifiedDiff returns a line-based unified diff of before and after with
+// contextLines of unchanged lines around each hunk, or "" if they are equal.
+func unifiedDiff(fromName, toName, before, after string, contextLines int) string {
+	type diffLine struct {
+		op   byte
+		text string
+	}
+	var lines []diffLine
+	for _, d := range diff.Do(before, after) {
+		if d.Text == "" {
+			continue
+		}
+		op := byte(' ')
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			op = '+'
+		case diffmatchpatch.DiffDelete:
+			op = '-'
+		}
+		for _, text := range strings.Split(strings.TrimSuffix(d.Text, "\n"), "\n") {
+			lines = append(lines, diffLine{op: op, text: text})
+		}
+	}
+
+	// oldLine[i] and newLine[i] count the lines of each side before lines[i].
+	oldLine := make([]int, len(lines)+1)
+	newLine := make([]int, len(lines)+1)
+	var changed []int
+	for i, l := range lines {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if l.op != '+' {
+			oldLine[i+1]++
+		}
+		if l.op != '-' {
+			newLine[i+1]++
+		}
+		if l.op != ' ' {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", fromName, toName))
+	for i := 0; i < len(changed); {
+		start := max(changed[i]-contextLines, 0)
+		end := min(changed[i]+contextLines+1, len(lines))
+		i++
+		for i < len(changed) && changed[i]-contextLines <= end {
+			end = min(changed[i]+contextLines+1, len(lines))
+			i++
+		}
+
+		oldStart, oldCount := oldLine[start], oldLine[end]-oldLine[start]
+		newStart, newCount := newLine[start], newLine[end]-newLine[start]
+		if oldCount > 0 {
+			oldStart++
+		}
+		if newCount > 0 {
+			newStart++
+		}
+		sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount))
+		for _, l := range lines[start:end] {
+			sb.WriteByte(l.op)
+			sb.WriteString(l.text)
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
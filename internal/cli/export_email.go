@@ -0,0 +1,315 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+var (
+	emailWeek     string
+	emailOut      string
+	emailTo       string
+	emailSend     bool
+	emailSMTPHost string
+	emailSMTPPort int
+	emailSMTPUser string
+	emailSMTPFrom string
+)
+
+var exportEmailCmd = &cobra.Command{
+	Use:   "email",
+	Short: "Render (or send) a weekly digest email",
+	Long: `Build a weekly digest from the cached weekly summaries of every repository
+in the profile and write it as an HTML file, ready to pipe into your own
+mailer. With an .eml output path a complete MIME message is written instead,
+and --send delivers it over SMTP.
+
+The digest uses the most recent week that has cached weekly summaries unless
+--week is given. Weekly summaries are cached by 'devlog worklog --days 14'
+(or any range longer than a week).
+
+SMTP settings given as flags are saved to the profile. The password is read
+from DEVLOG_SMTP_PASSWORD, or from "smtp.password" in the profile config.
+
+Examples:
+  devlog export email                                   # devlog-digest-<week>.html
+  devlog export email --week 2025-03-03 --out digest.eml
+  devlog export email --send --to me@x.com --smtp-host smtp.example.com --smtp-user me --smtp-from me@x.com
+  devlog export email --send                            # Reuse saved SMTP settings and recipients`,
+	Args: cobra.NoArgs,
+	RunE: runExportEmail,
+}
+
+func init() {
+	exportCmd.AddCommand(exportEmailCmd)
+
+	exportEmailCmd.Flags().StringVar(&emailWeek, "week", "", "Any date (YYYY-MM-DD) in the week to digest (default: latest cached week)")
+	exportEmailCmd.Flags().StringVarP(&emailOut, "out", "o", "", "Output file; .eml writes a full MIME message (default: devlog-digest-<week>.html unless sending)")
+	exportEmailCmd.Flags().StringVar(&emailTo, "to", "", "Comma-separated recipients (saved)")
+	exportEmailCmd.Flags().BoolVar(&emailSend, "send", false, "Send the digest over SMTP")
+	exportEmailCmd.Flags().StringVar(&emailSMTPHost, "smtp-host", "", "SMTP server host (saved)")
+	exportEmailCmd.Flags().IntVar(&emailSMTPPort, "smtp-port", 0, "SMTP server port (default: 587; saved)")
+	exportEmailCmd.Flags().StringVar(&emailSMTPUser, "smtp-user", "", "SMTP username (saved)")
+	exportEmailCmd.Flags().StringVar(&emailSMTPFrom, "smtp-from", "", "Sender address (default: SMTP username; saved)")
+}
+
+// digestSection is one repository's weekly summary in a digest.
+type digestSection struct {
+	Repo    string
+	Content string
+}
+
+func runExportEmail(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	dimColor := color.New(color.FgHiBlack)
+	successColor := color.New(color.FgHiGreen)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	loc := getProfileTimezone(cfg)
+	profileName := cfg.GetActiveProfileName()
+
+	if err := saveEmailSettings(cfg); err != nil {
+		return err
+	}
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	weekStart, sections, err := collectDigestSections(ctx, dbRepo, profileName, loc, cfg.GetWeekStart())
+	if err != nil {
+		return err
+	}
+	if len(sections) == 0 {
+		fmt.Println("No cached weekly summaries found for the digest.")
+		fmt.Println("Run `devlog worklog --days 14` in your repositories first to populate the cache.")
+		return nil
+	}
+
+	subject := fmt.Sprintf("DevLog weekly digest: %s", weekRangeLabel(weekStart))
+	body, err := renderDigestHTML(subject, sections)
+	if err != nil {
+		return err
+	}
+
+	var recipients []string
+	if p := cfg.GetActiveProfile(); p != nil {
+		recipients = p.DigestTo
+	}
+	smtpCfg := cfg.GetSMTPConfig()
+	from := ""
+	if smtpCfg != nil {
+		from = smtpCfg.From
+		if from == "" {
+			from = smtpCfg.Username
+		}
+	}
+
+	out := emailOut
+	if out == "" && !emailSend {
+		out = fmt.Sprintf("devlog-digest-%s.html", weekStart.Format("2006-01-02"))
+	}
+	if out != "" {
+		content := body
+		if strings.EqualFold(filepath.Ext(out), ".eml") {
+			content = buildDigestMessage(from, recipients, subject, body)
+		}
+		if err := os.WriteFile(out, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write digest %s: %w", out, err)
+		}
+		successColor.Printf("  Wrote digest for %s to %s\n", weekRangeLabel(weekStart), out)
+	}
+
+	if emailSend {
+		if smtpCfg == nil || smtpCfg.Host == "" {
+			return fmt.Errorf("no SMTP server configured; pass --smtp-host (and --smtp-user, --smtp-from)")
+		}
+		if len(recipients) == 0 {
+			return fmt.Errorf("no recipients; pass --to")
+		}
+		if from == "" {
+			return fmt.Errorf("no sender address; pass --smtp-from")
+		}
+		if err := sendDigest(smtpCfg, cfg.GetEffectiveSMTPPassword(), from, recipients, subject, body); err != nil {
+			return fmt.Errorf("failed to send digest: %w", err)
+		}
+		successColor.Printf("  Sent digest for %s to %s\n", weekRangeLabel(weekStart), strings.Join(recipients, ", "))
+	}
+	dimColor.Printf("  %d repositories included\n", len(sections))
+	return nil
+}
+
+// saveEmailSettings stores SMTP and recipient flags on the active profile.
+func saveEmailSettings(cfg *config.Config) error {
+	if emailTo == "" && emailSMTPHost == "" && emailSMTPPort == 0 && emailSMTPUser == "" && emailSMTPFrom == "" {
+		return nil
+	}
+	profile := cfg.GetActiveProfile()
+	if profile == nil {
+		return fmt.Errorf("no active profile; run 'devlog onboard' first")
+	}
+	if emailTo != "" {
+		profile.DigestTo = nil
+		for _, addr := range strings.Split(emailTo, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				profile.DigestTo = append(profile.DigestTo, addr)
+			}
+		}
+	}
+	if emailSMTPHost != "" || emailSMTPPort != 0 || emailSMTPUser != "" || emailSMTPFrom != "" {
+		if profile.SMTP == nil {
+			profile.SMTP = &config.SMTPConfig{}
+		}
+		if emailSMTPHost != "" {
+			profile.SMTP.Host = emailSMTPHost
+		}
+		if emailSMTPPort != 0 {
+			profile.SMTP.Port = emailSMTPPort
+		}
+		if emailSMTPUser != "" {
+			profile.SMTP.Username = emailSMTPUser
+		}
+		if emailSMTPFrom != "" {
+			profile.SMTP.From = emailSMTPFrom
+		}
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save email settings: %w", err)
+	}
+	return nil
+}
+
+// collectDigestSections returns the digest week and each repository's
+// cached summary for it. Without --week it picks the most recent week any
+// repository has a summary for.
+func collectDigestSections(ctx context.Context, dbRepo *db.SQLRepository, profileName string, loc *time.Location, firstDay time.Weekday) (time.Time, []digestSection, error) {
+	codebases, err := dbRepo.GetAllCodebases(ctx)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	var from, to time.Time
+	if emailWeek != "" {
+		date, err := time.ParseInLocation("2006-01-02", emailWeek, loc)
+		if err != nil {
+			return time.Time{}, nil, fmt.Errorf("invalid --week date %q (expected YYYY-MM-DD)", emailWeek)
+		}
+		from = getWeekStart(date, loc, firstDay)
+		to = from
+	} else {
+		to = time.Now().In(loc)
+		from = to.AddDate(0, 0, -12*7)
+	}
+
+	byRepo := make(map[string][]db.WorklogEntry)
+	var latest time.Time
+	for _, cb := range codebases {
+		entries, err := dbRepo.GetWeeklySummariesInRange(ctx, cb.ID, profileName, from, to)
+		if err != nil {
+			return time.Time{}, nil, fmt.Errorf("failed to load weekly summaries for %s: %w", cb.Name, err)
+		}
+		for _, e := range entries {
+			if e.EntryDate.After(latest) {
+				latest = e.EntryDate
+			}
+		}
+		byRepo[cb.Name] = entries
+	}
+	if latest.IsZero() {
+		return from, nil, nil
+	}
+
+	var sections []digestSection
+	for repo, entries := range byRepo {
+		for _, e := range entries {
+			if e.EntryDate.Equal(latest) && strings.TrimSpace(e.Content) != "" {
+				sections = append(sections, digestSection{Repo: repo, Content: e.Content})
+			}
+		}
+	}
+	sort.Slice(sections, func(i, j int) bool {
+		return sections[i].Repo < sections[j].Repo
+	})
+	weekStart := time.Date(latest.Year(), latest.Month(), latest.Day(), 0, 0, 0, 0, loc)
+	return weekStart, sections, nil
+}
+
+func weekRangeLabel(weekStart time.Time) string {
+	return fmt.Sprintf("%s to %s", weekStart.Format("Jan 2"), weekStart.AddDate(0, 0, 6).Format("Jan 2, 2006"))
+}
+
+// renderDigestHTML renders the digest as a standalone HTML document with
+// inline styles, since most mail clients ignore <style> blocks.
+func renderDigestHTML(title string, sections []digestSection) (string, error) {
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(title)))
+	sb.WriteString("</head>\n<body style=\"font-family: -apple-system, Segoe UI, Helvetica, Arial, sans-serif; max-width: 720px; margin: 0 auto; padding: 16px; color: #1f2328; line-height: 1.5;\">\n")
+	sb.WriteString(fmt.Sprintf("<h1 style=\"font-size: 22px;\">%s</h1>\n", html.EscapeString(title)))
+	for _, section := range sections {
+		sb.WriteString(fmt.Sprintf("<h2 style=\"font-size: 18px; border-bottom: 1px solid #d0d7de; padding-bottom: 4px;\">%s</h2>\n", html.EscapeString(section.Repo)))
+		var buf bytes.Buffer
+		if err := md.Convert([]byte(section.Content), &buf); err != nil {
+			return "", fmt.Errorf("failed to render summary for %s: %w", section.Repo, err)
+		}
+		sb.Write(buf.Bytes())
+	}
+	sb.WriteString("<p style=\"color: #656d76; font-size: 12px;\">Generated by <a href=\"https://github.com/ishaan812/devlog\">DevLog</a></p>\n")
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String(), nil
+}
+
+// buildDigestMessage wraps the HTML body in a MIME message.
+func buildDigestMessage(from string, to []string, subject, body string) string {
+	var sb strings.Builder
+	if from != "" {
+		sb.WriteString("From: " + from + "\r\n")
+	}
+	if len(to) > 0 {
+		sb.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	}
+	sb.WriteString("Subject: " + subject + "\r\n")
+	sb.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return sb.String()
+}
+
+func sendDigest(smtpCfg *config.SMTPConfig, password, from string, to []string, subject, body string) error {
+	port := smtpCfg.Port
+	if port == 0 {
+		port = 587
+	}
+	var auth smtp.Auth
+	if smtpCfg.Username != "" {
+		auth = smtp.PlainAuth("", smtpCfg.Username, password, smtpCfg.Host)
+	}
+	addr := net.JoinHostPort(smtpCfg.Host, strconv.Itoa(port))
+	return smtp.SendMail(addr, auth, from, to, []byte(buildDigestMessage(from, to, subject, body)))
+}
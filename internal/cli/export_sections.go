@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Exported notes keep devlog's content between <!-- devlog:begin --> and
+// <!-- devlog:end --> markers. Re-exports replace only that region and the
+// front matter keys devlog writes, so anything written outside the markers,
+// and any other front matter keys, survives.
+// Daily notes use one section per profile and repo, named by a section ID.
+
+func managedSectionMarkers(sectionID string) (begin, end string) {
+	if sectionID == "" {
+		return "<!-- devlog:begin -->", "<!-- devlog:end -->"
+	}
+	return fmt.Sprintf("<!-- devlog:begin %s -->", sectionID), fmt.Sprintf("<!-- devlog:end %s -->", sectionID)
+}
+
+// managedContent is what the export owns inside the item's markers.
+func (item obsidianExportItem) managedContent() string {
+	if item.SectionID != "" {
+		return item.Markdown
+	}
+	return noteBody(item.Markdown)
+}
+
+// noteBody returns a rendered note without its front matter.
+func noteBody(markdown string) string {
+	_, body := splitFrontMatter(markdown)
+	return strings.TrimSpace(body)
+}
+
+// mergeExportedFile reads the note at path and returns its current contents
+// and what exporting item would leave there.
+func mergeExportedFile(path string, item obsidianExportItem) (before, after string, exists bool, err error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", "", false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	before = string(existing)
+	exists = err == nil
+
+	if item.SectionID != "" {
+		return before, mergeManagedSection(before, item.SectionID, item.Markdown), exists, nil
+	}
+
+	rendered, _ := splitFrontMatter(item.Markdown)
+	existingFrontMatter, rest := splitFrontMatter(before)
+	frontMatter := mergeFrontMatter(existingFrontMatter, rendered)
+	if _, ok := managedRegion(before, ""); !ok {
+		// New notes, and notes exported before markers existed, are
+		// written in full.
+		begin, end := managedSectionMarkers("")
+		return before, frontMatter + "\n" + begin + "\n" + item.managedContent() + "\n" + end + "\n", exists, nil
+	}
+	return before, frontMatter + mergeManagedSection(rest, "", item.managedContent()), exists, nil
+}
+
+// frontMatterKeyLine matches a top-level "key:" line of YAML front matter.
+var frontMatterKeyLine = regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s#'"-][^:]*?)\s*:(\s|$)`)
+
+// frontMatterField is one top-level front matter key with its value lines,
+// kept as written. Lines before the first key have an empty key.
+type frontMatterField struct {
+	key  string
+	text string
+}
+
+// parseFrontMatterFields splits front matter (including its "---" fences)
+// into top-level fields. Indented lines, list items, blank lines and
+// comments belong to the key above them.
+func parseFrontMatterFields(frontMatter string) []frontMatterField {
+	body := strings.TrimSuffix(strings.TrimPrefix(frontMatter, "---\n"), "---\n")
+	var fields []frontMatterField
+	for _, line := range strings.SplitAfter(body, "\n") {
+		if line == "" {
+			continue
+		}
+		if m := frontMatterKeyLine.FindStringSubmatch(line); m != nil {
+			fields = append(fields, frontMatterField{key: strings.Trim(m[1], `"'`), text: line})
+			continue
+		}
+		if len(fields) == 0 {
+			fields = append(fields, frontMatterField{})
+		}
+		fields[len(fields)-1].text += line
+	}
+	return fields
+}
+
+// mergeFrontMatter updates the keys devlog writes (those in rendered) in a
+// note's existing front matter, keeping every other key, comment and the
+// order as the user left them. tags are merged rather than replaced, so
+// tags added by hand stay. When no owned key changed the existing front
+// matter is returned unchanged.
+func mergeFrontMatter(existing, rendered string) string {
+	if existing == "" {
+		return rendered
+	}
+	if rendered == "" {
+		return existing
+	}
+	fresh := make(map[string]frontMatterField)
+	freshFields := parseFrontMatterFields(rendered)
+	for _, f := range freshFields {
+		fresh[f.key] = f
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	seen := make(map[string]bool)
+	for _, f := range parseFrontMatterFields(existing) {
+		nf, owned := fresh[f.key]
+		if f.key == "" || !owned || seen[f.key] {
+			sb.WriteString(f.text)
+			continue
+		}
+		seen[f.key] = true
+		if f.key == "tags" {
+			sb.WriteString(mergeFrontMatterList(f, nf))
+		} else if strings.Join(strings.Fields(f.text), " ") == strings.Join(strings.Fields(nf.text), " ") {
+			// Same value, perhaps spaced differently: keep it as written.
+			sb.WriteString(f.text)
+		} else {
+			sb.WriteString(nf.text)
+		}
+	}
+	for _, nf := range freshFields {
+		if nf.key != "" && !seen[nf.key] {
+			sb.WriteString(nf.text)
+		}
+	}
+	sb.WriteString("---\n")
+
+	if merged := sb.String(); merged != existing {
+		return merged
+	}
+	return existing
+}
+
+// mergeFrontMatterList adds the items of devlog's block list field fresh
+// that the existing field lacks. A block list gets new "- item" lines after
+// its last item; an inline list or single value is rewritten inline.
+func mergeFrontMatterList(existing, fresh frontMatterField) string {
+	have := frontMatterListItems(existing)
+	var missing []string
+	for _, item := range frontMatterListItems(fresh) {
+		found := false
+		for _, h := range have {
+			if strings.TrimPrefix(h, "#") == strings.TrimPrefix(item, "#") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, item)
+		}
+	}
+	if len(missing) == 0 {
+		return existing.text
+	}
+
+	lines := strings.SplitAfter(existing.text, "\n")
+	value := strings.TrimSpace(strings.SplitN(lines[0], ":", 2)[1])
+	if value != "" {
+		// Inline list ("[a, b]") or a single value: rewrite it inline.
+		prefix := lines[0][:strings.Index(lines[0], ":")+1]
+		lines[0] = prefix + " [" + strings.Join(append(have, missing...), ", ") + "]\n"
+		return strings.Join(lines, "")
+	}
+
+	last := 0
+	indent := "  "
+	for i, line := range lines {
+		if trimmed := strings.TrimLeft(line, " \t"); strings.HasPrefix(trimmed, "- ") {
+			last = i
+			indent = line[:len(line)-len(trimmed)]
+		}
+	}
+	var added strings.Builder
+	for _, item := range missing {
+		added.WriteString(indent + "- " + item + "\n")
+	}
+	head := strings.Join(lines[:last+1], "")
+	if !strings.HasSuffix(head, "\n") {
+		head += "\n"
+	}
+	return head + added.String() + strings.Join(lines[last+1:], "")
+}
+
+// frontMatterListItems returns the items of a list field, written as a
+// block list, an inline "[a, b]" list or a single value, without quotes.
+func frontMatterListItems(f frontMatterField) []string {
+	lines := strings.Split(strings.TrimRight(f.text, "\n"), "\n")
+	value := strings.TrimSpace(strings.SplitN(lines[0], ":", 2)[1])
+	var items []string
+	if value != "" {
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.Trim(strings.TrimSpace(item), `"'`); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	for _, line := range lines[1:] {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "- ") {
+			items = append(items, strings.Trim(strings.TrimSpace(trimmed[2:]), `"'`))
+		}
+	}
+	return items
+}
+
+// mergeManagedSection returns content with the marked section for
+// sectionID replaced by section, or appended if it is not there.
+func mergeManagedSection(content, sectionID, section string) string {
+	begin, end := managedSectionMarkers(sectionID)
+	block := begin + "\n" + section + "\n" + end
+
+	start := strings.Index(content, begin)
+	stop := strings.Index(content, end)
+	switch {
+	case start >= 0 && stop > start:
+		return content[:start] + block + content[stop+len(end):]
+	case strings.TrimSpace(content) == "":
+		return block + "\n"
+	default:
+		return strings.TrimRight(content, "\n") + "\n\n" + block + "\n"
+	}
+}
+
+// managedRegion returns the text between the markers for sectionID.
+func managedRegion(content, sectionID string) (string, bool) {
+	begin, end := managedSectionMarkers(sectionID)
+	start := strings.Index(content, begin)
+	stop := strings.Index(content, end)
+	if start < 0 || stop < start+len(begin) {
+		return "", false
+	}
+	return strings.TrimSpace(content[start+len(begin) : stop]), true
+}
+
+// managedSectionIsCurrent reports whether the note at path still holds
+// item's managed region unchanged. Edits outside the markers don't count,
+// while a deleted or hand-edited region is written again.
+func managedSectionIsCurrent(path string, item obsidianExportItem) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	region, ok := managedRegion(string(content), item.SectionID)
+	if !ok {
+		return false
+	}
+	return region == strings.TrimSpace(item.managedContent())
+}
+
+// splitFrontMatter splits a leading YAML front matter block (including its
+// closing "---" line) from the rest of a note.
+func splitFrontMatter(content string) (frontMatter, rest string) {
+	if !strings.HasPrefix(content, "---\n") {
+		return "", content
+	}
+	idx := strings.Index(content[4:], "\n---\n")
+	if idx < 0 {
+		return "", content
+	}
+	cut := 4 + idx + len("\n---\n")
+	return content[:cut], content[cut:]
+}
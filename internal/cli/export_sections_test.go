@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const renderedDailyFrontMatter = "---\ntype: daily-worklog\ndate: 2025-03-03\nprofile: work\nrepo: devlog\ntags:\n  - worklog\n  - daily\n---\n"
+
+func TestMergeFrontMatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "new note",
+			existing: "",
+			want:     renderedDailyFrontMatter,
+		},
+		{
+			name:     "unchanged is kept verbatim",
+			existing: "---\n# my comment\ntype:   daily-worklog\ndate: 2025-03-03\nprofile: work\nrepo: devlog\ntags:\n- worklog\n- daily\n---\n",
+			want:     "---\n# my comment\ntype:   daily-worklog\ndate: 2025-03-03\nprofile: work\nrepo: devlog\ntags:\n- worklog\n- daily\n---\n",
+		},
+		{
+			name:     "user keys survive an update",
+			existing: "---\ntype: daily-worklog\naliases:\n  - Standup notes\ndate: 2025-03-03\nprofile: home\ncssclasses: wide\nrepo: devlog\ntags:\n  - worklog\n  - daily\n  - q1\n---\n",
+			want:     "---\ntype: daily-worklog\naliases:\n  - Standup notes\ndate: 2025-03-03\nprofile: work\ncssclasses: wide\nrepo: devlog\ntags:\n  - worklog\n  - daily\n  - q1\n---\n",
+		},
+		{
+			name:     "missing owned keys and tags are added",
+			existing: "---\ntags:\n  - q1\naliases: [Standup]\n---\n",
+			want:     "---\ntags:\n  - q1\n  - worklog\n  - daily\naliases: [Standup]\ntype: daily-worklog\ndate: 2025-03-03\nprofile: work\nrepo: devlog\n---\n",
+		},
+		{
+			name:     "inline tags",
+			existing: "---\ntype: daily-worklog\ndate: 2025-03-03\nprofile: work\nrepo: devlog\ntags: [q1, \"#worklog\"]\n---\n",
+			want:     "---\ntype: daily-worklog\ndate: 2025-03-03\nprofile: work\nrepo: devlog\ntags: [q1, #worklog, daily]\n---\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeFrontMatter(tt.existing, renderedDailyFrontMatter); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeExportedFileKeepsUserFrontMatter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2025-03-03.md")
+	item := obsidianExportItem{Markdown: renderedDailyFrontMatter + "\n# Daily Worklog\n\nWorked on export.\n"}
+
+	_, first, _, err := mergeExportedFile(path, item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The user adds keys and a note below the managed region.
+	edited := "---\naliases:\n  - Monday\n" + first[len("---\n"):] + "\nMy own notes.\n"
+	if err := os.WriteFile(path, []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-exporting the same note changes nothing.
+	_, again, _, err := mergeExportedFile(path, item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != edited {
+		t.Errorf("re-export changed the note:\n got %q\nwant %q", again, edited)
+	}
+}
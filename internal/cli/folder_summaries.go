@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/indexer"
+)
+
+// folderSummaryWorkers bounds how many folder summaries are generated at
+// once while indexing.
+const folderSummaryWorkers = 4
+
+// folderSummaryJob is a folder whose summary is (re)generated during
+// indexing.
+type folderSummaryJob struct {
+	Folder       *db.Folder
+	Info         *indexer.FolderInfo
+	TouchedFiles []string
+}
+
+// summarizeFolders generates the jobs' summaries with up to
+// folderSummaryWorkers LLM requests in flight. save is called for each
+// finished folder on the calling goroutine, so database writes and progress
+// updates stay serial. Once interrupt is cancelled or a summary fails, no
+// new folders are started; those already in flight are still saved, and the
+// first error is returned.
+func summarizeFolders(ctx, interrupt context.Context, summarizer *indexer.Summarizer, jobs []folderSummaryJob, maxChildren int, save func(folderSummaryJob, *indexer.FolderSummary)) error {
+	type result struct {
+		job     folderSummaryJob
+		summary *indexer.FolderSummary
+		err     error
+	}
+	jobCh := make(chan folderSummaryJob)
+	results := make(chan result)
+	stop := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(folderSummaryWorkers, len(jobs)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				summary, err := summarizer.SummarizeFolder(ctx, job.Info, job.TouchedFiles, maxChildren)
+				results <- result{job: job, summary: summary, err: err}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobCh)
+		for _, job := range jobs {
+			select {
+			case <-stop:
+				return
+			case <-interrupt.Done():
+				return
+			default:
+			}
+			select {
+			case jobCh <- job:
+			case <-stop:
+				return
+			case <-interrupt.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var firstErr error
+	for r := range results {
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to generate folder summary for %s: %w\n\nTo skip summaries, use: --summary-mode off", r.job.Folder.Path, r.err)
+				close(stop)
+			}
+			continue
+		}
+		save(r.job, r.summary)
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	return interrupt.Err()
+}
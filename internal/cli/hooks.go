@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/git"
+)
+
+const (
+	hookBlockStart = "# >>> devlog hook >>>"
+	hookBlockEnd   = "# <<< devlog hook <<<"
+)
+
+var hooksPostMerge bool
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage git hooks that keep devlog up to date",
+	Long: `Install or remove git hooks that run an incremental ingest after each commit.
+
+The hook runs in the background, so committing is not slowed down:
+  devlog ingest --git-only --days 1 --skip-worklog
+
+Existing hooks are preserved: the devlog block is added at their end (before
+a final exit or exec line) and removed again on uninstall. Hooks that exec
+or exit earlier are skipped with instructions to add devlog by hand.
+
+Examples:
+  devlog hooks install                 # post-commit hook for the current repo
+  devlog hooks install --post-merge    # Also run after merges and pulls
+  devlog hooks uninstall               # Remove devlog from the repo's hooks`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install [path]",
+	Short: "Install the devlog post-commit hook",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runHooksInstall,
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall [path]",
+	Short: "Remove devlog from the repo's git hooks",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runHooksUninstall,
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+
+	hooksInstallCmd.Flags().BoolVar(&hooksPostMerge, "post-merge", false, "Also install a post-merge hook")
+}
+
+// managedHooks lists every hook devlog may install, used for uninstall.
+var managedHooks = []string{"post-commit", "post-merge"}
+
+// resolveHooksDir returns the hooks directory for a repo, honouring
+// core.hooksPath and linked worktrees when the git binary is available.
+func resolveHooksDir(absPath string) (string, error) {
+	if _, err := git.OpenRepo(absPath); err != nil {
+		return "", err
+	}
+	out, err := exec.Command("git", "-C", absPath, "rev-parse", "--git-path", "hooks").Output()
+	if err == nil {
+		dir := strings.TrimSpace(string(out))
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(absPath, dir)
+		}
+		return dir, nil
+	}
+	VerboseLog("Warning: git rev-parse failed, falling back to .git/hooks: %v", err)
+	return filepath.Join(absPath, ".git", "hooks"), nil
+}
+
+// buildHookBlock returns the marked shell snippet that runs a background ingest.
+func buildHookBlock(execPath, profileName, logPath string) string {
+	command := fmt.Sprintf("%s --profile %s ingest --git-only --days 1 --skip-worklog", shellQuote(execPath), shellQuote(profileName))
+	return strings.Join([]string{
+		hookBlockStart,
+		fmt.Sprintf("(cd \"$(git rev-parse --show-toplevel)\" && %s </dev/null >>%s 2>&1 &)", command, shellQuote(logPath)),
+		hookBlockEnd,
+	}, "\n") + "\n"
+}
+
+// stripHookBlock removes the devlog block from hook content and reports
+// whether one was found.
+func stripHookBlock(content string) (string, bool) {
+	start := strings.Index(content, hookBlockStart)
+	if start < 0 {
+		return content, false
+	}
+	end := strings.Index(content[start:], hookBlockEnd)
+	if end < 0 {
+		return content, false
+	}
+	end += start + len(hookBlockEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:start] + content[end:], true
+}
+
+// isShellHook reports whether existing hook content can safely have shell
+// appended to it.
+func isShellHook(content string) bool {
+	if !strings.HasPrefix(content, "#!") {
+		return true
+	}
+	shebang := strings.SplitN(content, "\n", 2)[0]
+	return strings.HasSuffix(shebang, "sh") || strings.Contains(shebang, "/sh ") ||
+		strings.Contains(shebang, "bash") || strings.Contains(shebang, "zsh")
+}
+
+var (
+	// hookTerminalLine matches a top-level line that ends the hook: exit, or
+	// exec replacing the shell with another program.
+	hookTerminalLine = regexp.MustCompile(`^(exit(\s|;|$)|exec\s+[^\s<>0-9&])`)
+	// hookExecCommand matches an exec that replaces the shell anywhere in a
+	// line, e.g. inside an if branch. exec with only redirections
+	// ("exec >>log 2>&1") keeps the shell running and does not match.
+	hookExecCommand = regexp.MustCompile(`(^|[;&|(]|\b(then|else|do))\s*exec\s+[^\s<>0-9&]`)
+)
+
+// insertHookBlock adds the devlog block to existing shell hook content.
+// The block goes at the end, or just before a final top-level exit or exec
+// line. git ignores the exit status of post-commit and post-merge hooks, so
+// running the block before that line is safe. It reports false when the
+// hook may exec or exit before reaching the insertion point, such as the
+// if/elif exec chains pre-commit generates; those need a manual edit.
+func insertHookBlock(content, block string) (string, bool) {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	at := len(lines)
+	for i := len(lines) - 1; i >= 0; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if hookTerminalLine.MatchString(lines[i]) {
+			at = i
+		}
+		break
+	}
+	for _, line := range lines[:at] {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if hookTerminalLine.MatchString(line) || hookExecCommand.MatchString(line) {
+			return content, false
+		}
+	}
+
+	before := strings.Join(lines[:at], "")
+	if before != "" && !strings.HasSuffix(before, "\n") {
+		before += "\n"
+	}
+	return before + block + strings.Join(lines[at:], ""), true
+}
+
+func runHooksInstall(cmd *cobra.Command, args []string) error {
+	successColor := color.New(color.FgHiGreen)
+	dimColor := color.New(color.FgHiBlack)
+	warnColor := color.New(color.FgHiYellow)
+
+	absPath, profileName, err := resolveCronTargetAndProfile(args)
+	if err != nil {
+		return err
+	}
+	hooksDir, err := resolveHooksDir(absPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return fmt.Errorf("create hooks directory: %w", err)
+	}
+
+	execPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("resolve executable path: %w", err)
+	}
+	if resolved, resolveErr := filepath.EvalSymlinks(execPath); resolveErr == nil {
+		execPath = resolved
+	}
+
+	logDir := filepath.Join(config.GetDevlogDir(), "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return fmt.Errorf("create log directory: %w", err)
+	}
+	logPath := filepath.Join(logDir, fmt.Sprintf("hook-%s-%s.log", sanitizeToken(profileName), sanitizeToken(filepath.Base(absPath))))
+
+	hooks := []string{"post-commit"}
+	if hooksPostMerge {
+		hooks = append(hooks, "post-merge")
+	}
+	block := buildHookBlock(execPath, profileName, logPath)
+
+	fmt.Println()
+	for _, name := range hooks {
+		hookPath := filepath.Join(hooksDir, name)
+		content := ""
+		data, err := os.ReadFile(hookPath)
+		switch {
+		case err == nil:
+			content = string(data)
+		case !os.IsNotExist(err):
+			return fmt.Errorf("read %s hook: %w", name, err)
+		}
+
+		if content != "" && !isShellHook(content) {
+			warnColor.Printf("  ! Skipped %s: existing hook is not a shell script\n", name)
+			dimColor.Printf("    Add this to it manually: %s ingest --git-only --days 1 --skip-worklog\n", execPath)
+			continue
+		}
+
+		updated, replaced := stripHookBlock(content)
+		if strings.TrimSpace(updated) == "" {
+			updated = "#!/bin/sh\n" + block
+		} else if withBlock, ok := insertHookBlock(updated, block); ok {
+			updated = withBlock
+		} else {
+			warnColor.Printf("  ! Skipped %s: existing hook may exit or exec before devlog would run\n", name)
+			dimColor.Printf("    Add this to it manually: %s ingest --git-only --days 1 --skip-worklog\n", execPath)
+			continue
+		}
+
+		if err := os.WriteFile(hookPath, []byte(updated), 0755); err != nil {
+			return fmt.Errorf("write %s hook: %w", name, err)
+		}
+		if err := os.Chmod(hookPath, 0755); err != nil {
+			return fmt.Errorf("make %s hook executable: %w", name, err)
+		}
+
+		switch {
+		case replaced:
+			successColor.Printf("  ✓ Updated %s hook\n", name)
+		case content != "":
+			successColor.Printf("  ✓ Added to existing %s hook\n", name)
+		default:
+			successColor.Printf("  ✓ Installed %s hook\n", name)
+		}
+	}
+	dimColor.Printf("  Repo: %s\n", absPath)
+	dimColor.Printf("  Profile: %s\n", profileName)
+	dimColor.Printf("  Log: %s\n", logPath)
+	dimColor.Println("  Remove with: devlog hooks uninstall")
+	fmt.Println()
+	return nil
+}
+
+func runHooksUninstall(cmd *cobra.Command, args []string) error {
+	successColor := color.New(color.FgHiGreen)
+	dimColor := color.New(color.FgHiBlack)
+
+	absPath, err := resolveRepoArg(args)
+	if err != nil {
+		return err
+	}
+	hooksDir, err := resolveHooksDir(absPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	removed := 0
+	for _, name := range managedHooks {
+		hookPath := filepath.Join(hooksDir, name)
+		data, err := os.ReadFile(hookPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("read %s hook: %w", name, err)
+		}
+
+		remaining, found := stripHookBlock(string(data))
+		if !found {
+			continue
+		}
+		removed++
+
+		// Delete hooks that only ever contained the devlog block.
+		if trimmed := strings.TrimSpace(remaining); trimmed == "" || trimmed == "#!/bin/sh" {
+			if err := os.Remove(hookPath); err != nil {
+				return fmt.Errorf("remove %s hook: %w", name, err)
+			}
+			successColor.Printf("  ✓ Removed %s hook\n", name)
+			continue
+		}
+		if err := os.WriteFile(hookPath, []byte(remaining), 0755); err != nil {
+			return fmt.Errorf("write %s hook: %w", name, err)
+		}
+		successColor.Printf("  ✓ Removed devlog from %s hook (other contents kept)\n", name)
+	}
+
+	if removed == 0 {
+		dimColor.Println("  No devlog hooks found in this repository.")
+	}
+	fmt.Println()
+	return nil
+}
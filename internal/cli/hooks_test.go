@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInsertHookBlock(t *testing.T) {
+	block := buildHookBlock("/usr/local/bin/devlog", "default", "/tmp/hook.log")
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string // "" when the hook must be skipped
+	}{
+		{
+			name:     "plain hook",
+			existing: "#!/bin/sh\nnpx lint-staged\n",
+			want:     "#!/bin/sh\nnpx lint-staged\n" + block,
+		},
+		{
+			name:     "no trailing newline",
+			existing: "#!/bin/sh\nmake check",
+			want:     "#!/bin/sh\nmake check\n" + block,
+		},
+		{
+			name:     "trailing exit",
+			existing: "#!/bin/sh\n. \"$(dirname \"$0\")/_/husky.sh\"\nnpx lint-staged\nexit 0\n",
+			want:     "#!/bin/sh\n. \"$(dirname \"$0\")/_/husky.sh\"\nnpx lint-staged\n" + block + "exit 0\n",
+		},
+		{
+			name:     "trailing exec and comment",
+			existing: "#!/bin/sh\nexec >>/tmp/log 2>&1\nexec ./scripts/post-commit \"$@\"\n# end\n",
+			want:     "#!/bin/sh\nexec >>/tmp/log 2>&1\n" + block + "exec ./scripts/post-commit \"$@\"\n# end\n",
+		},
+		{
+			name:     "conditional exit is fine",
+			existing: "#!/bin/sh\n[ -n \"$CI\" ] && exit 0\nif ! make; then\n  exit 1\nfi\n",
+			want:     "#!/bin/sh\n[ -n \"$CI\" ] && exit 0\nif ! make; then\n  exit 1\nfi\n" + block,
+		},
+		{
+			name: "pre-commit exec chain",
+			existing: "#!/usr/bin/env bash\nif [ -x \"$INSTALL_PYTHON\" ]; then\n" +
+				"    exec \"$INSTALL_PYTHON\" -mpre_commit \"${ARGS[@]}\"\n" +
+				"elif command -v pre-commit > /dev/null; then\n    exec pre-commit \"${ARGS[@]}\"\nelse\n" +
+				"    echo '`pre-commit` not found.' 1>&2\n    exit 1\nfi\n",
+		},
+		{
+			name:     "exit before the end",
+			existing: "#!/bin/sh\nrun-checks\nexit 0\necho unreachable\n",
+		},
+		{
+			name:     "exec in a one-line branch",
+			existing: "#!/bin/sh\ntest -x .hooks/post-commit && exec .hooks/post-commit\necho fallback\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := insertHookBlock(tt.existing, block)
+			if tt.want == "" {
+				if ok {
+					t.Fatalf("inserted into a hook that may not reach the block:\n%s", got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("hook skipped, want block inserted")
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+			// Uninstalling gives the original hook back.
+			stripped, found := stripHookBlock(got)
+			if !found || strings.TrimSuffix(stripped, "\n") != strings.TrimSuffix(tt.existing, "\n") {
+				t.Errorf("after strip:\n%q\nwant:\n%q", stripped, tt.existing)
+			}
+		})
+	}
+}
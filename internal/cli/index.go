@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/indexer"
+	"github.com/ishaan812/devlog/internal/tui"
+)
+
+var (
+	indexFoldersAdd    []string
+	indexFoldersRemove []string
+	indexFoldersClear  bool
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Manage codebase indexing settings",
+	Long: `Manage how 'devlog ingest' indexes a codebase.
+
+Examples:
+  devlog index folders list             # Show saved folder selection
+  devlog index folders edit             # Edit the selection interactively`,
+}
+
+var indexFoldersCmd = &cobra.Command{
+	Use:   "folders",
+	Short: "View or edit the saved index folder selection",
+	Long: `View or edit which folders are indexed for a repository.
+
+Large repositories prompt for a folder selection during ingest. The selection
+is saved per repo in the active profile and reused on every ingest.`,
+}
+
+var indexFoldersListCmd = &cobra.Command{
+	Use:   "list [path]",
+	Short: "Show the saved index folders for a repo",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runIndexFoldersList,
+}
+
+var indexFoldersEditCmd = &cobra.Command{
+	Use:   "edit [path]",
+	Short: "Add or remove saved index folders",
+	Long: `Edit the saved index folder selection for a repo.
+
+With --add/--remove the selection is changed directly without scanning the
+repository. Without flags, the folder tree is scanned and opened in the
+interactive selector with the current selection pre-checked.
+
+Changes take effect on the next 'devlog ingest'.
+
+Examples:
+  devlog index folders edit                        # Interactive editor
+  devlog index folders edit --add services/api     # Add a folder
+  devlog index folders edit --remove docs,scripts  # Remove folders
+  devlog index folders edit --clear                # Forget the selection`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runIndexFoldersEdit,
+}
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+	indexCmd.AddCommand(indexFoldersCmd)
+	indexFoldersCmd.AddCommand(indexFoldersListCmd)
+	indexFoldersCmd.AddCommand(indexFoldersEditCmd)
+
+	indexFoldersEditCmd.Flags().StringSliceVar(&indexFoldersAdd, "add", nil, "Folders to add (comma-separated, relative to repo root)")
+	indexFoldersEditCmd.Flags().StringSliceVar(&indexFoldersRemove, "remove", nil, "Folders to remove (comma-separated)")
+	indexFoldersEditCmd.Flags().BoolVar(&indexFoldersClear, "clear", false, "Clear the saved selection")
+}
+
+// resolveRepoArg returns the absolute repo path from an optional path argument.
+func resolveRepoArg(args []string) (string, error) {
+	path := "."
+	if len(args) > 0 {
+		path = args[0]
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+	return absPath, nil
+}
+
+// normalizeIndexFolder cleans a user-supplied folder into the slash-separated,
+// repo-relative form stored in the config.
+func normalizeIndexFolder(folder string) string {
+	folder = filepath.ToSlash(filepath.Clean(strings.TrimSpace(folder)))
+	return strings.TrimSuffix(strings.TrimPrefix(folder, "./"), "/")
+}
+
+func runIndexFoldersList(cmd *cobra.Command, args []string) error {
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	absPath, err := resolveRepoArg(args)
+	if err != nil {
+		return err
+	}
+
+	folders := cfg.GetIndexFolders(cfg.GetActiveProfileName(), absPath)
+
+	fmt.Println()
+	titleColor.Printf("  Index Folders - %s\n", filepath.Base(absPath))
+	dimColor.Println("  " + strings.Repeat("─", 40))
+	fmt.Println()
+
+	if len(folders) == 0 {
+		dimColor.Println("  No folder selection saved; the whole repository is indexed.")
+		dimColor.Printf("  (Ingest prompts for folders when a repo has more than %d files)\n\n", cfg.GetIndexSoftLimit())
+		return nil
+	}
+
+	for _, folder := range folders {
+		infoColor.Printf("  %s", folder)
+		if _, err := os.Stat(filepath.Join(absPath, filepath.FromSlash(folder))); err != nil {
+			dimColor.Print(" (missing)")
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+	dimColor.Println("  Edit with: devlog index folders edit")
+	fmt.Println()
+	return nil
+}
+
+func runIndexFoldersEdit(cmd *cobra.Command, args []string) error {
+	successColor := color.New(color.FgHiGreen)
+	dimColor := color.New(color.FgHiBlack)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	absPath, err := resolveRepoArg(args)
+	if err != nil {
+		return err
+	}
+	if err := cfg.UseProjectConfig(absPath); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	profileName := cfg.GetActiveProfileName()
+	current := cfg.GetIndexFolders(profileName, absPath)
+
+	var updated []string
+	switch {
+	case indexFoldersClear:
+		updated = nil
+	case len(indexFoldersAdd) > 0 || len(indexFoldersRemove) > 0:
+		set := make(map[string]bool, len(current))
+		for _, f := range current {
+			set[f] = true
+		}
+		for _, f := range indexFoldersAdd {
+			f = normalizeIndexFolder(f)
+			info, err := os.Stat(filepath.Join(absPath, filepath.FromSlash(f)))
+			if err != nil || !info.IsDir() {
+				return fmt.Errorf("folder not found in repo: %s", f)
+			}
+			set[f] = true
+		}
+		for _, f := range indexFoldersRemove {
+			f = normalizeIndexFolder(f)
+			if !set[f] {
+				return fmt.Errorf("folder is not in the saved selection: %s", f)
+			}
+			delete(set, f)
+		}
+		for f := range set {
+			updated = append(updated, f)
+		}
+		sort.Strings(updated)
+	default:
+		dimColor.Println("  Scanning folders...")
+		scanResult, err := indexer.ScanCodebase(absPath, 500*1024, nil, cfg.GetIgnorePatterns())
+		if err != nil {
+			return fmt.Errorf("failed to scan codebase: %w", err)
+		}
+		allFolders := indexer.AllFoldersWithCounts(scanResult)
+		tuiFolders := make([]tui.FolderInfo, 0, len(allFolders))
+		for _, f := range allFolders {
+			tuiFolders = append(tuiFolders, tui.FolderInfo{Path: f.Path, FileCount: f.FileCount})
+		}
+		selection, err := tui.RunFolderSelectionWithSelected(tuiFolders, current)
+		if err != nil {
+			return err
+		}
+		updated = selection.SelectedFolders
+	}
+
+	if err := cfg.SaveIndexFolders(profileName, absPath, updated); err != nil {
+		return fmt.Errorf("failed to save folder selection: %w", err)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println()
+	if len(updated) == 0 {
+		successColor.Println("  ✓ Folder selection cleared; the whole repository will be indexed")
+	} else {
+		successColor.Printf("  ✓ Saved %d index folder(s)\n", len(updated))
+		for _, f := range updated {
+			dimColor.Printf("    %s\n", f)
+		}
+	}
+	dimColor.Println("  Run 'devlog ingest --index-only' to apply the new selection")
+	fmt.Println()
+	return nil
+}
@@ -2,9 +2,14 @@ package cli
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
 	"os"
+	"os/signal"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -45,6 +50,25 @@ func isUserCommitByGitHub(authorEmail string, githubUsername string) bool {
 	return strings.EqualFold(extractedUsername, githubUsername)
 }
 
+// coAuthorTrailerRE matches a "Co-authored-by: Name <email>" trailer line.
+var coAuthorTrailerRE = regexp.MustCompile(`(?mi)^co-authored-by:\s*(.*?)\s*<([^>\s]+)>\s*$`)
+
+// parseCoAuthors returns the developers named in a commit message's
+// Co-authored-by trailers, skipping the commit's own author and duplicates.
+func parseCoAuthors(message, authorEmail string) []*db.Developer {
+	var devs []*db.Developer
+	seen := map[string]bool{strings.ToLower(authorEmail): true}
+	for _, m := range coAuthorTrailerRE.FindAllStringSubmatch(message, -1) {
+		email := strings.TrimSpace(m[2])
+		if seen[strings.ToLower(email)] {
+			continue
+		}
+		seen[strings.ToLower(email)] = true
+		devs = append(devs, &db.Developer{ID: email, Name: m[1], Email: email})
+	}
+	return devs
+}
+
 func isMergeSyncCommit(commit *git.Commit, baseBranch string, isDefault bool, baseBranchHashes map[string]bool) bool {
 	if isDefault || baseBranch == "" || commit.NumParents() < 2 || len(baseBranchHashes) == 0 {
 		return false
@@ -64,9 +88,15 @@ func isMergeSyncCommit(commit *git.Commit, baseBranch string, isDefault bool, ba
 	return true
 }
 
+// ingestCheckpointInterval is how many persisted commits ingestBranch
+// processes between branch cursor checkpoints.
+const ingestCheckpointInterval = 25
+
+// Size caps for the context files added to the codebase summary prompt: per
+// file, and for all of them together.
 const (
-	indexSoftLimit = 500
-	indexHardLimit = 1000
+	contextFileMaxBytes  = 8000
+	contextFilesMaxBytes = 24000
 )
 
 const (
@@ -82,6 +112,7 @@ var (
 	ingestSince             string
 	ingestBranches          []string
 	ingestAllBranches       bool
+	ingestBranchGlobs       []string
 	ingestReselectBranch    bool
 	ingestSkipSummaries     bool
 	ingestSummaryMode       string
@@ -92,14 +123,25 @@ var (
 	ingestTargetedHighChurn int
 	ingestMaxFiles          int
 	ingestAllFiles          bool
+	ingestNoLimitWarnings   bool
 	ingestGitOnly           bool
 	ingestIndexOnly         bool
 	ingestSkipCommitSums    bool
 	ingestFillSummaries     bool
+	ingestFillFileSums      bool
 	ingestForceReindex      bool
 	ingestSkipWorklog       bool
 	ingestAutoWorklog       bool
 	ingestReselectFolders   bool
+	ingestStaleDays         int
+	ingestURL               string
+	ingestProvider          string
+	ingestModel             string
+	ingestPathFilters       []string
+	ingestForceUnlock       bool
+	ingestMergeStats        string // profile merge_stats mode for this run
+	ingestSummaryMinChurn   int    // profile commit_summary_min_churn for this run
+	ingestSummaryMaxWords   int    // profile commit_summary_max_words for this run
 	ingestPreparedSelection *BranchSelection
 )
 
@@ -126,13 +168,33 @@ Examples:
   devlog ingest ~/projects/myapp      # Ingest specific path
   devlog ingest --all-branches        # Ingest all branches without prompting
   devlog ingest --branches main,dev   # Ingest specific branches
+  devlog ingest --branches-glob 'feature/*'  # Default branch plus every feature branch
   devlog ingest --days 90             # Last 90 days of git history
   devlog ingest --all                 # Full git history
   devlog ingest --git-only            # Only git history, skip indexing
   devlog ingest --index-only          # Only indexing, skip git history
   devlog ingest --summary-mode auto   # Auto summary mode (full/targeted/off)
-  devlog ingest --all-files           # Index all files (bypass 500/1000 limits)
-  devlog ingest --reselect-folders    # Re-prompt for which folders to index`,
+  devlog ingest --index-only --fill-file-summaries  # Summarize files indexed without summaries
+  devlog ingest --all-files           # Index all files (bypass soft/hard limits)
+  devlog ingest --reselect-folders    # Re-prompt for which folders to index
+  devlog ingest --model gpt-4o-mini   # Cheaper model for summaries (worklogs keep the default)
+  devlog ingest --path-filter services/payments  # Only commits touching one monorepo service
+  devlog ingest --url https://github.com/org/repo.git --all-branches  # Remote repo, no checkout
+  devlog ingest --force-unlock        # Clear a stuck lock left by a crashed ingest
+
+With --url the repository is bare-cloned into a temporary directory (shallow,
+covering --days or --since), its git history is ingested, and the clone is
+removed afterwards. Authentication uses your git credential helpers and SSH
+config. Codebase indexing is skipped since there is no working tree.
+
+With --path-filter only commits that touch files under the given paths are
+ingested, and only their file changes under those paths are stored, so line
+counts and worklogs reflect work in that area alone. Pass the same filter on
+every ingest of the repository; commits skipped by it are not revisited.
+
+Only one ingest runs at a time. The lock records the PID, host and start
+time of its ingest, and is taken over once its process has exited or it is
+older than the profile's ingest_lock_max_age_minutes (default 120).`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runIngest,
 }
@@ -145,6 +207,7 @@ func init() {
 	ingestCmd.Flags().StringVar(&ingestSince, "since", "", "Ingest commits since date (YYYY-MM-DD)")
 	ingestCmd.Flags().StringSliceVar(&ingestBranches, "branches", nil, "Specific branches to ingest (comma-separated)")
 	ingestCmd.Flags().BoolVar(&ingestAllBranches, "all-branches", false, "Ingest all branches without prompting")
+	ingestCmd.Flags().StringSliceVar(&ingestBranchGlobs, "branches-glob", nil, "Ingest the default branch and branches matching these globs, e.g. 'feature/*' (comma-separated or repeated)")
 	ingestCmd.Flags().BoolVar(&ingestReselectBranch, "reselect-branches", false, "Re-select branches (ignore saved selection)")
 	ingestCmd.Flags().BoolVar(&ingestSkipSummaries, "skip-summaries", false, "Skip LLM-generated summaries")
 	ingestCmd.Flags().StringVar(&ingestSummaryMode, "summary-mode", summaryModeAuto, "Summary strategy: auto|full|targeted|off")
@@ -155,76 +218,250 @@ func init() {
 	ingestCmd.Flags().IntVar(&ingestTargetedHighChurn, "targeted-high-churn", 500, "Minimum folder churn required for incremental re-summarization in targeted mode")
 	ingestCmd.Flags().IntVar(&ingestMaxFiles, "max-files", 0, "Maximum files to index (overrides limits, 0 = use defaults)")
 	ingestCmd.Flags().BoolVar(&ingestAllFiles, "all-files", false, "Index all files (bypass soft/hard limits)")
+	ingestCmd.Flags().BoolVar(&ingestNoLimitWarnings, "no-commit-limit-warnings", false, "Don't list the folders left out when the file limit is reached")
 	ingestCmd.Flags().BoolVar(&ingestGitOnly, "git-only", false, "Only ingest git history")
 	ingestCmd.Flags().BoolVar(&ingestIndexOnly, "index-only", false, "Only index codebase")
 	ingestCmd.Flags().BoolVar(&ingestSkipCommitSums, "skip-commit-summaries", false, "Skip LLM-generated commit summaries")
 	ingestCmd.Flags().BoolVar(&ingestFillSummaries, "fill-summaries", false, "Generate summaries for existing commits that are missing them")
+	ingestCmd.Flags().BoolVar(&ingestFillFileSums, "fill-file-summaries", false, "Generate summaries for indexed files and folders that are missing them")
 	ingestCmd.Flags().BoolVar(&ingestForceReindex, "force-reindex", false, "Force re-indexing all files, ignoring content hashes")
-	ingestCmd.Flags().BoolVar(&ingestSkipWorklog, "skip-worklog", false, "Skip worklog generation prompt after ingestion")
-	ingestCmd.Flags().BoolVar(&ingestAutoWorklog, "auto-worklog", false, "Automatically generate worklog after ingestion (non-interactive)")
+	ingestCmd.Flags().BoolVar(&ingestSkipWorklog, "skip-worklog", false, "Skip worklog generation prompt after ingestion (overrides auto_worklog_after_ingest)")
+	ingestCmd.Flags().BoolVar(&ingestAutoWorklog, "auto-worklog", false, "Automatically generate worklog after ingestion (non-interactive; overrides auto_worklog_after_ingest)")
 	ingestCmd.Flags().BoolVar(&ingestReselectFolders, "reselect-folders", false, "Re-prompt for index folder selection")
+	ingestCmd.Flags().IntVar(&ingestStaleDays, "stale-days", 30, "Mark branches with no commits in this many days as stale")
+	ingestCmd.Flags().StringVar(&ingestURL, "url", "", "Ingest a remote repository URL via a temporary bare clone")
+	ingestCmd.Flags().StringVar(&ingestProvider, "provider", "", "LLM provider for commit and file summaries (default: profile setting)")
+	ingestCmd.Flags().StringVar(&ingestModel, "model", "", "LLM model for commit and file summaries (default: profile setting)")
+	ingestCmd.Flags().StringSliceVar(&ingestPathFilters, "path-filter", nil, "Only ingest commits touching these repo-relative paths (comma-separated)")
+	ingestCmd.Flags().BoolVar(&ingestForceUnlock, "force-unlock", false, "Remove a stuck ingest lock and exit")
+}
+
+// ingestLockInfo is the content of the ingest lock file.
+type ingestLockInfo struct {
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+	Hostname  string    `json:"hostname"`
+}
+
+func ingestLockPath() string {
+	return filepath.Join(config.GetDevlogDir(), "ingest.lock")
+}
+
+// readIngestLock returns the current lock, or nil if there is none. Locks
+// written before the JSON format held only a PID; their start time is the
+// file's modification time.
+func readIngestLock(lockPath string) (*ingestLockInfo, error) {
+	data, err := os.ReadFile(lockPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read ingest lock: %w", err)
+	}
+	info := &ingestLockInfo{}
+	if jsonErr := json.Unmarshal(data, info); jsonErr != nil {
+		pid, parseErr := strconv.Atoi(strings.TrimSpace(string(data)))
+		if parseErr != nil {
+			// Unreadable lock: treat as stale.
+			return &ingestLockInfo{}, nil
+		}
+		info = &ingestLockInfo{PID: pid}
+		if stat, statErr := os.Stat(lockPath); statErr == nil {
+			info.StartedAt = stat.ModTime()
+		}
+	}
+	return info, nil
+}
+
+// ingestLockIsStale reports whether lock no longer guards a running ingest:
+// it is older than maxAge, or it was taken on this host by a process that
+// has exited. A lock from another host (a shared home directory) is only
+// expired by age, since its PID means nothing here.
+func ingestLockIsStale(lock *ingestLockInfo, maxAge time.Duration) bool {
+	if lock.PID <= 0 {
+		return true
+	}
+	if !lock.StartedAt.IsZero() && time.Since(lock.StartedAt) > maxAge {
+		return true
+	}
+	host, _ := os.Hostname()
+	if lock.Hostname != "" && lock.Hostname != host {
+		return false
+	}
+	return !processExists(lock.PID)
 }
 
 // acquireIngestLock prevents concurrent ingest runs (which would conflict on DuckDB's exclusive lock).
+// For a Postgres profile it also takes the database's advisory lock, so ingests
+// from other machines are excluded too.
 // Returns a release function to call when done, or an error if another ingest is running.
-func acquireIngestLock() (release func(), err error) {
-	lockPath := filepath.Join(config.GetDevlogDir(), "ingest.lock")
+// A lock older than maxAge is taken over even if its PID still exists, since
+// after a reboot the PID may belong to an unrelated process.
+func acquireIngestLock(maxAge time.Duration) (release func(), err error) {
+	lockPath := ingestLockPath()
 	release = func() {
 		_ = os.Remove(lockPath)
 	}
 
-	data, err := os.ReadFile(lockPath)
-	if err == nil {
-		if pid, parseErr := strconv.Atoi(strings.TrimSpace(string(data))); parseErr == nil && processExists(pid) {
-			return nil, fmt.Errorf("another 'devlog ingest' is already running (PID %d).\n"+
+	lock, err := readIngestLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	if lock != nil {
+		if !ingestLockIsStale(lock, maxAge) {
+			return nil, fmt.Errorf("another 'devlog ingest' is already running (%s).\n"+
 				"Kill it with: kill -9 %d\n"+
-				"If it won't die (stuck), run: pkill -9 -f 'devlog ingest'\n"+
-				"If still stuck, reboot your Mac to clear zombie processes",
-				pid, pid)
+				"If no ingest is running (the lock is stuck), run: devlog ingest --force-unlock",
+				describeIngestLock(lock), lock.PID)
 		}
-		// Stale lock (process no longer exists)
+		VerboseLog("Removing stale ingest lock (%s)", describeIngestLock(lock))
 		_ = os.Remove(lockPath)
 	}
 
 	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
 		return release, fmt.Errorf("create devlog dir: %w", err)
 	}
-	if err := os.WriteFile(lockPath, []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+	host, _ := os.Hostname()
+	data, err := json.Marshal(ingestLockInfo{PID: os.Getpid(), StartedAt: time.Now(), Hostname: host})
+	if err != nil {
+		return release, fmt.Errorf("acquire ingest lock: %w", err)
+	}
+	if err := os.WriteFile(lockPath, data, 0644); err != nil {
 		return release, fmt.Errorf("acquire ingest lock: %w", err)
 	}
-	return release, nil
+
+	// The lock file only guards this machine; a profile on a shared Postgres
+	// database also needs the server-side lock.
+	releaseShared, err := db.AcquireSharedIngestLock(db.GetActiveProfile())
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return func() {
+		releaseShared()
+		release()
+	}, nil
+}
+
+// forceIngestUnlock removes the ingest lock regardless of its owner.
+func forceIngestUnlock() error {
+	lockPath := ingestLockPath()
+	lock, err := readIngestLock(lockPath)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		color.New(color.FgHiBlack).Println("  No ingest lock is held.")
+		return nil
+	}
+	if err := os.Remove(lockPath); err != nil {
+		return fmt.Errorf("remove ingest lock: %w", err)
+	}
+	color.New(color.FgHiGreen).Printf("  Removed ingest lock (%s)\n", describeIngestLock(lock))
+	return nil
+}
+
+// describeIngestLock renders a lock's owner, e.g. "PID 123 on laptop,
+// started 15:04:05 (5m ago)".
+func describeIngestLock(lock *ingestLockInfo) string {
+	desc := fmt.Sprintf("PID %d", lock.PID)
+	if lock.Hostname != "" {
+		desc += " on " + lock.Hostname
+	}
+	if !lock.StartedAt.IsZero() {
+		desc += fmt.Sprintf(", started %s (%s ago)", lock.StartedAt.Local().Format("2006-01-02 15:04:05"), formatProgressDuration(time.Since(lock.StartedAt)))
+	}
+	return desc
 }
 
 func processExists(pid int) bool {
 	return syscall.Kill(pid, 0) == nil
 }
 
+// ingestInterrupted reports an ingest stopped by Ctrl-C. Everything up to the
+// last checkpoint is saved, so re-running picks up where it stopped. The
+// returned error only sets the exit status; usage and error text are
+// silenced since the message above already explains what happened.
+func ingestInterrupted(cmd *cobra.Command) error {
+	fmt.Println()
+	color.New(color.FgHiYellow).Println("  Ingest interrupted. Progress so far is saved; run the same command again to resume.")
+	fmt.Println()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return context.Canceled
+}
+
 func runIngest(cmd *cobra.Command, args []string) error {
 	path := "."
 	if len(args) > 0 {
 		path = args[0]
 	}
+	if ingestURL != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("cannot use both a path and --url")
+		}
+		if ingestIndexOnly {
+			return fmt.Errorf("--index-only cannot be used with --url (remote clones have no working tree)")
+		}
+		// A bare clone has no files to index.
+		ingestGitOnly = true
+	}
+	filters, err := normalizePathFilters(ingestPathFilters)
+	if err != nil {
+		return err
+	}
+	ingestPathFilters = filters
 
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return fmt.Errorf("invalid path: %w", err)
 	}
 
-	release, err := acquireIngestLock()
+	if ingestForceUnlock {
+		return forceIngestUnlock()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	release, err := acquireIngestLock(cfg.GetIngestLockMaxAge())
 	if err != nil {
 		return err
 	}
 	defer release()
 
+	// Ctrl-C stops the ingest at the next safe point (between commits or
+	// files) so cursors are flushed and the lock is released by the defer
+	// above. A second Ctrl-C falls back to the default immediate exit.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	if ingestURL != "" {
+		clonePath, cleanup, err := cloneRemoteForIngest(ingestURL)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		absPath = clonePath
+	}
+
 	titleColor := color.New(color.FgHiCyan, color.Bold)
 	successColor := color.New(color.FgHiGreen)
 	dimColor := color.New(color.FgHiBlack)
 	promptColor := color.New(color.FgYellow)
 
-	cfg, err := config.Load()
-	if err != nil {
+	if err := cfg.UseProjectConfig(absPath); err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if path := cfg.ProjectConfigPath(); path != "" {
+		VerboseLog("Using project config %s", path)
+		if mode := cfg.GetSummaryMode(); mode != "" && !cmd.Flags().Changed("summary-mode") {
+			ingestSummaryMode = mode
+		}
+	}
 
 	if err := cfg.EnsureDefaultProfile(); err != nil {
 		return fmt.Errorf("failed to ensure default profile: %w", err)
@@ -233,17 +470,25 @@ func runIngest(cmd *cobra.Command, args []string) error {
 	db.SetActiveProfile(cfg.GetActiveProfileName())
 
 	profileName := cfg.GetActiveProfileName()
-	if err := cfg.AddRepoToProfile(profileName, absPath); err != nil {
-		VerboseLog("Warning: failed to add repo to profile: %v", err)
-	} else {
-		if err := cfg.Save(); err != nil {
-			VerboseLog("Warning: failed to save config: %v", err)
+	// Temporary clones are removed after ingest, so only local repos are
+	// remembered in the profile.
+	if ingestURL == "" {
+		if err := cfg.AddRepoToProfile(profileName, absPath); err != nil {
+			VerboseLog("Warning: failed to add repo to profile: %v", err)
+		} else {
+			if err := cfg.Save(); err != nil {
+				VerboseLog("Warning: failed to save config: %v", err)
+			}
 		}
 	}
 
 	fmt.Println()
 	titleColor.Printf("  Ingesting Repository\n")
-	dimColor.Printf("  %s\n", absPath)
+	if ingestURL != "" {
+		dimColor.Printf("  %s\n", ingestURL)
+	} else {
+		dimColor.Printf("  %s\n", absPath)
+	}
 	dimColor.Printf("  Profile: %s\n\n", profileName)
 
 	gitHistoryIngested := false
@@ -260,13 +505,19 @@ func runIngest(cmd *cobra.Command, args []string) error {
 	}
 
 	if !ingestGitOnly {
-		if err := indexCodebase(absPath, cfg); err != nil {
+		if err := indexCodebase(ctx, absPath, cfg); err != nil {
+			if ctx.Err() != nil {
+				return ingestInterrupted(cmd)
+			}
 			return fmt.Errorf("indexing failed: %w", err)
 		}
 	}
 
 	if canIngestGit {
-		if err := ingestGitHistory(absPath, cfg); err != nil {
+		if err := ingestGitHistory(ctx, absPath, cfg); err != nil {
+			if ctx.Err() != nil {
+				return ingestInterrupted(cmd)
+			}
 			VerboseLog("Git ingest warning: %v", err)
 			dimColor.Printf("  Note: Git ingestion skipped (%v)\n\n", err)
 		} else {
@@ -277,11 +528,18 @@ func runIngest(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	successColor.Printf("  Ingestion Complete!\n\n")
 
-	// Handle worklog generation if git history was ingested.
+	// Handle worklog generation if git history was ingested. The flags
+	// override the profile's auto_worklog_after_ingest.
 	if gitHistoryIngested {
+		worklogMode := cfg.GetAutoWorklogAfterIngest()
 		if ingestSkipWorklog {
-			dimColor.Println("  Use 'devlog worklog' to view your development activity")
+			worklogMode = config.AutoWorklogNever
 		} else if ingestAutoWorklog {
+			worklogMode = config.AutoWorklogAlways
+		}
+		if worklogMode == config.AutoWorklogNever {
+			dimColor.Println("  Use 'devlog worklog' to view your development activity")
+		} else if worklogMode == config.AutoWorklogAlways {
 			fmt.Println()
 			if err := generateWorklogAfterIngest(absPath, cfg); err != nil {
 				dimColor.Printf("  Warning: Failed to generate worklog: %v\n", err)
@@ -310,9 +568,84 @@ func runIngest(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// cloneRemoteForIngest bare-clones url for a one-off ingest and returns the
+// clone path and a cleanup function. The clone lives at a stable temp path
+// derived from the URL, so repeated ingests of the same remote map to the
+// same codebase and saved branch selection.
+func cloneRemoteForIngest(url string) (string, func(), error) {
+	dimColor := color.New(color.FgHiBlack)
+
+	var since time.Time
+	switch {
+	case ingestAll:
+	case ingestSince != "":
+		sinceDate, err := time.Parse("2006-01-02", ingestSince)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid --since date (expected YYYY-MM-DD): %w", err)
+		}
+		since = sinceDate
+	default:
+		since = time.Now().AddDate(0, 0, -ingestDays)
+	}
+	parent := filepath.Join(os.TempDir(), "devlog-remote", sanitizeToken(remoteURLKey(url)))
+	clonePath := filepath.Join(parent, remoteRepoName(url))
+	cleanup := func() {
+		if err := os.RemoveAll(parent); err != nil {
+			VerboseLog("Warning: failed to remove temporary clone %s: %v", parent, err)
+		}
+	}
+	// Clear out a clone left behind by an interrupted run.
+	cleanup()
+
+	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
+	s.Suffix = " Cloning " + url
+	s.Start()
+	_, err := git.CloneRepo(url, clonePath, since)
+	s.Stop()
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	dimColor.Printf("  Cloned %s\n", url)
+	return clonePath, cleanup, nil
+}
+
+// remoteURLKey strips the scheme and credentials from a remote URL so it can
+// be used as a directory name.
+func remoteURLKey(url string) string {
+	key := url
+	if i := strings.Index(key, "://"); i >= 0 {
+		key = key[i+3:]
+	}
+	if i := strings.LastIndex(key, "@"); i >= 0 {
+		key = key[i+1:]
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(key, "/"), ".git")
+}
+
+// remoteRepoName returns the repository name from an https, ssh or scp-style
+// remote URL, e.g. "repo" for "git@github.com:org/repo.git".
+func remoteRepoName(url string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return sanitizeToken(name)
+}
+
 type BranchSelection struct {
 	MainBranch       string
 	SelectedBranches []string
+	BaseBranches     map[string]string // stacked branch -> the branch it was cut from
+}
+
+// baseBranchFor returns the branch whose commits are excluded when ingesting
+// branch: its saved base-branch override, or the main branch.
+func (s *BranchSelection) baseBranchFor(branch string) string {
+	if base := s.BaseBranches[branch]; base != "" && base != branch {
+		return base
+	}
+	return s.MainBranch
 }
 
 type branchSelectionMode string
@@ -346,6 +679,9 @@ func generateWorklogAfterIngest(absPath string, cfg *config.Config) error {
 			loc = tzLoc
 		}
 	}
+	worklogStripGitmoji = cfg.GetStripGitmoji()
+	worklogGroupCommits = cfg.GetGroupDailyCommits()
+	worklogMaxWords = cfg.GetWorklogSummaryMaxWords()
 
 	// Determine date range based on ingest flags
 	endDate := time.Now().In(loc)
@@ -391,7 +727,7 @@ func generateWorklogAfterIngest(absPath string, cfg *config.Config) error {
 	// Create LLM client if needed
 	var client llm.Client
 	if !ingestSkipSummaries && !ingestSkipCommitSums {
-		client, err = createLLMClient(cfg)
+		client, err = createLLMClient(cfg, "", "")
 		if err != nil {
 			dimColor.Printf("  Skipping LLM summaries: %v\n", err)
 		}
@@ -441,7 +777,15 @@ func generateWorklogAfterIngest(absPath string, cfg *config.Config) error {
 		}
 	}
 
-	outputPath := fmt.Sprintf("worklog_%s_%s.md", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	outputPath, err := resolveWorklogOutputPath(cfg.GetWorklogOutputDir(), fmt.Sprintf("worklog_%s_%s.md", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")), codebase)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(outputPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
 
 	if err := os.WriteFile(outputPath, []byte(markdown), 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
@@ -453,8 +797,7 @@ func generateWorklogAfterIngest(absPath string, cfg *config.Config) error {
 	return nil
 }
 
-func ingestGitHistory(absPath string, cfg *config.Config) error {
-	ctx := context.Background()
+func ingestGitHistory(ctx context.Context, absPath string, cfg *config.Config) error {
 	titleColor := color.New(color.FgHiCyan, color.Bold)
 	successColor := color.New(color.FgHiGreen)
 	dimColor := color.New(color.FgHiBlack)
@@ -479,10 +822,11 @@ func ingestGitHistory(absPath string, cfg *config.Config) error {
 	}
 
 	if codebase == nil {
-		defaultBranch, err := repo.GetDefaultBranch()
+		defaultBranch, method, err := repo.DetectDefaultBranch()
 		if err != nil {
 			return fmt.Errorf("failed to detect default branch: %w", err)
 		}
+		VerboseLog("Detected default branch %q via %s", defaultBranch, method)
 		codebase = &db.Codebase{
 			ID:            uuid.New().String(),
 			Path:          absPath,
@@ -494,6 +838,12 @@ func ingestGitHistory(absPath string, cfg *config.Config) error {
 			return fmt.Errorf("failed to create codebase: %w", err)
 		}
 	}
+	if remoteURL := repo.RemoteURL(); remoteURL != codebase.RemoteURL {
+		codebase.RemoteURL = remoteURL
+		if err := dbRepo.UpsertCodebase(ctx, codebase); err != nil {
+			VerboseLog("Warning: failed to save remote URL: %v", err)
+		}
+	}
 
 	userEmail := cfg.GetEffectiveUserEmail()
 	if userEmail == "" {
@@ -571,11 +921,19 @@ func ingestGitHistory(absPath string, cfg *config.Config) error {
 	var totalCommits, totalFiles int
 	var llmClient llm.Client
 	if !ingestSkipCommitSums && !ingestSkipSummaries {
-		if llmClient, err = createLLMClient(cfg); err != nil {
+		if llmClient, err = createLLMClient(cfg, ingestProvider, ingestTaskModel(cfg.GetCommitSummaryModel())); err != nil {
 			return fmt.Errorf("failed to initialize LLM client: %w\n\nTo skip summaries, use: --skip-summaries or --skip-commit-summaries", err)
 		}
 	}
 
+	bots, err := newBotFilter(cfg)
+	if err != nil {
+		return err
+	}
+	ingestMergeStats = cfg.GetMergeStats()
+	ingestSummaryMinChurn = cfg.GetCommitSummaryMinChurn()
+	ingestSummaryMaxWords = cfg.GetCommitSummaryMaxWords()
+
 	existingHashes, err := dbRepo.GetExistingCommitHashes(ctx, codebase.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get existing commit hashes: %w", err)
@@ -589,6 +947,8 @@ func ingestGitHistory(absPath string, cfg *config.Config) error {
 
 	fmt.Println()
 	dimColor.Printf("  Scanning branches...\n")
+	baseHashes := newBaseBranchHashCache(repo)
+	reverts := newRevertTracker(dbRepo, codebase.ID)
 
 	for _, branchInfo := range allBranches {
 		if branchInfo.Name != selection.MainBranch {
@@ -596,8 +956,11 @@ func ingestGitHistory(absPath string, cfg *config.Config) error {
 		}
 		dimColor.Printf("    Processing %s (main)...\n", branchInfo.Name)
 		branchInfo.IsDefault = true
-		commits, files, err := ingestBranch(ctx, dbRepo, repo, codebase, branchInfo, "", sinceDate, userEmail, githubUsername, llmClient, existingHashes)
+		commits, files, err := ingestBranch(ctx, dbRepo, repo, codebase, branchInfo, "", sinceDate, userEmail, githubUsername, llmClient, existingHashes, baseHashes, bots, reverts)
 		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			warnColor := color.New(color.FgHiYellow)
 			warnColor.Printf("    Skipping %s: %v\n", branchInfo.Name, err)
 			continue
@@ -611,13 +974,30 @@ func ingestGitHistory(absPath string, cfg *config.Config) error {
 		}
 	}
 
+	branchExists := make(map[string]bool, len(allBranches))
+	for _, b := range allBranches {
+		branchExists[b.Name] = true
+	}
+
 	for _, branchInfo := range allBranches {
 		if branchInfo.Name == selection.MainBranch || !selectedMap[branchInfo.Name] {
 			continue
 		}
-		dimColor.Printf("    Processing %s...\n", branchInfo.Name)
-		commits, files, err := ingestBranch(ctx, dbRepo, repo, codebase, branchInfo, selection.MainBranch, sinceDate, userEmail, githubUsername, llmClient, existingHashes)
+		baseBranch := selection.baseBranchFor(branchInfo.Name)
+		if !branchExists[baseBranch] {
+			VerboseLog("Warning: base branch %q of %s not found, using %s", baseBranch, branchInfo.Name, selection.MainBranch)
+			baseBranch = selection.MainBranch
+		}
+		if baseBranch != selection.MainBranch {
+			dimColor.Printf("    Processing %s (based on %s)...\n", branchInfo.Name, baseBranch)
+		} else {
+			dimColor.Printf("    Processing %s...\n", branchInfo.Name)
+		}
+		commits, files, err := ingestBranch(ctx, dbRepo, repo, codebase, branchInfo, baseBranch, sinceDate, userEmail, githubUsername, llmClient, existingHashes, baseHashes, bots, reverts)
 		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			warnColor := color.New(color.FgHiYellow)
 			warnColor.Printf("    Skipping %s: %v\n", branchInfo.Name, err)
 			continue
@@ -629,6 +1009,16 @@ func ingestGitHistory(absPath string, cfg *config.Config) error {
 		}
 	}
 
+	if filled := fillMissingParents(ctx, dbRepo, repo, codebase.ID); filled > 0 {
+		VerboseLog("Recorded parent hashes for %d previously ingested commits", filled)
+	}
+	if filled := fillMissingSignatures(ctx, dbRepo, repo, codebase.ID); filled > 0 {
+		VerboseLog("Recorded signatures for %d previously ingested commits", filled)
+	}
+	if cleared := clearStaleBotFlags(ctx, dbRepo, bots, codebase.ID); cleared > 0 {
+		VerboseLog("Unflagged %d commits that no longer match a bot pattern", cleared)
+	}
+
 	if ingestFillSummaries && llmClient != nil {
 		fillCount, err := fillMissingSummaries(ctx, dbRepo, repo, codebase, llmClient)
 		if err != nil {
@@ -673,10 +1063,11 @@ func prepareBranchSelection(absPath string, cfg *config.Config) (*BranchSelectio
 		return nil, fmt.Errorf("failed to get codebase: %w", err)
 	}
 	if codebase == nil {
-		defaultBranch, err := repo.GetDefaultBranch()
+		defaultBranch, method, err := repo.DetectDefaultBranch()
 		if err != nil {
 			return nil, fmt.Errorf("failed to detect default branch: %w", err)
 		}
+		VerboseLog("Detected default branch %q via %s", defaultBranch, method)
 		codebase = &db.Codebase{
 			ID:            uuid.New().String(),
 			Path:          absPath,
@@ -716,19 +1107,69 @@ func prepareBranchSelection(absPath string, cfg *config.Config) (*BranchSelectio
 	return selection, nil
 }
 
+// selectBranchesByGlob selects the default branch, as the main branch, and
+// every branch whose name matches one of globs (path.Match syntax, so '*'
+// stops at '/').
+func selectBranchesByGlob(branches []git.BranchInfo, detectedDefault string, globs []string, savedBases map[string]string) (*BranchSelection, error) {
+	for _, g := range globs {
+		if _, err := path.Match(g, ""); err != nil {
+			return nil, fmt.Errorf("invalid --branches-glob %q: %w", g, err)
+		}
+	}
+	mainBranch := detectedDefault
+	for _, b := range branches {
+		if b.IsDefault {
+			mainBranch = b.Name
+		}
+	}
+	selected := []string{mainBranch}
+	matched := 0
+	for _, b := range branches {
+		for _, g := range globs {
+			if ok, _ := path.Match(g, b.Name); ok {
+				matched++
+				if b.Name != mainBranch {
+					selected = append(selected, b.Name)
+				}
+				break
+			}
+		}
+	}
+	if matched == 0 {
+		return nil, fmt.Errorf("no branches match --branches-glob %s", strings.Join(globs, ", "))
+	}
+	return &BranchSelection{
+		MainBranch:       mainBranch,
+		SelectedBranches: selected,
+		BaseBranches:     savedBases,
+	}, nil
+}
+
 func selectBranches(branches []git.BranchInfo, detectedDefault string, cfg *config.Config, repoPath string, repo *git.Repository, userEmail, githubUsername string) (*BranchSelection, error) {
 	dimColor := color.New(color.FgHiBlack)
 	infoColor := color.New(color.FgCyan)
 	promptColor := color.New(color.FgYellow)
 
+	profileName := cfg.GetActiveProfileName()
+	saved := cfg.GetBranchSelection(profileName, repoPath)
+	var savedBases map[string]string
+	if saved != nil {
+		savedBases = saved.BaseBranches
+	}
+
 	if len(ingestBranches) > 0 {
 		mainBranch := ingestBranches[0]
 		return &BranchSelection{
 			MainBranch:       mainBranch,
 			SelectedBranches: ingestBranches,
+			BaseBranches:     savedBases,
 		}, nil
 	}
 
+	if len(ingestBranchGlobs) > 0 {
+		return selectBranchesByGlob(branches, detectedDefault, ingestBranchGlobs, savedBases)
+	}
+
 	if ingestAllBranches {
 		var branchNames []string
 		mainBranch := detectedDefault
@@ -741,12 +1182,10 @@ func selectBranches(branches []git.BranchInfo, detectedDefault string, cfg *conf
 		return &BranchSelection{
 			MainBranch:       mainBranch,
 			SelectedBranches: branchNames,
+			BaseBranches:     savedBases,
 		}, nil
 	}
 
-	profileName := cfg.GetActiveProfileName()
-	saved := cfg.GetBranchSelection(profileName, repoPath)
-
 	if saved != nil && len(saved.SelectedBranches) > 0 && !ingestReselectBranch {
 		branchMap := make(map[string]bool)
 		for _, b := range branches {
@@ -765,6 +1204,11 @@ func selectBranches(branches []git.BranchInfo, detectedDefault string, cfg *conf
 			infoColor.Printf("  Saved branch selection:\n")
 			dimColor.Printf("    Main: %s\n", saved.MainBranch)
 			dimColor.Printf("    Branches: %s\n", strings.Join(validBranches, ", "))
+			for _, b := range validBranches {
+				if base := savedBases[b]; base != "" {
+					dimColor.Printf("    Base of %s: %s\n", b, base)
+				}
+			}
 			fmt.Println()
 
 			promptColor.Printf("  [Enter] Use current selection  [a] Auto  [m] Manual modify  [r] Reselect manual: ")
@@ -779,6 +1223,7 @@ func selectBranches(branches []git.BranchInfo, detectedDefault string, cfg *conf
 				return &BranchSelection{
 					MainBranch:       saved.MainBranch,
 					SelectedBranches: validBranches,
+					BaseBranches:     savedBases,
 				}, nil
 
 			case "a", "auto", "automatic":
@@ -791,7 +1236,7 @@ func selectBranches(branches []git.BranchInfo, detectedDefault string, cfg *conf
 
 			case "m", "modify":
 				fmt.Println()
-				selection, err := tui.RunBranchSelectionWithPreselected(branches, saved.MainBranch, validBranches)
+				selection, err := tui.RunBranchSelectionWithPreselected(branches, saved.MainBranch, validBranches, savedBases)
 				if err != nil {
 					return nil, err
 				}
@@ -890,7 +1335,7 @@ func saveBranchSelectionRaw(cfg *config.Config, profileName, repoPath, mainBranc
 	fmt.Println()
 	dimColor.Printf("  Selected %d branch(es): %s\n", len(selectedBranches), strings.Join(selectedBranches, ", "))
 
-	if err := cfg.SaveBranchSelection(profileName, repoPath, mainBranch, selectedBranches); err != nil {
+	if err := cfg.SaveBranchSelection(profileName, repoPath, mainBranch, selectedBranches, nil); err != nil {
 		VerboseLog("Warning: failed to save branch selection: %v", err)
 	} else {
 		if err := cfg.Save(); err != nil {
@@ -901,17 +1346,21 @@ func saveBranchSelectionRaw(cfg *config.Config, profileName, repoPath, mainBranc
 	}
 	fmt.Println()
 
-	return &BranchSelection{
+	selection := &BranchSelection{
 		MainBranch:       mainBranch,
 		SelectedBranches: selectedBranches,
 	}
+	if saved := cfg.GetBranchSelection(profileName, repoPath); saved != nil {
+		selection.BaseBranches = saved.BaseBranches
+	}
+	return selection
 }
 
 func saveBranchSelection(cfg *config.Config, profileName, repoPath string, selection *tui.BranchSelection, dimColor *color.Color) (*BranchSelection, error) {
 	fmt.Println()
 	dimColor.Printf("  Selected %d branch(es): %s\n", len(selection.SelectedBranches), strings.Join(selection.SelectedBranches, ", "))
 
-	if err := cfg.SaveBranchSelection(profileName, repoPath, selection.MainBranch, selection.SelectedBranches); err != nil {
+	if err := cfg.SaveBranchSelection(profileName, repoPath, selection.MainBranch, selection.SelectedBranches, selection.BaseBranches); err != nil {
 		VerboseLog("Warning: failed to save branch selection: %v", err)
 	} else {
 		if err := cfg.Save(); err != nil {
@@ -925,10 +1374,38 @@ func saveBranchSelection(cfg *config.Config, profileName, repoPath string, selec
 	return &BranchSelection{
 		MainBranch:       selection.MainBranch,
 		SelectedBranches: selection.SelectedBranches,
+		BaseBranches:     selection.BaseBranches,
 	}, nil
 }
 
-func ingestBranch(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repository, codebase *db.Codebase, branchInfo git.BranchInfo, baseBranch string, sinceDate time.Time, userEmail string, githubUsername string, llmClient llm.Client, existingHashes map[string]bool) (int, int, error) {
+// baseBranchHashCache memoizes base-branch commit hash sets for the duration
+// of an ingest, so feature branches sharing a base don't each walk its full
+// history.
+type baseBranchHashCache struct {
+	repo *git.Repository
+	sets map[string]map[string]bool
+}
+
+func newBaseBranchHashCache(repo *git.Repository) *baseBranchHashCache {
+	return &baseBranchHashCache{repo: repo, sets: make(map[string]map[string]bool)}
+}
+
+// get returns the commit hash set for base, loading it on first use. A base
+// that fails to load is cached as empty so the failure is only logged once.
+func (c *baseBranchHashCache) get(base string) map[string]bool {
+	if hashes, ok := c.sets[base]; ok {
+		return hashes
+	}
+	hashes, err := c.repo.GetCommitHashSet(base)
+	if err != nil {
+		VerboseLog("Warning: failed to load base branch hash set for %s: %v", base, err)
+		hashes = map[string]bool{}
+	}
+	c.sets[base] = hashes
+	return hashes
+}
+
+func ingestBranch(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repository, codebase *db.Codebase, branchInfo git.BranchInfo, baseBranch string, sinceDate time.Time, userEmail string, githubUsername string, llmClient llm.Client, existingHashes map[string]bool, baseHashes *baseBranchHashCache, bots *botFilter, reverts *revertTracker) (int, int, error) {
 	branch, err := dbRepo.GetBranch(ctx, codebase.ID, branchInfo.Name)
 	if err != nil {
 		return 0, 0, err
@@ -943,7 +1420,7 @@ func ingestBranch(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repos
 			Name:       branchInfo.Name,
 			IsDefault:  isDefault,
 			BaseBranch: baseBranch,
-			Status:     "active",
+			Status:     db.BranchStatusActive,
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
 		}
@@ -986,14 +1463,34 @@ func ingestBranch(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repos
 	var firstHash, latestHash string
 	baseBranchHashes := map[string]bool{}
 	if !isDefault && baseBranch != "" {
-		if hashes, hashErr := repo.GetCommitHashSet(baseBranch); hashErr == nil {
-			baseBranchHashes = hashes
-		} else {
-			VerboseLog("Warning: failed to load base branch hash set for %s: %v", baseBranch, hashErr)
+		baseBranchHashes = baseHashes.get(baseBranch)
+	}
+
+	// Process oldest-first so the cursor can be checkpointed as we go: every
+	// commit older than the checkpoint has been fully persisted, so a crashed
+	// ingest resumes from the checkpoint instead of rescanning the branch.
+	var checkpointHash string
+	checkpointHeld := false
+	sinceCheckpoint := 0
+	var filePurposes map[string]string
+	if llmClient != nil && len(newCommitHashes) > 0 {
+		filePurposes = loadFilePurposes(ctx, dbRepo, codebase.ID)
+	}
+
+	// Cancellation is only checked between commits; database writes use a
+	// context that survives it, so the commit in flight and the final cursor
+	// flush are never cut short.
+	interrupt := ctx
+	ctx = context.WithoutCancel(ctx)
+	progress := newProgressBar("      ", "commits", len(newCommitHashes))
+	defer progress.Done()
+	for i := len(newCommitHashes) - 1; i >= 0; i-- {
+		progress.Set(len(newCommitHashes) - 1 - i)
+		if interrupt.Err() != nil {
+			VerboseLog("Interrupted on %s after %d commits", branchInfo.Name, commitCount)
+			break
 		}
-	}
-
-	for _, hash := range newCommitHashes {
+		hash := newCommitHashes[i]
 		gitCommit, err := repo.GetCommit(hash)
 		if err != nil {
 			return 0, 0, fmt.Errorf("failed to get commit %s: %w", hash, err)
@@ -1004,10 +1501,27 @@ func ingestBranch(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repos
 			continue
 		}
 
-		if latestHash == "" {
-			latestHash = hash
+		isMergeSync := isMergeSyncCommit(gitCommit, baseBranch, isDefault, baseBranchHashes)
+		stats, fileChanges, err := getCommitStats(repo, gitCommit, resolveMergeStatsMode(ingestMergeStats, isMergeSync))
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to get stats for commit %s: %w", hash[:8], err)
+		}
+		if len(ingestPathFilters) > 0 {
+			stats, fileChanges = filterFileChanges(fileChanges, ingestPathFilters)
+			if len(fileChanges) == 0 {
+				VerboseLog("Skipping commit %s: no changes under --path-filter", hash[:8])
+				// Nothing to store, but the cursor may still move past it.
+				if !checkpointHeld {
+					checkpointHash = hash
+				}
+				continue
+			}
+		}
+
+		if firstHash == "" {
+			firstHash = hash
 		}
-		firstHash = hash
+		latestHash = hash
 
 		author := gitCommit.Author
 		dev := &db.Developer{ID: author.Email, Name: author.Name, Email: author.Email}
@@ -1016,25 +1530,62 @@ func ingestBranch(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repos
 		}
 
 		isUserCommit := (userEmail != "" && strings.EqualFold(author.Email, userEmail)) || isUserCommitByGitHub(author.Email, githubUsername)
-		parentCount := gitCommit.NumParents()
-		isMergeSync := isMergeSyncCommit(gitCommit, baseBranch, isDefault, baseBranchHashes)
 
-		stats, fileChanges, err := getCommitStats(repo, gitCommit)
-		if err != nil {
-			return 0, 0, fmt.Errorf("failed to get stats for commit %s: %w", hash[:8], err)
+		var coAuthors []db.CommitCoAuthor
+		isUserCoAuthor := false
+		for _, coDev := range parseCoAuthors(gitCommit.Message, author.Email) {
+			if err := dbRepo.UpsertDeveloper(ctx, coDev); err != nil {
+				return 0, 0, fmt.Errorf("failed to upsert developer %s: %w", coDev.Email, err)
+			}
+			isUser := (userEmail != "" && strings.EqualFold(coDev.Email, userEmail)) || isUserCommitByGitHub(coDev.Email, githubUsername)
+			isUserCoAuthor = isUserCoAuthor || isUser
+			coAuthors = append(coAuthors, db.CommitCoAuthor{CodebaseID: codebase.ID, CommitHash: hash, DeveloperID: coDev.ID, IsUser: isUser})
+		}
+		parentCount := gitCommit.NumParents()
+		parents := make([]string, 0, parentCount)
+		for _, p := range gitCommit.ParentHashes {
+			parents = append(parents, p.String())
+		}
+		isBot := bots.isBot(author.Email, gitCommit.Message)
+		if isBot {
+			VerboseLog("Flagging commit %s as a bot commit", hash[:8])
 		}
 
 		var commitSummary string
-		if isUserCommit && !isMergeSync && llmClient != nil && len(fileChanges) > 0 {
+		// Commits the user co-authored are summarized too, so they are ready
+		// if the profile counts co-authored commits as the user's own.
+		if (isUserCommit || isUserCoAuthor) && !isMergeSync && !isBot && llmClient != nil && len(fileChanges) > 0 {
 			projectCtx := ""
 			if codebase != nil {
 				projectCtx = codebase.Summary
 			}
-			summary, err := generateCommitSummary(llmClient, gitCommit.Message, fileChanges, projectCtx)
-			if err != nil {
-				return 0, 0, fmt.Errorf("failed to generate commit summary for %s: %w", hash[:8], err)
+			if terse := terseCommitSummary(gitCommit.Message, fileChanges, ingestSummaryMinChurn); terse != "" {
+				VerboseLog("Commit %s is below commit_summary_min_churn, using its subject line", hash[:8])
+				commitSummary = terse
+			} else {
+				summary, err := generateCommitSummary(llmClient, gitCommit.Message, fileChanges, projectCtx, filePurposes, ingestSummaryMaxWords)
+				if err != nil {
+					return 0, 0, fmt.Errorf("failed to generate commit summary for %s: %w", hash[:8], err)
+				}
+				commitSummary = summary
+			}
+		}
+
+		var revertsHash string
+		if parentCount == 1 {
+			revertsHash = reverts.revertedHash(ctx, gitCommit)
+		}
+
+		var commitType string
+		if revertsHash != "" {
+			VerboseLog("Commit %s reverts %s", hash[:8], revertsHash[:8])
+			commitType = "revert"
+		} else {
+			var classifyClient llm.Client
+			if isUserCommit && !isMergeSync && !isBot {
+				classifyClient = llmClient
 			}
-			commitSummary = summary
+			commitType = classifyCommitType(classifyClient, gitCommit.Message, parentCount, fileChanges)
 		}
 
 		commit := &db.Commit{
@@ -1050,33 +1601,78 @@ func ingestBranch(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repos
 			IsUserCommit:      isUserCommit,
 			IsOnDefaultBranch: isDefault,
 			ParentCount:       parentCount,
+			Parents:           parents,
 			IsMergeSync:       isMergeSync,
+			IsSigned:          gitCommit.PGPSignature != "",
+			CommitType:        commitType,
+			IsBot:             isBot,
+			RevertsHash:       revertsHash,
 		}
 
-		if err := dbRepo.UpsertCommit(ctx, commit); err != nil {
+		if err := dbRepo.UpsertCommitWithFileChanges(ctx, commit, fileChanges); err != nil {
 			VerboseLog("Warning: failed to insert commit %s: %v", hash[:8], err)
+			// Never checkpoint past a commit that was not persisted.
+			checkpointHeld = true
 			continue
 		}
 
-		existingHashes[hash] = true
+		// Merge-sync commits repeat changes already counted on their branch.
+		if !isMergeSync && len(fileChanges) > 0 {
+			churn := make(map[string]int, len(fileChanges))
+			for _, fc := range fileChanges {
+				churn[fc.FilePath] += fc.Additions + fc.Deletions
+			}
+			if err := dbRepo.AddFileChurn(ctx, codebase.ID, author.When, churn); err != nil {
+				VerboseLog("Warning: failed to record file churn for %s: %v", hash[:8], err)
+			}
+		}
 
-		for _, fc := range fileChanges {
-			fc.CommitID = commit.ID
-			if err := dbRepo.CreateFileChange(ctx, fc); err != nil {
-				VerboseLog("Warning: failed to insert file change: %v", err)
+		if len(coAuthors) > 0 {
+			if err := dbRepo.ReplaceCommitCoAuthors(ctx, codebase.ID, hash, coAuthors); err != nil {
+				VerboseLog("Warning: failed to store co-authors for %s: %v", hash[:8], err)
 			}
-			fileCount++
 		}
-		if isUserCommit && len(fileChanges) > 0 {
+
+		existingHashes[hash] = true
+		fileCount += len(fileChanges)
+		if isUserCommit && !isBot && len(fileChanges) > 0 {
 			updateCodebaseTouchActivity(codebase, author.When, fileChanges)
 		}
 
 		commitCount++
+
+		if checkpointHeld {
+			continue
+		}
+		checkpointHash = hash
+		sinceCheckpoint++
+		if sinceCheckpoint >= ingestCheckpointInterval {
+			if err := dbRepo.UpdateBranchCursor(ctx, codebase.ID, branchInfo.Name, checkpointHash); err != nil {
+				VerboseLog("Warning: failed to checkpoint branch cursor for %s: %v", branchInfo.Name, err)
+			} else {
+				VerboseLog("Checkpointed %s at %s", branchInfo.Name, checkpointHash[:8])
+				if err := dbRepo.UpsertCodebase(ctx, codebase); err != nil {
+					VerboseLog("Warning: failed to persist incremental codebase touch activity: %v", err)
+				}
+			}
+			sinceCheckpoint = 0
+		}
 	}
+	if interrupt.Err() == nil {
+		progress.Set(len(newCommitHashes))
+	}
+	progress.Done()
 
 	if commitCount > 0 || branch.ID != "" {
 		branch.CommitCount = commitCount
 		branch.IsDefault = isDefault
+		var tipTime time.Time
+		if tip, err := repo.GetCommit(branchInfo.Hash); err == nil {
+			tipTime = tip.Committer.When
+		} else {
+			VerboseLog("Warning: failed to read tip of %s: %v", branchInfo.Name, err)
+		}
+		branch.Status = classifyBranchStatus(isDefault, branchInfo.Hash, tipTime, baseBranchHashes, time.Duration(ingestStaleDays)*24*time.Hour, time.Now())
 		if firstHash != "" {
 			branch.FirstCommitHash = firstHash
 		}
@@ -1088,8 +1684,8 @@ func ingestBranch(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repos
 			return 0, 0, fmt.Errorf("failed to upsert branch %s: %w", branchInfo.Name, err)
 		}
 
-		if latestHash != "" {
-			if err := dbRepo.UpdateBranchCursor(ctx, codebase.ID, branchInfo.Name, latestHash); err != nil {
+		if checkpointHash != "" {
+			if err := dbRepo.UpdateBranchCursor(ctx, codebase.ID, branchInfo.Name, checkpointHash); err != nil {
 				return 0, 0, fmt.Errorf("failed to update branch cursor for %s: %w", branchInfo.Name, err)
 			}
 		}
@@ -1100,7 +1696,23 @@ func ingestBranch(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repos
 		}
 	}
 
-	return commitCount, fileCount, nil
+	return commitCount, fileCount, interrupt.Err()
+}
+
+// classifyBranchStatus marks a branch "merged" when its tip is reachable from
+// the base branch, "stale" when its newest commit is older than staleAfter,
+// and "active" otherwise. The default branch is always active.
+func classifyBranchStatus(isDefault bool, tipHash string, tipTime time.Time, baseBranchHashes map[string]bool, staleAfter time.Duration, now time.Time) string {
+	if isDefault {
+		return db.BranchStatusActive
+	}
+	if tipHash != "" && baseBranchHashes[tipHash] {
+		return db.BranchStatusMerged
+	}
+	if staleAfter > 0 && !tipTime.IsZero() && now.Sub(tipTime) > staleAfter {
+		return db.BranchStatusStale
+	}
+	return db.BranchStatusActive
 }
 
 func updateCodebaseTouchActivity(codebase *db.Codebase, committedAt time.Time, fileChanges []*db.FileChange) {
@@ -1142,7 +1754,26 @@ func updateCodebaseTouchActivity(codebase *db.Codebase, committedAt time.Time, f
 	}
 }
 
-func getCommitStats(repo *git.Repository, commit *git.Commit) (db.JSON, []*db.FileChange, error) {
+// resolveMergeStatsMode turns the auto merge_stats mode into a concrete one
+// for a commit. Merge-sync commits get no stats: everything they bring in
+// from the base branch is already counted through its own commits, even
+// with --include-merge-sync-stats. Other merges count only the edits the
+// merge itself made, such as conflict resolutions.
+func resolveMergeStatsMode(mode string, isMergeSync bool) string {
+	if mode != config.MergeStatsAuto {
+		return mode
+	}
+	if isMergeSync {
+		return config.MergeStatsNone
+	}
+	return config.MergeStatsAllParents
+}
+
+// getCommitStats diffs a commit and returns its line stats and file changes.
+// Merge commits are diffed according to mergeMode: against the first parent,
+// against all parents (only files that differ from each of them), or not at
+// all.
+func getCommitStats(repo *git.Repository, commit *git.Commit, mergeMode string) (db.JSON, []*db.FileChange, error) {
 	stats := db.JSON{
 		"additions":     0,
 		"deletions":     0,
@@ -1151,27 +1782,20 @@ func getCommitStats(repo *git.Repository, commit *git.Commit) (db.JSON, []*db.Fi
 
 	var fileChanges []*db.FileChange
 
-	// Get parent commit for diff
-	parentIter := commit.Parents()
-	parent, err := parentIter.Next()
-	if err != nil {
-		// Initial commit has no parent — that's fine, return empty stats
-		return stats, fileChanges, nil
-	}
-
-	parentTree, err := parent.Tree()
-	if err != nil {
-		return stats, fileChanges, fmt.Errorf("failed to get parent tree: %w", err)
-	}
-
-	commitTree, err := commit.Tree()
-	if err != nil {
-		return stats, fileChanges, fmt.Errorf("failed to get commit tree: %w", err)
+	// A root commit is diffed against an empty tree, so a project's first
+	// commit counts everything it adds.
+	diff := git.CommitChanges
+	if commit.NumParents() > 1 {
+		switch mergeMode {
+		case config.MergeStatsNone:
+			return stats, fileChanges, nil
+		case config.MergeStatsAllParents:
+			diff = git.CombinedCommitChanges
+		}
 	}
-
-	changes, err := parentTree.Diff(commitTree)
+	changes, err := diff(commit)
 	if err != nil {
-		return stats, fileChanges, fmt.Errorf("failed to diff trees: %w", err)
+		return stats, fileChanges, err
 	}
 
 	var totalAdditions, totalDeletions int
@@ -1200,6 +1824,12 @@ func getCommitStats(repo *git.Repository, commit *git.Commit) (db.JSON, []*db.Fi
 			continue
 		}
 
+		// The content hash covers the path and the changed lines only, not
+		// context or hunk positions, so a cherry-pick onto a slightly
+		// different base still hashes the same.
+		contentHash := sha256.New()
+		fmt.Fprintf(contentHash, "%s %s\n", fc.ChangeType, fc.FilePath)
+
 		patch, err := change.Patch()
 		if err == nil {
 			patchStr := patch.String()
@@ -1207,15 +1837,22 @@ func getCommitStats(repo *git.Repository, commit *git.Commit) (db.JSON, []*db.Fi
 				if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
 					fc.Additions++
 					totalAdditions++
+					fmt.Fprintln(contentHash, line)
 				} else if strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---") {
 					fc.Deletions++
 					totalDeletions++
+					fmt.Fprintln(contentHash, line)
 				}
 			}
 			if len(patchStr) < 10000 {
 				fc.Patch = patchStr
 			}
 		}
+		if fc.Additions == 0 && fc.Deletions == 0 {
+			// Binary files and mode changes have no lines to compare.
+			fmt.Fprintf(contentHash, "%s -> %s\n", change.From.TreeEntry.Hash, change.To.TreeEntry.Hash)
+		}
+		fc.ContentHash = hex.EncodeToString(contentHash.Sum(nil))
 
 		fileChanges = append(fileChanges, fc)
 	}
@@ -1227,8 +1864,67 @@ func getCommitStats(repo *git.Repository, commit *git.Commit) (db.JSON, []*db.Fi
 	return stats, fileChanges, nil
 }
 
-func indexCodebase(absPath string, cfg *config.Config) error {
-	ctx := context.Background()
+// normalizePathFilters cleans --path-filter values into slash-separated
+// paths relative to the repository root, without a trailing slash.
+func normalizePathFilters(filters []string) ([]string, error) {
+	var result []string
+	for _, f := range filters {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if filepath.IsAbs(f) {
+			return nil, fmt.Errorf("--path-filter %q must be relative to the repository root", f)
+		}
+		cleaned := path.Clean(filepath.ToSlash(f))
+		if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return nil, fmt.Errorf("--path-filter %q points outside the repository", f)
+		}
+		if cleaned == "." {
+			// The whole repository: no filtering at all.
+			return nil, nil
+		}
+		result = append(result, cleaned)
+	}
+	return result, nil
+}
+
+// matchesPathFilter reports whether a repo-relative file path is one of the
+// filters or lies under one of them.
+func matchesPathFilter(filePath string, filters []string) bool {
+	for _, f := range filters {
+		if filePath == f || strings.HasPrefix(filePath, f+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// filterFileChanges keeps the file changes under the path filters and
+// recomputes the commit stats from them.
+func filterFileChanges(changes []*db.FileChange, filters []string) (db.JSON, []*db.FileChange) {
+	var kept []*db.FileChange
+	var additions, deletions int
+	for _, fc := range changes {
+		if !matchesPathFilter(fc.FilePath, filters) {
+			continue
+		}
+		kept = append(kept, fc)
+		additions += fc.Additions
+		deletions += fc.Deletions
+	}
+	return db.JSON{
+		"additions":     additions,
+		"deletions":     deletions,
+		"files_changed": len(kept),
+	}, kept
+}
+
+func indexCodebase(ctx context.Context, absPath string, cfg *config.Config) error {
+	// As in ingestBranch, stop between folders and files rather than
+	// cancelling writes and LLM calls midway.
+	interrupt := ctx
+	ctx = context.WithoutCancel(ctx)
 	titleColor := color.New(color.FgHiCyan, color.Bold)
 	successColor := color.New(color.FgHiGreen)
 	infoColor := color.New(color.FgHiWhite)
@@ -1254,7 +1950,7 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 	s.Color("cyan")
 	s.Start()
 
-	scanResult, err := indexer.ScanCodebase(absPath, 500*1024, savedFolders)
+	scanResult, err := indexer.ScanCodebase(absPath, 500*1024, savedFolders, cfg.GetIgnorePatterns())
 	if err != nil {
 		s.Stop()
 		return fmt.Errorf("failed to scan codebase: %w", err)
@@ -1276,7 +1972,10 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 	totalFolders, internalFolders := countFolderStats(scanResult)
 	dimColor.Printf("  Scan stats: %d files, %d folders total, %d internal folders\n", len(scanResult.Files), totalFolders, internalFolders)
 
-	// Soft limit: if > 500 files, no saved config (or --reselect-folders), and not --all-files, prompt for folder selection
+	indexSoftLimit := cfg.GetIndexSoftLimit()
+	indexHardLimit := cfg.GetIndexHardLimit()
+
+	// Soft limit: if over the soft limit, no saved config (or --reselect-folders), and not --all-files, prompt for folder selection
 	needsFolderPrompt := len(scanResult.Files) > indexSoftLimit && !ingestAllFiles && ingestMaxFiles == 0 && len(savedFolders) == 0
 	if needsFolderPrompt {
 		allFolders := indexer.AllFoldersWithCounts(scanResult)
@@ -1298,7 +1997,7 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 			}
 			dimColor.Printf("  Saved folder selection. Re-scanning...\n")
 			s.Start()
-			scanResult, err = indexer.ScanCodebase(absPath, 500*1024, selection.SelectedFolders)
+			scanResult, err = indexer.ScanCodebase(absPath, 500*1024, selection.SelectedFolders, cfg.GetIgnorePatterns())
 			s.Stop()
 			if err != nil {
 				return fmt.Errorf("failed to re-scan codebase: %w", err)
@@ -1308,22 +2007,41 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 		}
 	}
 
-	// Hard limit: cap at 1000 unless --all-files or --max-files
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, absPath)
+	if err != nil {
+		return fmt.Errorf("failed to get codebase by path: %w", err)
+	}
+
+	// Hard limit: cap at the hard limit unless --all-files or --max-files,
+	// keeping the most relevant files rather than the first ones scanned
+	fileLimit, limitHint := 0, ""
 	if ingestMaxFiles > 0 {
-		if len(scanResult.Files) > ingestMaxFiles {
-			scanResult.Files = scanResult.Files[:ingestMaxFiles]
-			warnColor.Printf("  Limited to %d files (--max-files)\n", ingestMaxFiles)
+		fileLimit, limitHint = ingestMaxFiles, "--max-files"
+	} else if !ingestAllFiles {
+		fileLimit, limitHint = indexHardLimit, "use --all-files to index all, or raise index_hard_limit"
+	}
+	if fileLimit > 0 && len(scanResult.Files) > fileLimit {
+		var touchActivity map[string]any
+		if codebase != nil {
+			touchActivity = codebase.TouchActivity
+		}
+		var dropped []string
+		scanResult.Files, dropped = capFilesByRelevance(scanResult.Files, touchActivity, fileLimit)
+		warnColor.Printf("  Limited to %d files (%s)\n", fileLimit, limitHint)
+		if len(dropped) > 0 && !ingestNoLimitWarnings {
+			warnColor.Printf("  Left out %d folders: %s\n", len(dropped), joinMax(dropped, 8))
 		}
-	} else if !ingestAllFiles && len(scanResult.Files) > indexHardLimit {
-		scanResult.Files = scanResult.Files[:indexHardLimit]
-		warnColor.Printf("  Limited to %d files (use --all-files to index all)\n", indexHardLimit)
 	}
 
 	successColor.Printf("  Found %d files in %d folders (%d internal folders)\n", len(scanResult.Files), totalFolders, internalFolders)
 
-	summaryMode, modeReason := resolveSummaryMode(len(scanResult.Files))
+	summaryMode, modeReason := resolveSummaryMode(len(scanResult.Files), indexSoftLimit)
 	dimColor.Printf("  Summary mode: %s (%s)\n", summaryMode, modeReason)
 	enableSummaries := summaryMode != summaryModeOff
+	folderSummaryMaxDepth := cfg.GetFolderSummaryMaxDepth()
+	if ingestFillFileSums && !enableSummaries {
+		return fmt.Errorf("--fill-file-summaries needs summaries enabled, but summary mode is off")
+	}
 
 	techStack := indexer.DetectTechStack(scanResult.Files)
 	if len(techStack) > 0 {
@@ -1334,10 +2052,6 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 		dimColor.Printf("  Tech: %s\n", joinMax(techs, 5))
 	}
 
-	codebase, err := dbRepo.GetCodebaseByPath(ctx, absPath)
-	if err != nil {
-		return fmt.Errorf("failed to get codebase by path: %w", err)
-	}
 	isFirstIndex := codebase == nil
 	if codebase == nil {
 		codebase = &db.Codebase{
@@ -1353,10 +2067,17 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 	var summarizer *indexer.Summarizer
 
 	if enableSummaries {
-		if llmClient, err = createLLMClient(cfg); err != nil {
+		if llmClient, err = createLLMClient(cfg, ingestProvider, ingestModel); err != nil {
 			return fmt.Errorf("failed to initialize LLM client: %w\n\nTo skip file/folder summaries, use: --summary-mode off", err)
 		}
-		summarizer = indexer.NewSummarizer(llmClient, IsVerbose())
+		var fileClient llm.Client
+		if fileModel := ingestTaskModel(cfg.GetFileSummaryModel()); fileModel != "" && fileModel != ingestModel {
+			if fileClient, err = createLLMClient(cfg, ingestProvider, fileModel); err != nil {
+				return fmt.Errorf("failed to initialize file summary LLM client: %w", err)
+			}
+		}
+		summarizer = indexer.NewSummarizer(llmClient, fileClient, llmCallTimeout, IsVerbose())
+		summarizer.SetMaxWords(cfg.GetFileSummaryMaxWords(), cfg.GetFolderSummaryMaxWords())
 	}
 	shouldSummarizeCodebase := enableSummaries && summarizer != nil && (isFirstIndex || ingestForceReindex || strings.TrimSpace(codebase.Summary) == "")
 	if shouldSummarizeCodebase {
@@ -1368,6 +2089,7 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 				break
 			}
 		}
+		readmeContent += loadContextFiles(absPath, cfg.GetContextFiles())
 
 		s.Suffix = " Generating codebase summary..."
 		s.Start()
@@ -1406,6 +2128,16 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 			dimColor.Printf("  Targeted paths: %s\n", targetedPlan.Reason)
 		}
 	}
+	// Files that changed a lot since they were summarized are summarized
+	// again in targeted mode, even outside the plan's high-churn folders.
+	var staleFileChurn map[string]int
+	resummarizeChurn := cfg.GetResummarizeChurn()
+	if summaryMode == summaryModeTargeted && resummarizeChurn > 0 {
+		staleFileChurn, err = dbRepo.GetFileChurnSinceSummary(ctx, codebase.ID)
+		if err != nil {
+			VerboseLog("Warning: failed to load file churn: %v", err)
+		}
+	}
 	if err := dbRepo.UpsertCodebase(ctx, codebase); err != nil {
 		return fmt.Errorf("failed to save codebase: %w", err)
 	}
@@ -1482,11 +2214,16 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 	}
 
 	fmt.Println()
-	dimColor.Printf("  Indexing folders...")
-	folderCount := 0
+	dimColor.Println("  Indexing folders...")
+	folderProgress := newProgressBar("  ", "folders", len(scanResult.Folders))
+	defer folderProgress.Done()
 	folderIDMap := make(map[string]string)
+	var folderJobs []folderSummaryJob
 
 	for folderPath, folderInfo := range scanResult.Folders {
+		if err := interrupt.Err(); err != nil {
+			return err
+		}
 		folderID := existingFolders[folderPath]
 		if folderID == "" {
 			folderID = uuid.New().String()
@@ -1513,17 +2250,18 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 			shouldSummarizeFolder := false
 			switch summaryMode {
 			case summaryModeFull:
-				shouldSummarizeFolder = folderInfo.Depth <= 2 && (isNewFolder || ingestForceReindex)
+				shouldSummarizeFolder = folderInfo.Depth <= folderSummaryMaxDepth && (isNewFolder || ingestForceReindex)
 			case summaryModeTargeted:
 				shouldSummarizeFolder = isFirstIndex || ingestForceReindex || (targetedPlan.ActiveFolders[folderPath] && targetedPlan.HighChurnFolders[folderPath])
 			}
+			// Backfill folders indexed while summaries were off.
+			if ingestFillFileSums && strings.TrimSpace(folder.Summary) == "" {
+				shouldSummarizeFolder = true
+			}
 			if shouldSummarizeFolder {
-				summary, err := summarizer.SummarizeFolder(ctx, folderInfo, targetedPlan.TouchedFilesByFolder[folderPath], ingestTargetedChildren)
-				if err != nil {
-					return fmt.Errorf("failed to generate folder summary for %s: %w\n\nTo skip summaries, use: --summary-mode off", folderPath, err)
-				}
-				folder.Summary = composeFolderSummary(summary)
-				folder.Purpose = summary.Purpose
+				// Summarized and saved below, several at a time.
+				folderJobs = append(folderJobs, folderSummaryJob{Folder: folder, Info: folderInfo, TouchedFiles: targetedPlan.TouchedFilesByFolder[folderPath]})
+				continue
 			}
 		}
 
@@ -1531,18 +2269,36 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 			VerboseLog("Warning: failed to save folder %s: %v", folderPath, err)
 		}
 
-		folderCount++
-		fmt.Printf("\r  Processed %d/%d folders", folderCount, len(scanResult.Folders))
+		folderProgress.Add(1)
 	}
-	fmt.Println()
+	if len(folderJobs) > 0 {
+		err := summarizeFolders(ctx, interrupt, summarizer, folderJobs, ingestTargetedChildren, func(job folderSummaryJob, summary *indexer.FolderSummary) {
+			job.Folder.Summary = composeFolderSummary(summary)
+			job.Folder.Purpose = summary.Purpose
+			if err := dbRepo.UpsertFolder(ctx, job.Folder); err != nil {
+				VerboseLog("Warning: failed to save folder %s: %v", job.Folder.Path, err)
+			}
+			folderProgress.Add(1)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	folderProgress.Done()
 
 	filesToProcess := append(newFiles, changedFiles...)
-	dimColor.Printf("  Indexing files...")
-	fileCount := 0
+	dimColor.Println("  Indexing files...")
 	summarizedCount := 0
-	totalFiles := len(filesToProcess) + len(unchangedFiles)
+	filledCount := 0
+	refreshedCount := 0
+	var embedTargets []embeddingTarget
+	fileProgress := newProgressBar("  ", "files", len(filesToProcess)+len(unchangedFiles))
+	defer fileProgress.Done()
 
 	for _, fileInfo := range filesToProcess {
+		if err := interrupt.Err(); err != nil {
+			return err
+		}
 		existingInfo := existingFiles[fileInfo.Path]
 		fileID := existingInfo.ID
 		if fileID == "" {
@@ -1556,24 +2312,30 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 		folderID := folderIDMap[folderPath]
 
 		file := &db.FileIndex{
-			ID:          fileID,
-			CodebaseID:  codebase.ID,
-			FolderID:    folderID,
-			Path:        fileInfo.Path,
-			Name:        fileInfo.Name,
-			Extension:   fileInfo.Extension,
-			Language:    fileInfo.Language,
-			SizeBytes:   fileInfo.Size,
-			LineCount:   indexer.CountLines(fileInfo.Content),
-			ContentHash: fileInfo.Hash,
-			Summary:     existingInfo.Summary,
-			IndexedAt:   time.Now(),
-		}
-
+			ID:           fileID,
+			CodebaseID:   codebase.ID,
+			FolderID:     folderID,
+			Path:         fileInfo.Path,
+			Name:         fileInfo.Name,
+			Extension:    fileInfo.Extension,
+			Language:     fileInfo.Language,
+			SizeBytes:    fileInfo.Size,
+			LineCount:    indexer.CountLines(fileInfo.Content),
+			ContentHash:  fileInfo.Hash,
+			Summary:      existingInfo.Summary,
+			Purpose:      existingInfo.Purpose,
+			KeyExports:   existingInfo.KeyExports,
+			Dependencies: indexer.ExtractDependencies(fileInfo, scanResult.ModulePath),
+			IndexedAt:    time.Now(),
+		}
+
+		staleSummary := staleFileChurn[fileInfo.Path] >= resummarizeChurn && resummarizeChurn > 0
+		summarized := false
 		shouldSummarizeTargetedFile := summaryMode == summaryModeTargeted &&
-			(targetedPlan.HighChurnFolders[folderPath] || isFirstIndex || ingestForceReindex)
+			(targetedPlan.HighChurnFolders[folderPath] || isFirstIndex || ingestForceReindex || staleSummary)
+		fillMissingFile := ingestFillFileSums && strings.TrimSpace(existingInfo.Summary) == ""
 		if enableSummaries && summarizer != nil && shouldSummarizeFile(fileInfo) &&
-			((summaryMode == summaryModeFull) || shouldSummarizeTargetedFile) {
+			((summaryMode == summaryModeFull) || shouldSummarizeTargetedFile || fillMissingFile) {
 			summary, err := summarizer.SummarizeFile(ctx, fileInfo)
 			if err != nil {
 				return fmt.Errorf("failed to generate file summary for %s: %w\n\nTo skip summaries, use: --summary-mode off", fileInfo.Path, err)
@@ -1582,19 +2344,29 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 			file.Purpose = summary.Purpose
 			file.KeyExports = summary.KeyExports
 			summarizedCount++
+			summarized = true
+			if staleSummary {
+				VerboseLog("Re-summarized %s: %d lines changed since its last summary", fileInfo.Path, staleFileChurn[fileInfo.Path])
+			}
 		}
 
 		if err := dbRepo.UpsertFileIndex(ctx, file); err != nil {
 			return fmt.Errorf("failed to save file %s: %w", fileInfo.Path, err)
 		}
-
-		fileCount++
-		if fileCount%10 == 0 || fileCount == len(filesToProcess) {
-			fmt.Printf("\r  Processed %d/%d files (summarizing)", fileCount, len(filesToProcess))
+		if summarized {
+			if err := dbRepo.MarkFileSummarized(ctx, codebase.ID, file.Path); err != nil {
+				VerboseLog("Warning: failed to record summary time for %s: %v", file.Path, err)
+			}
 		}
+		embedTargets = append(embedTargets, embeddingTarget{File: file, Content: fileInfo.Content})
+
+		fileProgress.Add(1)
 	}
 
 	for _, fileInfo := range unchangedFiles {
+		if err := interrupt.Err(); err != nil {
+			return err
+		}
 		existingInfo := existingFiles[fileInfo.Path]
 
 		folderPath := filepath.Dir(fileInfo.Path)
@@ -1604,26 +2376,71 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 		folderID := folderIDMap[folderPath]
 
 		file := &db.FileIndex{
-			ID:          existingInfo.ID,
-			CodebaseID:  codebase.ID,
-			FolderID:    folderID,
-			Path:        fileInfo.Path,
-			Name:        fileInfo.Name,
-			Extension:   fileInfo.Extension,
-			Language:    fileInfo.Language,
-			SizeBytes:   fileInfo.Size,
-			LineCount:   indexer.CountLines(fileInfo.Content),
-			ContentHash: fileInfo.Hash,
-			Summary:     existingInfo.Summary, // Preserve existing summary
-			IndexedAt:   time.Now(),
+			ID:           existingInfo.ID,
+			CodebaseID:   codebase.ID,
+			FolderID:     folderID,
+			Path:         fileInfo.Path,
+			Name:         fileInfo.Name,
+			Extension:    fileInfo.Extension,
+			Language:     fileInfo.Language,
+			SizeBytes:    fileInfo.Size,
+			LineCount:    indexer.CountLines(fileInfo.Content),
+			ContentHash:  fileInfo.Hash,
+			Summary:      existingInfo.Summary, // Preserve existing summary
+			Purpose:      existingInfo.Purpose,
+			KeyExports:   existingInfo.KeyExports,
+			Dependencies: indexer.ExtractDependencies(fileInfo, scanResult.ModulePath),
+			IndexedAt:    time.Now(),
+		}
+
+		// Unchanged files keep their summary; only fill in missing ones and,
+		// in targeted mode, refresh those that changed a lot since they were
+		// summarized (their commits are ingested after the index that saw
+		// the new content).
+		missingSummary := ingestFillFileSums && strings.TrimSpace(file.Summary) == ""
+		staleSummary := staleFileChurn[fileInfo.Path] >= resummarizeChurn && resummarizeChurn > 0
+		summarized := false
+		if summarizer != nil && (missingSummary || staleSummary) && shouldSummarizeFile(fileInfo) {
+			summary, err := summarizer.SummarizeFile(ctx, fileInfo)
+			if err != nil {
+				return fmt.Errorf("failed to generate file summary for %s: %w", fileInfo.Path, err)
+			}
+			file.Summary = summary.Summary
+			file.Purpose = summary.Purpose
+			file.KeyExports = summary.KeyExports
+			if missingSummary {
+				filledCount++
+			} else {
+				refreshedCount++
+				VerboseLog("Re-summarized %s: %d lines changed since its last summary", fileInfo.Path, staleFileChurn[fileInfo.Path])
+			}
+			summarized = true
+			if err := dbRepo.MarkFileSummarized(ctx, codebase.ID, file.Path); err != nil {
+				VerboseLog("Warning: failed to record summary time for %s: %v", file.Path, err)
+			}
 		}
 
 		if err := dbRepo.UpsertFileIndex(ctx, file); err != nil {
 			return fmt.Errorf("failed to save unchanged file %s: %w", fileInfo.Path, err)
 		}
+		// Unchanged files keep their embedding too, unless they have none
+		// yet or the summary it was built from was just replaced.
+		if !existingInfo.HasEmbedding || summarized {
+			embedTargets = append(embedTargets, embeddingTarget{File: file, Content: fileInfo.Content})
+		}
+		fileProgress.Add(1)
 	}
+	fileProgress.Done()
 
-	fmt.Printf("\r  Processed %d/%d files                    \n", totalFiles, totalFiles)
+	todoSnapshot, err := recordTodoMarkers(ctx, dbRepo, codebase.ID, scanResult.Files)
+	if err != nil {
+		VerboseLog("Warning: failed to record TODO markers: %v", err)
+	}
+
+	embeddedCount := 0
+	if embedder, ok := llmClient.(llm.Embedder); ok {
+		embeddedCount = embedFiles(ctx, dbRepo, embedder, embedTargets)
+	}
 
 	fmt.Println()
 	stats, err := dbRepo.GetCodebaseStats(ctx, codebase.ID)
@@ -1638,11 +2455,29 @@ func indexCodebase(absPath string, cfg *config.Config) error {
 	infoColor.Printf("%s\n", formatBytes(stats.TotalSize))
 	dimColor.Printf("  Lines:      ")
 	infoColor.Printf("%d\n", stats.TotalLines)
+	if todoSnapshot.Total() > 0 {
+		dimColor.Printf("  Markers:    ")
+		infoColor.Printf("%d", todoSnapshot.Total())
+		dimColor.Printf(" (%d TODO, %d FIXME, %d HACK)\n", todoSnapshot.Todo, todoSnapshot.Fixme, todoSnapshot.Hack)
+	}
 
 	if summarizedCount > 0 {
 		dimColor.Printf("  Summaries:  ")
 		infoColor.Printf("%d files (new/changed)\n", summarizedCount)
 	}
+	if filledCount > 0 {
+		dimColor.Printf("  Backfilled: ")
+		infoColor.Printf("%d file summaries\n", filledCount)
+	}
+	if refreshedCount > 0 {
+		dimColor.Printf("  Refreshed:  ")
+		infoColor.Printf("%d stale file summaries\n", refreshedCount)
+	}
+
+	if embeddedCount > 0 {
+		dimColor.Printf("  Embeddings: ")
+		infoColor.Printf("%d files\n", embeddedCount)
+	}
 
 	if len(deletedFilePaths) > 0 {
 		dimColor.Printf("  Removed:    ")
@@ -1686,7 +2521,79 @@ type folderActivity struct {
 	lastTouched     time.Time
 }
 
-func resolveSummaryMode(totalFiles int) (string, string) {
+// capFilesByRelevance keeps the limit most relevant files: those in the
+// most recently touched folders first (by the folder's own touch activity
+// or its nearest touched parent's), then the shallowest, then in scan
+// order. It also returns the topmost folders none of whose files were kept.
+func capFilesByRelevance(files []indexer.FileInfo, touchActivity map[string]any, limit int) ([]indexer.FileInfo, []string) {
+	touched := make(map[string]time.Time, len(touchActivity))
+	for folderPath, raw := range touchActivity {
+		if t, ok := parseTimeAny(parseTouchEntry(raw)["last_touched_at"]); ok {
+			touched[normalizeFolderPath(folderPath)] = t
+		}
+	}
+	lastTouched := func(folder string) time.Time {
+		for {
+			if t, ok := touched[folder]; ok {
+				return t
+			}
+			if folder == "." {
+				return time.Time{}
+			}
+			folder = normalizeFolderPath(filepath.Dir(folder))
+		}
+	}
+
+	ranked := make([]indexer.FileInfo, len(files))
+	copy(ranked, files)
+	folderTouched := make(map[string]time.Time)
+	for _, f := range ranked {
+		folder := normalizeFolderPath(filepath.Dir(f.Path))
+		if _, ok := folderTouched[folder]; !ok {
+			folderTouched[folder] = lastTouched(folder)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		ti := folderTouched[normalizeFolderPath(filepath.Dir(ranked[i].Path))]
+		tj := folderTouched[normalizeFolderPath(filepath.Dir(ranked[j].Path))]
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return strings.Count(ranked[i].Path, string(filepath.Separator)) < strings.Count(ranked[j].Path, string(filepath.Separator))
+	})
+	if len(ranked) <= limit {
+		return ranked, nil
+	}
+	kept := ranked[:limit]
+
+	// A folder counts as kept if any file in it or below it was kept.
+	keptFolders := map[string]bool{".": true}
+	for _, f := range kept {
+		for folder := normalizeFolderPath(filepath.Dir(f.Path)); !keptFolders[folder]; folder = normalizeFolderPath(filepath.Dir(folder)) {
+			keptFolders[folder] = true
+		}
+	}
+	droppedSet := make(map[string]bool)
+	for _, f := range ranked[limit:] {
+		folder := normalizeFolderPath(filepath.Dir(f.Path))
+		if keptFolders[folder] {
+			continue
+		}
+		// Report the topmost folder that lost all its files.
+		for parent := normalizeFolderPath(filepath.Dir(folder)); !keptFolders[parent]; parent = normalizeFolderPath(filepath.Dir(parent)) {
+			folder = parent
+		}
+		droppedSet[folder] = true
+	}
+	dropped := make([]string, 0, len(droppedSet))
+	for folder := range droppedSet {
+		dropped = append(dropped, folder)
+	}
+	sort.Strings(dropped)
+	return kept, dropped
+}
+
+func resolveSummaryMode(totalFiles, indexSoftLimit int) (string, string) {
 	if ingestSkipSummaries {
 		return summaryModeOff, "--skip-summaries alias"
 	}
@@ -2084,9 +2991,13 @@ func topNNonRoot(paths []string, max int) []string {
 	return result
 }
 
+// targetedDigestHeader starts the block of active paths that targeted-mode
+// ingest keeps in a codebase's project context.
+const targetedDigestHeader = "[Targeted ingest context]"
+
 func buildTargetedDigest(paths []string, sinceDate time.Time, fallback bool) string {
 	var sb strings.Builder
-	sb.WriteString("[Targeted ingest context]\n")
+	sb.WriteString(targetedDigestHeader + "\n")
 	if fallback {
 		sb.WriteString("No user commit history was available; generated bootstrap folder context.\n")
 	} else {
@@ -2109,18 +3020,58 @@ func buildTargetedDigest(paths []string, sinceDate time.Time, fallback bool) str
 	return strings.TrimSpace(sb.String())
 }
 
+// mergeProjectContext replaces the targeted digest in a project context with
+// a new one. Every earlier digest block is removed wherever it appears, and
+// the rest of the context is kept exactly as it was, so repeated ingests
+// leave exactly one digest at the end instead of piling them up.
 func mergeProjectContext(existing, digest string) string {
 	if digest == "" {
 		return existing
 	}
-	parts := strings.Split(existing, "\n\n[Targeted ingest context]")
-	base := strings.TrimSpace(parts[0])
-	if base == "" {
+	base := stripTargetedDigests(existing)
+	if strings.TrimSpace(base) == "" {
 		return digest
 	}
 	return base + "\n\n" + digest
 }
 
+// stripTargetedDigests removes every digest block (its header line through
+// the next blank line) from a project context, along with the blank line
+// separating it from the text before it. Everything else is left byte for
+// byte.
+func stripTargetedDigests(context string) string {
+	for {
+		start := -1
+		for from := 0; from < len(context); {
+			i := strings.Index(context[from:], targetedDigestHeader)
+			if i < 0 {
+				break
+			}
+			i += from
+			if i == 0 || context[i-1] == '\n' {
+				start = i
+				break
+			}
+			from = i + len(targetedDigestHeader)
+		}
+		if start < 0 {
+			return context
+		}
+
+		end := len(context)
+		if i := strings.Index(context[start:], "\n\n"); i >= 0 {
+			end = start + i
+		}
+		if start == 0 {
+			// Nothing precedes the block: drop the separator after it instead.
+			end += len(context[end:]) - len(strings.TrimPrefix(context[end:], "\n\n"))
+		} else {
+			start -= len(context[:start]) - len(strings.TrimSuffix(context[:start], "\n\n"))
+		}
+		context = context[:start] + context[end:]
+	}
+}
+
 func composeFolderSummary(summary *indexer.FolderSummary) string {
 	if summary == nil {
 		return ""
@@ -2254,12 +3205,45 @@ func parseTimeAny(v any) (time.Time, bool) {
 	return time.Time{}, false
 }
 
-func createLLMClient(cfg *config.Config) (llm.Client, error) {
-	provider := cfg.GetEffectiveProvider()
+// ingestTaskModel returns the model for one ingest summary task. --model
+// wins; otherwise the profile's per-task override applies, unless --provider
+// switched away from the provider that override was configured for.
+func ingestTaskModel(profileTaskModel string) string {
+	if ingestModel != "" || ingestProvider != "" {
+		return ingestModel
+	}
+	return profileTaskModel
+}
+
+// llmCallTimeout bounds each LLM request; it is set from the profile's
+// llm_timeout_seconds before any command runs.
+var llmCallTimeout = time.Duration(config.DefaultLLMTimeoutSeconds) * time.Second
+
+// withLLMTimeout derives a context for a single LLM request.
+func withLLMTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, llmCallTimeout)
+}
+
+// createLLMClient builds a client for the profile's provider and model.
+// Non-empty provider or model arguments (from --provider/--model) take
+// precedence over the profile defaults.
+func createLLMClient(cfg *config.Config, provider, model string) (llm.Client, error) {
+	if provider == "" {
+		provider = cfg.GetEffectiveProvider()
+	}
 	if provider == "" {
 		return nil, fmt.Errorf("no provider configured; run 'devlog onboard' first")
 	}
-	llmCfg := llm.Config{Provider: llm.Provider(provider), Model: cfg.GetEffectiveModel()}
+	selectedModel := model
+	if selectedModel == "" {
+		selectedModel = cfg.GetEffectiveModel()
+	}
+	// A keychain: or secrets: reference that fails to resolve would
+	// otherwise surface as a confusing authentication error from the API.
+	if err := cfg.CheckSecretReferences(provider); err != nil {
+		return nil, fmt.Errorf("failed to resolve %s credentials: %w", provider, err)
+	}
+	llmCfg := llm.Config{Provider: llm.Provider(provider), Model: selectedModel}
 	switch llmCfg.Provider {
 	case llm.ProviderOpenAI:
 		llmCfg.APIKey = cfg.GetEffectiveAPIKey("openai")
@@ -2275,17 +3259,106 @@ func createLLMClient(cfg *config.Config) (llm.Client, error) {
 		llmCfg.AWSAccessKeyID = cfg.GetEffectiveAWSAccessKeyID()
 		llmCfg.AWSSecretAccessKey = cfg.GetEffectiveAWSSecretAccessKey()
 		llmCfg.AWSRegion = cfg.GetEffectiveAWSRegion()
+	case llm.ProviderAzureOpenAI:
+		llmCfg.APIKey = cfg.GetEffectiveAPIKey("azure")
+		llmCfg.AzureEndpoint = cfg.GetEffectiveAzureEndpoint()
+		llmCfg.AzureDeployment = cfg.GetEffectiveAzureDeployment()
+		llmCfg.AzureAPIVersion = cfg.GetEffectiveAzureAPIVersion()
+		if llmCfg.Model == "" {
+			llmCfg.Model = llmCfg.AzureDeployment
+		}
 	case llm.ProviderOllama:
 		if url := cfg.GetEffectiveOllamaBaseURL(); url != "" {
 			llmCfg.BaseURL = url
 		}
-		if model := cfg.GetEffectiveOllamaModel(); model != "" {
-			llmCfg.Model = model
+		// Ollama uses its own model field unless --model overrides it
+		if model == "" {
+			if ollamaModel := cfg.GetEffectiveOllamaModel(); ollamaModel != "" {
+				llmCfg.Model = ollamaModel
+			}
 		}
+		llmCfg.EmbeddingModel = cfg.GetEffectiveOllamaEmbeddingModel()
 	}
 	return llm.NewClient(llmCfg)
 }
 
+// embeddingTarget is an indexed file queued for the embedding step.
+type embeddingTarget struct {
+	File    *db.FileIndex
+	Content string
+}
+
+// embedFiles generates and stores embeddings for the given files using the
+// configured provider. Embedding is best-effort: the first failure (e.g. the
+// embedding model is not pulled) is reported and the step is skipped.
+func embedFiles(ctx context.Context, dbRepo *db.SQLRepository, embedder llm.Embedder, targets []embeddingTarget) int {
+	embedded := 0
+	progress := newProgressBar("  ", "files embedded", len(targets))
+	defer progress.Done()
+	for i, t := range targets {
+		progress.Set(i)
+		if t.File.Summary == "" && strings.TrimSpace(t.Content) == "" {
+			continue
+		}
+		text := t.File.Path
+		if t.File.Summary != "" {
+			text += "\n" + t.File.Summary
+		}
+		if t.File.Purpose != "" {
+			text += "\n" + t.File.Purpose
+		}
+		text += "\n" + truncate(t.Content, 4000)
+
+		embedCtx, cancel := withLLMTimeout(ctx)
+		vector, err := embedder.Embed(embedCtx, text)
+		cancel()
+		if err != nil {
+			progress.Done()
+			color.New(color.FgHiYellow).Printf("  Warning: skipping embeddings: %v\n", err)
+			return embedded
+		}
+		if err := dbRepo.UpdateFileEmbedding(ctx, t.File.ID, vector); err != nil {
+			VerboseLog("Warning: failed to save embedding for %s: %v", t.File.Path, err)
+			continue
+		}
+		embedded++
+	}
+	progress.Set(len(targets))
+	return embedded
+}
+
+// loadContextFiles reads the configured context documents under repoPath
+// for the codebase summary prompt, each headed by its path and cut to
+// contextFileMaxBytes, stopping once contextFilesMaxBytes have been read.
+// Missing files and paths outside the repository are skipped.
+func loadContextFiles(repoPath string, files []string) string {
+	var sb strings.Builder
+	total := 0
+	for _, name := range files {
+		rel := filepath.Clean(filepath.FromSlash(strings.TrimSpace(name)))
+		if rel == "." || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			VerboseLog("Warning: ignoring context file %q outside the repository", name)
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			VerboseLog("Warning: failed to read context file %s: %v", name, err)
+			continue
+		}
+		content := strings.TrimSpace(string(data))
+		if len(content) > contextFileMaxBytes {
+			content = content[:contextFileMaxBytes] + "\n(truncated)"
+		}
+		if total+len(content) > contextFilesMaxBytes {
+			VerboseLog("Context files exceed %d bytes; skipping %s and later files", contextFilesMaxBytes, name)
+			break
+		}
+		total += len(content)
+		sb.WriteString(fmt.Sprintf("\n\n--- %s ---\n%s", filepath.ToSlash(rel), content))
+	}
+	return sb.String()
+}
+
 func shouldSummarizeFile(f indexer.FileInfo) bool {
 	if f.Content == "" || f.Language == "" {
 		return false
@@ -2339,7 +3412,10 @@ func formatBytes(b int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
 }
 
-func generateCommitSummary(client llm.Client, commitMessage string, fileChanges []*db.FileChange, projectContext string) (string, error) {
+// generateCommitSummary asks the LLM to summarize a commit. filePurposes maps
+// indexed file paths to their stored one-line purpose; changed files found
+// there get that line in the prompt so the model knows what each file does.
+func generateCommitSummary(client llm.Client, commitMessage string, fileChanges []*db.FileChange, projectContext string, filePurposes map[string]string, maxWords int) (string, error) {
 	var sb strings.Builder
 	sb.WriteString("Commit message: ")
 	sb.WriteString(commitMessage)
@@ -2353,6 +3429,10 @@ func generateCommitSummary(client llm.Client, commitMessage string, fileChanges
 		totalAdditions += fc.Additions
 		totalDeletions += fc.Deletions
 
+		if purpose := filePurposeLine(filePurposes[fc.FilePath]); purpose != "" {
+			sb.WriteString(fmt.Sprintf("  File purpose: %s\n", purpose))
+		}
+
 		// Include full patch/diff for context
 		if fc.Patch != "" {
 			sb.WriteString("  Diff:\n")
@@ -2369,14 +3449,100 @@ func generateCommitSummary(client llm.Client, commitMessage string, fileChanges
 
 	sb.WriteString(fmt.Sprintf("\nTotal: +%d/-%d lines across %d files\n", totalAdditions, totalDeletions, len(fileChanges)))
 
-	prompt := prompts.BuildCommitSummarizerPrompt(projectContext, sb.String())
+	prompt := prompts.WithWordBudget(prompts.BuildCommitSummarizerPrompt(projectContext, sb.String()), maxWords)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := withLLMTimeout(context.Background())
 	defer cancel()
 
 	return client.Complete(ctx, prompt)
 }
 
+// terseCommitSummary returns the subject line of a commit that changes fewer
+// than minChurn lines, to store as its summary in place of an LLM one. It
+// returns "" when the commit should be summarized in full.
+func terseCommitSummary(commitMessage string, fileChanges []*db.FileChange, minChurn int) string {
+	if minChurn <= 0 {
+		return ""
+	}
+	churn := 0
+	for _, fc := range fileChanges {
+		churn += fc.Additions + fc.Deletions
+	}
+	if churn >= minChurn {
+		return ""
+	}
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(commitMessage), "\n", 2)[0])
+}
+
+// filePurposeLine reduces a stored file purpose or summary to one short line.
+func filePurposeLine(purpose string) string {
+	purpose = strings.TrimSpace(strings.SplitN(strings.TrimSpace(purpose), "\n", 2)[0])
+	return truncate(purpose, 200)
+}
+
+// loadFilePurposes returns the indexed purpose of each file in a codebase.
+// Failures only cost prompt context, so they are logged and ignored.
+func loadFilePurposes(ctx context.Context, dbRepo *db.SQLRepository, codebaseID string) map[string]string {
+	purposes, err := dbRepo.GetFilePurposes(ctx, codebaseID)
+	if err != nil {
+		VerboseLog("Warning: failed to load file purposes: %v", err)
+		return nil
+	}
+	return purposes
+}
+
+// fillMissingParents records the parent hashes of commits ingested before
+// they were stored, reading them from git. Commits git no longer has (or a
+// shallow clone never had) are left as they are.
+func fillMissingParents(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repository, codebaseID string) int {
+	hashes, err := dbRepo.GetCommitHashesMissingParents(ctx, codebaseID)
+	if err != nil {
+		VerboseLog("Warning: failed to find commits missing parent hashes: %v", err)
+		return 0
+	}
+	filled := 0
+	for _, hash := range hashes {
+		gitCommit, err := repo.GetCommit(hash)
+		if err != nil {
+			continue
+		}
+		parents := make([]string, 0, gitCommit.NumParents())
+		for _, p := range gitCommit.ParentHashes {
+			parents = append(parents, p.String())
+		}
+		if err := dbRepo.UpdateCommitParents(ctx, codebaseID, hash, parents); err != nil {
+			VerboseLog("Warning: failed to record parents of %s: %v", hash[:8], err)
+			continue
+		}
+		filled++
+	}
+	return filled
+}
+
+// fillMissingSignatures records whether commits ingested before
+// signatures were stored are signed. Commits no longer in the repository
+// stay unchecked.
+func fillMissingSignatures(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repository, codebaseID string) int {
+	hashes, err := dbRepo.GetCommitHashesMissingSignature(ctx, codebaseID)
+	if err != nil {
+		VerboseLog("Warning: failed to find commits missing signatures: %v", err)
+		return 0
+	}
+	filled := 0
+	for _, hash := range hashes {
+		gitCommit, err := repo.GetCommit(hash)
+		if err != nil {
+			continue
+		}
+		if err := dbRepo.UpdateCommitSigned(ctx, codebaseID, hash, gitCommit.PGPSignature != ""); err != nil {
+			VerboseLog("Warning: failed to record signature of %s: %v", hash[:8], err)
+			continue
+		}
+		filled++
+	}
+	return filled
+}
+
 func fillMissingSummaries(ctx context.Context, dbRepo *db.SQLRepository, repo *git.Repository, codebase *db.Codebase, llmClient llm.Client) (int, error) {
 	dimColor := color.New(color.FgHiBlack)
 
@@ -2390,8 +3556,15 @@ func fillMissingSummaries(ctx context.Context, dbRepo *db.SQLRepository, repo *g
 		return 0, nil
 	}
 	dimColor.Printf("  Filling %d missing commit summaries...\n", len(commits))
+	filePurposes := loadFilePurposes(ctx, dbRepo, codebase.ID)
 	filled := 0
+	progress := newProgressBar("  ", "commits", len(commits))
+	defer progress.Done()
 	for i, commit := range commits {
+		progress.Set(i)
+		if err := ctx.Err(); err != nil {
+			return filled, err
+		}
 		fileChanges, err := dbRepo.GetFileChangesByCommit(ctx, commit.ID)
 		if err != nil {
 			return 0, fmt.Errorf("failed to get file changes for commit %s: %w", commit.Hash[:8], err)
@@ -2408,22 +3581,19 @@ func fillMissingSummaries(ctx context.Context, dbRepo *db.SQLRepository, repo *g
 		if codebase != nil {
 			projectCtx = codebase.Summary
 		}
-		summary, err := generateCommitSummary(llmClient, commit.Message, fcPtrs, projectCtx)
-		if err != nil {
-			return 0, fmt.Errorf("failed to generate summary for commit %s: %w", commit.Hash[:8], err)
+		summary := terseCommitSummary(commit.Message, fcPtrs, ingestSummaryMinChurn)
+		if summary == "" {
+			summary, err = generateCommitSummary(llmClient, commit.Message, fcPtrs, projectCtx, filePurposes, ingestSummaryMaxWords)
+			if err != nil {
+				return 0, fmt.Errorf("failed to generate summary for commit %s: %w", commit.Hash[:8], err)
+			}
 		}
 		if err := dbRepo.UpdateCommitSummary(ctx, commit.ID, summary); err != nil {
 			return 0, fmt.Errorf("failed to update summary for commit %s: %w", commit.Hash[:8], err)
 		}
 		filled++
-		if (i+1)%10 == 0 || i+1 == len(commits) {
-			fmt.Printf("\r  Processed %d/%d commits", i+1, len(commits))
-		}
-	}
-
-	if len(commits) > 0 {
-		fmt.Println()
 	}
+	progress.Set(len(commits))
 
 	return filled, nil
 }
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+func TestMergeProjectContextRepeatedIngest(t *testing.T) {
+	stale := buildTargetedDigest([]string{"internal/old"}, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), false)
+	fresh := buildTargetedDigest([]string{"internal/cli", "internal/db"}, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), false)
+
+	tests := []struct {
+		name     string
+		existing string // project context before the first ingest
+	}{
+		{name: "empty", existing: ""},
+		{name: "plain summary", existing: "Devlog turns git history into worklogs."},
+		{
+			name:     "irregular whitespace",
+			existing: "  Devlog summary.\n\n\n- point one\n  - nested\n\ntrailing line  \n",
+		},
+		{
+			name:     "mentions header mid-line",
+			existing: "Notes about the " + targetedDigestHeader + " block are kept.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// First ingest adds a digest that is stale by the second one.
+			once := mergeProjectContext(tt.existing, stale)
+			twice := mergeProjectContext(once, fresh)
+
+			want := fresh
+			if strings.TrimSpace(tt.existing) != "" {
+				want = tt.existing + "\n\n" + fresh
+			}
+			if twice != want {
+				t.Errorf("after two ingests:\n got %q\nwant %q", twice, want)
+			}
+			if n := countDigestBlocks(twice); n != 1 {
+				t.Errorf("got %d digest blocks, want 1", n)
+			}
+			if strings.Contains(twice, "internal/old") {
+				t.Errorf("stale digest was kept: %q", twice)
+			}
+			if got := stripTargetedDigests(twice); strings.TrimSpace(tt.existing) != "" && got != tt.existing {
+				t.Errorf("context without digest:\n got %q\nwant %q", got, tt.existing)
+			}
+		})
+	}
+}
+
+func TestMergeProjectContextRemovesPiledUpDigests(t *testing.T) {
+	// Contexts written before digests were replaced can hold several.
+	old1 := buildTargetedDigest([]string{"a"}, time.Time{}, false)
+	old2 := buildTargetedDigest(nil, time.Time{}, true)
+	fresh := buildTargetedDigest([]string{"b"}, time.Time{}, false)
+	existing := old1 + "\n\nSummary paragraph.\n\n" + old2
+
+	got := mergeProjectContext(existing, fresh)
+	want := "Summary paragraph.\n\n" + fresh
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// countDigestBlocks counts the lines that start a targeted digest block.
+func countDigestBlocks(context string) int {
+	n := 0
+	for _, line := range strings.Split(context, "\n") {
+		if line == targetedDigestHeader {
+			n++
+		}
+	}
+	return n
+}
+
+func TestCreateLLMClientUnresolvedSecret(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ANTHROPIC_API_KEY", "env-key")
+	if err := os.MkdirAll(filepath.Join(home, ".devlog"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(config.GetSecretsPath(), []byte(`{"anthropic":"sk-from-file"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{
+		ActiveProfile: "work",
+		Profiles: map[string]*config.Profile{
+			"work": {DefaultProvider: "anthropic", DefaultModel: "claude", AnthropicAPIKey: "secrets:antropic"}, // typo
+		},
+	}
+
+	// The typo must not fall back to the env var or to no key.
+	_, err := createLLMClient(cfg, "", "")
+	if err == nil || !strings.Contains(err.Error(), `no secret named "antropic"`) {
+		t.Fatalf("err = %v, want the unresolved reference", err)
+	}
+
+	cfg.Profiles["work"].AnthropicAPIKey = "secrets:anthropic"
+	if _, err := createLLMClient(cfg, "", ""); err != nil {
+		t.Fatalf("resolved reference: %v", err)
+	}
+}
+
+func TestIndexCodebaseKeepsFilePurpose(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", t.TempDir())
+	const profile = "index-purpose-test"
+	previous := db.GetActiveProfile()
+	db.SetActiveProfile(profile)
+	t.Cleanup(func() {
+		db.CloseDB(profile)
+		db.SetActiveProfile(previous)
+	})
+	defer func(mode string) { ingestSummaryMode = mode }(ingestSummaryMode)
+	ingestSummaryMode = summaryModeOff
+
+	ctx := context.Background()
+	cfg := &config.Config{}
+	if err := indexCodebase(ctx, dir, cfg); err != nil {
+		t.Fatalf("first index: %v", err)
+	}
+	dbRepo, err := db.GetRepositoryForProfile(profile)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, dir)
+	if err != nil || codebase == nil {
+		t.Fatalf("get codebase: %v, %v", codebase, err)
+	}
+	// Stand in for an earlier ingest that summarized the file.
+	if _, err := dbRepo.DB().ExecContext(ctx, `UPDATE file_indexes SET summary = 'Entry point', purpose = 'Starts the app', key_exports = '["main"]' WHERE codebase_id = $1`, codebase.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-indexing the unchanged file must not clear what the summary recorded.
+	if err := indexCodebase(ctx, dir, cfg); err != nil {
+		t.Fatalf("second index: %v", err)
+	}
+	file, err := dbRepo.GetFileIndexByPath(ctx, codebase.ID, "main.go")
+	if err != nil || file == nil {
+		t.Fatalf("get file: %v, %v", file, err)
+	}
+	if file.Summary != "Entry point" || file.Purpose != "Starts the app" || len(file.KeyExports) != 1 || file.KeyExports[0] != "main" {
+		t.Errorf("file = summary %q, purpose %q, key exports %v; want them kept", file.Summary, file.Purpose, file.KeyExports)
+	}
+}
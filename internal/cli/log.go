@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+var (
+	logDays    int
+	logBranch  string
+	logAll     bool
+	logOneline bool
+)
+
+var (
+	logSearchAuthor   string
+	logSearchSince    string
+	logSearchLimit    int
+	logSearchAllRepos bool
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Browse ingested commits",
+	Long: `List the commits devlog has ingested, newest first, with their date, hash,
+branch, message and stored summary: a quick look at what you did without
+generating a worklog.
+
+Lists the current repository when run inside an ingested one, otherwise all
+repositories in the profile. Use 'devlog log search' to search the history.
+
+Examples:
+  devlog log                   # Your commits from the last 7 days
+  devlog log --days 30         # The last 30 days
+  devlog log --branch main     # Only one branch
+  devlog log --all             # Commits by everyone
+  devlog log --oneline         # One line per commit, without summaries`,
+	Args: cobra.NoArgs,
+	RunE: runLog,
+}
+
+var logSearchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Search commit messages and summaries",
+	Long: `Search ingested commits whose message or LLM summary contains the given
+text (case-insensitive), newest first.
+
+Searches the current repository when run inside an ingested one, otherwise
+all repositories in the profile.
+
+Examples:
+  devlog log search "auth bug"                 # Find that commit where you fixed the auth bug
+  devlog log search retry --since 2025-01-01   # Only commits since a date
+  devlog log search migration --author alice   # Only commits by a matching author email
+  devlog log search timeout --all-repos        # Every repository in the profile`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLogSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+	logCmd.AddCommand(logSearchCmd)
+
+	logCmd.Flags().IntVar(&logDays, "days", 7, "Number of days to list")
+	logCmd.Flags().StringVar(&logBranch, "branch", "", "Only commits on this branch")
+	logCmd.Flags().BoolVar(&logAll, "all", false, "Include commits by all authors")
+	logCmd.Flags().BoolVar(&logOneline, "oneline", false, "One line per commit, without summaries")
+
+	logSearchCmd.Flags().StringVar(&logSearchAuthor, "author", "", "Only commits whose author email contains this text")
+	logSearchCmd.Flags().StringVar(&logSearchSince, "since", "", "Only commits on or after this date (YYYY-MM-DD)")
+	logSearchCmd.Flags().IntVar(&logSearchLimit, "limit", 50, "Maximum number of matches to show (0 = no limit)")
+	logSearchCmd.Flags().BoolVar(&logSearchAllRepos, "all-repos", false, "Search every repository in the profile")
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	hashColor := color.New(color.FgYellow)
+	branchColor := color.New(color.FgHiGreen)
+
+	if logDays <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	loc := getProfileTimezone(cfg)
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	codebasePath, err := filepath.Abs(".")
+	if err != nil {
+		return fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, codebasePath)
+	if err != nil || codebase == nil {
+		if logBranch != "" {
+			return fmt.Errorf("--branch needs an ingested repository; run it inside one")
+		}
+		VerboseLog("No codebase found at current path, listing all repositories")
+		codebase = nil
+	}
+
+	endDate := time.Now().In(loc)
+	startDate := endDate.AddDate(0, 0, -logDays)
+	commits, err := queryCommits(ctx, dbRepo, codebase, startDate, endDate, logAll, cfg.GetCountCoAuthoredCommits(), logAll, logBranch)
+	if err != nil {
+		return fmt.Errorf("failed to query commits: %w", err)
+	}
+
+	fmt.Println()
+	if codebase != nil {
+		titleColor.Printf("  Commits in %s, last %d days\n\n", codebase.Name, logDays)
+	} else {
+		titleColor.Printf("  Commits in all repositories, last %d days\n\n", logDays)
+	}
+	if len(commits) == 0 {
+		dimColor.Println("  No commits found.")
+		fmt.Println()
+		return nil
+	}
+
+	for _, c := range commits {
+		hash := c.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		dimColor.Printf("  %s ", c.CommittedAt.In(loc).Format("2006-01-02 15:04"))
+		hashColor.Print(hash)
+		if c.BranchName != "" {
+			branchColor.Printf(" [%s]", c.BranchName)
+		}
+		if logAll && logOneline {
+			dimColor.Printf(" %s", c.AuthorEmail)
+		}
+		subject := strings.SplitN(c.Message, "\n", 2)[0]
+		infoColor.Printf(" %s\n", subject)
+		if logOneline {
+			continue
+		}
+		var details []string
+		if logAll {
+			details = append(details, c.AuthorEmail)
+		}
+		// Terse summaries of small commits repeat the subject line.
+		if summary := strings.SplitN(strings.TrimSpace(c.Summary), "\n", 2)[0]; summary != "" && summary != subject {
+			details = append(details, truncate(summary, 100))
+		}
+		if len(details) > 0 {
+			dimColor.Printf("    %s\n", strings.Join(details, " - "))
+		}
+	}
+	fmt.Println()
+	dimColor.Printf("  %d commits\n\n", len(commits))
+	return nil
+}
+
+func runLogSearch(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	hashColor := color.New(color.FgYellow)
+	branchColor := color.New(color.FgHiGreen)
+
+	text := strings.TrimSpace(args[0])
+	if text == "" {
+		return fmt.Errorf("search text cannot be empty")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	loc := getProfileTimezone(cfg)
+
+	var since time.Time
+	if logSearchSince != "" {
+		since, err = time.ParseInLocation("2006-01-02", logSearchSince, loc)
+		if err != nil {
+			return fmt.Errorf("invalid --since date %q (expected YYYY-MM-DD)", logSearchSince)
+		}
+	}
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	var codebase *db.Codebase
+	if !logSearchAllRepos {
+		codebasePath, err := filepath.Abs(".")
+		if err != nil {
+			return fmt.Errorf("failed to resolve current directory: %w", err)
+		}
+		codebase, err = dbRepo.GetCodebaseByPath(ctx, codebasePath)
+		if err != nil || codebase == nil {
+			VerboseLog("No codebase found at current path, searching all repositories")
+			codebase = nil
+		}
+	}
+	codebaseID := ""
+	if codebase != nil {
+		codebaseID = codebase.ID
+	}
+
+	commits, err := dbRepo.SearchCommits(ctx, codebaseID, text, logSearchAuthor, since, logSearchLimit)
+	if err != nil {
+		return fmt.Errorf("failed to search commits: %w", err)
+	}
+
+	fmt.Println()
+	if codebase != nil {
+		titleColor.Printf("  Commits matching %q in %s\n\n", text, codebase.Name)
+	} else {
+		titleColor.Printf("  Commits matching %q\n\n", text)
+	}
+	if len(commits) == 0 {
+		dimColor.Println("  No matching commits.")
+		fmt.Println()
+		return nil
+	}
+
+	branchNames, repoNames := logSearchNames(ctx, dbRepo, commits)
+	for _, c := range commits {
+		hash := c.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		dimColor.Printf("  %s ", c.CommittedAt.In(loc).Format("2006-01-02 15:04"))
+		hashColor.Print(hash)
+		if branch := branchNames[c.BranchID]; branch != "" {
+			branchColor.Printf(" [%s]", branch)
+		}
+		if codebase == nil {
+			dimColor.Printf(" %s", repoNames[c.CodebaseID])
+		}
+		infoColor.Printf(" %s\n", strings.SplitN(c.Message, "\n", 2)[0])
+		dimColor.Printf("    %s", c.AuthorEmail)
+		if c.Summary != "" {
+			dimColor.Printf(" - %s", truncate(strings.SplitN(c.Summary, "\n", 2)[0], 100))
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+	if logSearchLimit > 0 && len(commits) == logSearchLimit {
+		dimColor.Printf("  Showing the newest %d matches. Use --limit to see more.\n\n", logSearchLimit)
+	}
+	return nil
+}
+
+// logSearchNames maps the branch and codebase IDs of commits to names for
+// display. Lookup failures only cost labels, so they are ignored.
+func logSearchNames(ctx context.Context, dbRepo *db.SQLRepository, commits []db.Commit) (branches, repos map[string]string) {
+	branches = make(map[string]string)
+	repos = make(map[string]string)
+	for _, c := range commits {
+		if _, ok := repos[c.CodebaseID]; ok {
+			continue
+		}
+		repos[c.CodebaseID] = ""
+		if codebase, err := dbRepo.GetCodebaseByID(ctx, c.CodebaseID); err == nil && codebase != nil {
+			repos[c.CodebaseID] = codebase.Name
+		}
+		if list, err := dbRepo.GetBranchesByCodebase(ctx, c.CodebaseID); err == nil {
+			for _, b := range list {
+				branches[b.ID] = b.Name
+			}
+		}
+	}
+	return branches, repos
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/git"
+)
+
+func TestResolveMergeStatsMode(t *testing.T) {
+	tests := []struct {
+		mode        string
+		isMergeSync bool
+		want        string
+	}{
+		{config.MergeStatsAuto, true, config.MergeStatsNone},
+		{config.MergeStatsAuto, false, config.MergeStatsAllParents},
+		{config.MergeStatsFirstParent, true, config.MergeStatsFirstParent},
+		{config.MergeStatsNone, false, config.MergeStatsNone},
+	}
+	for _, tt := range tests {
+		if got := resolveMergeStatsMode(tt.mode, tt.isMergeSync); got != tt.want {
+			t.Errorf("resolveMergeStatsMode(%q, %v) = %q, want %q", tt.mode, tt.isMergeSync, got, tt.want)
+		}
+	}
+}
+
+func TestMergeSyncCommitHasNoStatsByDefault(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	when := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	commit := func(name string, parents ...plumbing.Hash) plumbing.Hash {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			t.Fatal(err)
+		}
+		when = when.Add(time.Minute)
+		sig := &object.Signature{Name: "Dev", Email: "dev@example.com", When: when}
+		hash, err := wt.Commit(name, &gogit.CommitOptions{Author: sig, Committer: sig, Parents: parents})
+		if err != nil {
+			t.Fatalf("commit %s: %v", name, err)
+		}
+		return hash
+	}
+
+	root := commit("root.txt")
+	// main moves on with a file the feature branch then merges in.
+	mainTip := commit("main.txt", root)
+	if err := wt.Checkout(&gogit.CheckoutOptions{Hash: root, Force: true}); err != nil {
+		t.Fatal(err)
+	}
+	feature := commit("feature.txt", root)
+	// Merging main into the feature branch: the merge's tree has both files.
+	if err := os.WriteFile(filepath.Join(dir, "main.txt"), []byte("main.txt\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mergeSync := commit("main.txt", feature, mainTip)
+
+	gitRepo, err := git.OpenRepo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merge, err := gitRepo.GetCommit(mergeSync.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, changes, err := getCommitStats(gitRepo, merge, resolveMergeStatsMode(config.MergeStatsAuto, true))
+	if err != nil {
+		t.Fatalf("getCommitStats: %v", err)
+	}
+	if len(changes) != 0 || stats["additions"] != 0 || stats["files_changed"] != 0 {
+		t.Errorf("merge-sync stats = %v with %d file changes, want none", stats, len(changes))
+	}
+
+	// first-parent still attributes main's file to the merge, which is why
+	// auto no longer uses it for merge-sync commits.
+	_, changes, err = getCommitStats(gitRepo, merge, config.MergeStatsFirstParent)
+	if err != nil {
+		t.Fatalf("getCommitStats first-parent: %v", err)
+	}
+	if len(changes) != 1 || changes[0].FilePath != "main.txt" {
+		t.Errorf("first-parent changes = %+v, want main.txt", changes)
+	}
+}
@@ -18,6 +18,9 @@ var (
 	modelsSetModel    string
 	modelsSetAPIKey   string
 	modelsSetGlobal   bool
+
+	modelsSetCommitSummaryModel string
+	modelsSetFileSummaryModel   string
 )
 
 var modelsCmd = &cobra.Command{
@@ -33,7 +36,12 @@ Examples:
   devlog models list                 # List available providers and models
   devlog models set                  # Interactive setup for active profile
   devlog models set --global         # Apply to all profiles
-  devlog models set --provider anthropic --model claude-sonnet-4-20250514 --api-key sk-...`,
+  devlog models set --provider anthropic --model claude-sonnet-4-20250514 --api-key sk-...
+  devlog models set --file-summary-model claude-3-5-haiku-latest   # Cheaper model for file summaries
+  devlog models set --commit-summary-model ""                      # Clear an override
+
+Commit and file summaries use the default model unless a per-task override
+is set. Overrides apply to the profile's default provider.`,
 	RunE: runModelsShow,
 }
 
@@ -60,6 +68,8 @@ func init() {
 	modelsSetCmd.Flags().StringVar(&modelsSetModel, "model", "", "Model name")
 	modelsSetCmd.Flags().StringVar(&modelsSetAPIKey, "api-key", "", "API key")
 	modelsSetCmd.Flags().BoolVar(&modelsSetGlobal, "global", false, "Apply to all profiles")
+	modelsSetCmd.Flags().StringVar(&modelsSetCommitSummaryModel, "commit-summary-model", "", "Model for commit summaries during ingest (empty clears)")
+	modelsSetCmd.Flags().StringVar(&modelsSetFileSummaryModel, "file-summary-model", "", "Model for file summaries during indexing (empty clears)")
 }
 
 func runModelsShow(cmd *cobra.Command, args []string) error {
@@ -106,6 +116,15 @@ func runModelsShow(cmd *cobra.Command, args []string) error {
 		dimColor.Printf("  Base URL:  %s\n", profile.OllamaBaseURL)
 	}
 
+	if profile.CommitSummaryModel != "" {
+		successColor.Print("  Commit summaries:  ")
+		infoColor.Println(profile.CommitSummaryModel)
+	}
+	if profile.FileSummaryModel != "" {
+		successColor.Print("  File summaries:    ")
+		infoColor.Println(profile.FileSummaryModel)
+	}
+
 	fmt.Println()
 	return nil
 }
@@ -163,15 +182,16 @@ func runModelsSet(cmd *cobra.Command, args []string) error {
 	}
 
 	// If no flags provided, run interactive mode
-	if modelsSetProvider == "" && modelsSetModel == "" && modelsSetAPIKey == "" {
+	taskFlagsChanged := cmd.Flags().Changed("commit-summary-model") || cmd.Flags().Changed("file-summary-model")
+	if modelsSetProvider == "" && modelsSetModel == "" && modelsSetAPIKey == "" && !taskFlagsChanged {
 		return runModelsSetInteractive(cfg, profile, profileName)
 	}
 
 	// Flag-based mode
-	return runModelsSetFlags(cfg, profile, profileName)
+	return runModelsSetFlags(cmd, cfg, profile, profileName)
 }
 
-func runModelsSetFlags(cfg *config.Config, profile *config.Profile, profileName string) error {
+func runModelsSetFlags(cmd *cobra.Command, cfg *config.Config, profile *config.Profile, profileName string) error {
 	successColor := color.New(color.FgHiGreen)
 
 	if modelsSetProvider != "" {
@@ -183,6 +203,12 @@ func runModelsSetFlags(cfg *config.Config, profile *config.Profile, profileName
 	if modelsSetAPIKey != "" {
 		setAPIKeyOnProfile(profile, profile.DefaultProvider, modelsSetAPIKey)
 	}
+	if cmd.Flags().Changed("commit-summary-model") {
+		profile.CommitSummaryModel = strings.TrimSpace(modelsSetCommitSummaryModel)
+	}
+	if cmd.Flags().Changed("file-summary-model") {
+		profile.FileSummaryModel = strings.TrimSpace(modelsSetFileSummaryModel)
+	}
 
 	if modelsSetGlobal {
 		if err := cfg.ApplyLLMConfigToAllProfiles(profileName); err != nil {
@@ -353,5 +379,7 @@ func setAPIKeyOnProfile(profile *config.Profile, provider, key string) {
 		profile.GeminiAPIKey = key
 	case constants.ProviderBedrock:
 		profile.AWSAccessKeyID = key
+	case constants.ProviderAzureOpenAI:
+		profile.AzureOpenAIAPIKey = key
 	}
 }
@@ -20,7 +20,20 @@ import (
 )
 
 var (
-	onboardLegacy bool
+	onboardLegacy         bool
+	onboardNonInteractive bool
+	onboardProvider       string
+	onboardModel          string
+	onboardAPIKey         string
+	onboardBaseURL        string
+	onboardUserEmail      string
+	onboardUserName       string
+	onboardGitHubUser     string
+	onboardWorklogStyle   string
+	onboardAWSRegion      string
+	onboardAzureEndpoint  string
+	onboardAzureDeploy    string
+	onboardAzureVersion   string
 )
 
 var onboardCmd = &cobra.Command{
@@ -29,13 +42,38 @@ var onboardCmd = &cobra.Command{
 	Long: `Welcome to DevLog! This command will guide you through the initial setup.
 
 You'll create a profile, configure your preferred LLM provider, and optionally
-set up your user information.`,
+set up your user information.
+
+For Docker, CI and other environments without a terminal, pass
+--non-interactive with the settings as flags. API keys fall back to the
+provider's environment variable (ANTHROPIC_API_KEY, OPENAI_API_KEY,
+OPENROUTER_API_KEY, GEMINI_API_KEY, AZURE_OPENAI_API_KEY) and Bedrock
+credentials to AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY. The profile is
+taken from --profile (default: "default") and created if needed.
+
+Examples:
+  devlog onboard
+  devlog onboard --non-interactive --provider anthropic --api-key $KEY --user-email me@example.com
+  devlog onboard --non-interactive --provider ollama --model llama3.2 --base-url http://ollama:11434`,
 	RunE: runOnboard,
 }
 
 func init() {
 	rootCmd.AddCommand(onboardCmd)
 	onboardCmd.Flags().BoolVar(&onboardLegacy, "legacy", false, "Use legacy text-based setup")
+	onboardCmd.Flags().BoolVar(&onboardNonInteractive, "non-interactive", false, "Configure from flags and environment without prompting")
+	onboardCmd.Flags().StringVar(&onboardProvider, "provider", "", "LLM provider (non-interactive): ollama, anthropic, openai, chatgpt, openrouter, gemini, bedrock, azure")
+	onboardCmd.Flags().StringVar(&onboardModel, "model", "", "Model name (non-interactive; default: provider default)")
+	onboardCmd.Flags().StringVar(&onboardAPIKey, "api-key", "", "API key (non-interactive; default: provider environment variable)")
+	onboardCmd.Flags().StringVar(&onboardBaseURL, "base-url", "", "Ollama server URL (non-interactive)")
+	onboardCmd.Flags().StringVar(&onboardUserEmail, "user-email", "", "Your git email, used to filter your commits (non-interactive)")
+	onboardCmd.Flags().StringVar(&onboardUserName, "user-name", "", "Your name for work logs (non-interactive)")
+	onboardCmd.Flags().StringVar(&onboardGitHubUser, "github-username", "", "GitHub username (non-interactive)")
+	onboardCmd.Flags().StringVar(&onboardWorklogStyle, "worklog-style", "non-technical", "Worklog style (non-interactive): technical or non-technical")
+	onboardCmd.Flags().StringVar(&onboardAWSRegion, "aws-region", "", "AWS region for Bedrock (non-interactive)")
+	onboardCmd.Flags().StringVar(&onboardAzureEndpoint, "azure-endpoint", "", "Azure OpenAI endpoint (non-interactive)")
+	onboardCmd.Flags().StringVar(&onboardAzureDeploy, "azure-deployment", "", "Azure OpenAI deployment name (non-interactive)")
+	onboardCmd.Flags().StringVar(&onboardAzureVersion, "azure-api-version", "", "Azure OpenAI api-version (non-interactive)")
 }
 
 var (
@@ -49,6 +87,9 @@ var (
 )
 
 func runOnboard(cmd *cobra.Command, args []string) error {
+	if onboardNonInteractive {
+		return runOnboardNonInteractive()
+	}
 	if !onboardLegacy && term.IsTerminal(int(os.Stdin.Fd())) {
 		cfg, err := tui.RunOnboard()
 		if err != nil {
@@ -182,6 +223,10 @@ func runOnboardLegacy() error {
 		if err := configureBedrock(cfg, reader); err != nil {
 			return err
 		}
+	case constants.ProviderAzureOpenAI:
+		if err := configureAzureOpenAI(cfg, reader); err != nil {
+			return err
+		}
 	}
 
 	fmt.Println()
@@ -251,6 +296,137 @@ func runOnboardLegacy() error {
 	return nil
 }
 
+// runOnboardNonInteractive writes a profile's configuration from flags and
+// environment variables, validating the fields each provider needs.
+func runOnboardNonInteractive() error {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+
+	profileName := profileFlag
+	if profileName == "" {
+		profileName = "default"
+	}
+	if err := cfg.CreateProfile(profileName, ""); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("failed to create profile: %w", err)
+		}
+	}
+	cfg.ActiveProfile = profileName
+
+	if onboardWorklogStyle != "technical" && onboardWorklogStyle != "non-technical" {
+		return fmt.Errorf("invalid worklog style: %s (must be 'technical' or 'non-technical')", onboardWorklogStyle)
+	}
+	if profile := cfg.Profiles[profileName]; profile != nil {
+		profile.WorklogStyle = onboardWorklogStyle
+	}
+
+	if onboardProvider == "" {
+		return fmt.Errorf("--provider is required with --non-interactive")
+	}
+	provider := constants.Provider(strings.ToLower(onboardProvider))
+	supported := false
+	var names []string
+	for _, p := range constants.AllProviders {
+		if !p.SupportsLLM {
+			continue
+		}
+		key := constants.Provider(strings.ToLower(p.Name))
+		names = append(names, string(key))
+		if key == provider {
+			supported = true
+		}
+	}
+	if !supported {
+		return fmt.Errorf("unknown provider: %s (must be one of: %s)", onboardProvider, strings.Join(names, ", "))
+	}
+
+	model := onboardModel
+	if model == "" {
+		model = constants.GetDefaultModel(provider)
+	}
+	cfg.DefaultProvider = string(provider)
+	cfg.DefaultModel = model
+
+	switch provider {
+	case constants.ProviderOllama:
+		cfg.OllamaBaseURL = onboardBaseURL
+		if cfg.OllamaBaseURL == "" {
+			cfg.OllamaBaseURL = constants.GetDefaultBaseURL(provider)
+		}
+		cfg.OllamaModel = model
+	case constants.ProviderBedrock:
+		cfg.AWSAccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		cfg.AWSSecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		if cfg.AWSAccessKeyID == "" || cfg.AWSSecretAccessKey == "" {
+			return fmt.Errorf("bedrock requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY to be set")
+		}
+		cfg.AWSRegion = onboardAWSRegion
+		if cfg.AWSRegion == "" {
+			cfg.AWSRegion = os.Getenv("AWS_REGION")
+		}
+		if cfg.AWSRegion == "" {
+			cfg.AWSRegion = constants.GetDefaultAWSRegion()
+		}
+	case constants.ProviderAzureOpenAI:
+		if onboardAzureEndpoint == "" || onboardAzureDeploy == "" {
+			return fmt.Errorf("azure requires --azure-endpoint and --azure-deployment")
+		}
+		cfg.AzureEndpoint = strings.TrimRight(onboardAzureEndpoint, "/")
+		cfg.AzureDeployment = onboardAzureDeploy
+		cfg.AzureAPIVersion = onboardAzureVersion
+		if cfg.AzureAPIVersion == "" {
+			cfg.AzureAPIVersion = constants.GetDefaultAzureAPIVersion()
+		}
+		if onboardModel == "" {
+			cfg.DefaultModel = onboardAzureDeploy
+		}
+	}
+
+	// API keys passed as flags are stored; otherwise the provider's
+	// environment variable must be set and is read at runtime.
+	if constants.GetProviderSetupInfo(provider).NeedsAPIKey && provider != constants.ProviderBedrock {
+		switch provider {
+		case constants.ProviderAnthropic:
+			cfg.AnthropicAPIKey = onboardAPIKey
+		case constants.ProviderOpenAI:
+			cfg.OpenAIAPIKey = onboardAPIKey
+		case constants.ProviderChatGPT:
+			cfg.ChatGPTAccessToken = onboardAPIKey
+		case constants.ProviderOpenRouter:
+			cfg.OpenRouterAPIKey = onboardAPIKey
+		case constants.ProviderGemini:
+			cfg.GeminiAPIKey = onboardAPIKey
+		case constants.ProviderAzureOpenAI:
+			cfg.AzureOpenAIAPIKey = onboardAPIKey
+		}
+		if cfg.GetAPIKey(string(provider)) == "" {
+			return fmt.Errorf("%s requires an API key: pass --api-key or set the provider's environment variable", provider)
+		}
+	}
+
+	if onboardUserEmail != "" {
+		cfg.UserEmail = onboardUserEmail
+	}
+	if onboardUserName != "" {
+		cfg.UserName = onboardUserName
+	}
+	if onboardGitHubUser != "" {
+		cfg.GitHubUsername = onboardGitHubUser
+	}
+
+	cfg.OnboardingComplete = true
+	cfg.CopyLLMConfigToProfile(profileName)
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	successColor.Printf("Configured profile '%s' (%s / %s)\n", profileName, cfg.DefaultProvider, cfg.DefaultModel)
+	return nil
+}
+
 func printBanner() {
 	banner := `
     ____            __
@@ -619,6 +795,62 @@ func configureBedrock(cfg *config.Config, reader *bufio.Reader) error {
 	return nil
 }
 
+func configureAzureOpenAI(cfg *config.Config, reader *bufio.Reader) error {
+	setupInfo := constants.GetProviderSetupInfo(constants.ProviderAzureOpenAI)
+	defaultAPIVersion := constants.GetDefaultAzureAPIVersion()
+
+	fmt.Println()
+	infoColor.Println(setupInfo.SetupHint)
+	fmt.Println()
+
+	promptColor.Print("Endpoint (e.g. https://my-resource.openai.azure.com): ")
+	endpoint, _ := reader.ReadString('\n')
+	endpoint = strings.TrimRight(strings.TrimSpace(endpoint), "/")
+
+	if endpoint == "" {
+		errorColor.Println("Endpoint is required")
+		return fmt.Errorf("endpoint required")
+	}
+
+	promptColor.Print("Deployment name: ")
+	deployment, _ := reader.ReadString('\n')
+	deployment = strings.TrimSpace(deployment)
+
+	if deployment == "" {
+		errorColor.Println("Deployment name is required")
+		return fmt.Errorf("deployment required")
+	}
+
+	promptColor.Print("API version (press Enter for default): ")
+	dimColor.Printf("[%s] ", defaultAPIVersion)
+	apiVersion, _ := reader.ReadString('\n')
+	apiVersion = strings.TrimSpace(apiVersion)
+	if apiVersion == "" {
+		apiVersion = defaultAPIVersion
+	}
+
+	promptColor.Print("API key: ")
+	apiKey, _ := reader.ReadString('\n')
+	apiKey = strings.TrimSpace(apiKey)
+
+	if apiKey == "" {
+		errorColor.Println("API key is required")
+		return fmt.Errorf("API key required")
+	}
+
+	cfg.AzureEndpoint = endpoint
+	cfg.AzureDeployment = deployment
+	cfg.AzureAPIVersion = apiVersion
+	cfg.AzureOpenAIAPIKey = apiKey
+	cfg.DefaultModel = deployment
+
+	fmt.Println()
+	successColor.Println("Azure OpenAI configured!")
+	dimColor.Printf("Deployment: %s\n", deployment)
+
+	return nil
+}
+
 func printTutorial() {
 	sections := []struct {
 		title    string
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+var (
+	openRepo  string
+	openPrint bool
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open",
+	Short: "Open the most recently generated worklog",
+	Long: `Open the worklog file most recently written by 'devlog worklog'.
+
+Inside an ingested repository (or with --repo) this is the latest worklog for
+that repository; elsewhere it is the latest worklog of the profile. If no
+worklog has been recorded yet, the newest worklog_*.md in the worklog output
+directory or the current directory is used.
+
+The file opens in $VISUAL or $EDITOR when set, otherwise in the system's
+default application for markdown files.
+
+Examples:
+  devlog open                 # Latest worklog for this repository
+  devlog open --repo api      # Latest worklog for another repository
+  devlog open --print         # Only print the path (e.g. for scripts)`,
+	Args: cobra.NoArgs,
+	RunE: runOpen,
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+
+	openCmd.Flags().StringVar(&openRepo, "repo", "", "Repository name or path (default: current repository)")
+	openCmd.Flags().BoolVar(&openPrint, "print", false, "Print the worklog path instead of opening it")
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	dimColor := color.New(color.FgHiBlack)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	codebase, err := resolveOpenCodebase(ctx)
+	if err != nil {
+		return err
+	}
+
+	path := findLatestWorklog(cfg, codebase)
+	if path == "" {
+		return fmt.Errorf("no worklog found; run 'devlog worklog' to generate one")
+	}
+
+	if openPrint {
+		fmt.Println(path)
+		return nil
+	}
+	dimColor.Printf("  Opening %s\n", path)
+	return openFile(path)
+}
+
+// resolveOpenCodebase picks the repository from --repo or the current
+// directory. Outside an ingested repository it returns nil, and so does a
+// missing database, since a worklog can be opened without one.
+func resolveOpenCodebase(ctx context.Context) (*db.Codebase, error) {
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		if openRepo != "" {
+			return nil, fmt.Errorf("failed to initialize database: %w", err)
+		}
+		VerboseLog("Warning: failed to open database: %v", err)
+		return nil, nil
+	}
+	if openRepo != "" {
+		return findCodebaseByRef(ctx, dbRepo, openRepo)
+	}
+	codebasePath, err := filepath.Abs(".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, codebasePath)
+	if err != nil || codebase == nil {
+		VerboseLog("No codebase found at current path, using the profile's latest worklog")
+		return nil, nil
+	}
+	return codebase, nil
+}
+
+// findLatestWorklog returns the recorded latest worklog for the repository
+// (or profile), falling back to the newest worklog_*.md in the output
+// directory or the current directory. It returns "" if there is none.
+func findLatestWorklog(cfg *config.Config, codebase *db.Codebase) string {
+	repoPath := ""
+	if codebase != nil {
+		repoPath = codebase.Path
+	}
+	if path := cfg.GetLastWorklog(cfg.GetActiveProfileName(), repoPath); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		VerboseLog("Recorded worklog %s no longer exists", path)
+	}
+
+	var dirs []string
+	if outputDir := cfg.GetWorklogOutputDir(); outputDir != "" {
+		if resolved, err := resolveWorklogOutputPath(outputDir, "worklog.md", codebase); err == nil {
+			dirs = append(dirs, filepath.Dir(resolved))
+		}
+	}
+	dirs = append(dirs, ".")
+
+	var newest string
+	var newestMod int64
+	for _, dir := range dirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, "worklog_*.md"))
+		for _, m := range matches {
+			info, err := os.Stat(m)
+			if err != nil || info.IsDir() {
+				continue
+			}
+			if mod := info.ModTime().UnixNano(); newest == "" || mod > newestMod {
+				newest, newestMod = m, mod
+			}
+		}
+	}
+	if newest == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(newest); err == nil {
+		return abs
+	}
+	return newest
+}
+
+// openFile opens a file in $VISUAL or $EDITOR, waiting for the editor to
+// exit, or hands it to the system's default application.
+func openFile(path string) error {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		parts := strings.Fields(os.Getenv(env))
+		if len(parts) == 0 {
+			continue
+		}
+		cmd := exec.Command(parts[0], append(parts[1:], path)...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to run %s (%s): %w", env, parts[0], err)
+		}
+		return nil
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "linux":
+		cmd = exec.Command("xdg-open", path)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", path)
+	default:
+		return fmt.Errorf("unsupported platform: %s (set $EDITOR or use --print)", runtime.GOOS)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w\n\nSet $EDITOR, or use --print to get the path", path, err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,6 +17,11 @@ import (
 
 var (
 	deleteProfileData bool
+
+	profileExportOut     string
+	profileExportSecrets bool
+	profileImportName    string
+	profileImportForce   bool
 )
 
 var profileCmd = &cobra.Command{
@@ -81,6 +87,52 @@ Valid styles:
 	RunE: runProfileSetWorklogStyle,
 }
 
+var profileCountCoAuthoredCmd = &cobra.Command{
+	Use:   "count-coauthored <on|off>",
+	Short: "Count commits you co-authored as your own",
+	Long: `Choose whether commits that name you in a Co-authored-by trailer count
+as your own in worklogs and stats, even when a teammate authored them.
+Useful for teams that pair program. Off by default.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"on", "off"},
+	RunE:      runProfileCountCoAuthored,
+}
+
+var profileAutoWorklogCmd = &cobra.Command{
+	Use:   "auto-worklog <prompt|always|never>",
+	Short: "Choose whether ingest generates a worklog afterwards",
+	Long: `Choose what 'devlog ingest' does about a worklog once it has ingested
+commits: ask each time (prompt, the default), always generate one, or never
+generate one and skip the question. --skip-worklog and --auto-worklog still
+override the setting for a single run.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{config.AutoWorklogPrompt, config.AutoWorklogAlways, config.AutoWorklogNever},
+	RunE:      runProfileAutoWorklog,
+}
+
+var profileExportCmd = &cobra.Command{
+	Use:   "export <name>",
+	Short: "Export a profile's settings to a JSON file",
+	Long: `Export a profile's settings (providers, models, repos, branch selections,
+worklog preferences) as JSON so they can be copied to another machine or
+shared with a team. Ingested data is not included.
+
+API keys, tokens and cloud credentials are removed unless --include-secrets
+is given. Without --out the JSON is written to stdout.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileExport,
+}
+
+var profileImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a profile from a JSON file",
+	Long: `Import a profile written by 'devlog profile export'. The profile keeps its
+exported name unless --name is given. An existing profile is only replaced
+with --force, and then keeps its own API keys where the file has none.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileImport,
+}
+
 func init() {
 	rootCmd.AddCommand(profileCmd)
 	profileCmd.AddCommand(profileListCmd)
@@ -89,8 +141,16 @@ func init() {
 	profileCmd.AddCommand(profileDeleteCmd)
 	profileCmd.AddCommand(profileReposCmd)
 	profileCmd.AddCommand(profileSetWorklogStyleCmd)
+	profileCmd.AddCommand(profileCountCoAuthoredCmd)
+	profileCmd.AddCommand(profileAutoWorklogCmd)
+	profileCmd.AddCommand(profileExportCmd)
+	profileCmd.AddCommand(profileImportCmd)
 
 	profileDeleteCmd.Flags().BoolVar(&deleteProfileData, "data", false, "Also delete the profile's database")
+	profileExportCmd.Flags().StringVarP(&profileExportOut, "out", "o", "", "File to write (default: stdout)")
+	profileExportCmd.Flags().BoolVar(&profileExportSecrets, "include-secrets", false, "Keep API keys, tokens and credentials in the export")
+	profileImportCmd.Flags().StringVar(&profileImportName, "name", "", "Import under a different profile name")
+	profileImportCmd.Flags().BoolVar(&profileImportForce, "force", false, "Replace an existing profile with the same name")
 }
 
 func runProfileShow(cmd *cobra.Command, args []string) error {
@@ -122,11 +182,19 @@ func runProfileShow(cmd *cobra.Command, args []string) error {
 			worklogStyle = "non-technical (default)"
 		}
 		infoColor.Printf("  Worklog Style: %s\n", worklogStyle)
+		if profile.CountCoAuthoredCommits {
+			infoColor.Println("  Co-authored commits: counted as yours")
+		}
+		if mode := cfg.GetAutoWorklogAfterIngest(); mode != config.AutoWorklogPrompt {
+			infoColor.Printf("  Worklog after ingest: %s\n", mode)
+		}
 		infoColor.Printf("  Repositories: %d\n", len(profile.Repos))
 	}
 
 	dbPath := config.GetProfileDBPath(profileName)
-	if info, err := os.Stat(dbPath); err == nil {
+	if storage, _ := cfg.GetProfileStorage(profileName); storage == config.StoragePostgres {
+		dimColor.Println("  Database: postgres (postgres_url)")
+	} else if info, err := os.Stat(dbPath); err == nil {
 		dimColor.Printf("  Database: %s (%.2f MB)\n", dbPath, float64(info.Size())/(1024*1024))
 	} else {
 		dimColor.Printf("  Database: %s (not created)\n", dbPath)
@@ -341,3 +409,149 @@ func runProfileSetWorklogStyle(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runProfileCountCoAuthored(cmd *cobra.Command, args []string) error {
+	var enabled bool
+	switch args[0] {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		return fmt.Errorf("invalid value: %s (must be 'on' or 'off')", args[0])
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	profileName := cfg.GetActiveProfileName()
+	if err := cfg.SetCountCoAuthoredCommits(profileName, enabled); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	successColor := color.New(color.FgHiGreen)
+	if enabled {
+		successColor.Printf("Co-authored commits now count as yours for profile '%s'\n", profileName)
+	} else {
+		successColor.Printf("Co-authored commits no longer count as yours for profile '%s'\n", profileName)
+	}
+	return nil
+}
+
+func runProfileAutoWorklog(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	profileName := cfg.GetActiveProfileName()
+	mode := strings.ToLower(args[0])
+	if err := cfg.SetAutoWorklogAfterIngest(profileName, mode); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	successColor := color.New(color.FgHiGreen)
+	switch mode {
+	case config.AutoWorklogAlways:
+		successColor.Printf("Ingest will always generate a worklog for profile '%s'\n", profileName)
+	case config.AutoWorklogNever:
+		successColor.Printf("Ingest will no longer offer a worklog for profile '%s'\n", profileName)
+	default:
+		successColor.Printf("Ingest will ask before generating a worklog for profile '%s'\n", profileName)
+	}
+	return nil
+}
+
+func runProfileExport(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	profile, ok := cfg.Profiles[name]
+	if !ok || profile == nil {
+		return fmt.Errorf("profile '%s' not found", name)
+	}
+	if !profileExportSecrets {
+		profile = profile.WithoutSecrets()
+	}
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile: %w", err)
+	}
+	data = append(data, '\n')
+
+	if profileExportOut == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(profileExportOut, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", profileExportOut, err)
+	}
+
+	successColor := color.New(color.FgHiGreen)
+	successColor.Printf("Exported profile '%s' to %s\n", name, profileExportOut)
+	if !profileExportSecrets {
+		dimColor := color.New(color.FgHiBlack)
+		dimColor.Println("Secrets were removed. Use --include-secrets to keep them.")
+	}
+	return nil
+}
+
+func runProfileImport(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+
+	var profile config.Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return fmt.Errorf("failed to parse profile from %s: %w", args[0], err)
+	}
+
+	name := profileImportName
+	if name == "" {
+		name = profile.Name
+	}
+	if name == "" {
+		return fmt.Errorf("profile in %s has no name; use --name to set one", args[0])
+	}
+	if strings.ContainsAny(name, "/\\:*?\"<>|") {
+		return fmt.Errorf("profile name cannot contain special characters: /\\:*?\"<>|")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := cfg.ImportProfile(name, &profile, profileImportForce); err != nil {
+		if !profileImportForce && cfg.Profiles[name] != nil {
+			return fmt.Errorf("%w (use --force to replace it or --name to import under another name)", err)
+		}
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	successColor := color.New(color.FgHiGreen)
+	successColor.Printf("Imported profile '%s'\n", name)
+	fmt.Printf("Use 'devlog profile use %s' to switch to it\n", name)
+	return nil
+}
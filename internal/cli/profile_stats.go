@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+var profileStatsDays int
+
+var profileStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Compare your activity across profiles",
+	Long: `Show your commits, active days and active repositories in every profile
+over the same window, side by side, to see how your time splits between
+work contexts (for example work and side projects).
+
+Each profile's database is opened read-only. Bot commits are left out, and
+days are counted in each profile's own timezone.
+
+Examples:
+  devlog profile stats              # Last 30 days
+  devlog profile stats --days 90    # Last 90 days`,
+	Args: cobra.NoArgs,
+	RunE: runProfileStats,
+}
+
+func init() {
+	profileCmd.AddCommand(profileStatsCmd)
+
+	profileStatsCmd.Flags().IntVar(&profileStatsDays, "days", 30, "Number of days to include")
+}
+
+// profileActivity is one profile's activity in the profile stats window.
+type profileActivity struct {
+	Name       string
+	Commits    int
+	ActiveDays int
+	Repos      int
+	Err        error
+}
+
+func runProfileStats(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	activeColor := color.New(color.FgHiGreen, color.Bold)
+
+	if profileStatsDays <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	names := cfg.ListProfiles()
+	if len(names) == 0 {
+		return fmt.Errorf("no profiles found\n\nRun 'devlog onboard' to create one")
+	}
+	sort.Strings(names)
+
+	since := time.Now().AddDate(0, 0, -profileStatsDays)
+	var activity []profileActivity
+	total := 0
+	for _, name := range names {
+		a := collectProfileActivity(ctx, cfg, name, since)
+		total += a.Commits
+		activity = append(activity, a)
+	}
+
+	fmt.Println()
+	titleColor.Printf("  Activity by profile (last %d days)\n\n", profileStatsDays)
+	dimColor.Printf("      %-20s %8s %12s %6s %6s\n", "Profile", "Commits", "Active days", "Repos", "Share")
+	active := cfg.GetActiveProfileName()
+	for _, a := range activity {
+		marker, nameColor := "   ", infoColor
+		if a.Name == active {
+			marker, nameColor = "(*)", activeColor
+		}
+		nameColor.Printf("  %s %-20s", marker, truncate(a.Name, 20))
+		if a.Err != nil {
+			dimColor.Printf(" unavailable: %v\n", a.Err)
+			continue
+		}
+		share := "-"
+		if total > 0 {
+			share = fmt.Sprintf("%d%%", a.Commits*100/total)
+		}
+		fmt.Printf(" %8d %12d %6d %6s\n", a.Commits, a.ActiveDays, a.Repos, share)
+	}
+	fmt.Println()
+	return nil
+}
+
+// collectProfileActivity counts the user's commits, active days and active
+// repositories since a time from a profile's own database. A profile that
+// has never ingested anything has no activity.
+func collectProfileActivity(ctx context.Context, cfg *config.Config, name string, since time.Time) profileActivity {
+	a := profileActivity{Name: name}
+	if storage, _ := cfg.GetProfileStorage(name); storage != config.StoragePostgres {
+		if _, err := os.Stat(config.GetProfileDBPath(name)); os.IsNotExist(err) {
+			return a
+		}
+	}
+	loc := time.UTC
+	if profile := cfg.Profiles[name]; profile != nil && profile.Timezone != "" {
+		if tzLoc, err := time.LoadLocation(profile.Timezone); err == nil {
+			loc = tzLoc
+		}
+	}
+
+	dbRepo, err := db.GetReadOnlyRepositoryForProfile(name)
+	if err != nil {
+		a.Err = err
+		return a
+	}
+	defer dbRepo.Close()
+
+	codebases, err := dbRepo.GetAllCodebases(ctx)
+	if err != nil {
+		a.Err = fmt.Errorf("failed to load repositories: %w", err)
+		return a
+	}
+	days := make(map[time.Time]bool)
+	for _, cb := range codebases {
+		commits, err := dbRepo.GetUserCommits(ctx, cb.ID, since)
+		if err != nil {
+			a.Err = fmt.Errorf("failed to load commits: %w", err)
+			return a
+		}
+		counted := 0
+		for _, c := range commits {
+			if c.IsBot {
+				continue
+			}
+			counted++
+			days[localDay(c.CommittedAt, loc)] = true
+		}
+		if counted > 0 {
+			a.Commits += counted
+			a.Repos++
+		}
+	}
+	a.ActiveDays = len(days)
+	return a
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	// progressBarWidth is the number of cells in a progress bar.
+	progressBarWidth = 24
+	// progressRedrawInterval throttles redraws so fast loops stay fast.
+	progressRedrawInterval = 100 * time.Millisecond
+)
+
+// progressBar draws a single-line progress bar with elapsed time and an
+// estimate of the time remaining, based on the average time per item so
+// far. When stdout is not a terminal it stays quiet until Done, which
+// prints the final line once, so logs do not fill with redraws.
+type progressBar struct {
+	indent   string
+	label    string
+	total    int
+	done     int
+	start    time.Time
+	lastDraw time.Time
+	width    int // length of the last line drawn, for clearing
+	live     bool
+	finished bool
+}
+
+// newProgressBar starts a progress bar for total items, e.g.
+// newProgressBar("  ", "files", 120).
+func newProgressBar(indent, label string, total int) *progressBar {
+	return &progressBar{
+		indent: indent,
+		label:  label,
+		total:  total,
+		start:  time.Now(),
+		live:   term.IsTerminal(int(os.Stdout.Fd())),
+	}
+}
+
+// Add records n more finished items and redraws the bar if it is due.
+func (p *progressBar) Add(n int) {
+	p.Set(p.done + n)
+}
+
+// Set records how many items are finished and redraws the bar if it is
+// due. Loops that skip items with continue call it at the top of each
+// iteration with the number of items before the current one.
+func (p *progressBar) Set(done int) {
+	p.done = done
+	if p.live && (p.done >= p.total || time.Since(p.lastDraw) >= progressRedrawInterval) {
+		p.draw()
+	}
+}
+
+// Done draws the current state and ends the line, so it can be deferred to
+// tidy up after an early return. Bars with nothing to do print nothing.
+// Calling Done more than once has no effect.
+func (p *progressBar) Done() {
+	if p.finished || p.total == 0 {
+		return
+	}
+	p.finished = true
+	p.draw()
+	fmt.Println()
+}
+
+func (p *progressBar) draw() {
+	p.lastDraw = time.Now()
+	done := p.done
+	if done > p.total {
+		done = p.total
+	}
+	filled := progressBarWidth * done / p.total
+	elapsed := time.Since(p.start)
+
+	line := fmt.Sprintf("%s[%s%s] %d/%d %s  %s elapsed",
+		p.indent,
+		strings.Repeat("█", filled), strings.Repeat("░", progressBarWidth-filled),
+		done, p.total, p.label, formatProgressDuration(elapsed))
+	if done > 0 && done < p.total {
+		remaining := elapsed / time.Duration(done) * time.Duration(p.total-done)
+		line += fmt.Sprintf(", ~%s left", formatProgressDuration(remaining))
+	}
+
+	width := len([]rune(line))
+	if pad := p.width - width; pad > 0 {
+		line += strings.Repeat(" ", pad)
+	}
+	p.width = width
+	if p.live {
+		line = "\r" + line
+	}
+	fmt.Print(line)
+}
+
+// formatProgressDuration renders a duration compactly: "42s", "3m05s" or
+// "1h02m".
+func formatProgressDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
+)
+
+var (
+	reposRemovePrune bool
+	reposRemoveForce bool
+)
+
+var reposCmd = &cobra.Command{
+	Use:   "repos",
+	Short: "List and manage the repositories in the active profile",
+	Long: `List and manage the repositories registered in the active profile.
+
+'devlog ingest' adds repositories automatically; these commands let you see
+the registry and clean up entries for repos that were moved or deleted.
+
+Examples:
+  devlog repos list                         # Show repos with commit counts
+  devlog repos add ~/projects/myapp         # Register a repo without ingesting
+  devlog repos remove ~/old/project         # Forget a repo, keep its data
+  devlog repos remove ~/old/project --prune # Also delete its ingested data`,
+}
+
+var reposListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List repositories in the active profile",
+	Args:  cobra.NoArgs,
+	RunE:  runReposList,
+}
+
+var reposAddCmd = &cobra.Command{
+	Use:   "add <path>",
+	Short: "Add a repository to the active profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runReposAdd,
+}
+
+var reposRemoveCmd = &cobra.Command{
+	Use:   "remove <path>",
+	Short: "Remove a repository from the active profile",
+	Long: `Remove a repository from the active profile.
+
+By default only the profile entry is removed and ingested data is kept, so
+re-adding the repo later picks up where it left off. With --prune the repo's
+commits, branches, indexes, cached worklogs and saved settings (branch
+selection, index folders, Obsidian vault) are deleted as well.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReposRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(reposCmd)
+	reposCmd.AddCommand(reposListCmd)
+	reposCmd.AddCommand(reposAddCmd)
+	reposCmd.AddCommand(reposRemoveCmd)
+
+	reposRemoveCmd.Flags().BoolVar(&reposRemovePrune, "prune", false, "Also delete the repo's ingested data and saved settings")
+	reposRemoveCmd.Flags().BoolVarP(&reposRemoveForce, "force", "f", false, "Skip the --prune confirmation prompt")
+}
+
+func runReposList(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	warnColor := color.New(color.FgHiYellow)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	profileName := cfg.GetActiveProfileName()
+	profile := cfg.GetActiveProfile()
+
+	fmt.Println()
+	titleColor.Printf("  Repositories in '%s'\n", profileName)
+	dimColor.Println("  " + strings.Repeat("─", 40))
+	fmt.Println()
+
+	if profile == nil || len(profile.Repos) == 0 {
+		dimColor.Println("  No repositories in this profile.")
+		dimColor.Println("  Use 'devlog ingest <path>' or 'devlog repos add <path>' to add one.")
+		fmt.Println()
+		return nil
+	}
+
+	db.SetActiveProfile(profileName)
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	missing := 0
+	for _, repoPath := range profile.Repos {
+		if _, err := os.Stat(repoPath); os.IsNotExist(err) {
+			missing++
+			warnColor.Printf("  %s", filepath.Base(repoPath))
+			dimColor.Print(" (missing)")
+		} else {
+			infoColor.Printf("  %s", filepath.Base(repoPath))
+		}
+		fmt.Println()
+		dimColor.Printf("    %s\n", repoPath)
+
+		commitCount, err := dbRepo.GetCommitCountByPath(ctx, repoPath)
+		if err != nil {
+			return fmt.Errorf("failed to get commit count for %s: %w", repoPath, err)
+		}
+		codebase, err := dbRepo.GetCodebaseByPath(ctx, repoPath)
+		if err != nil {
+			return fmt.Errorf("failed to get codebase for %s: %w", repoPath, err)
+		}
+		lastIndexed := "never"
+		if codebase != nil && !codebase.IndexedAt.IsZero() {
+			lastIndexed = codebase.IndexedAt.Local().Format("Jan 2, 2006 15:04")
+		}
+		dimColor.Printf("    %d commits · last indexed %s\n", commitCount, lastIndexed)
+		if codebase != nil && codebase.RemoteURL != "" {
+			remote := codebase.RemoteURL
+			if browse := git.BrowseURL(remote); browse != "" {
+				remote = browse
+			}
+			dimColor.Printf("    %s\n", remote)
+		}
+	}
+
+	fmt.Println()
+	if missing > 0 {
+		dimColor.Printf("  %d repo(s) no longer exist on disk. Remove with: devlog repos remove <path> --prune\n", missing)
+		fmt.Println()
+	}
+	return nil
+}
+
+func runReposAdd(cmd *cobra.Command, args []string) error {
+	successColor := color.New(color.FgHiGreen)
+	dimColor := color.New(color.FgHiBlack)
+
+	absPath, err := resolveRepoArg(args)
+	if err != nil {
+		return err
+	}
+	if _, err := git.OpenRepo(absPath); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.EnsureDefaultProfile(); err != nil {
+		return fmt.Errorf("failed to ensure default profile: %w", err)
+	}
+	profileName := cfg.GetActiveProfileName()
+
+	if isRepoInProfile(cfg, profileName, absPath) {
+		fmt.Println()
+		dimColor.Printf("  %s is already in profile '%s'\n\n", absPath, profileName)
+		return nil
+	}
+	if err := cfg.AddRepoToProfile(profileName, absPath); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println()
+	successColor.Printf("  ✓ Added %s to profile '%s'\n", absPath, profileName)
+	dimColor.Printf("  Run 'devlog ingest %s' to ingest its history\n\n", absPath)
+	return nil
+}
+
+func runReposRemove(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	successColor := color.New(color.FgHiGreen)
+	dimColor := color.New(color.FgHiBlack)
+	warnColor := color.New(color.FgHiYellow, color.Bold)
+
+	absPath, err := resolveRepoArg(args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	profileName := cfg.GetActiveProfileName()
+	inProfile := isRepoInProfile(cfg, profileName, absPath)
+
+	var dbRepo *db.SQLRepository
+	var codebase *db.Codebase
+	if reposRemovePrune {
+		db.SetActiveProfile(profileName)
+		dbRepo, err = db.GetRepository()
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		codebase, err = dbRepo.GetCodebaseByPath(ctx, absPath)
+		if err != nil {
+			return fmt.Errorf("failed to get codebase for %s: %w", absPath, err)
+		}
+	}
+	if !inProfile && codebase == nil {
+		return fmt.Errorf("%s is not a repository in profile '%s' (see 'devlog repos list')", absPath, profileName)
+	}
+
+	if codebase != nil && !reposRemoveForce {
+		commitCount, err := dbRepo.GetCommitCount(ctx, codebase.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get commit count for %s: %w", absPath, err)
+		}
+		fmt.Println()
+		warnColor.Printf("  This deletes %d commits, indexes and cached worklogs for %s.\n", commitCount, codebase.Name)
+		warnColor.Print("  Continue? [y/N]: ")
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println()
+			dimColor.Println("  Canceled.")
+			fmt.Println()
+			return nil
+		}
+	}
+
+	if err := cfg.RemoveRepoFromProfile(profileName, absPath); err != nil {
+		return err
+	}
+	if reposRemovePrune {
+		cfg.ClearRepoSettings(profileName, absPath)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println()
+	if inProfile {
+		successColor.Printf("  ✓ Removed %s from profile '%s'\n", absPath, profileName)
+	}
+	if codebase != nil {
+		if err := dbRepo.DeleteCodebase(ctx, codebase.ID); err != nil {
+			return fmt.Errorf("failed to delete data for %s: %w", absPath, err)
+		}
+		successColor.Println("  ✓ Deleted ingested data")
+	} else if reposRemovePrune {
+		dimColor.Println("  No ingested data found for this repo")
+	} else {
+		dimColor.Println("  Ingested data was kept; use --prune to delete it")
+	}
+	fmt.Println()
+	return nil
+}
+
+// isRepoInProfile reports whether absPath is registered in the profile.
+func isRepoInProfile(cfg *config.Config, profileName, absPath string) bool {
+	profile := cfg.Profiles[profileName]
+	if profile == nil {
+		return false
+	}
+	for _, r := range profile.Repos {
+		if r == absPath {
+			return true
+		}
+	}
+	return false
+}
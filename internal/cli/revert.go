@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
+)
+
+var (
+	// revertsCommitRE matches the body line `git revert` writes.
+	revertsCommitRE = regexp.MustCompile(`(?m)^This reverts commit ([0-9a-f]{40})`)
+	revertSubjectRE = regexp.MustCompile(`^Revert "(.+)"$`)
+)
+
+// revertTracker links revert commits to the commits they undo during an
+// ingest. It remembers the inverse diff of every commit it has seen, so a
+// revert is recognised even when its message was rewritten, as long as the
+// original was ingested in the same run.
+type revertTracker struct {
+	dbRepo     *db.SQLRepository
+	codebaseID string
+	byInverse  map[string]string // inverse diff signature -> commit hash
+}
+
+func newRevertTracker(dbRepo *db.SQLRepository, codebaseID string) *revertTracker {
+	return &revertTracker{dbRepo: dbRepo, codebaseID: codebaseID, byInverse: make(map[string]string)}
+}
+
+// revertedHash returns the hash of the commit that commit reverts, or "".
+// It checks, in order, the "This reverts commit <hash>" trailer, a
+// `Revert "<subject>"` subject naming an ingested commit, and an exact
+// inverse of a diff seen earlier in this run.
+func (t *revertTracker) revertedHash(ctx context.Context, commit *git.Commit) string {
+	forward, inverse, err := git.ChangeSignatures(commit)
+	if err != nil {
+		VerboseLog("Warning: failed to compare diff of %s for reverts: %v", commit.Hash.String()[:8], err)
+	}
+	if inverse != "" {
+		defer func() { t.byInverse[inverse] = commit.Hash.String() }()
+	}
+
+	if m := revertsCommitRE.FindStringSubmatch(commit.Message); m != nil {
+		return m[1]
+	}
+	subject := strings.TrimSpace(strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)[0])
+	if m := revertSubjectRE.FindStringSubmatch(subject); m != nil {
+		hash, err := t.dbRepo.FindCommitHashBySubject(ctx, t.codebaseID, m[1], commit.Author.When)
+		if err != nil {
+			VerboseLog("Warning: failed to look up reverted commit %q: %v", m[1], err)
+		} else if hash != "" {
+			return hash
+		}
+	}
+	if forward != "" {
+		return t.byInverse[forward]
+	}
+	return ""
+}
+
+// collapseReverts folds commit/revert pairs in a worklog's commit list. A
+// commit that was later reverted is marked "(later reverted)" and the revert
+// itself is dropped when the original is in the list; with omit, both are
+// dropped. Reverts of commits outside the list are kept as ordinary work.
+func collapseReverts(commits []commitData, omit bool) []commitData {
+	inList := make(map[string]bool, len(commits))
+	for _, c := range commits {
+		inList[c.Hash] = true
+	}
+
+	kept := commits[:0:0]
+	for _, c := range commits {
+		if c.RevertsHash != "" && inList[c.RevertsHash] {
+			continue
+		}
+		if c.RevertedBy != "" {
+			if omit {
+				continue
+			}
+			subject, rest, _ := strings.Cut(c.Message, "\n")
+			c.Message = subject + " (later reverted)"
+			if rest != "" {
+				c.Message += "\n" + rest
+			}
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
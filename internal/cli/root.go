@@ -8,6 +8,7 @@ import (
 
 	"github.com/ishaan812/devlog/internal/config"
 	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/llm"
 )
 
 var (
@@ -26,8 +27,9 @@ Use 'devlog ingest' to scan a repository and 'devlog worklog' to view your activ
 Profiles allow you to maintain separate databases for different work contexts.
 Use 'devlog profile' to manage profiles.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Skip profile setup for commands that don't need it
-		if cmd.Name() == "onboard" || cmd.Name() == "update" {
+		// Skip profile setup for commands that don't need it. doctor does its
+		// own checks, so it can still report when this setup would fail.
+		if cmd.Name() == "onboard" || cmd.Name() == "update" || cmd.Name() == "doctor" {
 			return nil
 		}
 
@@ -60,6 +62,7 @@ Use 'devlog profile' to manage profiles.`,
 
 		// Set active profile for DB operations
 		db.SetActiveProfile(profileName)
+		llmCallTimeout = cfg.GetLLMTimeout()
 
 		// Save config if we created the default profile
 		if err := cfg.Save(); err != nil {
@@ -71,7 +74,11 @@ Use 'devlog profile' to manage profiles.`,
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if hint := llm.Describe(err); hint != "" {
+		fmt.Fprintf(os.Stderr, "Hint: %s\n", hint)
+	}
+	return err
 }
 
 func init() {
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/llm"
+)
+
+var (
+	searchLimit      int
+	searchLanguage   string
+	searchPathPrefix string
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Find indexed files by meaning",
+	Long: `Rank the current repository's indexed files by semantic similarity to a
+natural-language query.
+
+The query is embedded with the profile's provider and compared with the file
+embeddings stored by 'devlog ingest'. Embeddings are currently generated by
+the Ollama provider (ollama_embedding_model, default nomic-embed-text), so
+search works fully offline.
+
+--language and --path-prefix narrow the files before they are ranked.
+
+Examples:
+  devlog search "where are retries handled"
+  devlog search "database schema migrations" --limit 5
+  devlog search "token refresh" --language go --path-prefix internal/auth`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 10, "Maximum number of files to show (0 = no limit)")
+	searchCmd.Flags().StringVar(&searchLanguage, "language", "", "Only files in this language (e.g. go, typescript)")
+	searchCmd.Flags().StringVar(&searchPathPrefix, "path-prefix", "", "Only files under this repo-relative directory (e.g. internal/)")
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	scoreColor := color.New(color.FgHiGreen)
+
+	query := strings.TrimSpace(args[0])
+	if query == "" {
+		return fmt.Errorf("search query cannot be empty")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	codebasePath, err := filepath.Abs(".")
+	if err != nil {
+		return fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, codebasePath)
+	if err != nil {
+		return fmt.Errorf("failed to get codebase: %w", err)
+	}
+	if codebase == nil {
+		return fmt.Errorf("%s is not an ingested repository. Run 'devlog ingest' first", codebasePath)
+	}
+
+	client, err := createLLMClient(cfg, "", "")
+	if err != nil {
+		return fmt.Errorf("failed to create LLM client: %w", err)
+	}
+	embedder, ok := client.(llm.Embedder)
+	if !ok {
+		return fmt.Errorf("the %s provider cannot create embeddings; semantic search needs the ollama provider", cfg.GetEffectiveProvider())
+	}
+
+	filter := db.FileSearchFilter{Language: searchLanguage, PathPrefix: filepath.ToSlash(searchPathPrefix)}
+	results, err := searchFiles(ctx, dbRepo, embedder, codebase.ID, query, filter, searchLimit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	titleColor.Printf("  Files matching %q\n", query)
+	dimColor.Println("  " + strings.Repeat("─", 40))
+	if len(results) == 0 {
+		dimColor.Println("  No embedded files match. Run 'devlog ingest' with the ollama provider to embed files.")
+		fmt.Println()
+		return nil
+	}
+	for _, r := range results {
+		fmt.Println()
+		scoreColor.Printf("  %.2f  ", r.Score)
+		infoColor.Println(r.Path)
+		if r.Purpose != "" {
+			dimColor.Printf("        %s\n", r.Purpose)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// searchFiles embeds the query and returns the codebase's files that pass
+// filter, ranked by similarity to it, best first.
+func searchFiles(ctx context.Context, dbRepo *db.SQLRepository, embedder llm.Embedder, codebaseID, query string, filter db.FileSearchFilter, limit int) ([]db.FileSearchResult, error) {
+	embedCtx, cancel := withLLMTimeout(ctx)
+	vector, err := embedder.Embed(embedCtx, query)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	results, err := dbRepo.SemanticSearchFiles(ctx, codebaseID, vector, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search files: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+// fakeEmbedder returns fixed vectors for known texts.
+type fakeEmbedder map[string][]float64
+
+func (f fakeEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	return f[text], nil
+}
+
+func TestSearchFilesEmbedsQuery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	const profile = "search-test"
+	dbRepo, err := db.GetRepositoryForProfile(profile)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	t.Cleanup(func() { db.CloseDB(profile) })
+	ctx := context.Background()
+
+	if err := dbRepo.UpsertCodebase(ctx, &db.Codebase{ID: "cb", Path: "/tmp/repo", Name: "repo"}); err != nil {
+		t.Fatalf("upsert codebase: %v", err)
+	}
+	files := map[string][]float64{
+		"internal/retry/backoff.go": {0.9, 0.1},
+		"internal/db/schema.go":     {0.1, 0.9},
+	}
+	for path, vector := range files {
+		f := &db.FileIndex{ID: path, CodebaseID: "cb", Path: path}
+		if err := dbRepo.UpsertFileIndex(ctx, f); err != nil {
+			t.Fatalf("upsert %s: %v", path, err)
+		}
+		if err := dbRepo.UpdateFileEmbedding(ctx, f.ID, vector); err != nil {
+			t.Fatalf("embed %s: %v", path, err)
+		}
+	}
+
+	embedder := fakeEmbedder{
+		"retries":    {1, 0},
+		"migrations": {0, 1},
+	}
+	for query, want := range map[string]string{
+		"retries":    "internal/retry/backoff.go",
+		"migrations": "internal/db/schema.go",
+	} {
+		results, err := searchFiles(ctx, dbRepo, embedder, "cb", query, db.FileSearchFilter{}, 1)
+		if err != nil {
+			t.Fatalf("searchFiles(%q): %v", query, err)
+		}
+		if len(results) != 1 || results[0].Path != want {
+			t.Errorf("searchFiles(%q) = %+v, want %s", query, results, want)
+		}
+	}
+
+	// A path prefix keeps a weaker match when the best one is outside it.
+	results, err := searchFiles(ctx, dbRepo, embedder, "cb", "retries", db.FileSearchFilter{PathPrefix: "internal/db/"}, 0)
+	if err != nil {
+		t.Fatalf("filtered search: %v", err)
+	}
+	if len(results) != 1 || results[0].Path != "internal/db/schema.go" {
+		t.Errorf("filtered search = %+v, want only internal/db/schema.go", results)
+	}
+}
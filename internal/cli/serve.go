@@ -0,0 +1,412 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
+	"github.com/ishaan812/devlog/internal/tui"
+)
+
+var (
+	servePort int
+	serveHost string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve cached worklogs over a local read-only JSON API",
+	Long: `Start a small read-only HTTP server over the active profile's database.
+
+The server exposes the same data as 'devlog console' as JSON, so a web UI or
+script can read cached worklogs without the TUI. Each request opens its own
+read-only connection, so ingest and worklog runs are not blocked.
+
+The server binds to localhost by default. Use --host to expose it on other
+interfaces; there is no authentication.
+
+Endpoints:
+  GET /api/codebases                          List codebases
+  GET /api/codebases/{id}/dates               Days with cached entries
+  GET /api/codebases/{id}/weeks               Weeks with cached entries
+  GET /api/codebases/{id}/months              Months with cached entries
+  GET /api/codebases/{id}/days/{YYYY-MM-DD}   A day's entries
+  GET /api/codebases/{id}/weeks/{YYYY-MM-DD}  A weekly summary (week start date)
+  GET /api/codebases/{id}/months/{YYYY-MM}    A monthly summary
+
+Examples:
+  devlog serve                 # http://127.0.0.1:8080
+  devlog serve --port 9000     # Custom port`,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to listen on")
+	serveCmd.Flags().StringVar(&serveHost, "host", "127.0.0.1", "Address to bind (default: localhost only)")
+}
+
+type serveCodebase struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	RepoURL     string `json:"repo_url,omitempty"`
+	CommitCount int    `json:"commit_count"`
+	IsIngested  bool   `json:"is_ingested"`
+	DateCount   int    `json:"date_count"`
+	WeekCount   int    `json:"week_count"`
+	MonthCount  int    `json:"month_count"`
+}
+
+type serveDate struct {
+	Date        string `json:"date"`
+	EntryCount  int    `json:"entry_count"`
+	CommitCount int    `json:"commit_count"`
+	Additions   int    `json:"additions"`
+	Deletions   int    `json:"deletions"`
+}
+
+type serveWeek struct {
+	WeekStart   string `json:"week_start"`
+	WeekEnd     string `json:"week_end"`
+	DateCount   int    `json:"date_count"`
+	EntryCount  int    `json:"entry_count"`
+	CommitCount int    `json:"commit_count"`
+	Additions   int    `json:"additions"`
+	Deletions   int    `json:"deletions"`
+}
+
+type serveMonth struct {
+	Month       string `json:"month"`
+	DateCount   int    `json:"date_count"`
+	WeekCount   int    `json:"week_count"`
+	EntryCount  int    `json:"entry_count"`
+	CommitCount int    `json:"commit_count"`
+	Additions   int    `json:"additions"`
+	Deletions   int    `json:"deletions"`
+}
+
+type serveEntry struct {
+	Date        string `json:"date"`
+	EntryType   string `json:"entry_type"`
+	BranchName  string `json:"branch_name,omitempty"`
+	Content     string `json:"content"`
+	CommitCount int    `json:"commit_count"`
+	Additions   int    `json:"additions"`
+	Deletions   int    `json:"deletions"`
+}
+
+type serveDay struct {
+	Date    string       `json:"date"`
+	Content string       `json:"content"`
+	Entries []serveEntry `json:"entries"`
+}
+
+// worklogServer serves read-only JSON views over a profile database.
+type worklogServer struct {
+	profileName string
+}
+
+// errNotFound marks lookups that should produce a 404.
+var errNotFound = errors.New("not found")
+
+func runServe(cmd *cobra.Command, args []string) error {
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	warnColor := color.New(color.FgHiYellow)
+
+	if servePort < 1 || servePort > 65535 {
+		return fmt.Errorf("invalid --port: %d (must be 1-65535)", servePort)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	profileName := cfg.GetActiveProfileName()
+	if profileFlag != "" {
+		profileName = profileFlag
+	}
+
+	// Fail fast if the profile has no database yet.
+	dbRepo, err := db.GetReadOnlyRepositoryForProfile(profileName)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w\n\nRun 'devlog ingest' first", err)
+	}
+	dbRepo.Close()
+
+	s := &worklogServer{profileName: profileName}
+	addr := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Println()
+	titleColor.Printf("  DevLog API\n")
+	dimColor.Printf("  Profile: %s\n", profileName)
+	dimColor.Printf("  Listening on http://%s/api/codebases (Ctrl-C to stop)\n", addr)
+	if serveHost != "127.0.0.1" && serveHost != "localhost" && serveHost != "::1" {
+		warnColor.Println("  ! Bound beyond localhost; the API has no authentication")
+	}
+	fmt.Println()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		dimColor.Println("  Shutting down")
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+func (s *worklogServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/codebases", s.handleCodebases)
+	mux.HandleFunc("GET /api/codebases/{id}/dates", s.handleDates)
+	mux.HandleFunc("GET /api/codebases/{id}/weeks", s.handleWeeks)
+	mux.HandleFunc("GET /api/codebases/{id}/months", s.handleMonths)
+	mux.HandleFunc("GET /api/codebases/{id}/days/{date}", s.handleDay)
+	mux.HandleFunc("GET /api/codebases/{id}/weeks/{date}", s.handleWeek)
+	mux.HandleFunc("GET /api/codebases/{id}/months/{month}", s.handleMonth)
+	return mux
+}
+
+// withRepo opens a fresh read-only connection for a single request, so the
+// server never holds the database between requests.
+func (s *worklogServer) withRepo(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, repo *db.SQLRepository) (any, error)) {
+	repo, err := db.GetReadOnlyRepositoryForProfile(s.profileName)
+	if err != nil {
+		writeJSONError(w, http.StatusServiceUnavailable, fmt.Sprintf("database unavailable: %v", err))
+		return
+	}
+	defer repo.Close()
+
+	result, err := fn(r.Context(), repo)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, errNotFound) {
+			status = http.StatusNotFound
+		}
+		writeJSONError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// loadCodebase returns the console data for one codebase.
+func loadCodebase(ctx context.Context, repo *db.SQLRepository, profileName, id string) (*tui.ConsoleCodebase, error) {
+	codebases, err := tui.LoadConsoleCodebases(ctx, repo, profileName)
+	if err != nil {
+		return nil, err
+	}
+	for i := range codebases {
+		if codebases[i].ID == id {
+			return &codebases[i], nil
+		}
+	}
+	return nil, fmt.Errorf("codebase %s: %w", id, errNotFound)
+}
+
+func (s *worklogServer) handleCodebases(w http.ResponseWriter, r *http.Request) {
+	s.withRepo(w, r, func(ctx context.Context, repo *db.SQLRepository) (any, error) {
+		codebases, err := tui.LoadConsoleCodebases(ctx, repo, s.profileName)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]serveCodebase, 0, len(codebases))
+		for _, cb := range codebases {
+			result = append(result, serveCodebase{
+				ID:          cb.ID,
+				Name:        cb.Name,
+				Path:        cb.Path,
+				RepoURL:     git.BrowseURL(cb.RemoteURL),
+				CommitCount: cb.CommitCount,
+				IsIngested:  cb.IsIngested,
+				DateCount:   cb.DateCount,
+				WeekCount:   len(cb.Weeks),
+				MonthCount:  len(cb.Months),
+			})
+		}
+		return result, nil
+	})
+}
+
+func (s *worklogServer) handleDates(w http.ResponseWriter, r *http.Request) {
+	s.withRepo(w, r, func(ctx context.Context, repo *db.SQLRepository) (any, error) {
+		cb, err := loadCodebase(ctx, repo, s.profileName, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		result := make([]serveDate, 0, len(cb.Dates))
+		for _, d := range cb.Dates {
+			result = append(result, serveDate{
+				Date:        d.EntryDate.Format("2006-01-02"),
+				EntryCount:  d.EntryCount,
+				CommitCount: d.CommitCount,
+				Additions:   d.Additions,
+				Deletions:   d.Deletions,
+			})
+		}
+		return result, nil
+	})
+}
+
+func (s *worklogServer) handleWeeks(w http.ResponseWriter, r *http.Request) {
+	s.withRepo(w, r, func(ctx context.Context, repo *db.SQLRepository) (any, error) {
+		cb, err := loadCodebase(ctx, repo, s.profileName, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		result := make([]serveWeek, 0, len(cb.Weeks))
+		for _, wk := range cb.Weeks {
+			result = append(result, serveWeek{
+				WeekStart:   wk.WeekStart.Format("2006-01-02"),
+				WeekEnd:     wk.WeekEnd.Format("2006-01-02"),
+				DateCount:   wk.DateCount,
+				EntryCount:  wk.EntryCount,
+				CommitCount: wk.CommitCount,
+				Additions:   wk.Additions,
+				Deletions:   wk.Deletions,
+			})
+		}
+		return result, nil
+	})
+}
+
+func (s *worklogServer) handleMonths(w http.ResponseWriter, r *http.Request) {
+	s.withRepo(w, r, func(ctx context.Context, repo *db.SQLRepository) (any, error) {
+		cb, err := loadCodebase(ctx, repo, s.profileName, r.PathValue("id"))
+		if err != nil {
+			return nil, err
+		}
+		result := make([]serveMonth, 0, len(cb.Months))
+		for _, m := range cb.Months {
+			result = append(result, serveMonth{
+				Month:       m.MonthStart.Format("2006-01"),
+				DateCount:   m.DateCount,
+				WeekCount:   m.WeekCount,
+				EntryCount:  m.EntryCount,
+				CommitCount: m.CommitCount,
+				Additions:   m.Additions,
+				Deletions:   m.Deletions,
+			})
+		}
+		return result, nil
+	})
+}
+
+func (s *worklogServer) handleDay(w http.ResponseWriter, r *http.Request) {
+	date, err := time.Parse("2006-01-02", r.PathValue("date"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid date (expected YYYY-MM-DD)")
+		return
+	}
+	s.withRepo(w, r, func(ctx context.Context, repo *db.SQLRepository) (any, error) {
+		entries, err := repo.ListWorklogEntriesByDate(ctx, r.PathValue("id"), s.profileName, date)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("no entries for %s: %w", date.Format("2006-01-02"), errNotFound)
+		}
+		day := serveDay{
+			Date:    date.Format("2006-01-02"),
+			Content: tui.RenderDayMarkdown(entries, date),
+			Entries: make([]serveEntry, 0, len(entries)),
+		}
+		for _, e := range entries {
+			day.Entries = append(day.Entries, toServeEntry(e))
+		}
+		return day, nil
+	})
+}
+
+func (s *worklogServer) handleWeek(w http.ResponseWriter, r *http.Request) {
+	weekStart, err := time.Parse("2006-01-02", r.PathValue("date"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid week start (expected YYYY-MM-DD)")
+		return
+	}
+	s.withRepo(w, r, func(ctx context.Context, repo *db.SQLRepository) (any, error) {
+		summary, err := repo.GetWeeklySummary(ctx, r.PathValue("id"), s.profileName, weekStart)
+		if err != nil {
+			return nil, err
+		}
+		if summary == nil {
+			return nil, fmt.Errorf("no weekly summary for %s: %w", weekStart.Format("2006-01-02"), errNotFound)
+		}
+		return toServeEntry(*summary), nil
+	})
+}
+
+func (s *worklogServer) handleMonth(w http.ResponseWriter, r *http.Request) {
+	monthStart, err := time.Parse("2006-01", r.PathValue("month"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid month (expected YYYY-MM)")
+		return
+	}
+	s.withRepo(w, r, func(ctx context.Context, repo *db.SQLRepository) (any, error) {
+		summary, err := repo.GetMonthlySummary(ctx, r.PathValue("id"), s.profileName, monthStart)
+		if err != nil {
+			return nil, err
+		}
+		if summary == nil {
+			return nil, fmt.Errorf("no monthly summary for %s: %w", monthStart.Format("2006-01"), errNotFound)
+		}
+		return toServeEntry(*summary), nil
+	})
+}
+
+func toServeEntry(e db.WorklogEntry) serveEntry {
+	return serveEntry{
+		Date:        e.EntryDate.Format("2006-01-02"),
+		EntryType:   e.EntryType,
+		BranchName:  e.BranchName,
+		Content:     e.Content,
+		CommitCount: e.CommitCount,
+		Additions:   e.Additions,
+		Deletions:   e.Deletions,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		VerboseLog("Warning: failed to write response: %v", err)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
+)
+
+func TestFillMissingSignatures(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := openpgp.NewEntity("Dev", "", "dev@example.com", nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	commit := func(name string, signKey *openpgp.Entity) string {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			t.Fatal(err)
+		}
+		sig := &object.Signature{Name: "Dev", Email: "dev@example.com", When: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)}
+		hash, err := wt.Commit(name, &gogit.CommitOptions{Author: sig, Committer: sig, SignKey: signKey})
+		if err != nil {
+			t.Fatalf("commit %s: %v", name, err)
+		}
+		return hash.String()
+	}
+	signed := commit("a", key)
+	unsigned := commit("b", nil)
+
+	t.Setenv("HOME", t.TempDir())
+	const profile = "signatures-test"
+	dbRepo, err := db.GetRepositoryForProfile(profile)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	t.Cleanup(func() { db.CloseDB(profile) })
+	ctx := context.Background()
+	if err := dbRepo.UpsertCodebase(ctx, &db.Codebase{ID: "cb", Path: dir, Name: "repo"}); err != nil {
+		t.Fatal(err)
+	}
+	for i, hash := range []string{signed, unsigned} {
+		c := &db.Commit{ID: hash, Hash: hash, CodebaseID: "cb", AuthorEmail: "dev@example.com", Message: "m", CommittedAt: time.Now().Add(time.Duration(i) * time.Second)}
+		if err := dbRepo.UpsertCommit(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Both rows look like they were ingested before signatures were stored.
+	if _, err := dbRepo.DB().ExecContext(ctx, `UPDATE commits SET signature_checked = FALSE`); err != nil {
+		t.Fatal(err)
+	}
+
+	gitRepo, err := git.OpenRepo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filled := fillMissingSignatures(ctx, dbRepo, gitRepo, "cb"); filled != 2 {
+		t.Errorf("filled %d, want 2", filled)
+	}
+	for hash, want := range map[string]bool{signed: true, unsigned: false} {
+		c, err := dbRepo.GetCommitByHash(ctx, "cb", hash)
+		if err != nil || c == nil {
+			t.Fatalf("get %s: %v", hash, err)
+		}
+		if c.IsSigned != want {
+			t.Errorf("%s: IsSigned = %v, want %v", hash[:8], c.IsSigned, want)
+		}
+	}
+	if missing, err := dbRepo.GetCommitHashesMissingSignature(ctx, "cb"); err != nil || len(missing) != 0 {
+		t.Errorf("still missing signatures: %v, %v", missing, err)
+	}
+	// Nothing left to do on the next ingest.
+	if filled := fillMissingSignatures(ctx, dbRepo, gitRepo, "cb"); filled != 0 {
+		t.Errorf("second pass filled %d, want 0", filled)
+	}
+}
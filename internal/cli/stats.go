@@ -0,0 +1,644 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/indexer"
+)
+
+const (
+	// defaultSessionGap is the idle gap after which a new work session starts.
+	defaultSessionGap = 90 * time.Minute
+	// sessionLeadIn is credited to every session for the work that happened
+	// before its first commit.
+	sessionLeadIn = 30 * time.Minute
+)
+
+var (
+	statsDays       int
+	statsAll        bool
+	statsSessionGap time.Duration
+	statsFormat     string
+	statsAnonymize  bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show commit activity and estimated active hours",
+	Long: `Show commit activity for the current repository (or all repositories
+when run outside an ingested one), broken down per day.
+
+Active hours are a rough estimate: commits closer together than the session
+gap are clustered into one work session, and each session is credited with a
+short lead-in for the work done before its first commit.
+
+Examples:
+  devlog stats                      # Last 7 days
+  devlog stats --days 30            # Last 30 days
+  devlog stats --session-gap 2h     # Treat gaps under 2 hours as one session
+  devlog stats --all                # Include all commits (not just yours)
+  devlog stats --format json        # Machine-readable metrics for dashboards
+  devlog stats --all --anonymize    # Show pairing partners by name, not email`,
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().IntVar(&statsDays, "days", 7, "Number of days to include")
+	statsCmd.Flags().BoolVar(&statsAll, "all", false, "Include all commits (not just your own)")
+	statsCmd.Flags().BoolVar(&includeMergeSyncStats, "include-merge-sync-stats", false, "Count merge-sync commits in line totals")
+	statsCmd.Flags().DurationVar(&statsSessionGap, "session-gap", defaultSessionGap, "Idle gap that ends a work session")
+	statsCmd.Flags().StringVar(&statsFormat, "format", "text", "Output format: text or json")
+	statsCmd.Flags().BoolVar(&statsAnonymize, "anonymize", false, "Show people by name or initials instead of email")
+}
+
+// workSession is a cluster of commits made without a long idle gap.
+type workSession struct {
+	Start   time.Time
+	End     time.Time
+	Commits int
+}
+
+// Duration returns the estimated active time for the session.
+func (s workSession) Duration() time.Duration {
+	return s.End.Sub(s.Start) + sessionLeadIn
+}
+
+// EstimateWorkSessions clusters commits into work sessions. Commits less than
+// gap apart belong to the same session.
+func EstimateWorkSessions(commits []commitData, gap time.Duration) []workSession {
+	if len(commits) == 0 {
+		return nil
+	}
+	if gap <= 0 {
+		gap = defaultSessionGap
+	}
+
+	times := make([]time.Time, len(commits))
+	for i, c := range commits {
+		times[i] = c.CommittedAt
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	sessions := []workSession{{Start: times[0], End: times[0], Commits: 1}}
+	for _, t := range times[1:] {
+		current := &sessions[len(sessions)-1]
+		if t.Sub(current.End) < gap {
+			current.End = t
+			current.Commits++
+			continue
+		}
+		sessions = append(sessions, workSession{Start: t, End: t, Commits: 1})
+	}
+	return sessions
+}
+
+// estimateActiveTime sums the estimated duration of all sessions.
+func estimateActiveTime(sessions []workSession) time.Duration {
+	var total time.Duration
+	for _, s := range sessions {
+		total += s.Duration()
+	}
+	return total
+}
+
+// formatActiveTime renders a duration as "3h 05m".
+func formatActiveTime(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+const (
+	// workingHoursShare is the percentage of commits the reported working
+	// hours must cover.
+	workingHoursShare = 60
+	// workingHoursMinCommits is how many commits are needed before working
+	// hours are worth reporting.
+	workingHoursMinCommits = 5
+)
+
+// hourRange is a span of clock hours, [Start, End), in which commits were
+// made. End may be past 24 when the span runs over midnight.
+type hourRange struct {
+	Start   int `json:"start"`
+	End     int `json:"end"`
+	Commits int `json:"commits"`
+}
+
+// String renders the range as "10:00-13:00".
+func (r hourRange) String() string {
+	return fmt.Sprintf("%02d:00-%02d:00", r.Start%24, r.End%24)
+}
+
+// commitHourHistogram counts the user's own commits by hour of day in loc.
+func commitHourHistogram(commits []commitData, loc *time.Location) [24]int {
+	var hist [24]int
+	for _, c := range commits {
+		if c.ByUser {
+			hist[c.CommittedAt.In(loc).Hour()]++
+		}
+	}
+	return hist
+}
+
+// primaryWorkingHours picks the busiest hours of a histogram until they
+// cover workingHoursShare percent of commits, and merges them into ranges
+// ordered by start hour. It returns nil when there are too few commits to
+// say anything.
+func primaryWorkingHours(hist [24]int) []hourRange {
+	total := 0
+	for _, n := range hist {
+		total += n
+	}
+	if total < workingHoursMinCommits {
+		return nil
+	}
+
+	hours := make([]int, 24)
+	for h := range hours {
+		hours[h] = h
+	}
+	sort.SliceStable(hours, func(i, j int) bool {
+		return hist[hours[i]] > hist[hours[j]]
+	})
+	var selected [24]bool
+	covered := 0
+	for _, h := range hours {
+		if covered*100 >= total*workingHoursShare || hist[h] == 0 {
+			break
+		}
+		selected[h] = true
+		covered += hist[h]
+	}
+
+	// Walk the clock from an unselected hour so a range over midnight stays
+	// in one piece.
+	first := -1
+	for h := 0; h < 24; h++ {
+		if !selected[h] {
+			first = h
+			break
+		}
+	}
+	if first < 0 {
+		return []hourRange{{Start: 0, End: 24, Commits: total}}
+	}
+	var ranges []hourRange
+	for k := 1; k <= 24; k++ {
+		h := (first + k) % 24
+		if !selected[h] {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].End%24 == h {
+			ranges[n-1].End++
+			ranges[n-1].Commits += hist[h]
+			continue
+		}
+		ranges = append(ranges, hourRange{Start: h, End: h + 1, Commits: hist[h]})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start < ranges[j].Start })
+	return ranges
+}
+
+// formatWorkingHours renders ranges as "10:00-13:00, 21:00-23:00".
+func formatWorkingHours(ranges []hourRange) string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// statsCount is the file changes and lines attributed to a folder or
+// language.
+type statsCount struct {
+	Name      string `json:"name"`
+	Count     int    `json:"count"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// statsPartner is someone the user paired with and how many commits they
+// made together.
+type statsPartner struct {
+	Email   string `json:"email"`
+	Commits int    `json:"commits"`
+}
+
+// statsDay is one day's row in the per-day breakdown.
+type statsDay struct {
+	Date          string `json:"date"`
+	Commits       int    `json:"commits"`
+	Additions     int    `json:"additions"`
+	Deletions     int    `json:"deletions"`
+	ActiveMinutes int    `json:"active_minutes"`
+	Sessions      int    `json:"sessions"`
+}
+
+// statsReport holds everything 'devlog stats' computes. The text view and
+// --format json both render it, so they always agree.
+type statsReport struct {
+	Repo              string         `json:"repo,omitempty"`
+	Days              int            `json:"days"`
+	Since             string         `json:"since"`
+	Until             string         `json:"until"`
+	Timezone          string         `json:"timezone"`
+	AllAuthors        bool           `json:"all_authors"`
+	Commits           int            `json:"commits"`
+	Additions         int            `json:"additions"`
+	Deletions         int            `json:"deletions"`
+	FileChanges       int            `json:"file_changes"`
+	UniqueFileChanges int            `json:"unique_file_changes"`
+	SignedCommits     int            `json:"signed_commits"`
+	CommitTypes       map[string]int `json:"commit_types"`
+	PairedCommits     int            `json:"paired_commits"`
+	Partners          []statsPartner `json:"partners"`
+	ActiveMinutes     int            `json:"active_minutes"`
+	Sessions          int            `json:"sessions"`
+	SessionGap        string         `json:"session_gap"`
+	HourHistogram     [24]int        `json:"hour_histogram"`
+	WorkingHours      []hourRange    `json:"working_hours"`
+	TopFolders        []statsCount   `json:"top_folders"`
+	Languages         []statsCount   `json:"languages"`
+	PerDay            []statsDay     `json:"per_day"` // newest first
+
+	DebtMarkers *statsDebtMarkers `json:"debt_markers,omitempty"` // single repository only
+}
+
+// statsDebtMarkers is the repository's TODO/FIXME/HACK count as of its last
+// index, and its change since the start of the range when there is history.
+type statsDebtMarkers struct {
+	Todo   int    `json:"todo"`
+	Fixme  int    `json:"fixme"`
+	Hack   int    `json:"hack"`
+	Total  int    `json:"total"`
+	Change int    `json:"change"`
+	Since  string `json:"since,omitempty"` // when the compared count was taken
+}
+
+// statsTopN is how many folders and languages the report lists.
+const statsTopN = 5
+
+// summarizePairing counts commits made with someone else, from
+// Co-authored-by trailers, and lists the most frequent partners. When
+// userOnly is set, the author of a commit the user only co-authored is
+// counted as a partner too.
+func summarizePairing(commits []commitData, userOnly bool) (int, []statsPartner) {
+	paired := 0
+	counts := make(map[string]int)
+	for _, c := range commits {
+		partners := c.CoAuthors
+		if userOnly && !c.ByUser {
+			partners = append([]string{c.AuthorEmail}, partners...)
+		}
+		if len(partners) == 0 {
+			continue
+		}
+		paired++
+		for _, p := range partners {
+			counts[p]++
+		}
+	}
+
+	result := make([]statsPartner, 0, len(counts))
+	for email, n := range counts {
+		result = append(result, statsPartner{Email: email, Commits: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Commits != result[j].Commits {
+			return result[i].Commits > result[j].Commits
+		}
+		return result[i].Email < result[j].Email
+	})
+	if len(result) > 3 {
+		result = result[:3]
+	}
+	return paired, result
+}
+
+// summarizeChurn tallies file changes and lines by folder and by language,
+// skipping changes replayed by cherry-picks or squashes.
+func summarizeChurn(commits []commitData) (folders, languages []statsCount) {
+	byFolder := make(map[string]*statsCount)
+	byLanguage := make(map[string]*statsCount)
+	add := func(m map[string]*statsCount, name string, fc fileChangeStat) {
+		if m[name] == nil {
+			m[name] = &statsCount{Name: name}
+		}
+		m[name].Count++
+		m[name].Additions += fc.Additions
+		m[name].Deletions += fc.Deletions
+	}
+
+	seen := make(map[string]bool)
+	for _, c := range commits {
+		if !countsTowardStats(c) {
+			continue
+		}
+		for _, fc := range c.Changes {
+			if fc.ContentHash != "" {
+				if seen[fc.ContentHash] {
+					continue
+				}
+				seen[fc.ContentHash] = true
+			}
+			if fc.Path == "" {
+				continue
+			}
+			add(byFolder, path.Dir(fc.Path), fc)
+			if lang := indexer.LanguageForPath(fc.Path); lang != "" {
+				add(byLanguage, lang, fc)
+			}
+		}
+	}
+	return topStatsCounts(byFolder, statsTopN), topStatsCounts(byLanguage, statsTopN)
+}
+
+// topStatsCounts returns the n largest counts (by lines changed, then by
+// count), ties broken by name.
+func topStatsCounts(m map[string]*statsCount, n int) []statsCount {
+	result := make([]statsCount, 0, len(m))
+	for _, c := range m {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		li, lj := result[i].Additions+result[i].Deletions, result[j].Additions+result[j].Deletions
+		if li != lj {
+			return li > lj
+		}
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// buildStatsReport computes the stats for commits over the given range.
+func buildStatsReport(commits []commitData, loc *time.Location, startDate, endDate time.Time) statsReport {
+	report := statsReport{
+		Days:         statsDays,
+		Since:        startDate.In(loc).Format("2006-01-02"),
+		Until:        endDate.In(loc).Format("2006-01-02"),
+		Timezone:     loc.String(),
+		AllAuthors:   statsAll,
+		Commits:      len(commits),
+		CommitTypes:  make(map[string]int),
+		SessionGap:   statsSessionGap.String(),
+		Partners:     []statsPartner{},
+		TopFolders:   []statsCount{},
+		Languages:    []statsCount{},
+		PerDay:       []statsDay{},
+		WorkingHours: []hourRange{},
+	}
+	if len(commits) == 0 {
+		return report
+	}
+
+	report.Additions, report.Deletions = computeCommitStats(commits)
+	report.FileChanges, report.UniqueFileChanges = countFileChanges(commits)
+	for _, c := range commits {
+		if c.IsSigned {
+			report.SignedCommits++
+		}
+	}
+	for _, tc := range countCommitTypes(commits) {
+		report.CommitTypes[tc.Type] = tc.Count
+	}
+	report.PairedCommits, report.Partners = summarizePairing(commits, !statsAll)
+	report.TopFolders, report.Languages = summarizeChurn(commits)
+	report.HourHistogram = commitHourHistogram(commits, loc)
+	if ranges := primaryWorkingHours(report.HourHistogram); ranges != nil {
+		report.WorkingHours = ranges
+	}
+
+	groups := groupByDate(commits, loc)
+	var allSessions []workSession
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		sessions := EstimateWorkSessions(g.Commits, statsSessionGap)
+		allSessions = append(allSessions, sessions...)
+		dayAdds, dayDels := computeCommitStats(g.Commits)
+		report.PerDay = append(report.PerDay, statsDay{
+			Date:          g.Date.In(loc).Format("2006-01-02"),
+			Commits:       len(g.Commits),
+			Additions:     dayAdds,
+			Deletions:     dayDels,
+			ActiveMinutes: int(estimateActiveTime(sessions).Round(time.Minute).Minutes()),
+			Sessions:      len(sessions),
+		})
+	}
+	report.ActiveMinutes = int(estimateActiveTime(allSessions).Round(time.Minute).Minutes())
+	report.Sessions = len(allSessions)
+	return report
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if statsFormat != "text" && statsFormat != "json" {
+		return fmt.Errorf("invalid --format: %s (must be 'text' or 'json')", statsFormat)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	loc := getProfileTimezone(cfg)
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	codebasePath, err := filepath.Abs(".")
+	if err != nil {
+		return fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, codebasePath)
+	if err != nil || codebase == nil {
+		VerboseLog("No codebase found at current path, querying all commits")
+		codebase = nil
+	}
+
+	endDate := time.Now().In(loc)
+	startDate := endDate.AddDate(0, 0, -statsDays)
+
+	commits, err := queryCommits(ctx, dbRepo, codebase, startDate, endDate, statsAll, cfg.GetCountCoAuthoredCommits(), statsAll, "")
+	if err != nil {
+		return fmt.Errorf("failed to query commits: %w", err)
+	}
+
+	if statsAnonymize {
+		anonymizer := newEmailAnonymizer(ctx, dbRepo, cfg)
+		for i := range commits {
+			commits[i].AuthorEmail = anonymizer.Name(commits[i].AuthorEmail)
+			for j, email := range commits[i].CoAuthors {
+				commits[i].CoAuthors[j] = anonymizer.Name(email)
+			}
+		}
+	}
+
+	report := buildStatsReport(commits, loc, startDate, endDate)
+	if codebase != nil {
+		report.Repo = codebase.Name
+		report.DebtMarkers = loadStatsDebtMarkers(ctx, dbRepo, codebase.ID, startDate)
+	}
+
+	if statsFormat == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode stats: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	printStatsReport(report, commits)
+	return nil
+}
+
+// loadStatsDebtMarkers returns the repository's marker counts, or nil if
+// it has never been indexed with marker tracking.
+func loadStatsDebtMarkers(ctx context.Context, dbRepo *db.SQLRepository, codebaseID string, since time.Time) *statsDebtMarkers {
+	snapshots, err := dbRepo.GetTodoSnapshots(ctx, codebaseID)
+	if err != nil {
+		VerboseLog("Warning: failed to load marker history: %v", err)
+		return nil
+	}
+	if len(snapshots) == 0 {
+		return nil
+	}
+	latest := snapshots[len(snapshots)-1]
+	m := &statsDebtMarkers{Todo: latest.Todo, Fixme: latest.Fixme, Hack: latest.Hack, Total: latest.Total()}
+	if _, base, ok := todoTrend(snapshots, since); ok {
+		m.Change = latest.Total() - base.Total()
+		m.Since = base.TakenAt.In(since.Location()).Format("2006-01-02")
+	}
+	return m
+}
+
+// printStatsReport renders a stats report for the terminal.
+func printStatsReport(report statsReport, commits []commitData) {
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	successColor := color.New(color.FgHiGreen)
+
+	fmt.Println()
+	if report.Repo != "" {
+		titleColor.Printf("  Stats for %s (last %d days)\n\n", report.Repo, report.Days)
+	} else {
+		titleColor.Printf("  Stats (last %d days)\n\n", report.Days)
+	}
+
+	if report.Commits == 0 {
+		dimColor.Println("  No commits found in the specified time range.")
+		if !report.AllAuthors {
+			dimColor.Println("  (Showing only your commits. Use --all to include everyone's)")
+		}
+		fmt.Println()
+		return
+	}
+
+	dimColor.Print("  Commits:      ")
+	infoColor.Printf("%d\n", report.Commits)
+	dimColor.Print("  Lines:        ")
+	infoColor.Printf("+%d/-%d\n", report.Additions, report.Deletions)
+	if total, unique := report.FileChanges, report.UniqueFileChanges; total > 0 {
+		dimColor.Print("  File changes: ")
+		infoColor.Printf("%d", total)
+		if unique < total {
+			dimColor.Printf(" (%d unique, %d replayed by cherry-picks or squashes)\n", unique, total-unique)
+		} else {
+			dimColor.Println(" (all unique)")
+		}
+	}
+	dimColor.Print("  Signed:       ")
+	infoColor.Printf("%d%%", report.SignedCommits*100/report.Commits)
+	dimColor.Printf(" (%d of %d, signature present, not verified)\n", report.SignedCommits, report.Commits)
+	if breakdown := formatCommitTypeBreakdown(commits); breakdown != "" {
+		dimColor.Print("  Types:        ")
+		infoColor.Println(breakdown)
+	}
+	if report.PairedCommits > 0 {
+		partners := make([]string, len(report.Partners))
+		for i, p := range report.Partners {
+			partners[i] = fmt.Sprintf("%s (%d)", p.Email, p.Commits)
+		}
+		dimColor.Print("  Paired:       ")
+		infoColor.Printf("%d%%", report.PairedCommits*100/report.Commits)
+		dimColor.Printf(" (%d of %d) with %s\n", report.PairedCommits, report.Commits, strings.Join(partners, ", "))
+	}
+	dimColor.Print("  Active time:  ")
+	successColor.Printf("~%s", formatActiveTime(time.Duration(report.ActiveMinutes)*time.Minute))
+	dimColor.Printf(" across %d sessions\n", report.Sessions)
+	if len(report.WorkingHours) > 0 {
+		covered := 0
+		for _, r := range report.WorkingHours {
+			covered += r.Commits
+		}
+		userCommits := 0
+		for _, n := range report.HourHistogram {
+			userCommits += n
+		}
+		dimColor.Print("  Usual hours:  ")
+		infoColor.Print(formatWorkingHours(report.WorkingHours))
+		dimColor.Printf(" (%d%% of your commits, %s)\n", covered*100/userCommits, report.Timezone)
+	}
+	if m := report.DebtMarkers; m != nil {
+		dimColor.Print("  Debt markers: ")
+		infoColor.Printf("%d", m.Total)
+		dimColor.Printf(" (%d TODO, %d FIXME, %d HACK)", m.Todo, m.Fixme, m.Hack)
+		if m.Since != "" {
+			since, _ := time.Parse("2006-01-02", m.Since)
+			dimColor.Printf(", %+d since %s", m.Change, since.Format("Jan 2"))
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+
+	printStatsCounts(titleColor, dimColor, infoColor, "Top Folders", report.TopFolders)
+	printStatsCounts(titleColor, dimColor, infoColor, "Languages", report.Languages)
+
+	titleColor.Println("  Per Day")
+	for _, d := range report.PerDay {
+		date, _ := time.Parse("2006-01-02", d.Date)
+		infoColor.Printf("  %-12s", date.Format("Mon, Jan 2"))
+		dimColor.Printf("  %3d commits  %-14s", d.Commits, fmt.Sprintf("+%d/-%d", d.Additions, d.Deletions))
+		successColor.Printf("  ~%s\n", formatActiveTime(time.Duration(d.ActiveMinutes)*time.Minute))
+	}
+
+	fmt.Println()
+	dimColor.Printf("  Active time is estimated from commit timestamps (session gap: %s).\n\n", report.SessionGap)
+}
+
+// printStatsCounts prints a titled list of folder or language counts, if any.
+func printStatsCounts(titleColor, dimColor, infoColor *color.Color, title string, counts []statsCount) {
+	if len(counts) == 0 {
+		return
+	}
+	titleColor.Printf("  %s\n", title)
+	for _, c := range counts {
+		infoColor.Printf("  %-28s", truncate(c.Name, 28))
+		dimColor.Printf("  %3d files  +%d/-%d\n", c.Count, c.Additions, c.Deletions)
+	}
+	fmt.Println()
+}
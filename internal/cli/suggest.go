@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/git"
+	"github.com/ishaan812/devlog/internal/prompts"
+)
+
+var (
+	suggestCount int
+	suggestCopy  bool
+)
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest-message",
+	Short: "Suggest conventional commit messages for staged changes",
+	Long: `Analyze your staged changes and suggest conventional-commit-style messages.
+
+The dominant change type (feat, fix, refactor, docs, test, chore) is detected
+from the touched files and the diff, and used as the default prefix.
+
+Examples:
+  devlog suggest-message             # Three suggestions for staged changes
+  devlog suggest-message --count 2   # Two suggestions
+  devlog suggest-message --copy      # Copy the top suggestion to the clipboard`,
+	RunE: runSuggestMessage,
+}
+
+func init() {
+	rootCmd.AddCommand(suggestCmd)
+
+	suggestCmd.Flags().IntVar(&suggestCount, "count", 3, "Number of suggestions (2-3)")
+	suggestCmd.Flags().BoolVar(&suggestCopy, "copy", false, "Copy the top suggestion to the clipboard")
+}
+
+var conventionalPrefixRE = regexp.MustCompile(`^[a-z]+(\([^)]*\))?!?:\s`)
+
+// suggestionMarkerRE matches the bullet or list number a model may put
+// before a suggestion, but not text such as "2fa: ..." that starts with a digit.
+var suggestionMarkerRE = regexp.MustCompile(`^\s*(?:[-*•]|\d+[.)])\s+`)
+
+func runSuggestMessage(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	successColor := color.New(color.FgHiGreen)
+
+	if suggestCount < 2 || suggestCount > 3 {
+		return fmt.Errorf("invalid --count: %d (must be 2 or 3)", suggestCount)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w\n\nRun 'devlog onboard' to set up your configuration", err)
+	}
+
+	absPath, err := filepath.Abs(".")
+	if err != nil {
+		return fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	repo, err := git.OpenRepo(absPath)
+	if err != nil {
+		return err
+	}
+
+	changes, err := repo.WorkingTreeChanges(true)
+	if err != nil {
+		return fmt.Errorf("failed to read staged changes: %w", err)
+	}
+	if len(changes) == 0 {
+		fmt.Println("No staged changes found. Stage changes with 'git add' first.")
+		return nil
+	}
+
+	changeType := detectChangeType(changes)
+	var patches []string
+	for _, c := range changes {
+		if c.Patch != "" {
+			patches = append(patches, c.Patch)
+		}
+	}
+
+	client, err := createLLMClient(cfg, "", "")
+	if err != nil {
+		return fmt.Errorf("failed to create LLM client: %w\n\nRun 'devlog onboard' to configure your LLM provider", err)
+	}
+
+	dimColor.Printf("  Analyzing %d staged file(s) (detected type: %s)...\n", len(changes), changeType)
+
+	prompt := prompts.BuildCommitSuggestionsPrompt(suggestCount, lookupProjectContext(ctx, absPath), changeType, strings.Join(patches, "\n"))
+	llmCtx, cancel := withLLMTimeout(ctx)
+	defer cancel()
+	result, err := client.Complete(llmCtx, prompt)
+	if err != nil {
+		return fmt.Errorf("failed to generate commit messages: %w", err)
+	}
+
+	suggestions := parseCommitSuggestions(result, changeType, suggestCount)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("LLM returned no usable commit messages")
+	}
+
+	fmt.Println()
+	titleColor.Println("  Suggested Commit Messages")
+	for i, s := range suggestions {
+		dimColor.Printf("  %d. ", i+1)
+		infoColor.Println(s)
+	}
+	fmt.Println()
+
+	if suggestCopy {
+		if err := clipboard.WriteAll(suggestions[0]); err != nil {
+			return fmt.Errorf("failed to copy to clipboard: %w", err)
+		}
+		successColor.Println("  ✓ Top suggestion copied to clipboard")
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// detectChangeType guesses the dominant conventional-commit type for a set of
+// changes from the touched paths and the size/shape of the diff.
+func detectChangeType(changes []git.WorkingChange) string {
+	var docs, tests, chores, added, additions, deletions int
+	for _, c := range changes {
+		path := strings.ToLower(c.Path)
+		base := filepath.Base(path)
+		ext := filepath.Ext(path)
+		switch {
+		case ext == ".md" || ext == ".rst" || ext == ".txt" || strings.HasPrefix(path, "docs/"):
+			docs++
+		case strings.HasSuffix(base, "_test.go") || strings.Contains(base, ".test.") ||
+			strings.Contains(base, ".spec.") || strings.HasPrefix(path, "test/") || strings.HasPrefix(path, "tests/"):
+			tests++
+		case strings.HasPrefix(path, ".github/") || base == "makefile" || base == "dockerfile" ||
+			base == "go.mod" || base == "go.sum" || base == "package.json" || base == "package-lock.json" ||
+			ext == ".yml" || ext == ".yaml" || ext == ".toml":
+			chores++
+		default:
+			if c.ChangeType == "add" {
+				added++
+			}
+		}
+		additions += c.Additions
+		deletions += c.Deletions
+	}
+
+	total := len(changes)
+	switch {
+	case docs == total:
+		return "docs"
+	case tests == total:
+		return "test"
+	case chores == total:
+		return "chore"
+	case added > 0:
+		return "feat"
+	case additions+deletions <= 20:
+		return "fix"
+	case deletions >= additions:
+		return "refactor"
+	default:
+		return "feat"
+	}
+}
+
+// parseCommitSuggestions cleans up the LLM output into at most max commit
+// lines, adding the detected type prefix where the model left it out.
+func parseCommitSuggestions(response, changeType string, max int) []string {
+	var suggestions []string
+	for _, line := range strings.Split(response, "\n") {
+		line = strings.TrimSpace(line)
+		line = suggestionMarkerRE.ReplaceAllString(line, "")
+		line = strings.Trim(line, "`\"")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !conventionalPrefixRE.MatchString(line) {
+			line = changeType + ": " + line
+		}
+		suggestions = append(suggestions, line)
+		if len(suggestions) == max {
+			break
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommitSuggestions(t *testing.T) {
+	response := "1. feat(auth): add login\n" +
+		"2) `fix: handle empty token`\n" +
+		"- 2fa: require a code on new devices\n" +
+		"* 3 retries before giving up\n" +
+		"\n" +
+		"• \"refactor: split config loader\"\n"
+	want := []string{
+		"feat(auth): add login",
+		"fix: handle empty token",
+		"feat: 2fa: require a code on new devices",
+		"feat: 3 retries before giving up",
+		"refactor: split config loader",
+	}
+	if got := parseCommitSuggestions(response, "feat", 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCommitSuggestions =\n%q\nwant\n%q", got, want)
+	}
+
+	// Text that merely starts with a digit is not a list number.
+	if got := parseCommitSuggestions("2fa: require a code", "feat", 3); !reflect.DeepEqual(got, []string{"feat: 2fa: require a code"}) {
+		t.Errorf("unnumbered line = %q, want its leading digit kept", got)
+	}
+	if got := parseCommitSuggestions("1. a\n2. b\n3. c", "fix", 2); len(got) != 2 {
+		t.Errorf("got %d suggestions, want the max of 2", len(got))
+	}
+}
@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+var (
+	timelineDays     int
+	timelineRepo     string
+	timelineAllRepos bool
+	timelineAll      bool
+)
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Show a contribution heatmap of your commits",
+	Long: `Show a GitHub-style contribution calendar in the terminal: one column per
+week, one row per weekday, each cell shaded by how many commits landed that day.
+
+Uses the current repository when run inside an ingested one, otherwise all
+repositories in the profile.
+
+Examples:
+  devlog timeline                   # Last 365 days
+  devlog timeline --days 90         # Last 90 days
+  devlog timeline --repo api        # A specific repository (name or path)
+  devlog timeline --all-repos       # Every repository in the profile
+  devlog timeline --all             # Include all commits (not just yours)`,
+	RunE: runTimeline,
+}
+
+func init() {
+	rootCmd.AddCommand(timelineCmd)
+
+	timelineCmd.Flags().IntVar(&timelineDays, "days", 365, "Number of days to include")
+	timelineCmd.Flags().StringVar(&timelineRepo, "repo", "", "Repository name or path (default: current repository)")
+	timelineCmd.Flags().BoolVar(&timelineAllRepos, "all-repos", false, "Aggregate every repository in the profile")
+	timelineCmd.Flags().BoolVar(&timelineAll, "all", false, "Include all commits (not just your own)")
+}
+
+// timelineLevels are the commit-count thresholds for each heatmap shade.
+var timelineLevels = []int{1, 3, 6, 10}
+
+func runTimeline(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+
+	if timelineDays <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	loc := getProfileTimezone(cfg)
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	codebase, err := resolveTimelineCodebase(ctx, dbRepo)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	start := today.AddDate(0, 0, -(timelineDays - 1))
+
+	counts, err := queryDailyCommitCounts(ctx, dbRepo, codebase, start, loc, timelineAll)
+	if err != nil {
+		return fmt.Errorf("failed to query commits: %w", err)
+	}
+
+	fmt.Println()
+	if codebase != nil {
+		titleColor.Printf("  Timeline for %s (last %d days)\n\n", codebase.Name, timelineDays)
+	} else {
+		titleColor.Printf("  Timeline, all repositories (last %d days)\n\n", timelineDays)
+	}
+
+	renderTimeline(counts, start, today)
+
+	total, activeDays := 0, 0
+	for _, n := range counts {
+		total += n
+		activeDays++
+	}
+	longest, current := commitStreaks(counts, start, today)
+	fmt.Println()
+	dimColor.Print("  Commits:        ")
+	infoColor.Printf("%d", total)
+	dimColor.Printf(" on %d days\n", activeDays)
+	dimColor.Print("  Longest streak: ")
+	infoColor.Printf("%d days\n", longest)
+	dimColor.Print("  Current streak: ")
+	infoColor.Printf("%d days\n\n", current)
+	if total == 0 && !timelineAll {
+		dimColor.Println("  (Showing only your commits. Use --all to include everyone's)")
+		fmt.Println()
+	}
+	return nil
+}
+
+// resolveTimelineCodebase picks the repository from --repo, the current
+// directory, or nil for all repositories.
+func resolveTimelineCodebase(ctx context.Context, dbRepo *db.SQLRepository) (*db.Codebase, error) {
+	if timelineAllRepos {
+		return nil, nil
+	}
+	if timelineRepo != "" {
+		return findCodebaseByRef(ctx, dbRepo, timelineRepo)
+	}
+
+	codebasePath, err := filepath.Abs(".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, codebasePath)
+	if err != nil || codebase == nil {
+		VerboseLog("No codebase found at current path, using all repositories")
+		return nil, nil
+	}
+	return codebase, nil
+}
+
+// findCodebaseByRef looks up an ingested repository by path, or failing
+// that by name (case-insensitive).
+func findCodebaseByRef(ctx context.Context, dbRepo *db.SQLRepository, ref string) (*db.Codebase, error) {
+	if absPath, err := filepath.Abs(ref); err == nil {
+		if codebase, err := dbRepo.GetCodebaseByPath(ctx, absPath); err == nil && codebase != nil {
+			return codebase, nil
+		}
+	}
+	codebases, err := dbRepo.GetAllCodebases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+	for i := range codebases {
+		if strings.EqualFold(codebases[i].Name, ref) {
+			return &codebases[i], nil
+		}
+	}
+	return nil, fmt.Errorf("repository '%s' not found; run 'devlog list repos' to see ingested repositories", ref)
+}
+
+// queryDailyCommitCounts returns commit counts keyed by local date
+// (YYYY-MM-DD) from start onwards. Bot commits are never counted.
+func queryDailyCommitCounts(ctx context.Context, dbRepo *db.SQLRepository, codebase *db.Codebase, start time.Time, loc *time.Location, allAuthors bool) (map[string]int, error) {
+	queryStr := `SELECT c.committed_at FROM commits c WHERE c.committed_at >= $1 AND c.is_bot = FALSE`
+	args := []any{start}
+	if codebase != nil {
+		queryStr += " AND c.codebase_id = $2"
+		args = append(args, codebase.ID)
+	}
+	if !allAuthors {
+		queryStr += " AND c.is_user_commit = TRUE"
+	}
+
+	results, err := dbRepo.ExecuteQueryWithArgs(ctx, queryStr, args...)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, row := range results {
+		if t, ok := row["committed_at"].(time.Time); ok {
+			counts[t.In(loc).Format("2006-01-02")]++
+		}
+	}
+	return counts, nil
+}
+
+// renderTimeline prints the heatmap: weekday rows, week columns, month
+// labels along the top and a legend underneath.
+func renderTimeline(counts map[string]int, start, end time.Time) {
+	dimColor := color.New(color.FgHiBlack)
+
+	// Columns start on the Sunday on or before start.
+	gridStart := start.AddDate(0, 0, -int(start.Weekday()))
+	weeks := int(end.Sub(gridStart).Hours()/24)/7 + 1
+
+	var header strings.Builder
+	header.WriteString("       ")
+	lastMonth := time.Month(0)
+	for w := 0; w < weeks; w++ {
+		weekStart := gridStart.AddDate(0, 0, w*7)
+		if weekStart.Month() != lastMonth && w+2 <= weeks {
+			lastMonth = weekStart.Month()
+			label := weekStart.Format("Jan")
+			header.WriteString(label)
+			w++ // the label spans this column and the next
+			header.WriteString(strings.Repeat(" ", 4-len(label)))
+			continue
+		}
+		header.WriteString("  ")
+	}
+	dimColor.Println(strings.TrimRight(header.String(), " "))
+
+	dayLabels := map[time.Weekday]string{time.Monday: "Mon", time.Wednesday: "Wed", time.Friday: "Fri"}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		dimColor.Printf("  %-4s ", dayLabels[wd])
+		for w := 0; w < weeks; w++ {
+			day := gridStart.AddDate(0, 0, w*7+int(wd))
+			if day.Before(start) || day.After(end) {
+				fmt.Print("  ")
+				continue
+			}
+			printTimelineCell(counts[day.Format("2006-01-02")])
+			fmt.Print(" ")
+		}
+		fmt.Println()
+	}
+
+	fmt.Println()
+	dimColor.Print("       Less ")
+	printTimelineCell(0)
+	for _, threshold := range timelineLevels {
+		fmt.Print(" ")
+		printTimelineCell(threshold)
+	}
+	dimColor.Printf(" More  (1, %d, %d, %d+ commits)\n", timelineLevels[1], timelineLevels[2], timelineLevels[3])
+}
+
+func printTimelineCell(count int) {
+	level := 0
+	for i, threshold := range timelineLevels {
+		if count >= threshold {
+			level = i + 1
+		}
+	}
+	switch level {
+	case 0:
+		color.New(color.FgHiBlack).Print("·")
+	case 1:
+		color.New(color.FgGreen).Print("▪")
+	case 2:
+		color.New(color.FgGreen).Print("■")
+	case 3:
+		color.New(color.FgHiGreen).Print("■")
+	default:
+		color.New(color.FgHiGreen, color.Bold).Print("█")
+	}
+}
+
+// commitStreaks returns the longest run of consecutive days with commits in
+// [start, end] and the run ending today (or yesterday, so a streak is not
+// broken before the day's first commit).
+func commitStreaks(counts map[string]int, start, end time.Time) (longest, current int) {
+	run := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if counts[day.Format("2006-01-02")] > 0 {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	day := end
+	if counts[day.Format("2006-01-02")] == 0 {
+		day = day.AddDate(0, 0, -1)
+	}
+	for !day.Before(start) && counts[day.Format("2006-01-02")] > 0 {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+	return longest, current
+}
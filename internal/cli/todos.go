@@ -0,0 +1,268 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/indexer"
+)
+
+var (
+	todosRepo     string
+	todosKinds    []string
+	todosPath     string
+	todosByFolder bool
+	todosDays     int
+)
+
+var todosCmd = &cobra.Command{
+	Use:   "todos",
+	Short: "List TODO, FIXME and HACK markers in the indexed code",
+	Long: `List the TODO, FIXME and HACK comments found in the repository's files the
+last time it was indexed, with file and line, and show whether the total has
+grown.
+
+Markers are collected from the files devlog indexes (your index folder
+selection and ignore patterns apply), each time 'devlog ingest' indexes the
+codebase, and the totals are recorded for trends. 'devlog stats' reports them
+too.
+
+Examples:
+  devlog todos                      # Every marker, grouped by file
+  devlog todos --kind fixme,hack    # Only FIXME and HACK
+  devlog todos --path internal/api  # Only files under a folder
+  devlog todos --by-folder          # Marker counts per folder
+  devlog todos --days 90            # Compare the total with 90 days ago`,
+	Args: cobra.NoArgs,
+	RunE: runTodos,
+}
+
+func init() {
+	rootCmd.AddCommand(todosCmd)
+
+	todosCmd.Flags().StringVar(&todosRepo, "repo", "", "Repository name or path (default: current repository)")
+	todosCmd.Flags().StringSliceVar(&todosKinds, "kind", nil, "Only these kinds: todo, fixme, hack (comma-separated)")
+	todosCmd.Flags().StringVar(&todosPath, "path", "", "Only files under this repo-relative path")
+	todosCmd.Flags().BoolVar(&todosByFolder, "by-folder", false, "Show marker counts per folder instead of listing them")
+	todosCmd.Flags().IntVar(&todosDays, "days", 30, "Compare the current total with this many days ago")
+}
+
+func runTodos(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	warnColor := color.New(color.FgYellow)
+
+	kinds := make(map[string]bool)
+	for _, k := range todosKinds {
+		k = strings.ToUpper(strings.TrimSpace(k))
+		switch k {
+		case indexer.TodoKindTodo, indexer.TodoKindFixme, indexer.TodoKindHack:
+			kinds[k] = true
+		case "":
+		default:
+			return fmt.Errorf("invalid --kind %q (must be todo, fixme or hack)", k)
+		}
+	}
+	prefix := strings.Trim(filepath.ToSlash(strings.TrimSpace(todosPath)), "/")
+	if prefix == "." {
+		prefix = ""
+	}
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	codebase, err := resolveTodosCodebase(ctx, dbRepo)
+	if err != nil {
+		return err
+	}
+
+	all, err := dbRepo.GetTodoMarkers(ctx, codebase.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load markers: %w", err)
+	}
+	var markers []db.TodoMarker
+	for _, m := range all {
+		if len(kinds) > 0 && !kinds[m.Kind] {
+			continue
+		}
+		if prefix != "" && m.FilePath != prefix && !strings.HasPrefix(m.FilePath, prefix+"/") {
+			continue
+		}
+		markers = append(markers, m)
+	}
+
+	fmt.Println()
+	titleColor.Printf("  Debt markers in %s\n\n", codebase.Name)
+	if len(markers) == 0 {
+		dimColor.Println("  No markers found.")
+		if len(all) == 0 {
+			dimColor.Println("  (Markers are collected when 'devlog ingest' indexes the codebase)")
+		}
+		fmt.Println()
+		return nil
+	}
+
+	if todosByFolder {
+		counts := make(map[string]int)
+		for _, m := range markers {
+			counts[path.Dir(m.FilePath)]++
+		}
+		folders := make([]string, 0, len(counts))
+		for f := range counts {
+			folders = append(folders, f)
+		}
+		sort.Slice(folders, func(i, j int) bool {
+			if counts[folders[i]] != counts[folders[j]] {
+				return counts[folders[i]] > counts[folders[j]]
+			}
+			return folders[i] < folders[j]
+		})
+		for _, f := range folders {
+			infoColor.Printf("  %-40s", truncate(f, 40))
+			dimColor.Printf("  %4d\n", counts[f])
+		}
+	} else {
+		currentFile := ""
+		for _, m := range markers {
+			if m.FilePath != currentFile {
+				if currentFile != "" {
+					fmt.Println()
+				}
+				currentFile = m.FilePath
+				infoColor.Printf("  %s\n", m.FilePath)
+			}
+			kindColor := dimColor
+			if m.Kind != indexer.TodoKindTodo {
+				kindColor = warnColor
+			}
+			dimColor.Printf("    %5d  ", m.Line)
+			kindColor.Printf("%-5s", m.Kind)
+			fmt.Printf("  %s\n", m.Text)
+		}
+	}
+
+	fmt.Println()
+	dimColor.Printf("  %s\n", formatTodoCounts(countTodoMarkers(markers)))
+	if len(kinds) == 0 && prefix == "" {
+		if trend := describeTodoTrend(ctx, dbRepo, codebase.ID, time.Now().AddDate(0, 0, -todosDays)); trend != "" {
+			dimColor.Printf("  %s\n", trend)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// resolveTodosCodebase picks the repository from --repo or the current
+// directory.
+func resolveTodosCodebase(ctx context.Context, dbRepo *db.SQLRepository) (*db.Codebase, error) {
+	if todosRepo != "" {
+		return findCodebaseByRef(ctx, dbRepo, todosRepo)
+	}
+	codebasePath, err := filepath.Abs(".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, codebasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up codebase: %w", err)
+	}
+	if codebase == nil {
+		return nil, fmt.Errorf("no indexed repository found at %s\n\nRun `devlog ingest` first, or pass --repo", codebasePath)
+	}
+	return codebase, nil
+}
+
+// recordTodoMarkers stores the markers found in the scanned files, replacing
+// the previous set, and records their totals for trends.
+func recordTodoMarkers(ctx context.Context, dbRepo *db.SQLRepository, codebaseID string, files []indexer.FileInfo) (db.TodoSnapshot, error) {
+	var markers []db.TodoMarker
+	for _, f := range files {
+		for _, m := range indexer.FindTodoMarkers(filepath.ToSlash(f.Path), f.Content) {
+			markers = append(markers, db.TodoMarker{CodebaseID: codebaseID, FilePath: m.Path, Line: m.Line, Kind: m.Kind, Text: m.Text})
+		}
+	}
+	snapshot := countTodoMarkers(markers)
+	snapshot.CodebaseID = codebaseID
+	snapshot.TakenAt = time.Now()
+	if err := dbRepo.ReplaceTodoMarkers(ctx, codebaseID, markers); err != nil {
+		return snapshot, err
+	}
+	if err := dbRepo.AddTodoSnapshot(ctx, &snapshot); err != nil {
+		return snapshot, err
+	}
+	return snapshot, nil
+}
+
+// countTodoMarkers totals markers by kind.
+func countTodoMarkers(markers []db.TodoMarker) db.TodoSnapshot {
+	var s db.TodoSnapshot
+	for _, m := range markers {
+		switch m.Kind {
+		case indexer.TodoKindTodo:
+			s.Todo++
+		case indexer.TodoKindFixme:
+			s.Fixme++
+		case indexer.TodoKindHack:
+			s.Hack++
+		}
+	}
+	return s
+}
+
+// formatTodoCounts renders totals, e.g. "12 markers (9 TODO, 2 FIXME, 1 HACK)".
+func formatTodoCounts(s db.TodoSnapshot) string {
+	return fmt.Sprintf("%d markers (%d TODO, %d FIXME, %d HACK)", s.Total(), s.Todo, s.Fixme, s.Hack)
+}
+
+// todoTrend returns the latest snapshot and the one to compare it with: the
+// last taken at or before since, or failing that the earliest. ok is false
+// when there are fewer than two snapshots.
+func todoTrend(snapshots []db.TodoSnapshot, since time.Time) (latest, base db.TodoSnapshot, ok bool) {
+	if len(snapshots) < 2 {
+		return db.TodoSnapshot{}, db.TodoSnapshot{}, false
+	}
+	latest = snapshots[len(snapshots)-1]
+	base = snapshots[0]
+	for _, s := range snapshots[:len(snapshots)-1] {
+		if s.TakenAt.After(since) {
+			break
+		}
+		base = s
+	}
+	return latest, base, true
+}
+
+// describeTodoTrend renders how the marker total changed since a date, e.g.
+// "+3 since Oct 1 (from 9)". It returns "" without enough history.
+func describeTodoTrend(ctx context.Context, dbRepo *db.SQLRepository, codebaseID string, since time.Time) string {
+	snapshots, err := dbRepo.GetTodoSnapshots(ctx, codebaseID)
+	if err != nil {
+		VerboseLog("Warning: failed to load marker history: %v", err)
+		return ""
+	}
+	latest, base, ok := todoTrend(snapshots, since)
+	if !ok {
+		return ""
+	}
+	change := latest.Total() - base.Total()
+	trend := "no change"
+	switch {
+	case change > 0:
+		trend = fmt.Sprintf("+%d (growing)", change)
+	case change < 0:
+		trend = fmt.Sprintf("%d (shrinking)", change)
+	}
+	return fmt.Sprintf("%s since %s (was %d)", trend, base.TakenAt.Format("Jan 2"), base.Total())
+}
@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
+)
+
+var (
+	watchInterval time.Duration
+	watchDays     int
+	watchWorklog  bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [path]",
+	Short: "Re-ingest git history whenever new commits land",
+	Long: `Watch a repository and run an incremental git ingest whenever its branch
+refs change (new commits, merges, rebases).
+
+Branch refs are polled every --interval. Ingest runs share the same lock as
+'devlog ingest', so a watch never overlaps a manual ingest; a change seen
+while another ingest is running is picked up on the next poll. The database
+is released between runs so other devlog commands keep working.
+
+The branch selection is made once when the watch starts. With --all-branches
+every branch is ingested and newly created branches are picked up as well.
+
+Examples:
+  devlog watch                        # Watch the current repo
+  devlog watch ~/projects/myapp       # Watch a specific repo
+  devlog watch --interval 5m          # Poll every 5 minutes
+  devlog watch --worklog              # Also regenerate today's worklog
+  devlog watch --all-branches         # Ingest every branch`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 30*time.Second, "How often to poll the repository for new commits")
+	watchCmd.Flags().IntVar(&watchDays, "days", 1, "Days of history to scan on each ingest")
+	watchCmd.Flags().BoolVar(&watchWorklog, "worklog", false, "Regenerate today's worklog after each ingest")
+	watchCmd.Flags().BoolVar(&ingestAllBranches, "all-branches", false, "Ingest all branches without prompting")
+}
+
+// refsFingerprint returns a stable string describing every local branch tip,
+// so any new commit, reset or branch creation changes it.
+func refsFingerprint(absPath string) (string, error) {
+	repo, err := git.OpenRepo(absPath)
+	if err != nil {
+		return "", err
+	}
+	branches, err := repo.ListBranches()
+	if err != nil {
+		return "", err
+	}
+	refs := make([]string, 0, len(branches))
+	for _, b := range branches {
+		refs = append(refs, b.Name+"="+b.Hash)
+	}
+	sort.Strings(refs)
+	return strings.Join(refs, "\n"), nil
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	warnColor := color.New(color.FgHiYellow)
+
+	if watchInterval < time.Second {
+		return fmt.Errorf("invalid --interval: %s (must be at least 1s)", watchInterval)
+	}
+	if watchDays < 1 {
+		return fmt.Errorf("invalid --days: %d (must be at least 1)", watchDays)
+	}
+
+	absPath, err := resolveRepoArg(args)
+	if err != nil {
+		return err
+	}
+	if _, err := git.OpenRepo(absPath); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.EnsureDefaultProfile(); err != nil {
+		return fmt.Errorf("failed to ensure default profile: %w", err)
+	}
+	db.SetActiveProfile(cfg.GetActiveProfileName())
+
+	// Watch only ever does incremental git ingests over a short window.
+	ingestDays = watchDays
+	ingestAll = false
+	ingestSince = ""
+	ingestGitOnly = true
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println()
+	titleColor.Printf("  Watching Repository\n")
+	dimColor.Printf("  %s\n", absPath)
+	dimColor.Printf("  Polling every %s (Ctrl-C to stop)\n\n", watchInterval)
+
+	var selection *BranchSelection
+	lastFingerprint := ""
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		fingerprint, err := refsFingerprint(absPath)
+		if err != nil {
+			warnColor.Printf("  Warning: failed to read branch refs: %v\n", err)
+		} else if fingerprint != lastFingerprint {
+			ingested, err := watchIngestOnce(ctx, absPath, cfg, &selection)
+			if err != nil {
+				warnColor.Printf("  Warning: %v\n", err)
+			}
+			if ingested {
+				lastFingerprint = fingerprint
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			dimColor.Println("  Stopped watching")
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchIngestOnce runs a single git ingest (and optional worklog) under the
+// ingest lock. It reports whether the ingest ran, so a change seen while the
+// lock was held elsewhere is retried on the next poll.
+func watchIngestOnce(ctx context.Context, absPath string, cfg *config.Config, selection **BranchSelection) (bool, error) {
+	dimColor := color.New(color.FgHiBlack)
+
+	release, err := acquireIngestLock(cfg.GetIngestLockMaxAge())
+	if err != nil {
+		VerboseLog("Watch: skipping run, ingest lock held: %v", err)
+		return false, nil
+	}
+	defer release()
+	// Release DuckDB's exclusive lock between runs.
+	defer db.CloseAllDBs()
+
+	dimColor.Printf("  [%s] Changes detected, ingesting...\n\n", time.Now().Format("15:04:05"))
+
+	if *selection == nil || ingestAllBranches {
+		s, err := prepareBranchSelection(absPath, cfg)
+		if err != nil {
+			return false, fmt.Errorf("branch selection failed: %w", err)
+		}
+		*selection = s
+	}
+	ingestPreparedSelection = *selection
+
+	if err := ingestGitHistory(ctx, absPath, cfg); err != nil {
+		return true, fmt.Errorf("git ingest failed: %w", err)
+	}
+
+	if watchWorklog {
+		fmt.Println()
+		if err := generateWorklogAfterIngest(absPath, cfg); err != nil {
+			return true, fmt.Errorf("failed to generate worklog: %w", err)
+		}
+	}
+	fmt.Println()
+	return true, nil
+}
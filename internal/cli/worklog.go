@@ -2,9 +2,12 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -12,26 +15,70 @@ import (
 	"github.com/fatih/color"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/ishaan812/devlog/internal/config"
 	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/git"
 	"github.com/ishaan812/devlog/internal/llm"
 	"github.com/ishaan812/devlog/internal/prompts"
 )
 
 var (
-	worklogDays     int
-	worklogOutput   string
-	worklogProvider string
-	worklogModel    string
-	worklogNoLLM    bool
-	worklogBranch   string
-	worklogAll      bool
-	worklogGroupBy  string
-	worklogNoCache  bool
-	worklogStyle    string
+	worklogDays       int
+	worklogOutput     string
+	worklogOutDir     string
+	worklogProvider   string
+	worklogModel      string
+	worklogNoLLM      bool
+	worklogBranch     string
+	worklogAll        bool
+	worklogGroupBy    string
+	worklogNoCache    bool
+	worklogRollups    bool
+	worklogStyle      string
+	worklogHours      bool
+	worklogGap        time.Duration
+	worklogCompact    bool
+	worklogMaxCap     int
+	worklogCompare    bool
+	worklogAnon       bool
+	worklogAppend     bool
+	worklogHighlights bool
+
+	worklogFlagUnsigned bool
+	worklogIncludeDiffs bool
+	worklogIncludeBots  bool
+	worklogOmitReverted bool
+	worklogTemplate     string
+	worklogSince        string
+	worklogUntil        string
+
+	// includeMergeSyncStats counts merge-sync churn in displayed line and
+	// file totals. Shared by the worklog and stats commands.
+	includeMergeSyncStats bool
+
+	// worklogCapNote explains, in the worklog header, that the commit list
+	// was cut down to the --max-commits cap. Empty when nothing was dropped.
+	worklogCapNote string
+
+	// worklogStripGitmoji mirrors the profile's strip_gitmoji setting for
+	// the current worklog run.
+	worklogStripGitmoji bool
+
+	// worklogGroupCommits mirrors the profile's group_daily_commits setting
+	// for the current worklog run.
+	worklogGroupCommits bool
+
+	// worklogMaxWords mirrors the profile's worklog_summary_max_words
+	// setting for the current worklog run.
+	worklogMaxWords int
 )
 
+// leadingGitmojiRE matches emoji and :shortcode: gitmoji at the start of a
+// commit message, including skin-tone modifiers and joiners.
+var leadingGitmojiRE = regexp.MustCompile(`^(?:\s*(?::[a-z0-9_+-]+:|[\p{So}\x{FE0F}\x{200D}\x{20E3}\x{1F3FB}-\x{1F3FF}]+))+\s*`)
+
 var worklogCmd = &cobra.Command{
 	Use:   "worklog",
 	Short: "Generate a work log from your commit history",
@@ -44,11 +91,23 @@ made by the current user.
 Grouping Options:
   date    - Group commits by date (default)
   branch  - Group commits by branch with stories
+  week    - Group days into weeks, each with a weekly narrative
 
 Style Options:
   non-technical - Focus on high-level goals and accomplishments (default)
   technical     - Include file paths, code changes, and technical details
 
+Template Options (change the structure and audience, not just detail level):
+  standup   - Terse, outcome-first bullets to read out at a standup
+  review    - Accomplishment and impact framing for performance reviews
+  changelog - User-facing Added/Changed/Fixed release notes
+
+Appending:
+  --append keeps one growing worklog file: each run inserts only the days
+  after the last one the file covers, below a devlog marker comment, and
+  leaves the rest of the file, including your edits, untouched. Today is
+  left for a later run so that every day is written once, complete.
+
 Note: Days are processed oldest-to-newest so that branch context builds
 chronologically. If you extend your date range (e.g. from 7 to 14 days),
 previously cached summaries for newer days will be regenerated to include
@@ -57,13 +116,30 @@ the correct context from the newly included older days.
 Examples:
   devlog worklog                              # Writes worklog_<start>_<end>.md
   devlog worklog --days 30                    # Last 30 days
+  devlog worklog --since 2025-03-01 --until 2025-03-07  # Explicit date range
   devlog worklog --days 14 --output log.md    # Custom output filename
+  devlog worklog --output-dir ~/worklogs/{repo}  # Collect worklogs per repo
   devlog worklog --no-llm                     # Without LLM summaries
   devlog worklog --group-by branch            # Group by branch
+  devlog worklog --days 28 --group-by week    # Week sections with weekly narratives
   devlog worklog --branch feature/auth        # Single branch worklog
   devlog worklog --all                        # Include all commits (not just yours)
+  devlog worklog --include-bots               # Include dependabot/CI commits
+  devlog worklog --omit-reverted              # Leave out commits that were reverted
+  devlog worklog --days 365 --max-commits 200 # Only the 200 largest commits
   devlog worklog --no-cache                   # Force regeneration of all summaries
-  devlog worklog --style technical            # Use technical style for this worklog`,
+  devlog worklog --days 28 --refresh-rollups  # Regenerate weekly/monthly summaries only
+  devlog worklog --style technical            # Use technical style for this worklog
+  devlog worklog --style technical --include-diffs  # Embed diffs of the largest changes
+  devlog worklog --days 1 --template standup  # Terse standup bullets
+  devlog worklog --days 90 --template review  # Accomplishments for a review
+  devlog worklog --template changelog         # User-facing Added/Changed/Fixed notes
+  devlog worklog --show-hours                 # Include estimated active hours
+  devlog worklog --compact                    # Summary plus one line per day, for chat
+  devlog worklog --highlights-only            # Top accomplishments only, for a status update
+  devlog worklog --days 30 --compare          # Add a comparison with the previous 30 days
+  devlog worklog --all --anonymize            # Names or initials instead of email addresses
+  devlog worklog --append -o journal.md       # Add the days since the last run to journal.md`,
 	RunE: runWorklog,
 }
 
@@ -72,14 +148,32 @@ func init() {
 
 	worklogCmd.Flags().IntVar(&worklogDays, "days", 7, "Number of days to include")
 	worklogCmd.Flags().StringVarP(&worklogOutput, "output", "o", "", "Output file path (default: worklog_<start>_<end>.md)")
+	worklogCmd.Flags().StringVar(&worklogOutDir, "output-dir", "", "Directory for worklog files; {repo} is replaced by the repo name (default: profile's worklog_output_dir)")
 	worklogCmd.Flags().StringVar(&worklogProvider, "provider", "", "LLM provider for summaries")
 	worklogCmd.Flags().StringVar(&worklogModel, "model", "", "LLM model to use")
 	worklogCmd.Flags().BoolVar(&worklogNoLLM, "no-llm", false, "Skip LLM summaries")
 	worklogCmd.Flags().StringVar(&worklogBranch, "branch", "", "Filter by specific branch")
 	worklogCmd.Flags().BoolVar(&worklogAll, "all", false, "Include all commits (not just your own)")
-	worklogCmd.Flags().StringVar(&worklogGroupBy, "group-by", "date", "Group commits by: date, branch")
+	worklogCmd.Flags().BoolVar(&worklogIncludeBots, "include-bots", false, "Include commits flagged by bot filters (see 'devlog profile bot-filters')")
+	worklogCmd.Flags().IntVar(&worklogMaxCap, "max-commits", 0, fmt.Sprintf("Keep only the N commits with the most churn (default: profile's worklog_max_commits, or %d)", config.DefaultWorklogMaxCommits))
+	worklogCmd.Flags().BoolVar(&worklogOmitReverted, "omit-reverted", false, "Drop reverted commits and their reverts instead of marking them \"(later reverted)\"")
+	worklogCmd.Flags().StringVar(&worklogGroupBy, "group-by", "date", "Group commits by: date, branch, week")
 	worklogCmd.Flags().BoolVar(&worklogNoCache, "no-cache", false, "Skip cache and regenerate all LLM summaries")
+	worklogCmd.Flags().BoolVar(&worklogRollups, "refresh-rollups", false, "Regenerate weekly and monthly summaries in the range, reusing cached days")
+	worklogCmd.Flags().StringVar(&worklogTemplate, "template", "", "Prompt preset: standup, review, changelog (changes structure and framing; not cached)")
 	worklogCmd.Flags().StringVar(&worklogStyle, "style", "", "Worklog style: 'technical' or 'non-technical' (default: profile setting or 'non-technical')")
+	worklogCmd.Flags().BoolVar(&worklogCompact, "compact", false, "Only the overall summary and one line per day, without commit lists")
+	worklogCmd.Flags().BoolVar(&worklogCompare, "compare", false, "Add a section comparing the period with the one before it")
+	worklogCmd.Flags().BoolVar(&worklogHighlights, "highlights-only", false, "Only the 3-5 top accomplishments of the period, without day or branch sections")
+	worklogCmd.Flags().BoolVar(&worklogAppend, "append", false, "Add only the days since the last run to an existing --output file (date grouping only)")
+	worklogCmd.Flags().BoolVar(&worklogAnon, "anonymize", false, "Replace email addresses with names or initials (for sharing outside your team)")
+	worklogCmd.Flags().BoolVar(&worklogHours, "show-hours", false, "Include estimated active time and usual working hours in the worklog header")
+	worklogCmd.Flags().BoolVar(&includeMergeSyncStats, "include-merge-sync-stats", false, "Count merge-sync commits in line and file totals")
+	worklogCmd.Flags().DurationVar(&worklogGap, "session-gap", defaultSessionGap, "Idle gap that ends a work session (used with --show-hours)")
+	worklogCmd.Flags().BoolVar(&worklogIncludeDiffs, "include-diffs", false, "Embed truncated diffs of each commit's largest file changes (technical style only)")
+	worklogCmd.Flags().BoolVar(&worklogFlagUnsigned, "flag-unsigned", false, "Mark commits on the default branch that carry no GPG/SSH signature")
+	worklogCmd.Flags().StringVar(&worklogSince, "since", "", "Start date (YYYY-MM-DD), overrides --days")
+	worklogCmd.Flags().StringVar(&worklogUntil, "until", "", "End date (YYYY-MM-DD, inclusive; default: today)")
 }
 
 type commitData struct {
@@ -95,6 +189,25 @@ type commitData struct {
 	BranchName  string
 	ParentCount int
 	IsMergeSync bool
+	IsSigned    bool
+	IsOnDefault bool
+	CommitType  string
+	URL         string   // Permalink on the repo's hosting service, if recognised
+	RevertsHash string   // Commit this one reverts, if detected at ingest
+	RevertedBy  string   // A later commit that reverts this one, if any
+	ByUser      bool     // Authored (not just co-authored) by the user
+	CoAuthors   []string // Co-authored-by emails, excluding the user
+	Changes     []fileChangeStat
+}
+
+// fileChangeStat is one file change's churn, keyed by its content hash so a
+// change replayed by a cherry-pick or squash can be counted once.
+type fileChangeStat struct {
+	ContentHash string
+	Path        string
+	Additions   int
+	Deletions   int
+	Patch       string // Stored diff, kept only for --include-diffs
 }
 
 type dayGroup struct {
@@ -107,6 +220,15 @@ type branchGroup struct {
 	Commits []commitData
 }
 
+// localDay returns midnight at the start of t's calendar day in loc.
+// Truncating to 24h can't be used for this: it rounds in absolute time,
+// landing on UTC midnight, so the result drifts off the local day boundary
+// and shifts by an hour across a DST change.
+func localDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
 // getProfileTimezone returns the timezone location for the active profile
 func getProfileTimezone(cfg *config.Config) *time.Location {
 	loc := time.UTC // Default to UTC
@@ -132,6 +254,9 @@ func runWorklog(cmd *cobra.Command, args []string) error {
 	}
 
 	loc := getProfileTimezone(cfg)
+	worklogStripGitmoji = cfg.GetStripGitmoji()
+	worklogGroupCommits = cfg.GetGroupDailyCommits()
+	worklogMaxWords = cfg.GetWorklogSummaryMaxWords()
 
 	dbRepo, err := db.GetRepository()
 	if err != nil {
@@ -147,8 +272,61 @@ func runWorklog(cmd *cobra.Command, args []string) error {
 		VerboseLog("No codebase found at current path, querying all commits")
 	}
 
-	endDate := time.Now().In(loc)
-	startDate := endDate.AddDate(0, 0, -worklogDays)
+	startDate, endDate, err := resolveWorklogRange(loc)
+	if err != nil {
+		return err
+	}
+
+	outputPath := worklogOutput
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("worklog_%s_%s.md", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	}
+	outputDir := worklogOutDir
+	if outputDir == "" {
+		outputDir = cfg.GetWorklogOutputDir()
+	}
+	outputPath, err = resolveWorklogOutputPath(outputDir, outputPath, codebase)
+	if err != nil {
+		return err
+	}
+
+	// --append writes each day once, complete: today is left for a later
+	// run, and the days the file already covers are skipped.
+	var appendContent string
+	appending := false
+	if worklogAppend {
+		if worklogGroupBy != "date" || worklogCompact || worklogCompare {
+			return fmt.Errorf("--append only applies to --group-by date, without --compact or --compare")
+		}
+		if worklogOutput == "" {
+			return fmt.Errorf("--append needs --output to name the worklog file to update")
+		}
+		var lastDay time.Time
+		appendContent, lastDay, appending, err = readWorklogAppendFile(outputPath, loc)
+		if err != nil {
+			return err
+		}
+		if today := localDay(time.Now(), loc); endDate.After(today) {
+			endDate = today.Add(-time.Nanosecond)
+		}
+		if next := lastDay.AddDate(0, 0, 1); appending && next.After(startDate) {
+			startDate = next
+		}
+		if !startDate.Before(endDate) {
+			dimColor.Printf("\n  %s is already up to date.\n\n", outputPath)
+			return nil
+		}
+	}
+
+	// Weekly and monthly roll-ups only make sense when the range covers at
+	// least a whole week or month.
+	wantWeekly := worklogDays > 7
+	wantMonthly := worklogDays > 28
+	if worklogSince != "" {
+		spanDays := int(endDate.Sub(startDate).Round(24*time.Hour).Hours() / 24)
+		wantWeekly = spanDays >= 7
+		wantMonthly = spanDays >= 28
+	}
 
 	commits, err := queryCommitsForWorklog(ctx, dbRepo, codebase, startDate, endDate, cfg)
 	if err != nil {
@@ -184,15 +362,40 @@ func runWorklog(cmd *cobra.Command, args []string) error {
 	if style != "technical" && style != "non-technical" {
 		return fmt.Errorf("invalid worklog style: %s (must be 'technical' or 'non-technical')", style)
 	}
+	if worklogTemplate != "" && !slices.Contains(prompts.WorklogTemplates, worklogTemplate) {
+		return fmt.Errorf("invalid worklog template: %s (must be one of: %s)", worklogTemplate, strings.Join(prompts.WorklogTemplates, ", "))
+	}
+	if worklogCompact && worklogGroupBy != "date" {
+		return fmt.Errorf("--compact only applies to --group-by date")
+	}
+	if worklogIncludeDiffs && style != "technical" {
+		return fmt.Errorf("--include-diffs requires the technical style (use --style technical)")
+	}
+	if worklogIncludeDiffs && worklogCompact {
+		return fmt.Errorf("--include-diffs cannot be used with --compact")
+	}
+	if worklogHighlights {
+		if worklogNoLLM {
+			return fmt.Errorf("--highlights-only needs LLM summaries and cannot be used with --no-llm")
+		}
+		if worklogCompact || worklogAppend || worklogTemplate != "" || worklogIncludeDiffs {
+			return fmt.Errorf("--highlights-only cannot be used with --compact, --append, --template or --include-diffs")
+		}
+	}
+	worklogDiffBudgetLeft = worklogDiffBudget
 
+	// Template output is framed for one audience, so it is neither read from
+	// nor written to the shared worklog cache.
 	var cache *worklogCacheContext
-	if codebase != nil {
+	if codebase != nil && worklogTemplate == "" {
 		cache = &worklogCacheContext{
 			dbRepo:                dbRepo,
 			codebaseID:            codebase.ID,
 			profileName:           cfg.GetActiveProfileName(),
 			loc:                   loc,
 			noCache:               worklogNoCache,
+			refreshRollups:        worklogRollups,
+			weekStart:             cfg.GetWeekStart(),
 			ChangedDailySummaries: make(map[time.Time]bool),
 		}
 	}
@@ -200,19 +403,30 @@ func runWorklog(cmd *cobra.Command, args []string) error {
 	var markdown string
 	var dayGroups []dayGroup // For weekly summary generation
 
-	switch worklogGroupBy {
-	case "branch":
+	switch {
+	case worklogHighlights:
+		// Highlights skip the day and branch sections and their roll-ups,
+		// so the grouping doesn't matter.
+		markdown, err = generateHighlightsMarkdown(groupByDate(commits, loc), client, cfg, loc, projectContext, codebaseContext, style, nameOfUser)
+	case worklogGroupBy == "branch":
 		groups, groupErr := groupByBranch(ctx, dbRepo, commits)
 		if groupErr != nil {
 			return groupErr
 		}
 		markdown, err = generateBranchWorklogMarkdown(groups, client, cfg, loc, projectContext, codebaseContext, cache, style, nameOfUser)
+	case worklogGroupBy == "week":
+		dayGroups = groupByDate(commits, loc)
+		markdown, err = generateWeekWorklogMarkdown(dayGroups, client, cfg, loc, projectContext, codebaseContext, cache, style, nameOfUser)
 	default:
 		dayGroups = groupByDate(commits, loc)
-		markdown, err = generateWorklogMarkdown(dayGroups, client, cfg, loc, projectContext, codebaseContext, cache, style, nameOfUser)
+		if appending {
+			markdown, err = generateWorklogAppendDays(dayGroups, client, cfg, loc, projectContext, cache, style, nameOfUser)
+		} else {
+			markdown, err = generateWorklogMarkdown(dayGroups, client, cfg, loc, projectContext, codebaseContext, cache, style, nameOfUser)
+		}
 
 		// Generate weekly summaries if the date range spans more than one week
-		if worklogDays > 7 && cache != nil && !worklogNoLLM {
+		if wantWeekly && cache != nil && !worklogNoLLM {
 			successColor.Println("\n  Generating weekly summaries...")
 			if err := generateWeeklySummaries(ctx, cache, dayGroups, client, projectContext, codebaseContext, loc, style, nameOfUser); err != nil {
 				fmt.Printf("Warning: failed to generate weekly summaries: %v\n", err)
@@ -222,7 +436,7 @@ func runWorklog(cmd *cobra.Command, args []string) error {
 		}
 
 		// Generate monthly summaries if the date range is long enough
-		if worklogDays > 28 && cache != nil && !worklogNoLLM {
+		if wantMonthly && cache != nil && !worklogNoLLM {
 			successColor.Println("\n  Generating monthly summaries...")
 			if err := generateMonthlySummaries(ctx, cache, client, projectContext, codebaseContext, loc, style, startDate, endDate, nameOfUser); err != nil {
 				fmt.Printf("Warning: failed to generate monthly summaries: %v\n", err)
@@ -236,9 +450,26 @@ func runWorklog(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate markdown: %w", err)
 	}
 
-	outputPath := worklogOutput
-	if outputPath == "" {
-		outputPath = fmt.Sprintf("worklog_%s_%s.md", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if worklogCompare {
+		section, err := buildCompareSection(ctx, dbRepo, codebase, cfg, client, commits, startDate, endDate, loc, projectContext, nameOfUser)
+		if err != nil {
+			return err
+		}
+		// The comparison goes right after the header, ahead of the day or
+		// branch sections.
+		if i := strings.Index(markdown, "\n---\n\n"); i >= 0 {
+			i += len("\n---\n\n")
+			markdown = markdown[:i] + section + markdown[i:]
+		} else {
+			markdown += "\n" + section
+		}
+	}
+
+	if worklogAnon {
+		markdown = newEmailAnonymizer(ctx, dbRepo, cfg).Replace(markdown)
+	}
+	if appending {
+		markdown = spliceWorklogDays(appendContent, markdown, dayGroups[len(dayGroups)-1].Date.In(loc))
 	}
 
 	dir := filepath.Dir(outputPath)
@@ -248,17 +479,140 @@ func runWorklog(cmd *cobra.Command, args []string) error {
 	if err := os.WriteFile(outputPath, []byte(markdown), 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
-	fmt.Printf("Work log written to %s\n", outputPath)
+	if appending {
+		fmt.Printf("Appended %d days to %s\n", len(dayGroups), outputPath)
+	} else {
+		fmt.Printf("Work log written to %s\n", outputPath)
+	}
+
+	repoPath := ""
+	if codebase != nil {
+		repoPath = codebase.Path
+	}
+	if err := cfg.SaveLastWorklog(cfg.GetActiveProfileName(), repoPath, outputPath); err != nil {
+		VerboseLog("Warning: failed to record last worklog: %v", err)
+	} else if err := cfg.Save(); err != nil {
+		VerboseLog("Warning: failed to save config: %v", err)
+	}
 
 	return nil
 }
 
+// resolveWorklogOutputPath places a bare output filename inside outputDir,
+// expanding a leading ~ and the {repo} placeholder. Paths that already name
+// a directory, and an empty outputDir, leave the filename unchanged.
+func resolveWorklogOutputPath(outputDir, filename string, codebase *db.Codebase) (string, error) {
+	if outputDir == "" || filepath.IsAbs(filename) || filepath.Base(filename) != filename {
+		return filename, nil
+	}
+	if outputDir == "~" || strings.HasPrefix(outputDir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve home directory: %w", err)
+		}
+		outputDir = filepath.Join(home, strings.TrimPrefix(outputDir, "~"))
+	}
+	repoName := "all"
+	if codebase != nil {
+		repoName = sanitizePathComponent(codebase.Name)
+	}
+	outputDir = strings.ReplaceAll(outputDir, "{repo}", repoName)
+	return filepath.Join(outputDir, filename), nil
+}
+
+// resolveWorklogRange returns the worklog date range from --since/--until,
+// falling back to the last --days days. --until is inclusive of the whole day.
+func resolveWorklogRange(loc *time.Location) (time.Time, time.Time, error) {
+	now := time.Now().In(loc)
+	if worklogSince == "" {
+		if worklogUntil != "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("--until requires --since")
+		}
+		return now.AddDate(0, 0, -worklogDays), now, nil
+	}
+
+	startDate, err := time.ParseInLocation("2006-01-02", worklogSince, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --since date (expected YYYY-MM-DD): %w", err)
+	}
+	endDate := now
+	if worklogUntil != "" {
+		untilDay, err := time.ParseInLocation("2006-01-02", worklogUntil, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --until date (expected YYYY-MM-DD): %w", err)
+		}
+		endDate = untilDay.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--until must not be before --since")
+	}
+	return startDate, endDate, nil
+}
+
 func queryCommitsForWorklog(ctx context.Context, dbRepo *db.SQLRepository, codebase *db.Codebase, startDate, endDate time.Time, cfg *config.Config) ([]commitData, error) {
+	commits, err := queryCommits(ctx, dbRepo, codebase, startDate, endDate, worklogAll, cfg.GetCountCoAuthoredCommits(), worklogIncludeBots, worklogBranch)
+	if err != nil {
+		return nil, err
+	}
+	commits = collapseReverts(commits, worklogOmitReverted)
+
+	maxCommits := worklogMaxCap
+	if maxCommits <= 0 {
+		maxCommits = cfg.GetWorklogMaxCommits()
+	}
+	worklogCapNote = ""
+	if len(commits) > maxCommits {
+		color.New(color.FgYellow).Printf("  %d commits in range; keeping the %d with the most changed lines (--max-commits)\n", len(commits), maxCommits)
+		worklogCapNote = fmt.Sprintf("Showing the %d most significant of %d commits (by lines changed); raise --max-commits to include the rest.", maxCommits, len(commits))
+		commits = capCommitsByChurn(commits, maxCommits)
+	}
+	return commits, nil
+}
+
+// capCommitsByChurn keeps the max commits with the most changed lines,
+// preferring newer commits on ties, and returns them in their original order.
+func capCommitsByChurn(commits []commitData, max int) []commitData {
+	ranked := make([]int, len(commits))
+	for i := range ranked {
+		ranked[i] = i
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		ca, cb := commits[ranked[a]], commits[ranked[b]]
+		if churnA, churnB := ca.Additions+ca.Deletions, cb.Additions+cb.Deletions; churnA != churnB {
+			return churnA > churnB
+		}
+		return ca.CommittedAt.After(cb.CommittedAt)
+	})
+	keep := make(map[int]bool, max)
+	for _, i := range ranked[:max] {
+		keep[i] = true
+	}
+	kept := make([]commitData, 0, max)
+	for i, c := range commits {
+		if keep[i] {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// queryCommits loads commits (with file change totals) in the given range.
+// When allAuthors is false only the user's own commits are returned (plus
+// those naming the user in a Co-authored-by trailer when includeCoAuthored is
+// set), and commits flagged as bot commits are dropped unless includeBots is
+// set.
+func queryCommits(ctx context.Context, dbRepo *db.SQLRepository, codebase *db.Codebase, startDate, endDate time.Time, allAuthors, includeCoAuthored, includeBots bool, branchName string) ([]commitData, error) {
 	queryStr := `
 		SELECT c.id, c.hash, c.codebase_id, c.branch_id, c.author_email, c.message, c.summary, c.committed_at,
-			b.name as branch_name, c.parent_count, c.is_merge_sync
+			b.name as branch_name, c.parent_count, c.is_merge_sync, c.is_signed, c.is_on_default_branch, c.commit_type,
+			cb.remote_url, c.reverts_hash,
+			(SELECT r.hash FROM commits r WHERE r.codebase_id = c.codebase_id AND r.reverts_hash = c.hash LIMIT 1) AS reverted_by,
+			c.is_user_commit,
+			(SELECT string_agg(ca.developer_id, ',') FROM commit_coauthors ca
+				WHERE ca.codebase_id = c.codebase_id AND ca.commit_hash = c.hash AND ca.is_user = FALSE) AS coauthors
 		FROM commits c
 		LEFT JOIN branches b ON c.branch_id = b.id
+		LEFT JOIN codebases cb ON c.codebase_id = cb.id
 		WHERE c.committed_at >= $1 AND c.committed_at <= $2
 	`
 	args := []any{startDate, endDate}
@@ -270,14 +624,20 @@ func queryCommitsForWorklog(ctx context.Context, dbRepo *db.SQLRepository, codeb
 		argIdx++
 	}
 
-	if !worklogAll {
+	if !allAuthors && includeCoAuthored {
+		queryStr += ` AND (c.is_user_commit = TRUE OR EXISTS (SELECT 1 FROM commit_coauthors ca
+			WHERE ca.codebase_id = c.codebase_id AND ca.commit_hash = c.hash AND ca.is_user = TRUE))`
+	} else if !allAuthors {
 		queryStr += " AND c.is_user_commit = TRUE"
 	}
+	if !includeBots {
+		queryStr += " AND c.is_bot = FALSE"
+	}
 
-	if worklogBranch != "" && codebase != nil {
-		branch, err := dbRepo.GetBranch(ctx, codebase.ID, worklogBranch)
+	if branchName != "" && codebase != nil {
+		branch, err := dbRepo.GetBranch(ctx, codebase.ID, branchName)
 		if err != nil || branch == nil {
-			return nil, fmt.Errorf("branch '%s' not found", worklogBranch)
+			return nil, fmt.Errorf("branch '%s' not found", branchName)
 		}
 		queryStr += fmt.Sprintf(" AND c.branch_id = $%d", argIdx)
 		args = append(args, branch.ID)
@@ -301,10 +661,20 @@ func queryCommitsForWorklog(ctx context.Context, dbRepo *db.SQLRepository, codeb
 			BranchName:  getString(row, "branch_name"),
 			ParentCount: getInt(row, "parent_count"),
 			IsMergeSync: getBool(row, "is_merge_sync"),
+			IsSigned:    getBool(row, "is_signed"),
+			IsOnDefault: getBool(row, "is_on_default_branch"),
+			CommitType:  getString(row, "commit_type"),
+			RevertsHash: getString(row, "reverts_hash"),
+			RevertedBy:  getString(row, "reverted_by"),
+			ByUser:      getBool(row, "is_user_commit"),
+		}
+		if coAuthors := getString(row, "coauthors"); coAuthors != "" {
+			cd.CoAuthors = strings.Split(coAuthors, ",")
 		}
 		if t, ok := row["committed_at"].(time.Time); ok {
 			cd.CommittedAt = t
 		}
+		cd.URL = git.CommitURL(git.BrowseURL(getString(row, "remote_url")), cd.Hash)
 
 		if id := getString(row, "id"); id != "" {
 			fileChanges, err := dbRepo.GetFileChangesByCommit(ctx, id)
@@ -315,6 +685,11 @@ func queryCommitsForWorklog(ctx context.Context, dbRepo *db.SQLRepository, codeb
 				cd.Additions += fc.Additions
 				cd.Deletions += fc.Deletions
 				cd.Files = append(cd.Files, fc.FilePath)
+				change := fileChangeStat{ContentHash: fc.ContentHash, Path: fc.FilePath, Additions: fc.Additions, Deletions: fc.Deletions}
+				if worklogIncludeDiffs {
+					change.Patch = fc.Patch
+				}
+				cd.Changes = append(cd.Changes, change)
 			}
 		}
 
@@ -362,11 +737,46 @@ func getBool(m map[string]any, key string) bool {
 	return false
 }
 
+// techStackContextMax caps how many detected technologies are named in the
+// project context.
+const techStackContextMax = 10
+
 func getProjectContext(codebase *db.Codebase) string {
-	if codebase != nil && codebase.Summary != "" {
-		return codebase.Summary
+	if codebase == nil {
+		return "(No project context available)"
+	}
+	var parts []string
+	if codebase.Summary != "" {
+		parts = append(parts, codebase.Summary)
+	}
+	if stack := formatTechStack(codebase.TechStack); stack != "" {
+		parts = append(parts, "Tech stack: "+stack)
 	}
-	return "(No project context available)"
+	if len(parts) == 0 {
+		return "(No project context available)"
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// formatTechStack lists the technologies detected at index time, most files
+// first, e.g. "TypeScript, Go, Node.js, Docker".
+func formatTechStack(stack map[string]int) string {
+	names := make([]string, 0, len(stack))
+	for name, count := range stack {
+		if count > 0 && strings.TrimSpace(name) != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if stack[names[i]] != stack[names[j]] {
+			return stack[names[i]] > stack[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > techStackContextMax {
+		names = names[:techStackContextMax]
+	}
+	return strings.Join(names, ", ")
 }
 
 func getCodebaseContext(codebase *db.Codebase) string {
@@ -421,6 +831,20 @@ func extractContextLine(section string) string {
 	return result
 }
 
+// appendBranchContext adds a dated line to a branch's running context,
+// keeping only the last contextLines lines.
+func appendBranchContext(branchCtx string, date time.Time, contextLine string, contextLines int) string {
+	entry := fmt.Sprintf("- %s: %s", date.Format("Jan 2"), contextLine)
+	if branchCtx != "" {
+		entry = branchCtx + "\n" + entry
+	}
+	lines := strings.Split(entry, "\n")
+	if len(lines) > contextLines {
+		lines = lines[len(lines)-contextLines:]
+	}
+	return strings.Join(lines, "\n")
+}
+
 func splitAttributionCommits(commits []commitData) (attribution []commitData, mergeSync []commitData) {
 	for _, c := range commits {
 		if c.IsMergeSync {
@@ -448,9 +872,19 @@ func mergeSyncUpdateLine(count int) string {
 	return fmt.Sprintf("- User resolved merge conflicts while syncing the current branch with its parent branch (%d merge-sync commits).", count)
 }
 
+// worklogMessage returns a commit message as worklogs show it, with leading
+// gitmoji removed when strip_gitmoji is set. The stored message is unchanged.
+func worklogMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if worklogStripGitmoji {
+		message = leadingGitmojiRE.ReplaceAllString(message, "")
+	}
+	return message
+}
+
 func buildCommitContext(c commitData, style string) string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Commit %s: %s\n", c.Hash[:7], strings.TrimSpace(c.Message)))
+	sb.WriteString(fmt.Sprintf("Commit %s: %s\n", c.Hash[:7], worklogMessage(c.Message)))
 	if c.IsMergeSync {
 		sb.WriteString("Classification: merge-sync (branch synchronization/conflict resolution)\n")
 	}
@@ -459,6 +893,9 @@ func buildCommitContext(c commitData, style string) string {
 	}
 
 	if style == "technical" {
+		if c.URL != "" {
+			sb.WriteString(fmt.Sprintf("Link: %s\n", c.URL))
+		}
 		sb.WriteString(fmt.Sprintf("Stats: +%d/-%d lines\n", c.Additions, c.Deletions))
 		if len(c.Files) > 0 {
 			sb.WriteString(fmt.Sprintf("Files: %s\n", strings.Join(c.Files, ", ")))
@@ -467,18 +904,49 @@ func buildCommitContext(c commitData, style string) string {
 	return sb.String()
 }
 
+// commitHashMarkdown renders a commit's short hash, as a link to the commit
+// when the repository's remote is on a recognised host.
+func commitHashMarkdown(c commitData) string {
+	if c.URL != "" {
+		return fmt.Sprintf("[%s](%s)", c.Hash[:7], c.URL)
+	}
+	return fmt.Sprintf("`%s`", c.Hash[:7])
+}
+
 func buildAggregateStats(commits []commitData) string {
-	totalAdditions := 0
-	totalDeletions := 0
+	totalAdditions, totalDeletions := computeCommitStats(commits)
+	stats := fmt.Sprintf("%d commits | +%d/-%d lines | %d unique files changed", len(commits), totalAdditions, totalDeletions, countUniqueFiles(commits))
+	if breakdown := formatCommitTypeBreakdown(commits); breakdown != "" {
+		stats += " | " + breakdown
+	}
+	return stats
+}
+
+// countsTowardStats reports whether a commit's churn is included in line and
+// file totals. Merge-sync commits are excluded unless --include-merge-sync-stats
+// is set, since their diffs are mostly conflict-resolution noise.
+func countsTowardStats(c commitData) bool {
+	return includeMergeSyncStats || !c.IsMergeSync
+}
+
+// isFlaggedUnsigned reports whether a commit should be marked as unsigned in
+// commit lists. Only default-branch commits are flagged, and only with
+// --flag-unsigned.
+func isFlaggedUnsigned(c commitData) bool {
+	return worklogFlagUnsigned && c.IsOnDefault && !c.IsSigned
+}
+
+func countUniqueFiles(commits []commitData) int {
 	fileSet := make(map[string]bool)
 	for _, c := range commits {
-		totalAdditions += c.Additions
-		totalDeletions += c.Deletions
+		if !countsTowardStats(c) {
+			continue
+		}
 		for _, f := range c.Files {
 			fileSet[f] = true
 		}
 	}
-	return fmt.Sprintf("%d commits | +%d/-%d lines | %d unique files changed", len(commits), totalAdditions, totalDeletions, len(fileSet))
+	return len(fileSet)
 }
 
 type worklogCacheContext struct {
@@ -487,6 +955,8 @@ type worklogCacheContext struct {
 	profileName           string
 	loc                   *time.Location
 	noCache               bool
+	refreshRollups        bool // Rebuild weekly/monthly summaries even if their days are unchanged
+	weekStart             time.Weekday
 	ChangedDailySummaries map[time.Time]bool
 }
 
@@ -494,20 +964,66 @@ func computeCommitHashes(commits []commitData) string {
 	hashes := make([]string, len(commits))
 	for i, c := range commits {
 		hashes[i] = c.Hash
+		// A revert landing after a day was cached must invalidate it so
+		// the "(later reverted)" note shows up.
+		if c.RevertedBy != "" {
+			hashes[i] += ":reverted"
+		}
 	}
 	sort.Strings(hashes)
 	return strings.Join(hashes, ",")
 }
 
+// computeCommitStats totals line churn. A file change that appears in
+// several commits (cherry-picks, squashes of already-counted work) counts
+// once; changes ingested before content hashes were recorded always count.
 func computeCommitStats(commits []commitData) (int, int) {
 	adds, dels := 0, 0
+	seen := make(map[string]bool)
 	for _, c := range commits {
-		adds += c.Additions
-		dels += c.Deletions
+		if !countsTowardStats(c) {
+			continue
+		}
+		if len(c.Changes) == 0 {
+			adds += c.Additions
+			dels += c.Deletions
+			continue
+		}
+		for _, fc := range c.Changes {
+			if fc.ContentHash != "" {
+				if seen[fc.ContentHash] {
+					continue
+				}
+				seen[fc.ContentHash] = true
+			}
+			adds += fc.Additions
+			dels += fc.Deletions
+		}
 	}
 	return adds, dels
 }
 
+// countFileChanges returns the number of file changes across commits and how
+// many of them are unique, i.e. not replayed from another commit in the set.
+func countFileChanges(commits []commitData) (total, unique int) {
+	seen := make(map[string]bool)
+	for _, c := range commits {
+		if !countsTowardStats(c) {
+			continue
+		}
+		for _, fc := range c.Changes {
+			total++
+			if fc.ContentHash == "" || !seen[fc.ContentHash] {
+				unique++
+			}
+			if fc.ContentHash != "" {
+				seen[fc.ContentHash] = true
+			}
+		}
+	}
+	return total, unique
+}
+
 func getCachedOrGenerate(
 	ctx context.Context,
 	cache *worklogCacheContext,
@@ -522,9 +1038,7 @@ func getCachedOrGenerate(
 		return content, false, err
 	}
 
-	today := time.Now().In(cache.loc).Truncate(24 * time.Hour)
-	entryDay := date.In(cache.loc).Truncate(24 * time.Hour)
-	isToday := entryDay.Equal(today)
+	isToday := localDay(date, cache.loc).Equal(localDay(time.Now(), cache.loc))
 	currentHashes := computeCommitHashes(commits)
 
 	if !cache.noCache && !isToday {
@@ -626,45 +1140,7 @@ func groupByBranch(ctx context.Context, dbRepo *db.SQLRepository, commits []comm
 }
 
 func createWorklogClient(cfg *config.Config) (llm.Client, error) {
-	selectedProvider := worklogProvider
-	if selectedProvider == "" {
-		selectedProvider = cfg.GetEffectiveProvider()
-	}
-	if selectedProvider == "" {
-		return nil, fmt.Errorf("no provider configured; run 'devlog onboard' first")
-	}
-	selectedModel := worklogModel // from CLI flag
-	if selectedModel == "" {
-		selectedModel = cfg.GetEffectiveModel()
-	}
-	llmCfg := llm.Config{Provider: llm.Provider(selectedProvider), Model: selectedModel}
-	switch llmCfg.Provider {
-	case llm.ProviderOpenAI:
-		llmCfg.APIKey = cfg.GetEffectiveAPIKey("openai")
-	case llm.ProviderChatGPT:
-		llmCfg.APIKey = cfg.GetEffectiveAPIKey("chatgpt")
-	case llm.ProviderAnthropic:
-		llmCfg.APIKey = cfg.GetEffectiveAPIKey("anthropic")
-	case llm.ProviderOpenRouter:
-		llmCfg.APIKey = cfg.GetEffectiveAPIKey("openrouter")
-	case llm.ProviderGemini:
-		llmCfg.APIKey = cfg.GetEffectiveAPIKey("gemini")
-	case llm.ProviderBedrock:
-		llmCfg.AWSAccessKeyID = cfg.GetEffectiveAWSAccessKeyID()
-		llmCfg.AWSSecretAccessKey = cfg.GetEffectiveAWSSecretAccessKey()
-		llmCfg.AWSRegion = cfg.GetEffectiveAWSRegion()
-	case llm.ProviderOllama:
-		if url := cfg.GetEffectiveOllamaBaseURL(); url != "" {
-			llmCfg.BaseURL = url
-		}
-		// Ollama uses its own model field as override
-		if selectedModel == "" {
-			if model := cfg.GetEffectiveOllamaModel(); model != "" {
-				llmCfg.Model = model
-			}
-		}
-	}
-	return llm.NewClient(llmCfg)
+	return createLLMClient(cfg, worklogProvider, worklogModel)
 }
 
 type dayOutputSection struct {
@@ -678,6 +1154,223 @@ type branchOutputSection struct {
 }
 
 func generateWorklogMarkdown(groups []dayGroup, client llm.Client, cfg *config.Config, loc *time.Location, projectContext string, codebaseContext string, cache *worklogCacheContext, style string, nameOfUser string) (string, error) {
+	daySections, err := buildDaySections(groups, client, loc, projectContext, cache, style, nameOfUser, cfg.GetWorklogContextLines())
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	writeDateWorklogHeader(&sb, cfg, groups, loc)
+
+	if client != nil {
+		summary, err := generateOverallSummary(groups, client, projectContext, codebaseContext, style, nameOfUser)
+		if err != nil {
+			color.New(color.FgYellow).Printf("  Overall summary unavailable: %v\n", err)
+		} else if summary != "" {
+			sb.WriteString("## Summary\n\n")
+			sb.WriteString(summary)
+			sb.WriteString("\n\n---\n\n")
+		}
+	}
+
+	if worklogCompact {
+		writeCompactDays(&sb, groups, daySections, loc)
+		sb.WriteString("\n*Generated by [DevLog](https://github.com/ishaan812/devlog)*\n")
+		return sb.String(), nil
+	}
+
+	if worklogAppend && len(groups) > 0 {
+		sb.WriteString(worklogAppendMarker(groups[len(groups)-1].Date.In(loc)))
+	}
+	writeDaySections(&sb, daySections)
+
+	sb.WriteString("*Generated by [DevLog](https://github.com/ishaan812/devlog)*\n")
+
+	return sb.String(), nil
+}
+
+// generateHighlightsMarkdown renders a --highlights-only worklog: the
+// header and the period's top accomplishments, from one LLM call over all
+// the commits instead of one per day and branch.
+func generateHighlightsMarkdown(groups []dayGroup, client llm.Client, cfg *config.Config, loc *time.Location, projectContext string, codebaseContext string, style string, nameOfUser string) (string, error) {
+	highlights, err := generateOverallSummary(groups, client, projectContext, codebaseContext, style, nameOfUser)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate highlights: %w", err)
+	}
+
+	var sb strings.Builder
+	writeDateWorklogHeader(&sb, cfg, groups, loc)
+	sb.WriteString("## Highlights\n\n")
+	sb.WriteString(strings.TrimSpace(highlights))
+	sb.WriteString("\n\n---\n\n")
+	sb.WriteString("*Generated by [DevLog](https://github.com/ishaan812/devlog)*\n")
+	return sb.String(), nil
+}
+
+// writeDaySections writes the day sections newest first, each with its
+// branches.
+func writeDaySections(sb *strings.Builder, daySections []dayOutputSection) {
+	for i := len(daySections) - 1; i >= 0; i-- {
+		ds := daySections[i]
+		sb.WriteString(fmt.Sprintf("# %s\n\n", ds.dayName))
+		for _, bs := range ds.branches {
+			sb.WriteString(fmt.Sprintf("## Branch: %s\n\n", bs.branchName))
+			sb.WriteString(bs.content)
+			sb.WriteString("\n")
+		}
+		sb.WriteString("---\n\n")
+	}
+}
+
+// writeCompactDays writes one bullet per day, newest first, with the day's
+// top accomplishment from its update sections. Days without a usable update
+// bullet (for example with --no-llm) fall back to their first commit message.
+func writeCompactDays(sb *strings.Builder, groups []dayGroup, daySections []dayOutputSection, loc *time.Location) {
+	sb.WriteString("## Days\n\n")
+	for i := len(daySections) - 1; i >= 0; i-- {
+		var line string
+		for _, bs := range daySections[i].branches {
+			if line = extractContextLine(bs.content); line != "" {
+				break
+			}
+		}
+		if line == "" && len(groups[i].Commits) > 0 {
+			line = strings.SplitN(worklogMessage(groups[i].Commits[0].Message), "\n", 2)[0]
+		}
+		count := fmt.Sprintf("%d commits", len(groups[i].Commits))
+		if len(groups[i].Commits) == 1 {
+			count = "1 commit"
+		}
+		sb.WriteString(fmt.Sprintf("- **%s**: %s (%s)\n", groups[i].Date.In(loc).Format("Mon, Jan 2"), line, count))
+	}
+}
+
+// weekOutputSection holds one week of the week-grouped layout.
+type weekOutputSection struct {
+	weekStart time.Time
+	days      []dayGroup
+	sections  []dayOutputSection
+	summary   string
+}
+
+// generateWeekWorklogMarkdown renders the worklog as top-level week sections,
+// each with a weekly narrative followed by that week's days. Weekly summaries
+// share the cache used by the date layout's weekly roll-ups.
+func generateWeekWorklogMarkdown(groups []dayGroup, client llm.Client, cfg *config.Config, loc *time.Location, projectContext string, codebaseContext string, cache *worklogCacheContext, style string, nameOfUser string) (string, error) {
+	ctx := context.Background()
+	dimColor := color.New(color.FgHiBlack)
+	cacheColor := color.New(color.FgHiGreen)
+
+	daySections, err := buildDaySections(groups, client, loc, projectContext, cache, style, nameOfUser, cfg.GetWorklogContextLines())
+	if err != nil {
+		return "", err
+	}
+
+	// groups are sorted oldest first, so days of a week are contiguous.
+	var weeks []weekOutputSection
+	for i, group := range groups {
+		weekStart := getWeekStart(group.Date, loc, cfg.GetWeekStart())
+		if len(weeks) == 0 || !weeks[len(weeks)-1].weekStart.Equal(weekStart) {
+			weeks = append(weeks, weekOutputSection{weekStart: weekStart})
+		}
+		w := &weeks[len(weeks)-1]
+		w.days = append(w.days, group)
+		w.sections = append(w.sections, daySections[i])
+	}
+
+	for i := range weeks {
+		w := &weeks[i]
+		var dailySummaries []string
+		for _, ds := range w.sections {
+			var day strings.Builder
+			day.WriteString(fmt.Sprintf("### %s\n\n", ds.dayName))
+			for _, bs := range ds.branches {
+				day.WriteString(fmt.Sprintf("Branch: %s\n%s\n", bs.branchName, bs.content))
+			}
+			dailySummaries = append(dailySummaries, day.String())
+		}
+
+		summary, cached, err := getOrGenerateWeekSummary(ctx, cache, w.weekStart, w.days, dailySummaries, client, projectContext, codebaseContext, loc, style, nameOfUser)
+		if err != nil {
+			return "", err
+		}
+		w.summary = summary
+		if cached {
+			cacheColor.Printf("  Week of %s: cached\n", w.weekStart.Format("Jan 2"))
+		} else {
+			dimColor.Printf("  Week of %s: generated\n", w.weekStart.Format("Jan 2"))
+		}
+	}
+
+	var sb strings.Builder
+	writeDateWorklogHeader(&sb, cfg, groups, loc)
+
+	if client != nil && len(weeks) > 1 {
+		summary, err := generateOverallSummary(groups, client, projectContext, codebaseContext, style, nameOfUser)
+		if err != nil {
+			color.New(color.FgYellow).Printf("  Overall summary unavailable: %v\n", err)
+		} else if summary != "" {
+			sb.WriteString("## Summary\n\n")
+			sb.WriteString(summary)
+			sb.WriteString("\n\n---\n\n")
+		}
+	}
+
+	for i := len(weeks) - 1; i >= 0; i-- {
+		w := weeks[i]
+		weekEnd := w.weekStart.AddDate(0, 0, 6)
+		sb.WriteString(fmt.Sprintf("# Week of %s - %s\n\n", w.weekStart.Format("Jan 2"), weekEnd.Format("Jan 2, 2006")))
+
+		var weekCommits []commitData
+		for _, day := range w.days {
+			weekCommits = append(weekCommits, day.Commits...)
+		}
+		attributionWeekCommits, _ := splitAttributionCommits(weekCommits)
+		sb.WriteString(fmt.Sprintf("*%s*\n\n", buildAggregateStats(attributionWeekCommits)))
+
+		// The fallback summary repeats the week range as its own heading.
+		summary := strings.TrimSpace(w.summary)
+		if strings.HasPrefix(summary, "## ") {
+			if _, rest, ok := strings.Cut(summary, "\n"); ok {
+				summary = strings.TrimSpace(rest)
+			}
+		}
+		sb.WriteString(summary)
+		sb.WriteString("\n\n")
+
+		for j := len(w.sections) - 1; j >= 0; j-- {
+			ds := w.sections[j]
+			sb.WriteString(fmt.Sprintf("## %s\n\n", ds.dayName))
+			for _, bs := range ds.branches {
+				sb.WriteString(fmt.Sprintf("### Branch: %s\n\n", bs.branchName))
+				sb.WriteString(demoteMarkdownHeadings(bs.content))
+				sb.WriteString("\n")
+			}
+		}
+		sb.WriteString("---\n\n")
+	}
+
+	sb.WriteString("*Generated by [DevLog](https://github.com/ishaan812/devlog)*\n")
+
+	return sb.String(), nil
+}
+
+// demoteMarkdownHeadings pushes every ATX heading in md down one level so a
+// section can be nested under an extra heading.
+func demoteMarkdownHeadings(md string) string {
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") && strings.HasPrefix(strings.TrimLeft(line, "#"), " ") {
+			lines[i] = "#" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// buildDaySections generates (or loads from cache) the per-day, per-branch
+// update sections for groups, carrying the last contextLines lines of each
+// branch's context forward chronologically.
+func buildDaySections(groups []dayGroup, client llm.Client, loc *time.Location, projectContext string, cache *worklogCacheContext, style string, nameOfUser string, contextLines int) ([]dayOutputSection, error) {
 	ctx := context.Background()
 	dimColor := color.New(color.FgHiBlack)
 	cacheColor := color.New(color.FgHiGreen)
@@ -686,6 +1379,8 @@ func generateWorklogMarkdown(groups []dayGroup, client llm.Client, cfg *config.C
 	branchContextMap := make(map[string]string)
 	branchCacheBusted := make(map[string]bool)
 	cacheInvalidatedCount := 0
+	var failedDays []string
+	var lastFailure error
 	daySections := make([]dayOutputSection, 0, len(groups))
 
 	for _, group := range groups {
@@ -726,10 +1421,9 @@ func generateWorklogMarkdown(groups []dayGroup, client llm.Client, cfg *config.C
 
 			if forceRegen {
 				content, err = buildDayBranchSection(commits, client, projectContext, branchCtx, loc, style, nameOfUser)
-				if err != nil {
-					return "", fmt.Errorf("failed to generate day/branch updates: %w", err)
+				if err == nil {
+					storeCacheEntry(ctx, cache, group.Date, branchID, bName, "day_updates", "date", commits, content)
 				}
-				storeCacheEntry(ctx, cache, group.Date, branchID, bName, "day_updates", "date", commits, content)
 			} else {
 				content, cached, err = getCachedOrGenerate(
 					ctx, cache, group.Date, branchID, bName,
@@ -738,15 +1432,30 @@ func generateWorklogMarkdown(groups []dayGroup, client llm.Client, cfg *config.C
 						return buildDayBranchSection(commits, client, projectContext, branchCtx, loc, style, nameOfUser)
 					},
 				)
-				if err != nil {
-					return "", fmt.Errorf("failed to generate day/branch updates: %w", err)
-				}
-				if !cached && cache != nil {
+				if err == nil && !cached && cache != nil {
 					if cache.ChangedDailySummaries == nil {
 						cache.ChangedDailySummaries = make(map[time.Time]bool)
 					}
-					cache.ChangedDailySummaries[group.Date.In(loc).Truncate(24*time.Hour)] = true
+					cache.ChangedDailySummaries[localDay(group.Date, loc)] = true
+				}
+			}
+			// A failed day gets a placeholder and is left uncached so the next
+			// run retries it; the days already generated are kept.
+			if err != nil {
+				// Every later call would be rejected the same way.
+				if errors.Is(err, llm.ErrAuth) {
+					return nil, err
+				}
+				label := fmt.Sprintf("%s [%s]", group.Date.In(loc).Format("Jan 2"), bName)
+				warnColor.Printf("  %s: failed (%v)\n", label, err)
+				failedDays = append(failedDays, label)
+				lastFailure = err
+				content = unavailableDayBranchSection(commits, loc)
+				if worklogIncludeDiffs {
+					content += buildDiffsSection(commits, loc)
 				}
+				ds.branches = append(ds.branches, branchOutputSection{branchName: bName, content: content})
+				continue
 			}
 			if cached {
 				cacheColor.Printf("  %s [%s]: cached\n", group.Date.In(loc).Format("Jan 2"), bName)
@@ -760,21 +1469,13 @@ func generateWorklogMarkdown(groups []dayGroup, client llm.Client, cfg *config.C
 				branchCacheBusted[branchID] = true
 			}
 
-			contextLine := extractContextLine(content)
-			if contextLine != "" && hasAttributionCommits(commits) {
-				dateStr := group.Date.In(loc).Format("Jan 2")
-				entry := fmt.Sprintf("- %s: %s", dateStr, contextLine)
-				if prev := branchContextMap[branchID]; prev != "" {
-					branchContextMap[branchID] = prev + "\n" + entry
-				} else {
-					branchContextMap[branchID] = entry
-				}
-				lines := strings.Split(branchContextMap[branchID], "\n")
-				if len(lines) > 10 {
-					branchContextMap[branchID] = strings.Join(lines[len(lines)-10:], "\n")
-				}
+			if contextLine := extractContextLine(content); contextLine != "" && hasAttributionCommits(commits) {
+				branchContextMap[branchID] = appendBranchContext(branchContextMap[branchID], group.Date.In(loc), contextLine, contextLines)
 			}
 
+			if worklogIncludeDiffs {
+				content += buildDiffsSection(commits, loc)
+			}
 			ds.branches = append(ds.branches, branchOutputSection{branchName: bName, content: content})
 		}
 
@@ -794,8 +1495,20 @@ func generateWorklogMarkdown(groups []dayGroup, client llm.Client, cfg *config.C
 		warnColor.Println("  Branch context flows chronologically, so newer days were re-summarized with updated context.")
 	}
 
-	var sb strings.Builder
+	if len(failedDays) > 0 {
+		warnColor.Printf("\n  Summary unavailable for %d day/branch section(s): %s\n", len(failedDays), strings.Join(failedDays, ", "))
+		warnColor.Println("  These were not cached; run the same command again to retry them.")
+		if hint := llm.Describe(lastFailure); hint != "" {
+			warnColor.Printf("  Hint: %s\n", hint)
+		}
+	}
 
+	return daySections, nil
+}
+
+// writeDateWorklogHeader writes the title, period and optional active-time
+// lines shared by the date- and week-grouped layouts.
+func writeDateWorklogHeader(sb *strings.Builder, cfg *config.Config, groups []dayGroup, loc *time.Location) {
 	userName := cfg.GetEffectiveUserName()
 	if userName == "" {
 		userName = cfg.GetEffectiveGitHubUsername()
@@ -813,34 +1526,26 @@ func generateWorklogMarkdown(groups []dayGroup, client llm.Client, cfg *config.C
 		sb.WriteString(fmt.Sprintf("**Period:** %s - %s\n\n", startDate.Format("Jan 2"), endDate.Format("Jan 2, 2006")))
 	}
 
-	sb.WriteString("---\n\n")
+	if worklogHours {
+		var sessions []workSession
+		for _, g := range groups {
+			sessions = append(sessions, EstimateWorkSessions(g.Commits, worklogGap)...)
+		}
+		sb.WriteString(fmt.Sprintf("**Estimated active time:** ~%s across %d sessions\n\n", formatActiveTime(estimateActiveTime(sessions)), len(sessions)))
 
-	if client != nil {
-		summary, err := generateOverallSummary(groups, client, projectContext, codebaseContext, style, nameOfUser)
-		if err != nil {
-			return "", fmt.Errorf("failed to generate overall summary: %w", err)
+		var commits []commitData
+		for _, g := range groups {
+			commits = append(commits, g.Commits...)
 		}
-		if summary != "" {
-			sb.WriteString("## Summary\n\n")
-			sb.WriteString(summary)
-			sb.WriteString("\n\n---\n\n")
+		if ranges := primaryWorkingHours(commitHourHistogram(commits, loc)); ranges != nil {
+			sb.WriteString(fmt.Sprintf("**Usual working hours:** %s (%s)\n\n", formatWorkingHours(ranges), loc))
 		}
 	}
-
-	for i := len(daySections) - 1; i >= 0; i-- {
-		ds := daySections[i]
-		sb.WriteString(fmt.Sprintf("# %s\n\n", ds.dayName))
-		for _, bs := range ds.branches {
-			sb.WriteString(fmt.Sprintf("## Branch: %s\n\n", bs.branchName))
-			sb.WriteString(bs.content)
-			sb.WriteString("\n")
-		}
-		sb.WriteString("---\n\n")
+	if worklogCapNote != "" {
+		sb.WriteString(fmt.Sprintf("> %s\n\n", worklogCapNote))
 	}
 
-	sb.WriteString("*Generated by [DevLog](https://github.com/ishaan812/devlog)*\n")
-
-	return sb.String(), nil
+	sb.WriteString("---\n\n")
 }
 
 func generateBranchWorklogMarkdown(groups []branchGroup, client llm.Client, cfg *config.Config, loc *time.Location, projectContext string, codebaseContext string, cache *worklogCacheContext, style string, nameOfUser string) (string, error) {
@@ -860,7 +1565,18 @@ func generateBranchWorklogMarkdown(groups []branchGroup, client llm.Client, cfg
 
 	sb.WriteString(fmt.Sprintf("# Work Log - %s\n\n", userName))
 	sb.WriteString(fmt.Sprintf("*Generated on %s*\n\n", time.Now().In(loc).Format("January 2, 2006")))
-	sb.WriteString(fmt.Sprintf("**Period:** Last %d days\n\n", worklogDays))
+	if worklogSince != "" {
+		period := worklogSince + " to today"
+		if worklogUntil != "" {
+			period = worklogSince + " to " + worklogUntil
+		}
+		sb.WriteString(fmt.Sprintf("**Period:** %s\n\n", period))
+	} else {
+		sb.WriteString(fmt.Sprintf("**Period:** Last %d days\n\n", worklogDays))
+	}
+	if worklogCapNote != "" {
+		sb.WriteString(fmt.Sprintf("> %s\n\n", worklogCapNote))
+	}
 	sb.WriteString("---\n\n")
 
 	for _, group := range groups {
@@ -871,15 +1587,14 @@ func generateBranchWorklogMarkdown(groups []branchGroup, client llm.Client, cfg
 			branchID = group.Branch.ID
 		}
 
-		sb.WriteString(fmt.Sprintf("# Branch: %s\n\n", branchName))
-
-		totalAdditions := 0
-		totalDeletions := 0
-		for _, c := range group.Commits {
-			totalAdditions += c.Additions
-			totalDeletions += c.Deletions
+		if group.Branch != nil && group.Branch.Status != "" && group.Branch.Status != db.BranchStatusActive {
+			sb.WriteString(fmt.Sprintf("# Branch: %s `%s`\n\n", branchName, group.Branch.Status))
+		} else {
+			sb.WriteString(fmt.Sprintf("# Branch: %s\n\n", branchName))
 		}
 
+		totalAdditions, totalDeletions := computeCommitStats(group.Commits)
+
 		sb.WriteString("## Summary\n\n")
 
 		var dbRepoRef *db.SQLRepository
@@ -896,7 +1611,7 @@ func generateBranchWorklogMarkdown(groups []branchGroup, client llm.Client, cfg
 		if client != nil {
 			var entryDate time.Time
 			if len(group.Commits) > 0 {
-				entryDate = group.Commits[0].CommittedAt.In(loc).Truncate(24 * time.Hour)
+				entryDate = localDay(group.Commits[0].CommittedAt, loc)
 			}
 
 			branchSummary, cached, err := getCachedOrGenerate(
@@ -948,19 +1663,25 @@ func generateBranchWorklogMarkdown(groups []branchGroup, client llm.Client, cfg
 
 			for _, c := range dayCommits {
 				commitTime := c.CommittedAt.In(loc).Format("15:04")
-				message := strings.Split(strings.TrimSpace(c.Message), "\n")[0]
-				sb.WriteString(fmt.Sprintf("- **%s** `%s` %s", commitTime, c.Hash[:7], message))
+				message := strings.Split(worklogMessage(c.Message), "\n")[0]
+				sb.WriteString(fmt.Sprintf("- **%s** %s %s", commitTime, commitHashMarkdown(c), message))
 				if c.Additions > 0 || c.Deletions > 0 {
 					sb.WriteString(fmt.Sprintf(" (+%d/-%d)", c.Additions, c.Deletions))
 				}
 				if c.IsMergeSync {
 					sb.WriteString(" [merge-sync]")
 				}
+				if isFlaggedUnsigned(c) {
+					sb.WriteString(" [unsigned]")
+				}
 				sb.WriteString("\n")
 
 				if c.Summary != "" {
 					sb.WriteString(fmt.Sprintf("  - %s\n", c.Summary))
 				}
+				if worklogIncludeDiffs && !c.IsMergeSync {
+					writeCommitDiffs(&sb, c, "  ")
+				}
 			}
 			sb.WriteString("\n")
 		}
@@ -994,6 +1715,28 @@ func buildDayBranchSection(commits []commitData, client llm.Client, projectConte
 	}
 	section.WriteString("\n")
 
+	writeDayCommitList(&section, commits, loc)
+	return section.String(), nil
+}
+
+// unavailableDayBranchSection is the placeholder for a day/branch whose
+// updates summary could not be generated. The commit list is still included.
+func unavailableDayBranchSection(commits []commitData, loc *time.Location) string {
+	var section strings.Builder
+	section.WriteString("### Updates\n\n")
+	section.WriteString("*Summary unavailable: generation failed. Re-run `devlog worklog` to retry.*\n")
+	if _, mergeSyncCommits := splitAttributionCommits(commits); len(mergeSyncCommits) > 0 {
+		section.WriteString(mergeSyncUpdateLine(len(mergeSyncCommits)))
+		section.WriteString("\n")
+	}
+	section.WriteString("\n")
+	writeDayCommitList(&section, commits, loc)
+	return section.String()
+}
+
+// writeDayCommitList writes the "Commits" list of a day/branch section,
+// newest first.
+func writeDayCommitList(section *strings.Builder, commits []commitData, loc *time.Location) {
 	section.WriteString("### Commits\n\n")
 
 	sorted := make([]commitData, len(commits))
@@ -1004,18 +1747,19 @@ func buildDayBranchSection(commits []commitData, client llm.Client, projectConte
 
 	for _, c := range sorted {
 		commitTime := c.CommittedAt.In(loc).Format("15:04")
-		message := strings.Split(strings.TrimSpace(c.Message), "\n")[0]
-		section.WriteString(fmt.Sprintf("- **%s** `%s` %s", commitTime, c.Hash[:7], message))
+		message := strings.Split(worklogMessage(c.Message), "\n")[0]
+		section.WriteString(fmt.Sprintf("- **%s** %s %s", commitTime, commitHashMarkdown(c), message))
 		if c.Additions > 0 || c.Deletions > 0 {
 			section.WriteString(fmt.Sprintf(" (+%d/-%d)", c.Additions, c.Deletions))
 		}
 		if c.IsMergeSync {
 			section.WriteString(" [merge-sync]")
 		}
+		if isFlaggedUnsigned(c) {
+			section.WriteString(" [unsigned]")
+		}
 		section.WriteString("\n")
 	}
-
-	return section.String(), nil
 }
 
 func generateBranchSummary(group branchGroup, client llm.Client, projectContext string, branchContext string, style string, nameOfUser string) (string, error) {
@@ -1039,13 +1783,16 @@ func generateBranchSummary(group branchGroup, client llm.Client, projectContext
 	stats := buildAggregateStats(attributionCommits)
 
 	var prompt string
-	if style == "technical" {
+	if worklogTemplate != "" {
+		prompt = prompts.BuildWorklogTemplateSummaryPrompt(worklogTemplate, nameOfUser, projectContext, branchContext, strings.Join(commitBlocks, "\n---\n"), stats)
+	} else if style == "technical" {
 		prompt = prompts.BuildWorklogBranchSummaryPrompt(nameOfUser, projectContext, branchContext, strings.Join(commitBlocks, "\n---\n"), stats)
 	} else {
 		prompt = prompts.BuildWorklogBranchSummaryPromptNonTechnical(nameOfUser, projectContext, branchContext, strings.Join(commitBlocks, "\n---\n"), stats)
 	}
+	prompt = prompts.WithWordBudget(prompt, worklogMaxWords)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := withLLMTimeout(context.Background())
 	defer cancel()
 	result, err := client.Complete(ctx, prompt)
 	if err != nil {
@@ -1056,22 +1803,71 @@ func generateBranchSummary(group branchGroup, client llm.Client, projectContext
 
 func generateDayBranchUpdates(commits []commitData, client llm.Client, projectContext string, branchContext string, style string, nameOfUser string) (string, error) {
 	var commitBlocks []string
-	for _, c := range commits {
-		commitBlocks = append(commitBlocks, buildCommitContext(c, style))
+	if worklogGroupCommits {
+		for _, unit := range groupCommitUnits(commits) {
+			commitBlocks = append(commitBlocks, buildCommitUnitContext(unit, style))
+		}
+	} else {
+		for _, c := range commits {
+			commitBlocks = append(commitBlocks, buildCommitContext(c, style))
+		}
 	}
 
 	if len(commitBlocks) == 0 {
 		return "", nil
 	}
 
+	return completeDayBranchUpdates(commitBlocks, client, projectContext, branchContext, style, nameOfUser)
+}
+
+// completeDayBranchUpdates asks the LLM for the updates covering commit
+// blocks. If the prompt is too long for the model, the blocks are split in
+// half, each half is summarized on its own (splitting again as needed), and
+// the partial updates are merged with one more call.
+func completeDayBranchUpdates(commitBlocks []string, client llm.Client, projectContext string, branchContext string, style string, nameOfUser string) (string, error) {
+	result, err := completeDayBranchUpdatesPrompt(commitBlocks, client, projectContext, branchContext, style, nameOfUser)
+	if err == nil || !errors.Is(err, llm.ErrContextLength) || len(commitBlocks) < 2 {
+		return result, err
+	}
+
+	mid := len(commitBlocks) / 2
+	VerboseLog("Prompt for %d commits is too long for the model, summarizing them in two parts", len(commitBlocks))
+	first, err := completeDayBranchUpdates(commitBlocks[:mid], client, projectContext, branchContext, style, nameOfUser)
+	if err != nil {
+		return "", err
+	}
+	second, err := completeDayBranchUpdates(commitBlocks[mid:], client, projectContext, branchContext, style, nameOfUser)
+	if err != nil {
+		return "", err
+	}
+
+	partials := []string{
+		"Partial update (already summarized from the earlier commits; merge it with the next one):\n" + strings.TrimSpace(first),
+		"Partial update (already summarized from the later commits; merge it with the previous one):\n" + strings.TrimSpace(second),
+	}
+	merged, err := completeDayBranchUpdatesPrompt(partials, client, projectContext, branchContext, style, nameOfUser)
+	if errors.Is(err, llm.ErrContextLength) {
+		VerboseLog("Merged updates are still too long for the model, keeping the parts as they are")
+		return strings.TrimSpace(first) + "\n\n" + strings.TrimSpace(second), nil
+	}
+	return merged, err
+}
+
+// completeDayBranchUpdatesPrompt builds the updates prompt for commit
+// blocks in the worklog's style and runs it.
+func completeDayBranchUpdatesPrompt(commitBlocks []string, client llm.Client, projectContext string, branchContext string, style string, nameOfUser string) (string, error) {
+	commitsText := strings.Join(commitBlocks, "\n---\n")
 	var prompt string
-	if style == "technical" {
-		prompt = prompts.BuildWorklogDayUpdatesPrompt(nameOfUser, projectContext, branchContext, strings.Join(commitBlocks, "\n---\n"))
+	if worklogTemplate != "" {
+		prompt = prompts.BuildWorklogTemplateUpdatesPrompt(worklogTemplate, nameOfUser, projectContext, branchContext, commitsText)
+	} else if style == "technical" {
+		prompt = prompts.BuildWorklogDayUpdatesPrompt(nameOfUser, projectContext, branchContext, commitsText)
 	} else {
-		prompt = prompts.BuildWorklogDayUpdatesPromptNonTechnical(nameOfUser, projectContext, branchContext, strings.Join(commitBlocks, "\n---\n"))
+		prompt = prompts.BuildWorklogDayUpdatesPromptNonTechnical(nameOfUser, projectContext, branchContext, commitsText)
 	}
+	prompt = prompts.WithWordBudget(prompt, worklogMaxWords)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := withLLMTimeout(context.Background())
 	defer cancel()
 	result, err := client.Complete(ctx, prompt)
 	if err != nil {
@@ -1100,28 +1896,61 @@ func generateOverallSummary(groups []dayGroup, client llm.Client, projectContext
 	stats := buildAggregateStats(allCommits)
 
 	var prompt string
-	if style == "technical" {
+	if worklogHighlights && style == "technical" {
+		prompt = prompts.BuildWorklogHighlightsPrompt(nameOfUser, projectContext, codebaseContext, strings.Join(commitBlocks, "\n---\n"), stats)
+	} else if worklogHighlights {
+		prompt = prompts.BuildWorklogHighlightsPromptNonTechnical(nameOfUser, projectContext, codebaseContext, strings.Join(commitBlocks, "\n---\n"), stats)
+	} else if worklogTemplate != "" {
+		prompt = prompts.BuildWorklogTemplateSummaryPrompt(worklogTemplate, nameOfUser, projectContext, codebaseContext, strings.Join(commitBlocks, "\n---\n"), stats)
+	} else if style == "technical" {
 		prompt = prompts.BuildWorklogOverallSummaryPrompt(nameOfUser, projectContext, codebaseContext, strings.Join(commitBlocks, "\n---\n"), stats)
 	} else {
 		prompt = prompts.BuildWorklogOverallSummaryPromptNonTechnical(nameOfUser, projectContext, codebaseContext, strings.Join(commitBlocks, "\n---\n"), stats)
 	}
+	prompt = prompts.WithWordBudget(prompt, worklogMaxWords)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := withLLMTimeout(context.Background())
 	defer cancel()
 
-	result, err := client.Complete(ctx, prompt)
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return client.Complete(ctx, prompt)
+	}
+	return streamWorklogSummary(ctx, client, prompt)
+}
+
+// streamWorklogSummary generates the overall summary with CompleteStream,
+// echoing the text dimmed as it arrives so long ranges show progress. The
+// preview goes to the terminal only; the caller writes the returned text.
+func streamWorklogSummary(ctx context.Context, client llm.Client, prompt string) (string, error) {
+	stream, err := client.CompleteStream(ctx, prompt)
 	if err != nil {
 		return "", err
 	}
-	return result, nil
+
+	dimColor := color.New(color.FgHiBlack)
+	dimColor.Print("\n  ")
+	var sb strings.Builder
+	for chunk := range stream {
+		if chunk.Err != nil {
+			fmt.Println()
+			return "", chunk.Err
+		}
+		sb.WriteString(chunk.Text)
+		dimColor.Print(strings.ReplaceAll(chunk.Text, "\n", "\n  "))
+	}
+	fmt.Print("\n\n")
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sb.String()), nil
 }
 
-// getWeekStart returns the Sunday (start of week) for a given date
-func getWeekStart(t time.Time, loc *time.Location) time.Time {
+// getWeekStart returns the first day of the week containing a given date,
+// for weeks starting on firstDay (the profile's week_start).
+func getWeekStart(t time.Time, loc *time.Location, firstDay time.Weekday) time.Time {
 	localTime := t.In(loc)
-	// Go's time.Weekday() returns 0 for Sunday, 1 for Monday, etc.
-	daysSinceSunday := int(localTime.Weekday())
-	weekStart := localTime.AddDate(0, 0, -daysSinceSunday)
+	daysSinceStart := (int(localTime.Weekday()) - int(firstDay) + 7) % 7
+	weekStart := localTime.AddDate(0, 0, -daysSinceStart)
 	return time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, loc)
 }
 
@@ -1134,7 +1963,7 @@ func generateWeeklySummaries(ctx context.Context, cache *worklogCacheContext, gr
 	// Group days by week
 	weekGroups := make(map[time.Time][]dayGroup)
 	for _, group := range groups {
-		weekStart := getWeekStart(group.Date, loc)
+		weekStart := getWeekStart(group.Date, loc, cache.weekStart)
 		weekGroups[weekStart] = append(weekGroups[weekStart], group)
 	}
 
@@ -1145,12 +1974,9 @@ func generateWeeklySummaries(ctx context.Context, cache *worklogCacheContext, gr
 			continue
 		}
 
-		// Collect all commits for the week
-		var weekCommits []commitData
+		// Get cached daily summaries to include in weekly context
 		var dailySummaries []string
 		for _, day := range weekDays {
-			weekCommits = append(weekCommits, day.Commits...)
-			// Get cached daily summaries to include in weekly context
 			entries, err := cache.dbRepo.ListWorklogEntriesByDate(ctx, cache.codebaseID, cache.profileName, day.Date)
 			if err == nil {
 				for _, entry := range entries {
@@ -1162,15 +1988,36 @@ func generateWeeklySummaries(ctx context.Context, cache *worklogCacheContext, gr
 			}
 		}
 
-		if len(weekCommits) == 0 {
-			continue
+		if _, _, err := getOrGenerateWeekSummary(ctx, cache, weekStart, weekDays, dailySummaries, client, projectContext, codebaseContext, loc, style, nameOfUser); err != nil {
+			return err
 		}
+	}
 
-		// Check if any daily summaries within this week have changed
-		dailySummariesChanged := false
+	return nil
+}
+
+// getOrGenerateWeekSummary returns the weekly summary for one week, reusing
+// the cached entry when its commits and daily summaries are unchanged. The
+// fallback summary is used when client is nil or the LLM call fails. It
+// reports whether the cached entry was used.
+func getOrGenerateWeekSummary(ctx context.Context, cache *worklogCacheContext, weekStart time.Time, weekDays []dayGroup, dailySummaries []string, client llm.Client, projectContext, codebaseContext string, loc *time.Location, style string, nameOfUser string) (string, bool, error) {
+	// Collect all commits for the week
+	var weekCommits []commitData
+	for _, day := range weekDays {
+		weekCommits = append(weekCommits, day.Commits...)
+	}
+	if len(weekCommits) == 0 {
+		return "", false, nil
+	}
+	currentHashes := computeCommitHashes(weekCommits)
+
+	if cache != nil && cache.dbRepo != nil {
+		// Check if any daily summaries within this week have changed.
+		// --refresh-rollups treats them all as changed.
+		dailySummariesChanged := cache.refreshRollups
 		if cache.ChangedDailySummaries != nil {
 			for _, dayGroup := range weekDays {
-				if cache.ChangedDailySummaries[dayGroup.Date.In(loc).Truncate(24*time.Hour)] {
+				if cache.ChangedDailySummaries[localDay(dayGroup.Date, loc)] {
 					dailySummariesChanged = true
 					break
 				}
@@ -1179,20 +2026,27 @@ func generateWeeklySummaries(ctx context.Context, cache *worklogCacheContext, gr
 
 		// Check if we already have a cached weekly summary
 		existing, err := cache.dbRepo.GetWeeklySummary(ctx, cache.codebaseID, cache.profileName, weekStart)
-		currentHashes := computeCommitHashes(weekCommits)
 
-		// Skip if cache is valid and no daily summaries changed
-		if err == nil && existing != nil && existing.CommitHashes == currentHashes && !cache.noCache && !dailySummariesChanged {
-			continue
+		// An entry found under another week start (week_start was changed)
+		// is regenerated under the current one rather than reused.
+		staleKey := existing != nil && existing.EntryDate.Format("2006-01-02") != weekStart.Format("2006-01-02")
+
+		// Reuse if cache is valid and no daily summaries changed
+		if err == nil && existing != nil && existing.CommitHashes == currentHashes && !cache.noCache && !dailySummariesChanged && !staleKey {
+			return existing.Content, true, nil
 		}
 
-		// If the cache is being busted, clear the old entry first
-		if existing != nil && (cache.noCache || dailySummariesChanged) {
+		// If the cache is being busted, clear the old entry first. Without a
+		// client nothing would replace it, so it is kept.
+		if existing != nil && client != nil && (cache.noCache || dailySummariesChanged || staleKey) {
 			if err := cache.dbRepo.DeleteWorklogEntry(ctx, existing.ID); err != nil {
 				VerboseLog("Warning: failed to delete old weekly summary: %v", err)
 			}
 		}
+	}
 
+	var content string
+	if client != nil {
 		// Generate weekly summary
 		attributionWeekCommits, _ := splitAttributionCommits(weekCommits)
 		stats := buildAggregateStats(attributionWeekCommits)
@@ -1205,41 +2059,49 @@ func generateWeeklySummaries(ctx context.Context, cache *worklogCacheContext, gr
 		} else {
 			prompt = prompts.BuildWorklogWeekSummaryPromptNonTechnical(nameOfUser, projectContext, codebaseContext, periodContext, dailySummaryText, stats)
 		}
+		prompt = prompts.WithWordBudget(prompt, worklogMaxWords)
 
-		timeoutCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
-		content, err := client.Complete(timeoutCtx, prompt)
+		timeoutCtx, cancel := withLLMTimeout(ctx)
+		var err error
+		content, err = client.Complete(timeoutCtx, prompt)
 		cancel()
 
 		if err != nil {
 			VerboseLog("Warning: LLM weekly summary failed for %s, using fallback: %v", weekStart.Format("Jan 2"), err)
-			content = buildFallbackWeeklySummary(weekStart, weekDays, weekCommits, loc)
+			content = ""
 		}
+	}
+	if content == "" {
+		content = buildFallbackWeeklySummary(weekStart, weekDays, weekCommits, loc)
+	}
 
-		// Store the weekly summary
-		adds, dels := computeCommitStats(weekCommits)
-		weekEntry := &db.WorklogEntry{
-			ID:           fmt.Sprintf("week-%s-%s", cache.codebaseID, weekStart.Format("2006-01-02")),
-			CodebaseID:   cache.codebaseID,
-			ProfileName:  cache.profileName,
-			EntryDate:    weekStart,
-			BranchID:     "",
-			BranchName:   "",
-			EntryType:    "week_summary",
-			GroupBy:      "date",
-			Content:      content,
-			CommitCount:  len(weekCommits),
-			Additions:    adds,
-			Deletions:    dels,
-			CommitHashes: currentHashes,
-			CreatedAt:    time.Now(),
-		}
+	if cache == nil || cache.dbRepo == nil || client == nil {
+		return content, false, nil
+	}
 
-		if err := cache.dbRepo.UpsertWorklogEntry(ctx, weekEntry); err != nil {
-			return fmt.Errorf("failed to cache weekly summary: %w", err)
-		}
+	// Store the weekly summary
+	adds, dels := computeCommitStats(weekCommits)
+	weekEntry := &db.WorklogEntry{
+		ID:           fmt.Sprintf("week-%s-%s", cache.codebaseID, weekStart.Format("2006-01-02")),
+		CodebaseID:   cache.codebaseID,
+		ProfileName:  cache.profileName,
+		EntryDate:    weekStart,
+		BranchID:     "",
+		BranchName:   "",
+		EntryType:    "week_summary",
+		GroupBy:      "date",
+		Content:      content,
+		CommitCount:  len(weekCommits),
+		Additions:    adds,
+		Deletions:    dels,
+		CommitHashes: currentHashes,
+		CreatedAt:    time.Now(),
 	}
 
-	return nil
+	if err := cache.dbRepo.UpsertWorklogEntry(ctx, weekEntry); err != nil {
+		return "", false, fmt.Errorf("failed to cache weekly summary: %w", err)
+	}
+	return content, false, nil
 }
 
 // getMonthStart returns the first day of the month for a given date
@@ -1248,8 +2110,8 @@ func getMonthStart(t time.Time, loc *time.Location) time.Time {
 	return time.Date(localTime.Year(), localTime.Month(), 1, 0, 0, 0, 0, loc)
 }
 
-func weekLabelFromDate(t time.Time, loc *time.Location) string {
-	return getWeekStart(t, loc).Format("Jan 2")
+func weekLabelFromDate(t time.Time, loc *time.Location, firstDay time.Weekday) string {
+	return getWeekStart(t, loc, firstDay).Format("Jan 2")
 }
 
 func buildWeeklyPeriodContext(weekCommits []commitData, weekDays []dayGroup, loc *time.Location) string {
@@ -1302,9 +2164,13 @@ func buildMonthlyPeriodContext(ctx context.Context, cache *worklogCacheContext,
 	var sb strings.Builder
 
 	// Explicit week labels for THIS month only - LLM must use these for (Weeks: ...) citations.
+	firstDay := time.Sunday
+	if cache != nil {
+		firstDay = cache.weekStart
+	}
 	weekSet := make(map[string]time.Time)
 	for d := monthStart; !d.After(monthEnd); d = d.AddDate(0, 0, 1) {
-		ws := getWeekStart(d, loc)
+		ws := getWeekStart(d, loc, firstDay)
 		label := ws.In(loc).Format("Jan 2")
 		weekSet[label] = ws
 	}
@@ -1347,7 +2213,7 @@ func buildMonthlyPeriodContext(ctx context.Context, cache *worklogCacheContext,
 			continue
 		}
 
-		week := weekLabelFromDate(entryDate, loc)
+		week := weekLabelFromDate(entryDate, loc, firstDay)
 		if branchWeeks[branch] == nil {
 			branchWeeks[branch] = make(map[string]bool)
 		}
@@ -1394,10 +2260,11 @@ func generateMonthlySummaries(ctx context.Context, cache *worklogCacheContext, c
 		// are missing, using month commits as fallback context.
 
 		// Check if any daily summaries within this month have changed.
-		dailySummariesChanged := false
+		// --refresh-rollups treats them all as changed.
+		dailySummariesChanged := cache.refreshRollups
 		if cache.ChangedDailySummaries != nil {
 			for date := monthStart; !date.After(monthEnd); date = date.AddDate(0, 0, 1) {
-				if cache.ChangedDailySummaries[date.In(loc).Truncate(24*time.Hour)] {
+				if cache.ChangedDailySummaries[localDay(date, loc)] {
 					dailySummariesChanged = true
 					break
 				}
@@ -1454,6 +2321,9 @@ func generateMonthlySummaries(ctx context.Context, cache *worklogCacheContext, c
 				CommittedAt: c.CommittedAt,
 				ParentCount: c.ParentCount,
 				IsMergeSync: c.IsMergeSync,
+				IsSigned:    c.IsSigned,
+				IsOnDefault: c.IsOnDefaultBranch,
+				CommitType:  c.CommitType,
 			}
 			switch v := c.Stats["additions"].(type) {
 			case int:
@@ -1488,8 +2358,9 @@ func generateMonthlySummaries(ctx context.Context, cache *worklogCacheContext, c
 		} else {
 			prompt = prompts.BuildWorklogMonthSummaryPromptNonTechnical(nameOfUser, projectContext, codebaseContext, periodContext, strings.Join(summaryTexts, "\n\n"), monthStats)
 		}
+		prompt = prompts.WithWordBudget(prompt, worklogMaxWords)
 
-		timeoutCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+		timeoutCtx, cancel := withLLMTimeout(ctx)
 		content, err := client.Complete(timeoutCtx, prompt)
 		cancel()
 
@@ -1528,12 +2399,8 @@ func generateMonthlySummaries(ctx context.Context, cache *worklogCacheContext, c
 func buildFallbackWeeklySummary(weekStart time.Time, weekDays []dayGroup, weekCommits []commitData, loc *time.Location) string {
 	weekEnd := weekStart.AddDate(0, 0, 6)
 	adds, dels := computeCommitStats(weekCommits)
-	fileSet := make(map[string]bool)
 	branchSet := make(map[string]bool)
 	for _, c := range weekCommits {
-		for _, f := range c.Files {
-			fileSet[f] = true
-		}
 		if c.BranchName != "" {
 			branchSet[c.BranchName] = true
 		}
@@ -1541,7 +2408,7 @@ func buildFallbackWeeklySummary(weekStart time.Time, weekDays []dayGroup, weekCo
 
 	dayCount := len(weekDays)
 	branchCount := len(branchSet)
-	fileCount := len(fileSet)
+	fileCount := countUniqueFiles(weekCommits)
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("## %s - %s\n\n", weekStart.In(loc).Format("Jan 2"), weekEnd.In(loc).Format("Jan 2, 2006")))
@@ -1556,7 +2423,7 @@ func buildFallbackWeeklySummary(weekStart time.Time, weekDays []dayGroup, weekCo
 	}
 	for i := 0; i < limit; i++ {
 		c := weekCommits[i]
-		msg := strings.Split(strings.TrimSpace(c.Message), "\n")[0]
+		msg := strings.Split(worklogMessage(c.Message), "\n")[0]
 		if len(msg) > 100 {
 			msg = msg[:97] + "..."
 		}
@@ -1564,7 +2431,7 @@ func buildFallbackWeeklySummary(weekStart time.Time, weekDays []dayGroup, weekCo
 		if branch == "" {
 			branch = "unknown"
 		}
-		sb.WriteString(fmt.Sprintf("- `%s` (%s) %s\n", c.Hash[:7], branch, msg))
+		sb.WriteString(fmt.Sprintf("- %s (%s) %s\n", commitHashMarkdown(c), branch, msg))
 	}
 
 	return sb.String()
@@ -1572,12 +2439,8 @@ func buildFallbackWeeklySummary(weekStart time.Time, weekDays []dayGroup, weekCo
 
 func buildFallbackMonthlySummary(monthStart, monthEnd time.Time, monthCommits []commitData, loc *time.Location) string {
 	adds, dels := computeCommitStats(monthCommits)
-	fileSet := make(map[string]bool)
 	branchSet := make(map[string]bool)
 	for _, c := range monthCommits {
-		for _, f := range c.Files {
-			fileSet[f] = true
-		}
 		if c.BranchName != "" {
 			branchSet[c.BranchName] = true
 		}
@@ -1587,7 +2450,7 @@ func buildFallbackMonthlySummary(monthStart, monthEnd time.Time, monthCommits []
 	sb.WriteString(fmt.Sprintf("## %s\n\n", monthStart.In(loc).Format("January 2006")))
 	sb.WriteString("- Monthly summary fallback (LLM unavailable for this run)\n")
 	sb.WriteString(fmt.Sprintf("- %d commit(s) across %d branch(es)\n", len(monthCommits), len(branchSet)))
-	sb.WriteString(fmt.Sprintf("- +%d/-%d lines changed across %d file(s)\n", adds, dels, len(fileSet)))
+	sb.WriteString(fmt.Sprintf("- +%d/-%d lines changed across %d file(s)\n", adds, dels, countUniqueFiles(monthCommits)))
 	sb.WriteString(fmt.Sprintf("- Period: %s - %s\n", monthStart.In(loc).Format("Jan 2"), monthEnd.In(loc).Format("Jan 2, 2006")))
 	return sb.String()
 }
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/llm"
+)
+
+// worklogAppendMarkerRE matches the marker an --append worklog keeps above
+// its newest day. New days are inserted right below it, newest first, and
+// the date records the last day the file covers.
+var worklogAppendMarkerRE = regexp.MustCompile(`(?m)^<!-- devlog:append through (\d{4}-\d{2}-\d{2}) -->\n?`)
+
+// worklogAppendMarker renders the marker for a file covering days up to
+// and including lastDay.
+func worklogAppendMarker(lastDay time.Time) string {
+	return fmt.Sprintf("<!-- devlog:append through %s -->\n\n", lastDay.Format("2006-01-02"))
+}
+
+// readWorklogAppendFile reads an existing --append worklog and the last day
+// it covers. found is false when the file doesn't exist yet.
+func readWorklogAppendFile(path string, loc *time.Location) (content string, lastDay time.Time, found bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", time.Time{}, false, nil
+	}
+	if err != nil {
+		return "", time.Time{}, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	content = string(data)
+	m := worklogAppendMarkerRE.FindStringSubmatch(content)
+	if m == nil {
+		return "", time.Time{}, false, fmt.Errorf("%s has no devlog append marker; --append only updates worklogs it created", path)
+	}
+	lastDay, err = time.ParseInLocation("2006-01-02", m[1], loc)
+	if err != nil {
+		return "", time.Time{}, false, fmt.Errorf("invalid append marker in %s: %w", path, err)
+	}
+	return content, lastDay, true, nil
+}
+
+// spliceWorklogDays inserts new day sections below the append marker and
+// moves the marker up to lastDay. Everything else in the file, including
+// manual edits, is kept as it is.
+func spliceWorklogDays(content, days string, lastDay time.Time) string {
+	loc := worklogAppendMarkerRE.FindStringIndex(content)
+	if loc == nil {
+		return content
+	}
+	rest := strings.TrimLeft(content[loc[1]:], "\n")
+	return content[:loc[0]] + worklogAppendMarker(lastDay) + days + rest
+}
+
+// generateWorklogAppendDays renders only the day sections for groups,
+// newest first, for splicing into an existing --append worklog.
+func generateWorklogAppendDays(groups []dayGroup, client llm.Client, cfg *config.Config, loc *time.Location, projectContext string, cache *worklogCacheContext, style string, nameOfUser string) (string, error) {
+	daySections, err := buildDaySections(groups, client, loc, projectContext, cache, style, nameOfUser, cfg.GetWorklogContextLines())
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	writeDaySections(&sb, daySections)
+	return sb.String(), nil
+}
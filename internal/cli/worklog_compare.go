@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+	"github.com/ishaan812/devlog/internal/llm"
+	"github.com/ishaan812/devlog/internal/prompts"
+)
+
+// compareCommitSamples is how many of each period's largest commits are
+// shown to the LLM when it narrates a --compare section.
+const compareCommitSamples = 15
+
+// periodMetrics summarizes one worklog period for --compare.
+type periodMetrics struct {
+	Start, End time.Time
+	Commits    int
+	Additions  int
+	Deletions  int
+	ActiveDays int
+	Branches   map[string]int
+	Folders    []statsCount
+	Types      map[string]int
+}
+
+// computePeriodMetrics totals a period's commits the same way stats does.
+func computePeriodMetrics(commits []commitData, start, end time.Time, loc *time.Location) periodMetrics {
+	m := periodMetrics{
+		Start:      start,
+		End:        end,
+		Commits:    len(commits),
+		ActiveDays: len(groupByDate(commits, loc)),
+		Branches:   make(map[string]int),
+		Types:      make(map[string]int),
+	}
+	m.Additions, m.Deletions = computeCommitStats(commits)
+	m.Folders, _ = summarizeChurn(commits)
+	for _, c := range commits {
+		if c.BranchName != "" {
+			m.Branches[c.BranchName]++
+		}
+	}
+	for _, tc := range countCommitTypes(commits) {
+		m.Types[tc.Type] = tc.Count
+	}
+	return m
+}
+
+// previousPeriod returns the range of equal length that ends just before
+// start.
+func previousPeriod(start, end time.Time) (time.Time, time.Time) {
+	return start.Add(-end.Sub(start)), start.Add(-time.Nanosecond)
+}
+
+// buildCompareSection queries the period before [start, end] and renders a
+// "Compared with" section contrasting it with the current commits. When
+// client is set, the LLM adds a short narrative above the numbers; if that
+// fails the numbers are still returned.
+func buildCompareSection(ctx context.Context, dbRepo *db.SQLRepository, codebase *db.Codebase, cfg *config.Config, client llm.Client, commits []commitData, start, end time.Time, loc *time.Location, projectContext, nameOfUser string) (string, error) {
+	prevStart, prevEnd := previousPeriod(start, end)
+	// The previous period only feeds totals, so it is not capped like the
+	// worklog's own commits.
+	prevCommits, err := queryCommits(ctx, dbRepo, codebase, prevStart, prevEnd, worklogAll, cfg.GetCountCoAuthoredCommits(), worklogIncludeBots, worklogBranch)
+	if err != nil {
+		return "", fmt.Errorf("failed to query previous period: %w", err)
+	}
+	prevCommits = collapseReverts(prevCommits, worklogOmitReverted)
+
+	cur := computePeriodMetrics(commits, start, end, loc)
+	prev := computePeriodMetrics(prevCommits, prevStart, prevEnd, loc)
+	metrics := renderCompareMetrics(cur, prev)
+
+	days := int(math.Round(end.Sub(start).Hours() / 24))
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Compared with the previous %d days\n\n", days))
+	sb.WriteString(fmt.Sprintf("*%s - %s vs. %s - %s*\n\n",
+		prev.Start.In(loc).Format("Jan 2"), prev.End.In(loc).Format("Jan 2"),
+		cur.Start.In(loc).Format("Jan 2"), cur.End.In(loc).Format("Jan 2, 2006")))
+
+	if client != nil {
+		periods := fmt.Sprintf("Previous period: %s to %s\nCurrent period: %s to %s",
+			prev.Start.In(loc).Format("2006-01-02"), prev.End.In(loc).Format("2006-01-02"),
+			cur.Start.In(loc).Format("2006-01-02"), cur.End.In(loc).Format("2006-01-02"))
+		prompt := prompts.BuildWorklogComparePrompt(nameOfUser, projectContext, periods, metrics, compareCommitList(prevCommits), compareCommitList(commits))
+		llmCtx, cancel := withLLMTimeout(ctx)
+		narrative, err := client.Complete(llmCtx, prompt)
+		cancel()
+		if err != nil {
+			VerboseLog("Warning: failed to narrate period comparison: %v", err)
+		} else if narrative = strings.TrimSpace(narrative); narrative != "" {
+			sb.WriteString(narrative + "\n\n")
+		}
+	}
+
+	sb.WriteString(metrics)
+	sb.WriteString("\n---\n\n")
+	return sb.String(), nil
+}
+
+// renderCompareMetrics renders the comparison table and the branch, focus
+// and commit-mix lines shared by the worklog and the LLM prompt.
+func renderCompareMetrics(cur, prev periodMetrics) string {
+	var sb strings.Builder
+	sb.WriteString("| | Previous | This period | Change |\n")
+	sb.WriteString("|---|---:|---:|---:|\n")
+	sb.WriteString(fmt.Sprintf("| Commits | %d | %d | %s |\n", prev.Commits, cur.Commits, percentChange(prev.Commits, cur.Commits)))
+	sb.WriteString(fmt.Sprintf("| Lines changed | +%d/-%d | +%d/-%d | %s |\n",
+		prev.Additions, prev.Deletions, cur.Additions, cur.Deletions,
+		percentChange(prev.Additions+prev.Deletions, cur.Additions+cur.Deletions)))
+	sb.WriteString(fmt.Sprintf("| Active days | %d | %d | %+d |\n", prev.ActiveDays, cur.ActiveDays, cur.ActiveDays-prev.ActiveDays))
+	sb.WriteString("\n")
+
+	var newBranches []string
+	for name := range cur.Branches {
+		if prev.Branches[name] == 0 {
+			newBranches = append(newBranches, name)
+		}
+	}
+	sort.Strings(newBranches)
+	if len(newBranches) > 0 {
+		sb.WriteString(fmt.Sprintf("**New branches:** %s\n\n", strings.Join(newBranches, ", ")))
+	}
+
+	if len(cur.Folders) > 0 {
+		prevShare := make(map[string]int)
+		for _, f := range prev.Folders {
+			prevShare[f.Name] = folderShare(f, prev)
+		}
+		var parts []string
+		for _, f := range cur.Folders {
+			if len(parts) == 3 {
+				break
+			}
+			part := fmt.Sprintf("`%s` %d%%", f.Name, folderShare(f, cur))
+			if share, ok := prevShare[f.Name]; ok {
+				part += fmt.Sprintf(" (was %d%%)", share)
+			} else {
+				part += " (new)"
+			}
+			parts = append(parts, part)
+		}
+		sb.WriteString(fmt.Sprintf("**Focus (share of changed lines):** %s\n\n", strings.Join(parts, ", ")))
+	}
+
+	types := make([]string, 0, len(cur.Types)+len(prev.Types))
+	for t := range cur.Types {
+		types = append(types, t)
+	}
+	for t := range prev.Types {
+		if _, ok := cur.Types[t]; !ok {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if cur.Types[types[i]] != cur.Types[types[j]] {
+			return cur.Types[types[i]] > cur.Types[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	if len(types) > 0 {
+		parts := make([]string, len(types))
+		for i, t := range types {
+			label, ok := commitTypeLabels[t]
+			if !ok {
+				label = [2]string{t, t}
+			}
+			parts[i] = fmt.Sprintf("%s %d (was %d)", label[1], cur.Types[t], prev.Types[t])
+		}
+		sb.WriteString(fmt.Sprintf("**Commit mix:** %s\n", strings.Join(parts, ", ")))
+	}
+	return sb.String()
+}
+
+// folderShare is a folder's percentage of the period's changed lines.
+func folderShare(f statsCount, m periodMetrics) int {
+	total := m.Additions + m.Deletions
+	if total == 0 {
+		return 0
+	}
+	return (f.Additions + f.Deletions) * 100 / total
+}
+
+// percentChange renders the relative change from prev to cur, e.g. "+50%".
+func percentChange(prev, cur int) string {
+	switch {
+	case prev == 0 && cur == 0:
+		return "-"
+	case prev == 0:
+		return "new"
+	}
+	return fmt.Sprintf("%+d%%", (cur-prev)*100/prev)
+}
+
+// compareCommitList lists a period's largest commits, one subject per line,
+// for the comparison prompt.
+func compareCommitList(commits []commitData) string {
+	if len(commits) == 0 {
+		return "(no commits)"
+	}
+	sample := commits
+	if len(sample) > compareCommitSamples {
+		sample = capCommitsByChurn(commits, compareCommitSamples)
+	}
+	var sb strings.Builder
+	for _, c := range sample {
+		sb.WriteString(fmt.Sprintf("- [%s] %s (+%d/-%d)\n", c.CommittedAt.Format("2006-01-02"), strings.Split(worklogMessage(c.Message), "\n")[0], c.Additions, c.Deletions))
+	}
+	return sb.String()
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ishaan812/devlog/internal/config"
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+var rebuildContextBranch string
+
+var worklogRebuildContextCmd = &cobra.Command{
+	Use:   "rebuild-context",
+	Short: "Recompute branch context summaries from cached daily entries",
+	Long: `Recompute the running context summary stored for each branch.
+
+Worklog generation carries a few lines of context per branch from one day to
+the next and saves it for later runs. After changing the style, model or
+worklog_context_lines, this rebuilds that context from the cached daily
+entries of the current repository, oldest first, without regenerating
+summaries or writing a worklog file.
+
+Examples:
+  devlog worklog rebuild-context                   # Every branch with cached days
+  devlog worklog rebuild-context --branch feature  # A single branch`,
+	Args: cobra.NoArgs,
+	RunE: runWorklogRebuildContext,
+}
+
+func init() {
+	worklogCmd.AddCommand(worklogRebuildContextCmd)
+
+	worklogRebuildContextCmd.Flags().StringVar(&rebuildContextBranch, "branch", "", "Only rebuild the context for this branch")
+}
+
+func runWorklogRebuildContext(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	titleColor := color.New(color.FgHiCyan, color.Bold)
+	dimColor := color.New(color.FgHiBlack)
+	infoColor := color.New(color.FgHiWhite)
+	successColor := color.New(color.FgHiGreen)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	loc := getProfileTimezone(cfg)
+	contextLines := cfg.GetWorklogContextLines()
+
+	dbRepo, err := db.GetRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	codebasePath, err := filepath.Abs(".")
+	if err != nil {
+		return fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+	codebase, err := dbRepo.GetCodebaseByPath(ctx, codebasePath)
+	if err != nil || codebase == nil {
+		return fmt.Errorf("no indexed repository found at %s\nRun `devlog ingest` first", codebasePath)
+	}
+
+	entries, err := dbRepo.ListWorklogEntriesForExport(ctx, codebase.ID, cfg.GetActiveProfileName())
+	if err != nil {
+		return fmt.Errorf("failed to load cached worklog entries: %w", err)
+	}
+
+	// Entries come back oldest first, so context builds chronologically.
+	rebuilt := make(map[string]string)
+	names := make(map[string]string)
+	days := make(map[string]int)
+	for _, e := range entries {
+		if e.EntryType != "day_updates" || e.BranchID == "" {
+			continue
+		}
+		if rebuildContextBranch != "" && e.BranchName != rebuildContextBranch {
+			continue
+		}
+		names[e.BranchID] = e.BranchName
+		days[e.BranchID]++
+		contextLine := extractContextLine(e.Content)
+		if contextLine == "" || isMergeSyncContextLine(contextLine) {
+			continue
+		}
+		rebuilt[e.BranchID] = appendBranchContext(rebuilt[e.BranchID], e.EntryDate.In(loc), contextLine, contextLines)
+	}
+
+	if len(names) == 0 {
+		if rebuildContextBranch != "" {
+			return fmt.Errorf("no cached daily entries for branch '%s'; run 'devlog worklog --branch %s' first", rebuildContextBranch, rebuildContextBranch)
+		}
+		dimColor.Println("  No cached daily entries found. Run 'devlog worklog' first.")
+		return nil
+	}
+
+	branchIDs := make([]string, 0, len(names))
+	for id := range names {
+		branchIDs = append(branchIDs, id)
+	}
+	sort.Slice(branchIDs, func(i, j int) bool {
+		return names[branchIDs[i]] < names[branchIDs[j]]
+	})
+
+	fmt.Println()
+	titleColor.Printf("  Rebuilding branch context for %s\n\n", codebase.Name)
+	for _, id := range branchIDs {
+		if err := dbRepo.UpdateBranchContext(ctx, id, rebuilt[id]); err != nil {
+			return fmt.Errorf("failed to save branch context for %s: %w", names[id], err)
+		}
+		lines := 0
+		if rebuilt[id] != "" {
+			lines = strings.Count(rebuilt[id], "\n") + 1
+		}
+		infoColor.Printf("  %s", names[id])
+		dimColor.Printf("  %d cached days, %d context lines\n", days[id], lines)
+	}
+	fmt.Println()
+	successColor.Printf("  Rebuilt context for %d branch(es).\n\n", len(branchIDs))
+	return nil
+}
+
+// isMergeSyncContextLine reports whether a context line only records merge
+// conflict resolution, which worklog generation leaves out of branch context.
+func isMergeSyncContextLine(line string) bool {
+	return strings.HasPrefix(line, "User resolved merge conflicts")
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// worklogDiffFilesPerCommit is how many of a commit's largest file
+	// changes get a diff with --include-diffs.
+	worklogDiffFilesPerCommit = 3
+	// worklogDiffMaxLines truncates each embedded diff.
+	worklogDiffMaxLines = 40
+	// worklogDiffBudget caps the bytes of diff embedded in one worklog.
+	worklogDiffBudget = 64 * 1024
+)
+
+// worklogDiffBudgetLeft is the diff budget remaining in the current worklog
+// run. The diff that would overrun it is replaced by a note, and no further
+// diffs are embedded.
+var worklogDiffBudgetLeft int
+
+// diffChanges returns the file changes of a commit worth embedding: those
+// with a stored patch, largest churn first, at most worklogDiffFilesPerCommit.
+func diffChanges(c commitData) []fileChangeStat {
+	var changes []fileChangeStat
+	for _, fc := range c.Changes {
+		if strings.TrimSpace(fc.Patch) != "" {
+			changes = append(changes, fc)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Additions+changes[i].Deletions > changes[j].Additions+changes[j].Deletions
+	})
+	if len(changes) > worklogDiffFilesPerCommit {
+		changes = changes[:worklogDiffFilesPerCommit]
+	}
+	return changes
+}
+
+// truncatedPatch returns a patch from its first hunk onwards (the file is
+// already named by the caller), cut to worklogDiffMaxLines.
+func truncatedPatch(patch string) string {
+	lines := strings.Split(strings.TrimRight(patch, "\n"), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "@@") {
+			lines = lines[i:]
+			break
+		}
+	}
+	if len(lines) > worklogDiffMaxLines {
+		more := len(lines) - worklogDiffMaxLines
+		lines = append(lines[:worklogDiffMaxLines], fmt.Sprintf("... (%d more lines)", more))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeCommitDiffs writes fenced diffs for a commit's most significant file
+// changes, each line prefixed with indent so they nest under a list item.
+// Each block starts with a blank line, so it can follow the item directly.
+func writeCommitDiffs(sb *strings.Builder, c commitData, indent string) {
+	for _, fc := range diffChanges(c) {
+		patch := truncatedPatch(fc.Patch)
+		if len(patch) > worklogDiffBudgetLeft {
+			if worklogDiffBudgetLeft > 0 {
+				sb.WriteString(fmt.Sprintf("\n%s*Remaining diffs omitted: this worklog reached its %d KB diff limit.*\n", indent, worklogDiffBudget/1024))
+				worklogDiffBudgetLeft = 0
+			}
+			return
+		}
+		worklogDiffBudgetLeft -= len(patch)
+
+		fence := "```"
+		for strings.Contains(patch, fence) {
+			fence += "`"
+		}
+		sb.WriteString(fmt.Sprintf("\n%s`%s` (+%d/-%d)\n\n", indent, fc.Path, fc.Additions, fc.Deletions))
+		sb.WriteString(indent + fence + "diff\n")
+		for _, line := range strings.Split(patch, "\n") {
+			sb.WriteString(indent + line + "\n")
+		}
+		sb.WriteString(indent + fence + "\n")
+	}
+}
+
+// buildDiffsSection renders the "Diffs" part of a day/branch section. It is
+// added after caching, so cached summaries stay the same with or without
+// --include-diffs.
+func buildDiffsSection(commits []commitData, loc *time.Location) string {
+	sorted := make([]commitData, len(commits))
+	copy(sorted, commits)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CommittedAt.After(sorted[j].CommittedAt)
+	})
+
+	var sb strings.Builder
+	for _, c := range sorted {
+		if worklogDiffBudgetLeft <= 0 {
+			break
+		}
+		if c.IsMergeSync || len(diffChanges(c)) == 0 {
+			continue
+		}
+		message := strings.Split(worklogMessage(c.Message), "\n")[0]
+		sb.WriteString(fmt.Sprintf("- **%s** %s %s\n", c.CommittedAt.In(loc).Format("15:04"), commitHashMarkdown(c), message))
+		writeCommitDiffs(&sb, c, "  ")
+		sb.WriteString("\n")
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "\n### Diffs\n\n" + strings.TrimRight(sb.String(), "\n") + "\n"
+}
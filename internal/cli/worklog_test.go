@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+	_ "time/tzdata" // America/New_York without relying on the system zoneinfo
+
+	"github.com/ishaan812/devlog/internal/db"
+)
+
+// newYorkDSTDays are the 2025 transition days in America/New_York: the
+// spring-forward day has 23 hours and the fall-back day 25.
+var newYorkDSTDays = []struct {
+	name  string
+	day   string
+	hours int
+}{
+	{name: "spring forward", day: "2025-03-09", hours: 23},
+	{name: "fall back", day: "2025-11-02", hours: 25},
+}
+
+func loadNewYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load zone: %v", err)
+	}
+	return loc
+}
+
+func TestLocalDayAcrossDST(t *testing.T) {
+	loc := loadNewYork(t)
+
+	for _, tt := range newYorkDSTDays {
+		t.Run(tt.name, func(t *testing.T) {
+			day, err := time.ParseInLocation("2006-01-02", tt.day, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			next := day.AddDate(0, 0, 1)
+			if got := next.Sub(day); got != time.Duration(tt.hours)*time.Hour {
+				t.Fatalf("%s lasts %v, want %dh", tt.day, got, tt.hours)
+			}
+
+			cases := []struct {
+				at   time.Time
+				want time.Time
+			}{
+				{at: time.Date(day.Year(), day.Month(), day.Day()-1, 23, 30, 0, 0, loc), want: day.AddDate(0, 0, -1)},
+				{at: time.Date(day.Year(), day.Month(), day.Day(), 0, 30, 0, 0, loc), want: day},
+				{at: time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, loc), want: day},
+				{at: time.Date(day.Year(), day.Month(), day.Day(), 23, 30, 0, 0, loc), want: day},
+				{at: time.Date(next.Year(), next.Month(), next.Day(), 0, 30, 0, 0, loc), want: next},
+			}
+			for _, c := range cases {
+				// The same instant seen from UTC must land on the same local day.
+				got := localDay(c.at.UTC(), loc)
+				if !got.Equal(c.want) {
+					t.Errorf("localDay(%s) = %s, want %s", c.at.Format(time.RFC3339), got.Format(time.RFC3339), c.want.Format(time.RFC3339))
+				}
+				if h, m, s := got.In(loc).Clock(); h != 0 || m != 0 || s != 0 {
+					t.Errorf("localDay(%s) = %s, not local midnight", c.at.Format(time.RFC3339), got.Format(time.RFC3339))
+				}
+			}
+
+			// Keys of consecutive days stay one calendar day apart however
+			// long the day in between is.
+			if got := localDay(next, loc); !got.Equal(day.AddDate(0, 0, 1)) {
+				t.Errorf("day after %s keyed %s", tt.day, got.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestGetCachedOrGenerateAcrossDST(t *testing.T) {
+	loc := loadNewYork(t)
+	t.Setenv("HOME", t.TempDir())
+	const profile = "dst-test"
+	dbRepo, err := db.GetRepositoryForProfile(profile)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	t.Cleanup(func() { db.CloseDB(profile) })
+	ctx := context.Background()
+
+	for _, tt := range newYorkDSTDays {
+		t.Run(tt.name, func(t *testing.T) {
+			day, err := time.ParseInLocation("2006-01-02", tt.day, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			at := func(d time.Time, hour, minute int) time.Time {
+				return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, loc).UTC()
+			}
+			commits := []commitData{
+				{Hash: "before-" + tt.day, CommittedAt: at(day.AddDate(0, 0, -1), 23, 30)},
+				{Hash: "early-" + tt.day, CommittedAt: at(day, 0, 30)},
+				{Hash: "late-" + tt.day, CommittedAt: at(day, 23, 30)},
+				{Hash: "after-" + tt.day, CommittedAt: at(day.AddDate(0, 0, 1), 0, 30)},
+			}
+			groups := groupByDate(commits, loc)
+			if len(groups) != 3 {
+				t.Fatalf("got %d day groups, want 3", len(groups))
+			}
+			group := groups[1]
+			if !group.Date.Equal(day) || len(group.Commits) != 2 {
+				t.Fatalf("transition day group = %s with %d commits, want %s with 2", group.Date, len(group.Commits), day)
+			}
+
+			cache := &worklogCacheContext{dbRepo: dbRepo, codebaseID: "cb", profileName: profile, loc: loc}
+			content := "updates for " + tt.day
+			calls := 0
+			generate := func() (string, error) {
+				calls++
+				return content, nil
+			}
+
+			if _, cached, err := getCachedOrGenerate(ctx, cache, group.Date, "", "main", "day_updates", "date", group.Commits, generate); err != nil || cached {
+				t.Fatalf("first call: cached=%v err=%v", cached, err)
+			}
+			// The day key computed from either of its commits finds the entry.
+			for _, c := range group.Commits {
+				got, cached, err := getCachedOrGenerate(ctx, cache, localDay(c.CommittedAt, loc), "", "main", "day_updates", "date", group.Commits, generate)
+				if err != nil || !cached || got != content {
+					t.Errorf("lookup via %s: content=%q cached=%v err=%v", c.Hash, got, cached, err)
+				}
+			}
+			if calls != 1 {
+				t.Errorf("generator called %d times, want 1", calls)
+			}
+
+			entry, err := dbRepo.GetWorklogEntry(ctx, "cb", profile, day, "", "day_updates", "date")
+			if err != nil || entry == nil {
+				t.Fatalf("entry for %s: %v, %v", tt.day, entry, err)
+			}
+			if got := entry.EntryDate.Format("2006-01-02"); got != tt.day {
+				t.Errorf("entry stored on %s, want %s", got, tt.day)
+			}
+			for _, neighbour := range []time.Time{day.AddDate(0, 0, -1), day.AddDate(0, 0, 1)} {
+				if e, _ := dbRepo.GetWorklogEntry(ctx, "cb", profile, neighbour, "", "day_updates", "date"); e != nil {
+					t.Errorf("entry leaked onto %s", neighbour.Format("2006-01-02"))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// commitUnitMaxGap is the longest pause between two commits that still lets
+// them belong to one logical unit of work.
+const commitUnitMaxGap = 3 * time.Hour
+
+// wipSubjectRE matches commit subjects that say nothing on their own and so
+// continue whatever came before them.
+var wipSubjectRE = regexp.MustCompile(`(?i)^(wip\b|fixup!|squash!|amend!|tmp\b|temp\b|more\b|oops\b|typo\b|fix typo|minor\b|small fix|cleanup$|clean up$|updates?$|changes$|[.\-_]+$)`)
+
+// subjectWordRE splits a commit subject into words for similarity checks.
+var subjectWordRE = regexp.MustCompile(`[a-z0-9]+`)
+
+// groupCommitUnits clusters one branch's commits for a day into logical
+// units, oldest first. A commit joins the unit before it when it follows
+// within commitUnitMaxGap and is a WIP-style commit, shares the unit's
+// conventional-commit scope, only touches files the unit already touched,
+// or has a subject much like the previous commit's.
+func groupCommitUnits(commits []commitData) [][]commitData {
+	sorted := make([]commitData, len(commits))
+	copy(sorted, commits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CommittedAt.Before(sorted[j].CommittedAt)
+	})
+
+	var units [][]commitData
+	for _, c := range sorted {
+		if n := len(units); n > 0 && continuesUnit(units[n-1], c) {
+			units[n-1] = append(units[n-1], c)
+			continue
+		}
+		units = append(units, []commitData{c})
+	}
+	return units
+}
+
+// continuesUnit reports whether c belongs to the unit of work before it.
+func continuesUnit(unit []commitData, c commitData) bool {
+	prev := unit[len(unit)-1]
+	if c.CommittedAt.Sub(prev.CommittedAt) > commitUnitMaxGap {
+		return false
+	}
+	subject := commitSubject(c.Message)
+	if wipSubjectRE.MatchString(subject) {
+		return true
+	}
+	if scope := conventionalScope(subject); scope != "" && scope == conventionalScope(commitSubject(prev.Message)) {
+		return true
+	}
+	if len(c.Files) > 0 {
+		touched := make(map[string]bool)
+		for _, u := range unit {
+			for _, f := range u.Files {
+				touched[f] = true
+			}
+		}
+		within := true
+		for _, f := range c.Files {
+			if !touched[f] {
+				within = false
+				break
+			}
+		}
+		if within {
+			return true
+		}
+	}
+	return subjectSimilarity(subject, commitSubject(prev.Message)) >= 0.5
+}
+
+// commitSubject returns the first line of a commit message as the worklog
+// shows it.
+func commitSubject(message string) string {
+	return strings.TrimSpace(strings.SplitN(worklogMessage(message), "\n", 2)[0])
+}
+
+// conventionalScope returns the lower-cased scope of a conventional-commit
+// subject ("feat(api): ..." gives "api"), or "" if it has none.
+func conventionalScope(subject string) string {
+	m := conventionalTypeRE.FindStringSubmatch(subject)
+	if m == nil || len(m[2]) < 3 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(m[2][1 : len(m[2])-1]))
+}
+
+// subjectSimilarity is the Jaccard similarity of two subjects' words,
+// ignoring any conventional-commit prefix and words under three letters.
+func subjectSimilarity(a, b string) float64 {
+	wordsA, wordsB := subjectWords(a), subjectWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func subjectWords(subject string) map[string]bool {
+	subject = conventionalTypeRE.ReplaceAllString(subject, "")
+	words := make(map[string]bool)
+	for _, w := range subjectWordRE.FindAllString(strings.ToLower(subject), -1) {
+		if len(w) >= 3 {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// buildCommitUnitContext describes a unit of work for the day updates
+// prompt. Single commits are described as usual; larger units list their
+// commits and summaries together so the LLM narrates them as one change.
+func buildCommitUnitContext(unit []commitData, style string) string {
+	if len(unit) == 1 {
+		return buildCommitContext(unit[0], style)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Work unit of %d commits toward one change (describe it as a single piece of work):\n", len(unit)))
+	for _, c := range unit {
+		sb.WriteString(fmt.Sprintf("- Commit %s: %s\n", c.Hash[:7], commitSubject(c.Message)))
+	}
+	var summaries []string
+	for _, c := range unit {
+		if c.Summary != "" {
+			summaries = append(summaries, fmt.Sprintf("- %s: %s", c.Hash[:7], strings.TrimSpace(c.Summary)))
+		}
+	}
+	if len(summaries) > 0 {
+		sb.WriteString("Summaries:\n")
+		sb.WriteString(strings.Join(summaries, "\n"))
+		sb.WriteString("\n")
+	}
+
+	if style == "technical" {
+		additions, deletions := computeCommitStats(unit)
+		sb.WriteString(fmt.Sprintf("Stats: +%d/-%d lines\n", additions, deletions))
+		seen := make(map[string]bool)
+		var files []string
+		for _, c := range unit {
+			for _, f := range c.Files {
+				if !seen[f] {
+					seen[f] = true
+					files = append(files, f)
+				}
+			}
+		}
+		if len(files) > 0 {
+			sb.WriteString(fmt.Sprintf("Files: %s\n", strings.Join(files, ", ")))
+		}
+	}
+	return sb.String()
+}
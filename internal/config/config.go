@@ -11,9 +11,58 @@ import (
 
 const DefaultConfigFileName = "config.json"
 
+// Default file-count limits for codebase indexing. Above the soft limit ingest
+// asks which folders to index; above the hard limit the file list is truncated.
+const (
+	DefaultIndexSoftLimit = 500
+	DefaultIndexHardLimit = 1000
+)
+
+// DefaultFolderSummaryMaxDepth is the deepest folder level (the root is 0)
+// that full-mode ingest summarizes unless the profile sets
+// folder_summary_max_depth.
+const DefaultFolderSummaryMaxDepth = 2
+
+// DefaultLLMTimeoutSeconds bounds a single LLM request unless the profile
+// sets llm_timeout_seconds.
+const DefaultLLMTimeoutSeconds = 120
+
+// DefaultIngestLockMaxAgeMinutes is how old an ingest lock may get before
+// it is treated as stale, even if its PID is still running, unless the
+// profile sets ingest_lock_max_age_minutes.
+const DefaultIngestLockMaxAgeMinutes = 120
+
+// Storage backends for a profile's data.
+const (
+	StorageDuckDB   = "duckdb"
+	StoragePostgres = "postgres"
+)
+
+// DefaultCommitSummaryMinChurn is the fewest changed lines a commit needs
+// for an LLM summary unless the profile sets commit_summary_min_churn; 0
+// summarizes every commit.
+const DefaultCommitSummaryMinChurn = 0
+
+// DefaultResummarizeChurn is how many lines a file may change since its
+// summary was written before targeted-mode indexing summarizes it again,
+// unless the profile sets resummarize_churn.
+const DefaultResummarizeChurn = 300
+
+// DefaultWorklogMaxCommits caps how many commits a single worklog sends to
+// the LLM unless the profile sets worklog_max_commits.
+const DefaultWorklogMaxCommits = 1000
+
+// DefaultWorklogContextLines is how many recent per-day lines of a branch's
+// story are carried into the next day's worklog prompt.
+const DefaultWorklogContextLines = 10
+
 type RepoBranchSelection struct {
 	MainBranch       string   `json:"main_branch"`
 	SelectedBranches []string `json:"selected_branches"`
+
+	// BaseBranches maps a branch to the branch it was cut from, for stacked
+	// branches whose parent is not the main branch.
+	BaseBranches map[string]string `json:"base_branches,omitempty"`
 }
 
 // IndexFoldersConfig stores which folders to index for a repo (for repos with many files).
@@ -24,6 +73,33 @@ type IndexFoldersConfig struct {
 type ObsidianVaultConfig struct {
 	VaultPath  string `json:"vault_path"`
 	RootFolder string `json:"root_folder,omitempty"`
+
+	// Daily-notes export settings: a vault-relative path template such as
+	// "Journal/{{date:YYYY-MM-DD}}.md" and the heading devlog's section is
+	// written under.
+	DailyNotesPath    string `json:"daily_notes_path,omitempty"`
+	DailyNotesHeading string `json:"daily_notes_heading,omitempty"`
+}
+
+// SMTPConfig holds the mail server used to send digests. The password can
+// instead come from the DEVLOG_SMTP_PASSWORD environment variable.
+type SMTPConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	From     string `json:"from,omitempty"`
+}
+
+// ConfluenceConfig holds the Confluence site and page that worklogs are
+// exported under. The API token can instead come from the
+// DEVLOG_CONFLUENCE_TOKEN environment variable.
+type ConfluenceConfig struct {
+	BaseURL  string `json:"base_url"`
+	SpaceKey string `json:"space_key,omitempty"`
+	ParentID string `json:"parent_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	APIToken string `json:"api_token,omitempty"`
 }
 
 type Profile struct {
@@ -32,14 +108,63 @@ type Profile struct {
 	CreatedAt        string                          `json:"created_at"`
 	Timezone         string                          `json:"timezone,omitempty"`
 	WorklogStyle     string                          `json:"worklog_style,omitempty"`
+	WorklogOutputDir string                          `json:"worklog_output_dir,omitempty"`
 	Repos            []string                        `json:"repos"`
 	BranchSelections map[string]*RepoBranchSelection `json:"branch_selections"`
 	IndexFolders     map[string]*IndexFoldersConfig  `json:"index_folders,omitempty"`
 	ObsidianVaults   map[string]*ObsidianVaultConfig `json:"obsidian_vaults,omitempty"`
+	LastWorklogs     map[string]string               `json:"last_worklogs,omitempty"`
+	LastWorklog      string                          `json:"last_worklog,omitempty"`
+	IndexSoftLimit   int                             `json:"index_soft_limit,omitempty"`
+	IndexHardLimit   int                             `json:"index_hard_limit,omitempty"`
+	FolderSumDepth   int                             `json:"folder_summary_max_depth,omitempty"`
+	LLMTimeoutSecs   int                             `json:"llm_timeout_seconds,omitempty"`
+	LockMaxAgeMins   int                             `json:"ingest_lock_max_age_minutes,omitempty"`
+	SummaryMinChurn  int                             `json:"commit_summary_min_churn,omitempty"`
+	ResummarizeChurn int                             `json:"resummarize_churn,omitempty"`
+	CommitMaxWords   int                             `json:"commit_summary_max_words,omitempty"`
+	FileMaxWords     int                             `json:"file_summary_max_words,omitempty"`
+	FolderMaxWords   int                             `json:"folder_summary_max_words,omitempty"`
+	WorklogMaxWords  int                             `json:"worklog_summary_max_words,omitempty"`
+	MaxCommits       int                             `json:"worklog_max_commits,omitempty"`
+	StripGitmoji     bool                            `json:"strip_gitmoji,omitempty"`
+	GroupCommits     bool                            `json:"group_daily_commits,omitempty"`
+	ContextLines     int                             `json:"worklog_context_lines,omitempty"`
+	WeekStart        string                          `json:"week_start,omitempty"`
+	MergeStats       string                          `json:"merge_stats,omitempty"`
+	AutoWorklog      string                          `json:"auto_worklog_after_ingest,omitempty"`
+	ContextFiles     []string                        `json:"context_files,omitempty"`
+
+	// Bot filters are case-insensitive regular expressions; commits whose
+	// author email or message matches are flagged as bot commits.
+	BotAuthorPatterns  []string `json:"bot_author_patterns,omitempty"`
+	BotMessagePatterns []string `json:"bot_message_patterns,omitempty"`
+
+	// CountCoAuthoredCommits treats commits that name the user in a
+	// Co-authored-by trailer as the user's own in worklogs and stats.
+	CountCoAuthoredCommits bool `json:"count_coauthored_commits,omitempty"`
+
+	// SMTP and DigestTo configure 'devlog export email --send'.
+	SMTP     *SMTPConfig `json:"smtp,omitempty"`
+	DigestTo []string    `json:"digest_to,omitempty"`
+
+	// Confluence configures 'devlog export confluence'.
+	Confluence *ConfluenceConfig `json:"confluence,omitempty"`
+
+	// Storage selects where the profile's data lives: StorageDuckDB (a
+	// local file, the default) or StoragePostgres at PostgresURL, which
+	// several machines can share.
+	Storage     string `json:"storage,omitempty"`
+	PostgresURL string `json:"postgres_url,omitempty"`
 
 	DefaultProvider string `json:"default_provider,omitempty"`
 	DefaultModel    string `json:"default_model,omitempty"`
 
+	// Optional per-task model overrides for the default provider. Empty
+	// means DefaultModel is used.
+	CommitSummaryModel string `json:"commit_summary_model,omitempty"`
+	FileSummaryModel   string `json:"file_summary_model,omitempty"`
+
 	AnthropicAPIKey     string `json:"anthropic_api_key,omitempty"`
 	OpenAIAPIKey        string `json:"openai_api_key,omitempty"`
 	ChatGPTAccessToken  string `json:"chatgpt_access_token,omitempty"`
@@ -51,9 +176,16 @@ type Profile struct {
 	AWSAccessKeyID     string `json:"aws_access_key_id,omitempty"`
 	AWSSecretAccessKey string `json:"aws_secret_access_key,omitempty"`
 
+	AzureOpenAIAPIKey string `json:"azure_openai_api_key,omitempty"`
+	AzureEndpoint     string `json:"azure_endpoint,omitempty"`
+	AzureDeployment   string `json:"azure_deployment,omitempty"`
+	AzureAPIVersion   string `json:"azure_api_version,omitempty"`
+
 	OllamaBaseURL string `json:"ollama_base_url,omitempty"`
 	OllamaModel   string `json:"ollama_model,omitempty"`
 
+	OllamaEmbeddingModel string `json:"ollama_embedding_model,omitempty"`
+
 	UserName       string `json:"user_name,omitempty"`
 	UserEmail      string `json:"user_email,omitempty"`
 	GitHubUsername string `json:"github_username,omitempty"`
@@ -64,24 +196,30 @@ type Config struct {
 	Profiles           map[string]*Profile `json:"profiles,omitempty"`
 	ActiveProfile      string              `json:"active_profile,omitempty"`
 
-	path string
-
-	DefaultProvider     string `json:"-"`
-	DefaultModel        string `json:"-"`
-	AnthropicAPIKey     string `json:"-"`
-	OpenAIAPIKey        string `json:"-"`
-	ChatGPTAccessToken  string `json:"-"`
-	ChatGPTRefreshToken string `json:"-"`
-	OpenRouterAPIKey    string `json:"-"`
-	GeminiAPIKey        string `json:"-"`
-	AWSRegion           string `json:"-"`
-	AWSAccessKeyID      string `json:"-"`
-	AWSSecretAccessKey  string `json:"-"`
-	OllamaBaseURL       string `json:"-"`
-	OllamaModel         string `json:"-"`
-	UserName            string `json:"-"`
-	UserEmail           string `json:"-"`
-	GitHubUsername      string `json:"-"`
+	path    string
+	project *ProjectConfig // .devlog/config.json of the current repo, if any
+
+	DefaultProvider      string `json:"-"`
+	DefaultModel         string `json:"-"`
+	AnthropicAPIKey      string `json:"-"`
+	OpenAIAPIKey         string `json:"-"`
+	ChatGPTAccessToken   string `json:"-"`
+	ChatGPTRefreshToken  string `json:"-"`
+	OpenRouterAPIKey     string `json:"-"`
+	GeminiAPIKey         string `json:"-"`
+	AWSRegion            string `json:"-"`
+	AWSAccessKeyID       string `json:"-"`
+	AWSSecretAccessKey   string `json:"-"`
+	AzureOpenAIAPIKey    string `json:"-"`
+	AzureEndpoint        string `json:"-"`
+	AzureDeployment      string `json:"-"`
+	AzureAPIVersion      string `json:"-"`
+	OllamaBaseURL        string `json:"-"`
+	OllamaModel          string `json:"-"`
+	OllamaEmbeddingModel string `json:"-"`
+	UserName             string `json:"-"`
+	UserEmail            string `json:"-"`
+	GitHubUsername       string `json:"-"`
 }
 
 type Option func(*Config)
@@ -131,8 +269,19 @@ func GetProfileDBPath(name string) string {
 	return filepath.Join(GetDevlogDir(), "profiles", name, "devlog.db")
 }
 
+// Load reads the global config and, when run inside a repository that has
+// one, its .devlog/config.json overrides (see ProjectConfig).
 func Load(opts ...Option) (*Config, error) {
-	return LoadFrom(GetConfigPath(), opts...)
+	cfg, err := LoadFrom(GetConfigPath(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	project, err := LoadProjectConfig(".")
+	if err != nil {
+		return nil, err
+	}
+	cfg.project = project
+	return cfg, nil
 }
 
 func LoadFrom(path string, opts ...Option) (*Config, error) {
@@ -213,6 +362,11 @@ func (c *Config) GetAPIKey(provider string) string {
 			return c.GeminiAPIKey
 		}
 		return os.Getenv("GEMINI_API_KEY")
+	case "azure":
+		if c.AzureOpenAIAPIKey != "" {
+			return c.AzureOpenAIAPIKey
+		}
+		return os.Getenv("AZURE_OPENAI_API_KEY")
 	case "bedrock":
 		return c.AWSAccessKeyID
 	default:
@@ -236,6 +390,8 @@ func (c *Config) HasProvider(provider string) bool {
 		return c.GetAPIKey("gemini") != ""
 	case "bedrock":
 		return c.AWSAccessKeyID != "" && c.AWSSecretAccessKey != ""
+	case "azure":
+		return c.GetAPIKey("azure") != "" && c.AzureEndpoint != "" && c.AzureDeployment != ""
 	default:
 		return false
 	}
@@ -309,6 +465,110 @@ func (c *Config) DeleteProfile(name string, deleteData bool) error {
 	return nil
 }
 
+// WithoutSecrets returns a copy of the profile with API keys, tokens and
+// cloud credentials cleared, safe to share or commit.
+func (p *Profile) WithoutSecrets() *Profile {
+	cp := *p
+	cp.clearSecrets()
+	return &cp
+}
+
+func (p *Profile) clearSecrets() {
+	// References to the keychain or secrets file hold no secret themselves.
+	drop := func(s *string) {
+		if !IsSecretReference(*s) {
+			*s = ""
+		}
+	}
+	drop(&p.AnthropicAPIKey)
+	drop(&p.OpenAIAPIKey)
+	drop(&p.ChatGPTAccessToken)
+	drop(&p.ChatGPTRefreshToken)
+	drop(&p.OpenRouterAPIKey)
+	drop(&p.GeminiAPIKey)
+	drop(&p.AWSAccessKeyID)
+	drop(&p.AWSSecretAccessKey)
+	drop(&p.AzureOpenAIAPIKey)
+	if p.SMTP != nil {
+		smtp := *p.SMTP
+		smtp.Password = ""
+		p.SMTP = &smtp
+	}
+	if p.Confluence != nil {
+		confluence := *p.Confluence
+		confluence.APIToken = ""
+		p.Confluence = &confluence
+	}
+	// Connection URLs usually carry a password.
+	p.PostgresURL = ""
+}
+
+// keepSecretsFrom fills any secret left empty in p (e.g. by a redacted
+// export) with the value from old.
+func (p *Profile) keepSecretsFrom(old *Profile) {
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&p.AnthropicAPIKey, old.AnthropicAPIKey)
+	fill(&p.OpenAIAPIKey, old.OpenAIAPIKey)
+	fill(&p.ChatGPTAccessToken, old.ChatGPTAccessToken)
+	fill(&p.ChatGPTRefreshToken, old.ChatGPTRefreshToken)
+	fill(&p.OpenRouterAPIKey, old.OpenRouterAPIKey)
+	fill(&p.GeminiAPIKey, old.GeminiAPIKey)
+	fill(&p.AWSAccessKeyID, old.AWSAccessKeyID)
+	fill(&p.AWSSecretAccessKey, old.AWSSecretAccessKey)
+	fill(&p.AzureOpenAIAPIKey, old.AzureOpenAIAPIKey)
+	if p.SMTP != nil && old.SMTP != nil {
+		fill(&p.SMTP.Password, old.SMTP.Password)
+	}
+	if p.Confluence != nil && old.Confluence != nil {
+		fill(&p.Confluence.APIToken, old.Confluence.APIToken)
+	}
+	fill(&p.PostgresURL, old.PostgresURL)
+}
+
+// ImportProfile adds an exported profile under name. An existing profile of
+// that name is only replaced when overwrite is set, and then keeps its own
+// secrets wherever the imported profile has none.
+func (c *Config) ImportProfile(name string, profile *Profile, overwrite bool) error {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*Profile)
+	}
+
+	existing, exists := c.Profiles[name]
+	if exists && !overwrite {
+		return fmt.Errorf("profile '%s' already exists", name)
+	}
+	if !exists {
+		for existingName := range c.Profiles {
+			if strings.EqualFold(existingName, name) {
+				return fmt.Errorf("profile '%s' conflicts with existing profile '%s' (names are case-insensitive on some filesystems)", name, existingName)
+			}
+		}
+	}
+
+	profileDir := filepath.Join(GetDevlogDir(), "profiles", name)
+	if err := os.MkdirAll(profileDir, 0755); err != nil {
+		return fmt.Errorf("create profile directory: %w", err)
+	}
+
+	imported := *profile
+	imported.Name = name
+	if imported.CreatedAt == "" {
+		imported.CreatedAt = time.Now().Format(time.RFC3339)
+	}
+	if imported.Repos == nil {
+		imported.Repos = []string{}
+	}
+	if exists {
+		imported.keepSecretsFrom(existing)
+	}
+	c.Profiles[name] = &imported
+	return nil
+}
+
 func (c *Config) SetActiveProfile(name string) error {
 	if c.Profiles == nil || c.Profiles[name] == nil {
 		return fmt.Errorf("profile '%s' not found", name)
@@ -389,8 +649,9 @@ func (c *Config) GetBranchSelection(profileName, repoPath string) *RepoBranchSel
 	return profile.BranchSelections[absPath]
 }
 
-// SaveBranchSelection saves the branch selection for a repo in a profile.
-func (c *Config) SaveBranchSelection(profileName, repoPath, mainBranch string, selectedBranches []string) error {
+// SaveBranchSelection saves the branch selection for a repo in a profile. A
+// nil baseBranches keeps the base-branch overrides saved earlier.
+func (c *Config) SaveBranchSelection(profileName, repoPath, mainBranch string, selectedBranches []string, baseBranches map[string]string) error {
 	if c.Profiles == nil {
 		return fmt.Errorf("profile '%s' not found", profileName)
 	}
@@ -411,9 +672,16 @@ func (c *Config) SaveBranchSelection(profileName, repoPath, mainBranch string, s
 		absPath = repoPath
 	}
 
+	if baseBranches == nil {
+		if existing := profile.BranchSelections[absPath]; existing != nil {
+			baseBranches = existing.BaseBranches
+		}
+	}
+
 	profile.BranchSelections[absPath] = &RepoBranchSelection{
 		MainBranch:       mainBranch,
 		SelectedBranches: selectedBranches,
+		BaseBranches:     baseBranches,
 	}
 
 	return nil
@@ -440,6 +708,26 @@ func (c *Config) ClearBranchSelection(profileName, repoPath string) error {
 	return nil
 }
 
+// ClearRepoSettings removes every saved per-repo setting (branch selection,
+// index folders, Obsidian vault and last worklog) for a repo in a profile.
+func (c *Config) ClearRepoSettings(profileName, repoPath string) {
+	if c.Profiles == nil {
+		return
+	}
+	profile, exists := c.Profiles[profileName]
+	if !exists {
+		return
+	}
+	absPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		absPath = repoPath
+	}
+	delete(profile.BranchSelections, absPath)
+	delete(profile.IndexFolders, absPath)
+	delete(profile.ObsidianVaults, absPath)
+	delete(profile.LastWorklogs, absPath)
+}
+
 // GetIndexFolders returns the saved index folder selection for a repo, or nil if not found.
 func (c *Config) GetIndexFolders(profileName, repoPath string) []string {
 	if c.Profiles == nil {
@@ -518,10 +806,75 @@ func (c *Config) SaveObsidianVault(profileName, repoPath, vaultPath, rootFolder
 		absVaultPath = vaultPath
 	}
 
-	profile.ObsidianVaults[absRepoPath] = &ObsidianVaultConfig{
-		VaultPath:  absVaultPath,
-		RootFolder: strings.TrimSpace(rootFolder),
+	vault := profile.ObsidianVaults[absRepoPath]
+	if vault == nil {
+		vault = &ObsidianVaultConfig{}
+		profile.ObsidianVaults[absRepoPath] = vault
+	}
+	vault.VaultPath = absVaultPath
+	vault.RootFolder = strings.TrimSpace(rootFolder)
+	return nil
+}
+
+// SaveObsidianDailyNotes saves the daily-notes path template and heading for
+// a repo whose vault is already configured.
+func (c *Config) SaveObsidianDailyNotes(profileName, repoPath, pathTemplate, heading string) error {
+	vault := c.GetObsidianVault(profileName, repoPath)
+	if vault == nil {
+		return fmt.Errorf("no obsidian vault configured for %s", repoPath)
 	}
+	vault.DailyNotesPath = strings.TrimSpace(pathTemplate)
+	vault.DailyNotesHeading = strings.TrimSpace(heading)
+	return nil
+}
+
+// GetLastWorklog returns the path of the worklog most recently written for
+// a repo, or for any repo when repoPath is "". It returns "" if none was
+// recorded.
+func (c *Config) GetLastWorklog(profileName, repoPath string) string {
+	if c.Profiles == nil {
+		return ""
+	}
+	profile, exists := c.Profiles[profileName]
+	if !exists {
+		return ""
+	}
+	if repoPath == "" {
+		return profile.LastWorklog
+	}
+	absPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		absPath = repoPath
+	}
+	return profile.LastWorklogs[absPath]
+}
+
+// SaveLastWorklog records a worklog file as the most recent one, for the
+// repo when repoPath is set and for the profile as a whole.
+func (c *Config) SaveLastWorklog(profileName, repoPath, worklogPath string) error {
+	if c.Profiles == nil {
+		return fmt.Errorf("no profiles found")
+	}
+	profile, exists := c.Profiles[profileName]
+	if !exists {
+		return fmt.Errorf("profile '%s' not found", profileName)
+	}
+	absWorklog, err := filepath.Abs(worklogPath)
+	if err != nil {
+		absWorklog = worklogPath
+	}
+	profile.LastWorklog = absWorklog
+	if repoPath == "" {
+		return nil
+	}
+	if profile.LastWorklogs == nil {
+		profile.LastWorklogs = make(map[string]string)
+	}
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		absRepoPath = repoPath
+	}
+	profile.LastWorklogs[absRepoPath] = absWorklog
 	return nil
 }
 
@@ -597,6 +950,9 @@ func (c *Config) GetTimezone() string {
 
 // GetWorklogStyle returns the worklog style for the active profile, defaulting to "non-technical"
 func (c *Config) GetWorklogStyle() string {
+	if c.project != nil && c.project.WorklogStyle != "" {
+		return c.project.WorklogStyle
+	}
 	if c.Profiles != nil && c.ActiveProfile != "" {
 		if profile := c.Profiles[c.ActiveProfile]; profile != nil && profile.WorklogStyle != "" {
 			return profile.WorklogStyle
@@ -605,6 +961,259 @@ func (c *Config) GetWorklogStyle() string {
 	return "non-technical"
 }
 
+// GetWorklogOutputDir returns the directory worklog files are written to,
+// or "" to write them to the current directory.
+func (c *Config) GetWorklogOutputDir() string {
+	if p := c.GetActiveProfile(); p != nil {
+		return strings.TrimSpace(p.WorklogOutputDir)
+	}
+	return ""
+}
+
+// GetWorklogMaxCommits returns the most commits a worklog includes,
+// defaulting to DefaultWorklogMaxCommits.
+func (c *Config) GetWorklogMaxCommits() int {
+	if c.project != nil && c.project.MaxCommits > 0 {
+		return c.project.MaxCommits
+	}
+	if p := c.GetActiveProfile(); p != nil && p.MaxCommits > 0 {
+		return p.MaxCommits
+	}
+	return DefaultWorklogMaxCommits
+}
+
+// GetWorklogContextLines returns how many recent branch context lines
+// worklog prompts carry forward, defaulting to DefaultWorklogContextLines.
+func (c *Config) GetWorklogContextLines() int {
+	if c.project != nil && c.project.ContextLines > 0 {
+		return c.project.ContextLines
+	}
+	if p := c.GetActiveProfile(); p != nil && p.ContextLines > 0 {
+		return p.ContextLines
+	}
+	return DefaultWorklogContextLines
+}
+
+// GetWeekStart returns the first day of the week for weekly summaries:
+// Monday when the profile's week_start is "monday", otherwise Sunday.
+func (c *Config) GetWeekStart() time.Weekday {
+	if p := c.GetActiveProfile(); p != nil && strings.EqualFold(p.WeekStart, "monday") {
+		return time.Monday
+	}
+	return time.Sunday
+}
+
+// Merge stats modes: how ingest attributes file changes to merge commits.
+const (
+	MergeStatsAuto        = "auto"
+	MergeStatsFirstParent = "first-parent"
+	MergeStatsAllParents  = "all-parents"
+	MergeStatsNone        = "none"
+)
+
+// GetMergeStats returns the profile's merge_stats mode, defaulting to
+// MergeStatsAuto for unset or unknown values.
+func (c *Config) GetMergeStats() string {
+	if p := c.GetActiveProfile(); p != nil {
+		switch mode := strings.ToLower(strings.TrimSpace(p.MergeStats)); mode {
+		case MergeStatsFirstParent, MergeStatsAllParents, MergeStatsNone:
+			return mode
+		}
+	}
+	return MergeStatsAuto
+}
+
+// Post-ingest worklog modes: whether ingest asks to generate a worklog.
+const (
+	AutoWorklogPrompt = "prompt"
+	AutoWorklogAlways = "always"
+	AutoWorklogNever  = "never"
+)
+
+// GetAutoWorklogAfterIngest returns the profile's auto_worklog_after_ingest
+// mode, defaulting to AutoWorklogPrompt for unset or unknown values.
+func (c *Config) GetAutoWorklogAfterIngest() string {
+	if p := c.GetActiveProfile(); p != nil {
+		switch mode := strings.ToLower(strings.TrimSpace(p.AutoWorklog)); mode {
+		case AutoWorklogAlways, AutoWorklogNever:
+			return mode
+		}
+	}
+	return AutoWorklogPrompt
+}
+
+// SetAutoWorklogAfterIngest sets whether ingest asks to generate a
+// worklog, always generates one, or never does.
+func (c *Config) SetAutoWorklogAfterIngest(profileName, mode string) error {
+	if c.Profiles == nil || c.Profiles[profileName] == nil {
+		return fmt.Errorf("profile '%s' not found", profileName)
+	}
+	switch mode {
+	case AutoWorklogPrompt, AutoWorklogAlways, AutoWorklogNever:
+	default:
+		return fmt.Errorf("invalid mode: %s (must be 'prompt', 'always' or 'never')", mode)
+	}
+	c.Profiles[profileName].AutoWorklog = mode
+	return nil
+}
+
+// GetGroupDailyCommits reports whether worklogs cluster a day's commits on a
+// branch into logical units before narrating them.
+func (c *Config) GetGroupDailyCommits() bool {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.GroupCommits
+	}
+	return false
+}
+
+// GetStripGitmoji reports whether worklogs drop leading emoji and gitmoji
+// codes from commit messages.
+func (c *Config) GetStripGitmoji() bool {
+	if c.project != nil && c.project.StripGitmoji != nil {
+		return *c.project.StripGitmoji
+	}
+	if p := c.GetActiveProfile(); p != nil {
+		return p.StripGitmoji
+	}
+	return false
+}
+
+// GetIndexSoftLimit returns the file count above which ingest asks which
+// folders to index, defaulting to DefaultIndexSoftLimit.
+func (c *Config) GetIndexSoftLimit() int {
+	if c.project != nil && c.project.IndexSoftLimit > 0 {
+		return c.project.IndexSoftLimit
+	}
+	if p := c.GetActiveProfile(); p != nil && p.IndexSoftLimit > 0 {
+		return p.IndexSoftLimit
+	}
+	return DefaultIndexSoftLimit
+}
+
+// GetIndexHardLimit returns the maximum number of files indexed without
+// --all-files, defaulting to DefaultIndexHardLimit. It is never lower than
+// the soft limit.
+func (c *Config) GetIndexHardLimit() int {
+	limit := DefaultIndexHardLimit
+	if c.project != nil && c.project.IndexHardLimit > 0 {
+		limit = c.project.IndexHardLimit
+	} else if p := c.GetActiveProfile(); p != nil && p.IndexHardLimit > 0 {
+		limit = p.IndexHardLimit
+	}
+	if soft := c.GetIndexSoftLimit(); limit < soft {
+		return soft
+	}
+	return limit
+}
+
+// GetFolderSummaryMaxDepth returns the deepest folder level full-mode
+// ingest summarizes, defaulting to DefaultFolderSummaryMaxDepth.
+func (c *Config) GetFolderSummaryMaxDepth() int {
+	if p := c.GetActiveProfile(); p != nil && p.FolderSumDepth > 0 {
+		return p.FolderSumDepth
+	}
+	return DefaultFolderSummaryMaxDepth
+}
+
+// GetLLMTimeout returns how long a single LLM request may take, from the
+// profile's llm_timeout_seconds or DefaultLLMTimeoutSeconds.
+func (c *Config) GetLLMTimeout() time.Duration {
+	seconds := DefaultLLMTimeoutSeconds
+	if p := c.GetActiveProfile(); p != nil && p.LLMTimeoutSecs > 0 {
+		seconds = p.LLMTimeoutSecs
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetIngestLockMaxAge returns how old an ingest lock may get before it is
+// stale, from the profile's ingest_lock_max_age_minutes or
+// DefaultIngestLockMaxAgeMinutes.
+func (c *Config) GetIngestLockMaxAge() time.Duration {
+	minutes := DefaultIngestLockMaxAgeMinutes
+	if p := c.GetActiveProfile(); p != nil && p.LockMaxAgeMins > 0 {
+		minutes = p.LockMaxAgeMins
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// GetCommitSummaryMinChurn returns the fewest added plus deleted lines a
+// commit needs for a full LLM summary, from the profile's
+// commit_summary_min_churn or DefaultCommitSummaryMinChurn. Smaller commits
+// keep their message's subject line as the summary.
+func (c *Config) GetCommitSummaryMinChurn() int {
+	if p := c.GetActiveProfile(); p != nil && p.SummaryMinChurn > 0 {
+		return p.SummaryMinChurn
+	}
+	return DefaultCommitSummaryMinChurn
+}
+
+// GetCommitSummaryMaxWords returns the word budget for LLM commit
+// summaries from the profile's commit_summary_max_words, or 0 for none.
+func (c *Config) GetCommitSummaryMaxWords() int {
+	if p := c.GetActiveProfile(); p != nil && p.CommitMaxWords > 0 {
+		return p.CommitMaxWords
+	}
+	return 0
+}
+
+// GetFileSummaryMaxWords returns the word budget for file summaries from
+// the profile's file_summary_max_words, or 0 for none.
+func (c *Config) GetFileSummaryMaxWords() int {
+	if p := c.GetActiveProfile(); p != nil && p.FileMaxWords > 0 {
+		return p.FileMaxWords
+	}
+	return 0
+}
+
+// GetFolderSummaryMaxWords returns the word budget for folder summaries
+// from the profile's folder_summary_max_words, or 0 for none.
+func (c *Config) GetFolderSummaryMaxWords() int {
+	if p := c.GetActiveProfile(); p != nil && p.FolderMaxWords > 0 {
+		return p.FolderMaxWords
+	}
+	return 0
+}
+
+// GetWorklogSummaryMaxWords returns the word budget for each LLM-written
+// worklog section (day updates, branch, period, week and month summaries)
+// from the profile's worklog_summary_max_words, or 0 for none.
+func (c *Config) GetWorklogSummaryMaxWords() int {
+	if p := c.GetActiveProfile(); p != nil && p.WorklogMaxWords > 0 {
+		return p.WorklogMaxWords
+	}
+	return 0
+}
+
+// GetResummarizeChurn returns how many lines a file may change after it was
+// summarized before targeted-mode indexing summarizes it again, from the
+// profile's resummarize_churn or DefaultResummarizeChurn. A negative setting
+// turns this off and is returned as 0.
+func (c *Config) GetResummarizeChurn() int {
+	if p := c.GetActiveProfile(); p != nil && p.ResummarizeChurn != 0 {
+		return max(p.ResummarizeChurn, 0)
+	}
+	return DefaultResummarizeChurn
+}
+
+// GetBotFilters returns the active profile's bot author and message patterns.
+func (c *Config) GetBotFilters() (authorPatterns, messagePatterns []string) {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.BotAuthorPatterns, p.BotMessagePatterns
+	}
+	return nil, nil
+}
+
+// SetBotFilters replaces a profile's bot author and message patterns.
+func (c *Config) SetBotFilters(profileName string, authorPatterns, messagePatterns []string) error {
+	profile, exists := c.Profiles[profileName]
+	if !exists || profile == nil {
+		return fmt.Errorf("profile '%s' not found", profileName)
+	}
+	profile.BotAuthorPatterns = authorPatterns
+	profile.BotMessagePatterns = messagePatterns
+	return nil
+}
+
 // SetWorklogStyle sets the worklog style for a profile
 func (c *Config) SetWorklogStyle(profileName, style string) error {
 	if c.Profiles == nil {
@@ -624,6 +1233,73 @@ func (c *Config) SetWorklogStyle(profileName, style string) error {
 	return nil
 }
 
+// GetCountCoAuthoredCommits reports whether commits co-authored by the user
+// count as the user's own.
+func (c *Config) GetCountCoAuthoredCommits() bool {
+	p := c.GetActiveProfile()
+	return p != nil && p.CountCoAuthoredCommits
+}
+
+// SetCountCoAuthoredCommits turns co-authored commit attribution on or off.
+func (c *Config) SetCountCoAuthoredCommits(profileName string, enabled bool) error {
+	if c.Profiles == nil || c.Profiles[profileName] == nil {
+		return fmt.Errorf("profile '%s' not found", profileName)
+	}
+	c.Profiles[profileName].CountCoAuthoredCommits = enabled
+	return nil
+}
+
+// GetSMTPConfig returns the active profile's SMTP settings, or nil.
+func (c *Config) GetSMTPConfig() *SMTPConfig {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.SMTP
+	}
+	return nil
+}
+
+// GetEffectiveSMTPPassword returns the SMTP password from the profile,
+// falling back to DEVLOG_SMTP_PASSWORD.
+func (c *Config) GetEffectiveSMTPPassword() string {
+	if smtp := c.GetSMTPConfig(); smtp != nil && smtp.Password != "" {
+		return smtp.Password
+	}
+	return os.Getenv("DEVLOG_SMTP_PASSWORD")
+}
+
+// GetConfluenceConfig returns the active profile's Confluence settings, or nil.
+func (c *Config) GetConfluenceConfig() *ConfluenceConfig {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.Confluence
+	}
+	return nil
+}
+
+// GetEffectiveConfluenceToken returns the Confluence API token from the
+// profile, falling back to DEVLOG_CONFLUENCE_TOKEN.
+func (c *Config) GetEffectiveConfluenceToken() string {
+	if confluence := c.GetConfluenceConfig(); confluence != nil && confluence.APIToken != "" {
+		return confluence.APIToken
+	}
+	return os.Getenv("DEVLOG_CONFLUENCE_TOKEN")
+}
+
+// GetProfileStorage returns where a profile keeps its data, StorageDuckDB
+// unless it sets storage, and for StoragePostgres the connection URL from
+// postgres_url, falling back to DEVLOG_POSTGRES_URL.
+func (c *Config) GetProfileStorage(name string) (storage, postgresURL string) {
+	storage = StorageDuckDB
+	if p := c.Profiles[name]; p != nil {
+		if s := strings.ToLower(strings.TrimSpace(p.Storage)); s != "" {
+			storage = s
+		}
+		postgresURL = p.PostgresURL
+	}
+	if postgresURL == "" {
+		postgresURL = os.Getenv("DEVLOG_POSTGRES_URL")
+	}
+	return storage, postgresURL
+}
+
 // ── Per-profile LLM config helpers ─────────────────────────────────────────
 // LLM configuration lives exclusively on Profile. These helpers read from
 // the active profile, with environment-variable fallback for API keys.
@@ -644,35 +1320,33 @@ func (c *Config) GetEffectiveModel() string {
 	return ""
 }
 
+// GetCommitSummaryModel returns the active profile's model override for
+// commit summaries, or "" to use the default model.
+func (c *Config) GetCommitSummaryModel() string {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.CommitSummaryModel
+	}
+	return ""
+}
+
+// GetFileSummaryModel returns the active profile's model override for file
+// summaries during indexing, or "" to use the default model.
+func (c *Config) GetFileSummaryModel() string {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.FileSummaryModel
+	}
+	return ""
+}
+
 // GetEffectiveAPIKey returns the API key for a provider from the active profile,
-// falling back to environment variables.
+// falling back to environment variables. A keychain: or secrets: reference in
+// the profile is resolved (see ResolveSecret); one that cannot be resolved
+// counts as unset, so code creating a client checks CheckSecretReferences
+// first to report why.
 func (c *Config) GetEffectiveAPIKey(provider string) string {
 	if p := c.GetActiveProfile(); p != nil {
-		switch provider {
-		case "anthropic":
-			if p.AnthropicAPIKey != "" {
-				return p.AnthropicAPIKey
-			}
-		case "openai":
-			if p.OpenAIAPIKey != "" {
-				return p.OpenAIAPIKey
-			}
-		case "chatgpt":
-			if p.ChatGPTAccessToken != "" {
-				return p.ChatGPTAccessToken
-			}
-		case "openrouter":
-			if p.OpenRouterAPIKey != "" {
-				return p.OpenRouterAPIKey
-			}
-		case "gemini":
-			if p.GeminiAPIKey != "" {
-				return p.GeminiAPIKey
-			}
-		case "bedrock":
-			if p.AWSAccessKeyID != "" {
-				return p.AWSAccessKeyID
-			}
+		if key, err := ResolveSecret(profileAPIKey(p, provider)); err == nil && key != "" {
+			return key
 		}
 	}
 	// Fall back to environment variables
@@ -687,11 +1361,55 @@ func (c *Config) GetEffectiveAPIKey(provider string) string {
 		return os.Getenv("OPENROUTER_API_KEY")
 	case "gemini":
 		return os.Getenv("GEMINI_API_KEY")
+	case "azure":
+		return os.Getenv("AZURE_OPENAI_API_KEY")
 	default:
 		return ""
 	}
 }
 
+// profileAPIKey returns the API key field a profile holds for a provider,
+// as stored (possibly a secret reference).
+func profileAPIKey(p *Profile, provider string) string {
+	switch provider {
+	case "anthropic":
+		return p.AnthropicAPIKey
+	case "openai":
+		return p.OpenAIAPIKey
+	case "chatgpt":
+		return p.ChatGPTAccessToken
+	case "openrouter":
+		return p.OpenRouterAPIKey
+	case "gemini":
+		return p.GeminiAPIKey
+	case "bedrock":
+		return p.AWSAccessKeyID
+	case "azure":
+		return p.AzureOpenAIAPIKey
+	}
+	return ""
+}
+
+// CheckSecretReferences resolves the secret references among the active
+// profile's credentials for a provider and returns the first failure, or
+// nil when they resolve or none are used.
+func (c *Config) CheckSecretReferences(provider string) error {
+	p := c.GetActiveProfile()
+	if p == nil {
+		return nil
+	}
+	values := []string{profileAPIKey(p, provider)}
+	if provider == "bedrock" {
+		values = append(values, p.AWSSecretAccessKey)
+	}
+	for _, v := range values {
+		if _, err := ResolveSecret(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetEffectiveChatGPTRefreshToken returns the ChatGPT refresh token for the active profile.
 func (c *Config) GetEffectiveChatGPTRefreshToken() string {
 	if p := c.GetActiveProfile(); p != nil && p.ChatGPTRefreshToken != "" {
@@ -716,6 +1434,14 @@ func (c *Config) GetEffectiveOllamaModel() string {
 	return ""
 }
 
+// GetEffectiveOllamaEmbeddingModel returns the Ollama embedding model for the active profile.
+func (c *Config) GetEffectiveOllamaEmbeddingModel() string {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.OllamaEmbeddingModel
+	}
+	return ""
+}
+
 // GetEffectiveAWSRegion returns the AWS region for the active profile.
 func (c *Config) GetEffectiveAWSRegion() string {
 	if p := c.GetActiveProfile(); p != nil {
@@ -727,7 +1453,8 @@ func (c *Config) GetEffectiveAWSRegion() string {
 // GetEffectiveAWSAccessKeyID returns the AWS access key ID for the active profile.
 func (c *Config) GetEffectiveAWSAccessKeyID() string {
 	if p := c.GetActiveProfile(); p != nil {
-		return p.AWSAccessKeyID
+		secret, _ := ResolveSecret(p.AWSAccessKeyID)
+		return secret
 	}
 	return ""
 }
@@ -735,7 +1462,32 @@ func (c *Config) GetEffectiveAWSAccessKeyID() string {
 // GetEffectiveAWSSecretAccessKey returns the AWS secret access key for the active profile.
 func (c *Config) GetEffectiveAWSSecretAccessKey() string {
 	if p := c.GetActiveProfile(); p != nil {
-		return p.AWSSecretAccessKey
+		secret, _ := ResolveSecret(p.AWSSecretAccessKey)
+		return secret
+	}
+	return ""
+}
+
+// GetEffectiveAzureEndpoint returns the Azure OpenAI resource endpoint for the active profile.
+func (c *Config) GetEffectiveAzureEndpoint() string {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.AzureEndpoint
+	}
+	return ""
+}
+
+// GetEffectiveAzureDeployment returns the Azure OpenAI deployment name for the active profile.
+func (c *Config) GetEffectiveAzureDeployment() string {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.AzureDeployment
+	}
+	return ""
+}
+
+// GetEffectiveAzureAPIVersion returns the Azure OpenAI api-version for the active profile.
+func (c *Config) GetEffectiveAzureAPIVersion() string {
+	if p := c.GetActiveProfile(); p != nil {
+		return p.AzureAPIVersion
 	}
 	return ""
 }
@@ -786,8 +1538,13 @@ func (c *Config) CopyLLMConfigToProfile(profileName string) {
 	profile.AWSRegion = c.AWSRegion
 	profile.AWSAccessKeyID = c.AWSAccessKeyID
 	profile.AWSSecretAccessKey = c.AWSSecretAccessKey
+	profile.AzureOpenAIAPIKey = c.AzureOpenAIAPIKey
+	profile.AzureEndpoint = c.AzureEndpoint
+	profile.AzureDeployment = c.AzureDeployment
+	profile.AzureAPIVersion = c.AzureAPIVersion
 	profile.OllamaBaseURL = c.OllamaBaseURL
 	profile.OllamaModel = c.OllamaModel
+	profile.OllamaEmbeddingModel = c.OllamaEmbeddingModel
 	profile.UserName = c.UserName
 	profile.UserEmail = c.UserEmail
 	profile.GitHubUsername = c.GitHubUsername
@@ -812,8 +1569,13 @@ func (c *Config) HydrateGlobalFromActiveProfile() {
 	c.AWSRegion = p.AWSRegion
 	c.AWSAccessKeyID = p.AWSAccessKeyID
 	c.AWSSecretAccessKey = p.AWSSecretAccessKey
+	c.AzureOpenAIAPIKey = p.AzureOpenAIAPIKey
+	c.AzureEndpoint = p.AzureEndpoint
+	c.AzureDeployment = p.AzureDeployment
+	c.AzureAPIVersion = p.AzureAPIVersion
 	c.OllamaBaseURL = p.OllamaBaseURL
 	c.OllamaModel = p.OllamaModel
+	c.OllamaEmbeddingModel = p.OllamaEmbeddingModel
 	c.UserName = p.UserName
 	c.UserEmail = p.UserEmail
 	c.GitHubUsername = p.GitHubUsername
@@ -835,6 +1597,8 @@ func (c *Config) ApplyLLMConfigToAllProfiles(sourceProfileName string) error {
 		}
 		profile.DefaultProvider = src.DefaultProvider
 		profile.DefaultModel = src.DefaultModel
+		profile.CommitSummaryModel = src.CommitSummaryModel
+		profile.FileSummaryModel = src.FileSummaryModel
 		profile.AnthropicAPIKey = src.AnthropicAPIKey
 		profile.OpenAIAPIKey = src.OpenAIAPIKey
 		profile.ChatGPTAccessToken = src.ChatGPTAccessToken
@@ -844,8 +1608,13 @@ func (c *Config) ApplyLLMConfigToAllProfiles(sourceProfileName string) error {
 		profile.AWSRegion = src.AWSRegion
 		profile.AWSAccessKeyID = src.AWSAccessKeyID
 		profile.AWSSecretAccessKey = src.AWSSecretAccessKey
+		profile.AzureOpenAIAPIKey = src.AzureOpenAIAPIKey
+		profile.AzureEndpoint = src.AzureEndpoint
+		profile.AzureDeployment = src.AzureDeployment
+		profile.AzureAPIVersion = src.AzureAPIVersion
 		profile.OllamaBaseURL = src.OllamaBaseURL
 		profile.OllamaModel = src.OllamaModel
+		profile.OllamaEmbeddingModel = src.OllamaEmbeddingModel
 	}
 	return nil
 }
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ProjectConfigDir is the directory inside a repository that holds its
+// project-local devlog settings.
+const ProjectConfigDir = ".devlog"
+
+// ProjectConfig holds repository defaults read from .devlog/config.json,
+// so a repo can ship sensible devlog settings for everyone who runs it.
+//
+// Precedence, highest first: command-line flags, the project file, the
+// active profile, built-in defaults. A field that is absent (or zero) in
+// the project file leaves the profile value in place; a field that is set
+// replaces it for that invocation only. Project values are never written
+// back to ~/.devlog/config.json.
+//
+// Only the settings below can come from a project. Providers, models,
+// endpoints and credentials always come from the profile, so a cloned
+// repository cannot redirect where code and keys are sent.
+type ProjectConfig struct {
+	WorklogStyle   string   `json:"worklog_style,omitempty"`
+	SummaryMode    string   `json:"summary_mode,omitempty"`
+	IgnorePatterns []string `json:"ignore_patterns,omitempty"`
+	IndexSoftLimit int      `json:"index_soft_limit,omitempty"`
+	IndexHardLimit int      `json:"index_hard_limit,omitempty"`
+	MaxCommits     int      `json:"worklog_max_commits,omitempty"`
+	ContextLines   int      `json:"worklog_context_lines,omitempty"`
+	StripGitmoji   *bool    `json:"strip_gitmoji,omitempty"`
+	ContextFiles   []string `json:"context_files,omitempty"`
+
+	path string
+}
+
+// FindProjectConfig looks for .devlog/config.json in dir and its parents,
+// stopping at the repository root (the first directory containing .git).
+// It returns "" outside a repository or when no project file exists.
+func FindProjectConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	globalPath := GetConfigPath()
+	var found string
+	for {
+		candidate := filepath.Join(dir, ProjectConfigDir, DefaultConfigFileName)
+		if found == "" && candidate != globalPath {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				found = candidate
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return found
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectConfig reads the project file that applies to dir, returning
+// nil if there is none.
+func LoadProjectConfig(dir string) (*ProjectConfig, error) {
+	path := FindProjectConfig(dir)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read project config: %w", err)
+	}
+	project := &ProjectConfig{}
+	if err := json.Unmarshal(data, project); err != nil {
+		return nil, fmt.Errorf("parse project config %s: %w", path, err)
+	}
+	project.path = path
+	return project, nil
+}
+
+// UseProjectConfig replaces the project overrides with those of the
+// repository at dir, for commands that operate on a repo other than the
+// current directory.
+func (c *Config) UseProjectConfig(dir string) error {
+	project, err := LoadProjectConfig(dir)
+	if err != nil {
+		return err
+	}
+	c.project = project
+	return nil
+}
+
+// ProjectConfigPath returns the project file merged into this config, or
+// "" if none applies.
+func (c *Config) ProjectConfigPath() string {
+	if c.project == nil {
+		return ""
+	}
+	return c.project.path
+}
+
+// GetSummaryMode returns the project's default ingest summary mode, or ""
+// when the project does not set one.
+func (c *Config) GetSummaryMode() string {
+	if c.project != nil {
+		return c.project.SummaryMode
+	}
+	return ""
+}
+
+// GetContextFiles returns the repo-relative documents, beyond the README,
+// that seed the codebase summary: the project's list if it sets one,
+// otherwise the profile's.
+func (c *Config) GetContextFiles() []string {
+	if c.project != nil && len(c.project.ContextFiles) > 0 {
+		return c.project.ContextFiles
+	}
+	if p := c.GetActiveProfile(); p != nil {
+		return p.ContextFiles
+	}
+	return nil
+}
+
+// GetIgnorePatterns returns the project's extra glob patterns for files and
+// folders to leave out of the codebase index.
+func (c *Config) GetIgnorePatterns() []string {
+	if c.project != nil {
+		return c.project.IgnorePatterns
+	}
+	return nil
+}
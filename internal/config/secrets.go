@@ -0,0 +1,121 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Secret references. A profile secret such as anthropic_api_key may hold a
+// reference instead of the key itself, so the key can live outside
+// config.json:
+//
+//	keychain:<service>/<account>  the OS keychain (macOS Keychain, or the
+//	                              Secret Service through secret-tool on Linux)
+//	secrets:<name>                an entry in ~/.devlog/secrets.json
+const (
+	keychainSecretPrefix = "keychain:"
+	fileSecretPrefix     = "secrets:"
+)
+
+// SecretsFileName is the file under ~/.devlog holding secrets referenced
+// with secrets:<name>, as a flat JSON object of names to values.
+const SecretsFileName = "secrets.json"
+
+var (
+	resolvedSecretsMu sync.Mutex
+	resolvedSecrets   = map[string]string{}
+)
+
+// GetSecretsPath returns the path of the secrets file.
+func GetSecretsPath() string {
+	return filepath.Join(GetDevlogDir(), SecretsFileName)
+}
+
+// IsSecretReference reports whether value refers to a secret stored
+// elsewhere rather than being the secret itself.
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, keychainSecretPrefix) || strings.HasPrefix(value, fileSecretPrefix)
+}
+
+// ResolveSecret returns the secret a profile value stands for: the value
+// itself, or what a keychain: or secrets: reference points to. Resolved
+// references are remembered for the rest of the process, so the keychain
+// is asked at most once per secret.
+func ResolveSecret(value string) (string, error) {
+	if !IsSecretReference(value) {
+		return value, nil
+	}
+	resolvedSecretsMu.Lock()
+	defer resolvedSecretsMu.Unlock()
+	if secret, ok := resolvedSecrets[value]; ok {
+		return secret, nil
+	}
+
+	var secret string
+	var err error
+	if ref, ok := strings.CutPrefix(value, keychainSecretPrefix); ok {
+		secret, err = readKeychainSecret(ref)
+	} else {
+		secret, err = readFileSecret(strings.TrimPrefix(value, fileSecretPrefix))
+	}
+	if err != nil {
+		return "", err
+	}
+	resolvedSecrets[value] = secret
+	return secret, nil
+}
+
+// readKeychainSecret reads a generic password stored under service and
+// account ("<service>/<account>") from the OS keychain.
+func readKeychainSecret(ref string) (string, error) {
+	service, account, ok := strings.Cut(ref, "/")
+	if !ok || service == "" || account == "" {
+		return "", fmt.Errorf("invalid keychain reference %q (expected keychain:<service>/<account>)", keychainSecretPrefix+ref)
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("secret-tool", "lookup", "service", service, "account", account)
+	default:
+		return "", fmt.Errorf("keychain references are not supported on %s; use a secrets: reference instead", runtime.GOOS)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			err = fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("failed to read %s/%s from the keychain: %w", service, account, err)
+	}
+	secret := strings.TrimRight(string(out), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("no keychain entry for %s/%s", service, account)
+	}
+	return secret, nil
+}
+
+// readFileSecret reads one entry from the secrets file.
+func readFileSecret(name string) (string, error) {
+	path := GetSecretsPath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secrets file: %w", err)
+	}
+	var secrets map[string]string
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	secret := secrets[name]
+	if secret == "" {
+		return "", fmt.Errorf("no secret named %q in %s", name, path)
+	}
+	return secret, nil
+}
@@ -1,5 +1,9 @@
 package constants
 
+// DefaultOllamaEmbeddingModel is used for local embeddings when the profile
+// does not set ollama_embedding_model.
+const DefaultOllamaEmbeddingModel = "nomic-embed-text"
+
 type ModelConfig struct {
 	LLMModel  string
 	BaseURL   string
@@ -123,6 +127,12 @@ func GetDefaultAWSRegion() string {
 	return "us-east-1"
 }
 
+// GetDefaultAzureAPIVersion returns the Azure OpenAI api-version used when
+// none is configured.
+func GetDefaultAzureAPIVersion() string {
+	return "2024-10-21"
+}
+
 func ProviderHasModelSelection(provider Provider) bool {
 	models := GetLLMModels(provider)
 	return len(models) > 1
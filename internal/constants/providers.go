@@ -5,13 +5,14 @@ import "strings"
 type Provider string
 
 const (
-	ProviderOllama     Provider = "ollama"
-	ProviderOpenAI     Provider = "openai"
-	ProviderChatGPT    Provider = "chatgpt"
-	ProviderAnthropic  Provider = "anthropic"
-	ProviderOpenRouter Provider = "openrouter"
-	ProviderBedrock    Provider = "bedrock"
-	ProviderGemini     Provider = "gemini"
+	ProviderOllama      Provider = "ollama"
+	ProviderOpenAI      Provider = "openai"
+	ProviderChatGPT     Provider = "chatgpt"
+	ProviderAnthropic   Provider = "anthropic"
+	ProviderOpenRouter  Provider = "openrouter"
+	ProviderBedrock     Provider = "bedrock"
+	ProviderGemini      Provider = "gemini"
+	ProviderAzureOpenAI Provider = "azure"
 )
 
 type ProviderInfo struct {
@@ -64,6 +65,12 @@ var AllProviders = []ProviderInfo{
 		Description: "Claude via AWS (enterprise)",
 		SupportsLLM: true,
 	},
+	{
+		Key:         "8",
+		Name:        "Azure",
+		Description: "Azure OpenAI — GPT via your org's deployment (enterprise)",
+		SupportsLLM: true,
+	},
 }
 
 func GetProviderByKey(key string) Provider {
@@ -101,6 +108,8 @@ func ProviderDescription(provider Provider) string {
 		return "OpenRouter (unified API, multiple models)"
 	case ProviderGemini:
 		return "Google Gemini (Flash, Pro, 1M context)"
+	case ProviderAzureOpenAI:
+		return "Azure OpenAI (deployment-based GPT)"
 	default:
 		return string(provider)
 	}
@@ -170,6 +179,13 @@ func GetProviderSetupInfo(provider Provider) ProviderSetupInfo {
 			SetupHint:    "Get your API key from: aistudio.google.com/apikey",
 			NeedsAPIKey:  true,
 		}
+	case ProviderAzureOpenAI:
+		return ProviderSetupInfo{
+			APIKeyURL:   "https://portal.azure.com/",
+			Placeholder: "Azure OpenAI API key",
+			SetupHint:   "Find the endpoint and keys under your Azure OpenAI resource in portal.azure.com",
+			NeedsAPIKey: true,
+		}
 	default:
 		return ProviderSetupInfo{}
 	}
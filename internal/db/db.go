@@ -83,6 +83,24 @@ func (m *Manager) GetDBForProfile(profile string) (*sql.DB, error) {
 		delete(m.connections, profile)
 		delete(m.repositories, profile)
 	}
+	storage, postgresURL, err := profileStorage(profile)
+	if err != nil {
+		return nil, err
+	}
+	if storage == config.StoragePostgres {
+		db, err := openPostgres(postgresURL, false)
+		if err != nil {
+			return nil, err
+		}
+		db.SetMaxOpenConns(4)
+		db.SetMaxIdleConns(2)
+		if err := initializePostgresSchema(db); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("initialize schema: %w", err)
+		}
+		m.connections[profile] = db
+		return db, nil
+	}
 	dbPath := config.GetProfileDBPath(profile)
 	dir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -103,6 +121,26 @@ func (m *Manager) GetDBForProfile(profile string) (*sql.DB, error) {
 	return db, nil
 }
 
+// profileStorage returns the profile's storage backend and, for Postgres,
+// its connection URL.
+func profileStorage(profile string) (string, string, error) {
+	cfg, err := config.LoadFrom(config.GetConfigPath())
+	if err != nil {
+		return "", "", fmt.Errorf("load config: %w", err)
+	}
+	storage, postgresURL := cfg.GetProfileStorage(profile)
+	switch storage {
+	case config.StorageDuckDB:
+	case config.StoragePostgres:
+		if postgresURL == "" {
+			return "", "", fmt.Errorf("profile %q uses postgres storage but has no postgres_url (or DEVLOG_POSTGRES_URL)", profile)
+		}
+	default:
+		return "", "", fmt.Errorf("profile %q has unknown storage %q (must be %s or %s)", profile, storage, config.StorageDuckDB, config.StoragePostgres)
+	}
+	return storage, postgresURL, nil
+}
+
 // openDBWithTimeout opens the database, failing with a clear error if another process holds the lock.
 func openDBWithTimeout(dbPath string) (*sql.DB, error) {
 	type result struct {
@@ -161,6 +199,20 @@ func (m *Manager) GetRepositoryForProfile(profile string) (*SQLRepository, error
 // GetReadOnlyDBForProfile returns a read-only database connection for a specific profile.
 // This allows multiple readers without conflicting with writers.
 func (m *Manager) GetReadOnlyDBForProfile(profile string) (*sql.DB, error) {
+	storage, postgresURL, err := profileStorage(profile)
+	if err != nil {
+		return nil, err
+	}
+	if storage == config.StoragePostgres {
+		db, err := openPostgres(postgresURL, true)
+		if err != nil {
+			return nil, err
+		}
+		db.SetMaxOpenConns(10)
+		db.SetMaxIdleConns(2)
+		return db, nil
+	}
+
 	dbPath := config.GetProfileDBPath(profile)
 
 	// Check if database exists
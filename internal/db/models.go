@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"encoding/json"
+	"math"
 	"time"
 )
 
@@ -26,6 +27,7 @@ type Codebase struct {
 	ProjectContext  string         // Higher-level summary of features being worked on across branches
 	LongtermContext string         // Long-term goals and ongoing initiatives
 	TouchActivity   map[string]any // Incremental folder touch-map cache keyed by folder path
+	RemoteURL       string         // URL of the origin remote, if any
 }
 
 // Branch represents a git branch
@@ -46,6 +48,13 @@ type Branch struct {
 	ContextSummary  string // Day-by-day progress context for multi-day feature tracking
 }
 
+// Branch statuses set during ingest.
+const (
+	BranchStatusActive = "active"
+	BranchStatusMerged = "merged" // tip is reachable from the base branch
+	BranchStatusStale  = "stale"  // no commits within the stale window
+)
+
 // Commit represents a git commit
 type Commit struct {
 	ID                string
@@ -60,18 +69,35 @@ type Commit struct {
 	IsUserCommit      bool
 	IsOnDefaultBranch bool
 	ParentCount       int
+	Parents           []string // parent hashes, first parent first
 	IsMergeSync       bool
+	IsSigned          bool   // commit carries a GPG/SSH signature (not verified)
+	CommitType        string // conventional-commit type: feat, fix, chore, ...
+	IsBot             bool   // matched a bot/CI filter; hidden from worklogs by default
+	RevertsHash       string // hash of the commit this one reverts, if detected
+}
+
+// CommitCoAuthor links a commit to a developer named in one of its
+// Co-authored-by trailers.
+type CommitCoAuthor struct {
+	CodebaseID  string
+	CommitHash  string
+	DeveloperID string
+	IsUser      bool // the co-author is the profile's user
 }
 
-// FileChange represents a file change within a commit
+// FileChange represents a file change within a commit. ContentHash
+// identifies the change itself (path and changed lines), so the same change
+// replayed by a cherry-pick or squash has the same hash in every commit.
 type FileChange struct {
-	ID         string
-	CommitID   string
-	FilePath   string
-	ChangeType string
-	Additions  int
-	Deletions  int
-	Patch      string
+	ID          string
+	CommitID    string
+	FilePath    string
+	ChangeType  string
+	Additions   int
+	Deletions   int
+	Patch       string
+	ContentHash string
 }
 
 // Folder represents a folder in the codebase
@@ -107,6 +133,22 @@ type FileIndex struct {
 	IndexedAt    time.Time
 }
 
+// FileSearchResult is a file index ranked by similarity to a query embedding.
+type FileSearchResult struct {
+	FileIndex
+	Score float64
+}
+
+// FileSearchFilter narrows a semantic file search before ranking. Empty
+// fields don't filter.
+type FileSearchFilter struct {
+	// Language matches a file's language case-insensitively; "typescript"
+	// also matches variants such as "TypeScript (React)".
+	Language string
+	// PathPrefix keeps files under a repo-relative directory or path.
+	PathPrefix string
+}
+
 // IngestCursor tracks ingestion state per branch
 type IngestCursor struct {
 	ID             string
@@ -179,6 +221,29 @@ type WorklogExportState struct {
 	ExportedAt  time.Time
 }
 
+// TodoMarker is a TODO, FIXME or HACK comment found in an indexed file.
+type TodoMarker struct {
+	CodebaseID string
+	FilePath   string
+	Line       int
+	Kind       string
+	Text       string
+}
+
+// TodoSnapshot is a codebase's marker totals as of one index run.
+type TodoSnapshot struct {
+	CodebaseID string
+	TakenAt    time.Time
+	Todo       int
+	Fixme      int
+	Hack       int
+}
+
+// Total is the number of markers of every kind.
+func (s TodoSnapshot) Total() int {
+	return s.Todo + s.Fixme + s.Hack
+}
+
 // JSON is a type alias for map[string]any used for JSON columns
 type JSON = map[string]any
 
@@ -272,6 +337,9 @@ func convertToIntMap(v any) map[string]int {
 	case string:
 		// Fallback to string parsing
 		return FromJSONIntMap(m)
+	case []byte:
+		// Postgres returns JSON as raw bytes
+		return FromJSONIntMap(string(m))
 	}
 	return nil
 }
@@ -287,6 +355,8 @@ func convertToMap(v any) map[string]any {
 		return m
 	case string:
 		return FromJSON(m)
+	case []byte:
+		return FromJSON(string(m))
 	}
 	return nil
 }
@@ -308,6 +378,57 @@ func convertToStringSlice(v any) []string {
 		return result
 	case string:
 		return FromJSONStringSlice(s)
+	case []byte:
+		return FromJSONStringSlice(string(s))
 	}
 	return nil
 }
+
+// convertToFloatSlice converts a DuckDB JSON array to []float64
+func convertToFloatSlice(v any) []float64 {
+	if v == nil {
+		return nil
+	}
+
+	switch s := v.(type) {
+	case []any:
+		result := make([]float64, 0, len(s))
+		for _, item := range s {
+			if f, ok := item.(float64); ok {
+				result = append(result, f)
+			}
+		}
+		return result
+	case string:
+		var result []float64
+		if err := json.Unmarshal([]byte(s), &result); err != nil {
+			return nil
+		}
+		return result
+	case []byte:
+		var result []float64
+		if err := json.Unmarshal(s, &result); err != nil {
+			return nil
+		}
+		return result
+	}
+	return nil
+}
+
+// cosineSimilarity returns the cosine similarity of two vectors, or 0 when
+// their dimensions differ (e.g. embeddings from a different model).
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/ishaan812/devlog/internal/config"
+)
+
+// postgresConnectTimeout is how long we wait for a Postgres server to answer.
+const postgresConnectTimeout = 10 * time.Second
+
+// postgresTimestampRE matches the schema's TIMESTAMP columns. Postgres drops
+// the zone from plain TIMESTAMP values, so they are created as TIMESTAMPTZ.
+var postgresTimestampRE = regexp.MustCompile(`\bTIMESTAMP\b`)
+
+// openPostgres connects to the Postgres database at url. A read-only
+// connection refuses writes at the server, like DuckDB's read_only mode.
+func openPostgres(url string, readOnly bool) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid postgres_url: %w", err)
+	}
+	if connConfig.ConnectTimeout == 0 {
+		connConfig.ConnectTimeout = postgresConnectTimeout
+	}
+	if readOnly {
+		connConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	}
+	db := stdlib.OpenDB(*connConfig)
+	ctx, cancel := context.WithTimeout(context.Background(), postgresConnectTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connect to postgres: %w", err)
+	}
+	return db, nil
+}
+
+// initializePostgresSchema creates the tables on a Postgres server. The
+// DuckDB schema is used as-is apart from its timestamp columns.
+func initializePostgresSchema(db *sql.DB) error {
+	if _, err := db.Exec(postgresTimestampRE.ReplaceAllString(Schema, "TIMESTAMPTZ")); err != nil {
+		return fmt.Errorf("execute schema: %w", err)
+	}
+	for _, m := range Migrations {
+		_, _ = db.Exec(postgresTimestampRE.ReplaceAllString(m, "TIMESTAMPTZ"))
+	}
+	return nil
+}
+
+// postgresIngestLockKey names the advisory lock held while ingesting into a
+// Postgres database. It covers the whole database, so ingests into one shared
+// team database run one at a time whichever machine starts them.
+const postgresIngestLockKey = "devlog-ingest"
+
+// AcquireSharedIngestLock takes the ingest lock on the profile's Postgres
+// database, returning a function that releases it. The lock file used for
+// DuckDB only guards one machine, so for a shared database the lock is a
+// Postgres advisory lock held on its own connection; the server drops it if
+// devlog dies mid-ingest. DuckDB profiles need nothing and get a no-op.
+func AcquireSharedIngestLock(profile string) (release func(), err error) {
+	storage, postgresURL, err := profileStorage(profile)
+	if err != nil {
+		return nil, err
+	}
+	if storage != config.StoragePostgres {
+		return func() {}, nil
+	}
+	db, err := openPostgres(postgresURL, false)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), postgresConnectTimeout)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connect to postgres: %w", err)
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", postgresIngestLockKey).Scan(&acquired); err != nil {
+		conn.Close()
+		db.Close()
+		return nil, fmt.Errorf("acquire postgres ingest lock: %w", err)
+	}
+	if !acquired {
+		conn.Close()
+		db.Close()
+		return nil, fmt.Errorf("another 'devlog ingest' is writing to this profile's Postgres database (possibly from another machine); try again when it finishes")
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), postgresConnectTimeout)
+		defer cancel()
+		_, _ = conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1))", postgresIngestLockKey)
+		conn.Close()
+		db.Close()
+	}, nil
+}
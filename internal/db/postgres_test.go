@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ishaan812/devlog/internal/config"
+)
+
+// postgresTestURL returns the URL of a scratch schema on the server named by
+// DEVLOG_TEST_POSTGRES_URL, skipping the test when it is unset. The schema is
+// dropped when the test ends, so runs don't see each other's rows.
+func postgresTestURL(t *testing.T) string {
+	t.Helper()
+	base := os.Getenv("DEVLOG_TEST_POSTGRES_URL")
+	if base == "" {
+		t.Skip("DEVLOG_TEST_POSTGRES_URL not set")
+	}
+	admin, err := openPostgres(base, false)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("devlog_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() { _, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	switch {
+	case !strings.Contains(base, "://"):
+		return base + " search_path=" + schema
+	case strings.Contains(base, "?"):
+		return base + "&search_path=" + schema
+	default:
+		return base + "?search_path=" + schema
+	}
+}
+
+// usePostgresProfile points a fresh HOME at a config whose "team" profile
+// stores its data at url.
+func usePostgresProfile(t *testing.T, url string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".devlog"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{
+		ActiveProfile: "team",
+		Profiles: map[string]*config.Profile{
+			"team": {Storage: config.StoragePostgres, PostgresURL: url},
+		},
+	}
+	if err := cfg.SaveTo(config.GetConfigPath()); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+}
+
+func TestPostgresRepository(t *testing.T) {
+	usePostgresProfile(t, postgresTestURL(t))
+	ctx := context.Background()
+
+	// Opening the profile creates the schema; reopening must find it intact.
+	m := NewManager()
+	if _, err := m.GetRepositoryForProfile("team"); err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	m.CloseDB("team")
+	repo, err := m.GetRepositoryForProfile("team")
+	if err != nil {
+		t.Fatalf("reopen repository: %v", err)
+	}
+	t.Cleanup(func() { m.CloseDB("team") })
+
+	if err := repo.UpsertCodebase(ctx, &Codebase{ID: "cb", Path: "/tmp/repo", Name: "repo"}); err != nil {
+		t.Fatalf("upsert codebase: %v", err)
+	}
+	committedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, message := range []string{"feat: first", "feat: first (re-ingested)"} {
+		commit := &Commit{ID: fmt.Sprintf("c%d", i), Hash: "abc123", CodebaseID: "cb", AuthorEmail: "me@x.com",
+			Message: message, CommittedAt: committedAt, ParentCount: 1, IsUserCommit: true}
+		if err := repo.UpsertCommitWithFileChanges(ctx, commit, []*FileChange{
+			{ID: fmt.Sprintf("fc%d", i), FilePath: "a.go", ChangeType: "modified", Additions: 3, Deletions: 1},
+		}); err != nil {
+			t.Fatalf("upsert commit %d: %v", i, err)
+		}
+	}
+	commits, err := repo.GetCommitsBetweenDates(ctx, "cb", committedAt.Add(-time.Hour), committedAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("get commits: %v", err)
+	}
+	if len(commits) != 1 || commits[0].Message != "feat: first (re-ingested)" || !commits[0].CommittedAt.Equal(committedAt) {
+		t.Fatalf("commits = %+v, want the re-ingested commit at %s", commits, committedAt)
+	}
+
+	entry := &WorklogEntry{ID: "w1", CodebaseID: "cb", ProfileName: "team", EntryDate: committedAt,
+		EntryType: "day_updates", GroupBy: "date", Content: "Shipped a", CommitCount: 1,
+		Additions: 3, Deletions: 1, CommitHashes: "abc123", CreatedAt: time.Now()}
+	if err := repo.UpsertWorklogEntry(ctx, entry); err != nil {
+		t.Fatalf("upsert worklog entry: %v", err)
+	}
+	got, err := repo.GetWorklogEntry(ctx, "cb", "team", committedAt, "", "day_updates", "date")
+	if err != nil || got == nil || got.Content != "Shipped a" {
+		t.Fatalf("GetWorklogEntry = %+v, %v; want the stored entry", got, err)
+	}
+	entries, err := repo.ListWorklogEntriesByDate(ctx, "cb", "team", committedAt)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ListWorklogEntriesByDate = %+v, %v; want one entry", entries, err)
+	}
+}
+
+func TestPostgresIngestLock(t *testing.T) {
+	usePostgresProfile(t, postgresTestURL(t))
+
+	release, err := AcquireSharedIngestLock("team")
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+	// A second ingest, as from another machine, is refused while it is held.
+	if _, err := AcquireSharedIngestLock("team"); err == nil || !strings.Contains(err.Error(), "another 'devlog ingest'") {
+		t.Fatalf("second acquire err = %v, want the lock to be held", err)
+	}
+	release()
+
+	release, err = AcquireSharedIngestLock("team")
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	release()
+}
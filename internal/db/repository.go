@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	pathpkg "path"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -22,6 +25,7 @@ type Repository interface {
 	GetDeveloperByEmail(ctx context.Context, email string) (*Developer, error)
 	SetCurrentUser(ctx context.Context, email string) error
 	GetCurrentUser(ctx context.Context) (*Developer, error)
+	ListDevelopers(ctx context.Context) ([]Developer, error)
 
 	// Codebase operations
 	// -------------------
@@ -29,6 +33,7 @@ type Repository interface {
 	GetCodebaseByPath(ctx context.Context, path string) (*Codebase, error)
 	GetCodebaseByID(ctx context.Context, id string) (*Codebase, error)
 	GetAllCodebases(ctx context.Context) ([]Codebase, error)
+	DeleteCodebase(ctx context.Context, codebaseID string) error
 
 	// Branch operations
 	// -----------------
@@ -44,12 +49,21 @@ type Repository interface {
 	// Commit operations
 	// -----------------
 	UpsertCommit(ctx context.Context, commit *Commit) error
+	UpsertCommitWithFileChanges(ctx context.Context, commit *Commit, fileChanges []*FileChange) error
 	CommitExists(ctx context.Context, codebaseID, hash string) (bool, error)
 	GetExistingCommitHashes(ctx context.Context, codebaseID string) (map[string]bool, error)
 	GetUserCommitsMissingSummaries(ctx context.Context, codebaseID string) ([]Commit, error)
 	UpdateCommitSummary(ctx context.Context, commitID, summary string) error
+	GetCommitHashesMissingParents(ctx context.Context, codebaseID string) ([]string, error)
+	UpdateCommitParents(ctx context.Context, codebaseID, hash string, parents []string) error
+	GetCommitHashesMissingSignature(ctx context.Context, codebaseID string) ([]string, error)
+	UpdateCommitSigned(ctx context.Context, codebaseID, hash string, signed bool) error
+	GetBotCommits(ctx context.Context, codebaseID string) ([]Commit, error)
+	ClearCommitBotFlag(ctx context.Context, codebaseID, hash string) error
 	GetCommitByHash(ctx context.Context, codebaseID, hash string) (*Commit, error)
+	FindCommitsByHashPrefix(ctx context.Context, codebaseID, prefix string, limit int) ([]Commit, error)
 	GetUserCommits(ctx context.Context, codebaseID string, since time.Time) ([]Commit, error)
+	SearchCommits(ctx context.Context, codebaseID, text, author string, since time.Time, limit int) ([]Commit, error)
 	GetCommitCount(ctx context.Context, codebaseID string) (int64, error)
 	GetCommitCountByPath(ctx context.Context, repoPath string) (int64, error)
 	GetEarliestCommitDate(ctx context.Context, codebaseID string) (time.Time, error)
@@ -75,11 +89,19 @@ type Repository interface {
 
 	// File index operations
 	UpsertFileIndex(ctx context.Context, file *FileIndex) error
+	AddFileChurn(ctx context.Context, codebaseID string, committedAt time.Time, churn map[string]int) error
+	GetFileChurnSinceSummary(ctx context.Context, codebaseID string) (map[string]int, error)
+	MarkFileSummarized(ctx context.Context, codebaseID, path string) error
 	GetFilesByCodebase(ctx context.Context, codebaseID string) ([]FileIndex, error)
 	GetExistingFileHashes(ctx context.Context, codebaseID string) (map[string]ExistingFileInfo, error)
+	GetFilePurposes(ctx context.Context, codebaseID string) (map[string]string, error)
 	DeleteFileIndex(ctx context.Context, codebaseID, path string) error
 	DeleteFileIndexesByPaths(ctx context.Context, codebaseID string, paths []string) error
 	GetFilesByFolder(ctx context.Context, folderID string) ([]FileIndex, error)
+	GetFileIndexByPath(ctx context.Context, codebaseID, path string) (*FileIndex, error)
+	GetFileDependents(ctx context.Context, codebaseID, path string) ([]string, error)
+	UpdateFileEmbedding(ctx context.Context, fileID string, embedding []float64) error
+	SemanticSearchFiles(ctx context.Context, codebaseID string, query []float64, filter FileSearchFilter, limit int) ([]FileSearchResult, error)
 
 	// Codebase statistics
 	// -----------------------------------------
@@ -91,8 +113,8 @@ type Repository interface {
 	GetWorklogEntry(ctx context.Context, codebaseID, profile string, date time.Time, branchID, entryType, groupBy string) (*WorklogEntry, error)
 	ListWorklogEntriesByDate(ctx context.Context, codebaseID, profile string, date time.Time) ([]WorklogEntry, error)
 	ListWorklogDates(ctx context.Context, codebaseID, profile string) ([]WorklogDateInfo, error)
-	ListWorklogWeeks(ctx context.Context, codebaseID, profile string) ([]WorklogWeekInfo, error)
-	ListWorklogMonths(ctx context.Context, codebaseID, profile string) ([]WorklogMonthInfo, error)
+	ListWorklogWeeks(ctx context.Context, codebaseID, profile string, weekStart time.Weekday) ([]WorklogWeekInfo, error)
+	ListWorklogMonths(ctx context.Context, codebaseID, profile string, weekStart time.Weekday) ([]WorklogMonthInfo, error)
 	ListWorklogEntriesForExport(ctx context.Context, codebaseID, profile string) ([]WorklogEntry, error)
 	GetWeeklySummary(ctx context.Context, codebaseID, profile string, weekStart time.Time) (*WorklogEntry, error)
 	GetWeeklySummariesInRange(ctx context.Context, codebaseID, profile string, startDate, endDate time.Time) ([]WorklogEntry, error)
@@ -102,6 +124,13 @@ type Repository interface {
 	DeleteWorklogEntry(ctx context.Context, entryID string) error
 	DeleteWorklogEntriesByCodebase(ctx context.Context, codebaseID string) error
 
+	// Todo marker operations
+	// ----------------------
+	ReplaceTodoMarkers(ctx context.Context, codebaseID string, markers []TodoMarker) error
+	GetTodoMarkers(ctx context.Context, codebaseID string) ([]TodoMarker, error)
+	AddTodoSnapshot(ctx context.Context, snapshot *TodoSnapshot) error
+	GetTodoSnapshots(ctx context.Context, codebaseID string) ([]TodoSnapshot, error)
+
 	// Raw query operations
 	// --------------------
 	ExecuteQuery(ctx context.Context, query string) ([]map[string]any, error)
@@ -182,19 +211,41 @@ func (r *SQLRepository) GetCurrentUser(ctx context.Context) (*Developer, error)
 	return dev, nil
 }
 
+// ListDevelopers retrieves every known developer.
+func (r *SQLRepository) ListDevelopers(ctx context.Context) ([]Developer, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, email, is_current_user FROM developers ORDER BY email`)
+	if err != nil {
+		return nil, fmt.Errorf("query developers: %w", err)
+	}
+	defer rows.Close()
+	var devs []Developer
+	for rows.Next() {
+		var dev Developer
+		if err := rows.Scan(&dev.ID, &dev.Name, &dev.Email, &dev.IsCurrentUser); err != nil {
+			return nil, fmt.Errorf("scan developer: %w", err)
+		}
+		devs = append(devs, dev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate developers: %w", err)
+	}
+	return devs, nil
+}
+
 // UpsertCodebase creates or updates a codebase.
 func (r *SQLRepository) UpsertCodebase(ctx context.Context, codebase *Codebase) error {
 	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO codebases (id, path, name, summary, tech_stack, default_branch, indexed_at, project_context, longterm_context, touch_activity)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO codebases (id, path, name, summary, tech_stack, default_branch, indexed_at, project_context, longterm_context, touch_activity, remote_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (path) DO UPDATE SET
 			name = EXCLUDED.name, summary = EXCLUDED.summary, tech_stack = EXCLUDED.tech_stack,
 			default_branch = EXCLUDED.default_branch, indexed_at = EXCLUDED.indexed_at,
 			project_context = EXCLUDED.project_context, longterm_context = EXCLUDED.longterm_context,
-			touch_activity = EXCLUDED.touch_activity`,
+			touch_activity = EXCLUDED.touch_activity, remote_url = EXCLUDED.remote_url`,
 		codebase.ID, codebase.Path, codebase.Name, NullString(codebase.Summary),
 		ToJSON(codebase.TechStack), NullString(codebase.DefaultBranch), NullTime(codebase.IndexedAt),
-		NullString(codebase.ProjectContext), NullString(codebase.LongtermContext), ToJSON(codebase.TouchActivity))
+		NullString(codebase.ProjectContext), NullString(codebase.LongtermContext), ToJSON(codebase.TouchActivity),
+		NullString(codebase.RemoteURL))
 	if err != nil {
 		return fmt.Errorf("upsert codebase: %w", err)
 	}
@@ -203,22 +254,22 @@ func (r *SQLRepository) UpsertCodebase(ctx context.Context, codebase *Codebase)
 
 // GetCodebaseByPath retrieves a codebase by path.
 func (r *SQLRepository) GetCodebaseByPath(ctx context.Context, path string) (*Codebase, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, path, name, summary, tech_stack, default_branch, indexed_at, project_context, longterm_context, touch_activity FROM codebases WHERE path = $1`, path)
+	row := r.db.QueryRowContext(ctx, `SELECT id, path, name, summary, tech_stack, default_branch, indexed_at, project_context, longterm_context, touch_activity, remote_url FROM codebases WHERE path = $1`, path)
 	return r.scanCodebase(row)
 }
 
 // GetCodebaseByID retrieves a codebase by ID.
 func (r *SQLRepository) GetCodebaseByID(ctx context.Context, id string) (*Codebase, error) {
-	row := r.db.QueryRowContext(ctx, `SELECT id, path, name, summary, tech_stack, default_branch, indexed_at, project_context, longterm_context, touch_activity FROM codebases WHERE id = $1`, id)
+	row := r.db.QueryRowContext(ctx, `SELECT id, path, name, summary, tech_stack, default_branch, indexed_at, project_context, longterm_context, touch_activity, remote_url FROM codebases WHERE id = $1`, id)
 	return r.scanCodebase(row)
 }
 
 func (r *SQLRepository) scanCodebase(row *sql.Row) (*Codebase, error) {
 	c := &Codebase{}
-	var summary, defaultBranch, projectContext, longtermContext sql.NullString
+	var summary, defaultBranch, projectContext, longtermContext, remoteURL sql.NullString
 	var techStack, touchActivity any
 	var indexedAt sql.NullTime
-	err := row.Scan(&c.ID, &c.Path, &c.Name, &summary, &techStack, &defaultBranch, &indexedAt, &projectContext, &longtermContext, &touchActivity)
+	err := row.Scan(&c.ID, &c.Path, &c.Name, &summary, &techStack, &defaultBranch, &indexedAt, &projectContext, &longtermContext, &touchActivity, &remoteURL)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -234,12 +285,13 @@ func (r *SQLRepository) scanCodebase(row *sql.Row) (*Codebase, error) {
 	c.ProjectContext = projectContext.String
 	c.LongtermContext = longtermContext.String
 	c.TouchActivity = convertToMap(touchActivity)
+	c.RemoteURL = remoteURL.String
 	return c, nil
 }
 
 // GetAllCodebases retrieves all codebases.
 func (r *SQLRepository) GetAllCodebases(ctx context.Context) ([]Codebase, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, path, name, summary, tech_stack, default_branch, indexed_at, project_context, longterm_context, touch_activity FROM codebases ORDER BY name`)
+	rows, err := r.db.QueryContext(ctx, `SELECT id, path, name, summary, tech_stack, default_branch, indexed_at, project_context, longterm_context, touch_activity, remote_url FROM codebases ORDER BY name`)
 	if err != nil {
 		return nil, fmt.Errorf("query codebases: %w", err)
 	}
@@ -247,10 +299,10 @@ func (r *SQLRepository) GetAllCodebases(ctx context.Context) ([]Codebase, error)
 	var codebases []Codebase
 	for rows.Next() {
 		c := Codebase{}
-		var summary, defaultBranch, projectContext, longtermContext sql.NullString
+		var summary, defaultBranch, projectContext, longtermContext, remoteURL sql.NullString
 		var techStack, touchActivity any
 		var indexedAt sql.NullTime
-		if err := rows.Scan(&c.ID, &c.Path, &c.Name, &summary, &techStack, &defaultBranch, &indexedAt, &projectContext, &longtermContext, &touchActivity); err != nil {
+		if err := rows.Scan(&c.ID, &c.Path, &c.Name, &summary, &techStack, &defaultBranch, &indexedAt, &projectContext, &longtermContext, &touchActivity, &remoteURL); err != nil {
 			return nil, fmt.Errorf("scan codebase row: %w", err)
 		}
 		c.Summary = summary.String
@@ -262,6 +314,7 @@ func (r *SQLRepository) GetAllCodebases(ctx context.Context) ([]Codebase, error)
 		c.ProjectContext = projectContext.String
 		c.LongtermContext = longtermContext.String
 		c.TouchActivity = convertToMap(touchActivity)
+		c.RemoteURL = remoteURL.String
 		codebases = append(codebases, c)
 	}
 	if err := rows.Err(); err != nil {
@@ -270,6 +323,32 @@ func (r *SQLRepository) GetAllCodebases(ctx context.Context) ([]Codebase, error)
 	return codebases, nil
 }
 
+// DeleteCodebase deletes a codebase and everything ingested or generated for
+// it: commits, file changes, co-authors, branches, indexes and cached worklogs.
+func (r *SQLRepository) DeleteCodebase(ctx context.Context, codebaseID string) error {
+	// Children first; DuckDB enforces the foreign keys on delete.
+	statements := []string{
+		`DELETE FROM worklog_export_state WHERE codebase_id = $1`,
+		`DELETE FROM todo_markers WHERE codebase_id = $1`,
+		`DELETE FROM todo_snapshots WHERE codebase_id = $1`,
+		`DELETE FROM worklog_entries WHERE codebase_id = $1`,
+		`DELETE FROM file_changes WHERE commit_id IN (SELECT id FROM commits WHERE codebase_id = $1)`,
+		`DELETE FROM commit_coauthors WHERE codebase_id = $1`,
+		`DELETE FROM ingest_cursors WHERE codebase_id = $1`,
+		`DELETE FROM commits WHERE codebase_id = $1`,
+		`DELETE FROM branches WHERE codebase_id = $1`,
+		`DELETE FROM file_indexes WHERE codebase_id = $1`,
+		`DELETE FROM folders WHERE codebase_id = $1`,
+		`DELETE FROM codebases WHERE id = $1`,
+	}
+	for _, stmt := range statements {
+		if _, err := r.db.ExecContext(ctx, stmt, codebaseID); err != nil {
+			return fmt.Errorf("delete codebase %s: %w", codebaseID, err)
+		}
+	}
+	return nil
+}
+
 // UpsertBranch creates or updates a branch.
 func (r *SQLRepository) UpsertBranch(ctx context.Context, branch *Branch) error {
 	_, err := r.db.ExecContext(ctx, `
@@ -382,7 +461,7 @@ func (r *SQLRepository) GetBranchesByCodebase(ctx context.Context, codebaseID st
 func (r *SQLRepository) GetBranchCommits(ctx context.Context, branchID string, limit int) ([]Commit, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, hash, codebase_id, branch_id, author_email, message, summary,
-			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync
+			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync, is_signed, commit_type, is_bot, reverts_hash, parents
 		FROM commits WHERE branch_id = $1 ORDER BY committed_at DESC LIMIT $2`, branchID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("query branch commits: %w", err)
@@ -425,17 +504,90 @@ func (r *SQLRepository) UpsertCommit(ctx context.Context, commit *Commit) error
 	}
 	_, err := r.db.ExecContext(ctx, `
 		INSERT INTO commits (id, hash, codebase_id, branch_id, author_email, message, summary,
-			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync, is_signed, commit_type, is_bot, reverts_hash, parents, signature_checked)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, TRUE)`,
 		commit.ID, commit.Hash, commit.CodebaseID, NullString(commit.BranchID), commit.AuthorEmail,
 		commit.Message, NullString(commit.Summary), commit.CommittedAt, ToJSON(commit.Stats),
-		commit.IsUserCommit, commit.IsOnDefaultBranch, commit.ParentCount, commit.IsMergeSync)
+		commit.IsUserCommit, commit.IsOnDefaultBranch, commit.ParentCount, commit.IsMergeSync, commit.IsSigned, commit.CommitType, commit.IsBot, commit.RevertsHash, ToJSON(commit.Parents))
 	if err != nil {
 		return fmt.Errorf("insert commit: %w", err)
 	}
 	return nil
 }
 
+// UpsertCommitWithFileChanges replaces any stored copy of a commit, then
+// stores the commit and its file changes in a single transaction, so a
+// commit row is never persisted without its file changes.
+func (r *SQLRepository) UpsertCommitWithFileChanges(ctx context.Context, commit *Commit, fileChanges []*FileChange) error {
+	// DuckDB checks foreign keys eagerly, so a commit can't be deleted in the
+	// transaction that deletes its file changes. The old rows go first, each
+	// in its own statement; a commit lost to a failure in between is simply
+	// ingested again on the next run.
+	if _, err := r.db.ExecContext(ctx, `
+		DELETE FROM file_changes WHERE commit_id IN (
+			SELECT id FROM commits WHERE codebase_id = $1 AND hash = $2)`,
+		commit.CodebaseID, commit.Hash); err != nil {
+		return fmt.Errorf("delete existing file changes: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM commits WHERE codebase_id = $1 AND hash = $2`, commit.CodebaseID, commit.Hash); err != nil {
+		return fmt.Errorf("delete existing commit: %w", err)
+	}
+	return Transaction(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO commits (id, hash, codebase_id, branch_id, author_email, message, summary,
+				committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync, is_signed, commit_type, is_bot, reverts_hash, parents, signature_checked)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, TRUE)`,
+			commit.ID, commit.Hash, commit.CodebaseID, NullString(commit.BranchID), commit.AuthorEmail,
+			commit.Message, NullString(commit.Summary), commit.CommittedAt, ToJSON(commit.Stats),
+			commit.IsUserCommit, commit.IsOnDefaultBranch, commit.ParentCount, commit.IsMergeSync, commit.IsSigned, commit.CommitType, commit.IsBot, commit.RevertsHash, ToJSON(commit.Parents)); err != nil {
+			return fmt.Errorf("insert commit: %w", err)
+		}
+		for _, fc := range fileChanges {
+			fc.CommitID = commit.ID
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO file_changes (id, commit_id, file_path, change_type, additions, deletions, patch, content_hash)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT DO NOTHING`,
+				fc.ID, fc.CommitID, fc.FilePath, fc.ChangeType, fc.Additions, fc.Deletions, NullString(fc.Patch), fc.ContentHash); err != nil {
+				return fmt.Errorf("create file change: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ReplaceCommitCoAuthors stores the co-authors of a commit, replacing any
+// recorded earlier.
+func (r *SQLRepository) ReplaceCommitCoAuthors(ctx context.Context, codebaseID, commitHash string, coAuthors []CommitCoAuthor) error {
+	return Transaction(ctx, r.db, func(tx *sql.Tx) error {
+		// DuckDB still sees a key deleted earlier in the same transaction
+		// as a conflict, so only co-authors no longer listed are deleted
+		// and the rest are updated in place.
+		query := `DELETE FROM commit_coauthors WHERE codebase_id = $1 AND commit_hash = $2`
+		args := []any{codebaseID, commitHash}
+		if len(coAuthors) > 0 {
+			placeholders := make([]string, len(coAuthors))
+			for i, ca := range coAuthors {
+				args = append(args, ca.DeveloperID)
+				placeholders[i] = fmt.Sprintf("$%d", len(args))
+			}
+			query += " AND developer_id NOT IN (" + strings.Join(placeholders, ", ") + ")"
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("delete commit co-authors: %w", err)
+		}
+		for _, ca := range coAuthors {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO commit_coauthors (codebase_id, commit_hash, developer_id, is_user)
+				VALUES ($1, $2, $3, $4)
+				ON CONFLICT (codebase_id, commit_hash, developer_id) DO UPDATE SET is_user = EXCLUDED.is_user`,
+				codebaseID, commitHash, ca.DeveloperID, ca.IsUser); err != nil {
+				return fmt.Errorf("insert commit co-author: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
 // CommitExists checks if a commit exists.
 func (r *SQLRepository) CommitExists(ctx context.Context, codebaseID, hash string) (bool, error) {
 	var count int
@@ -471,8 +623,8 @@ func (r *SQLRepository) GetExistingCommitHashes(ctx context.Context, codebaseID
 func (r *SQLRepository) GetUserCommitsMissingSummaries(ctx context.Context, codebaseID string) ([]Commit, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, hash, codebase_id, branch_id, author_email, message, summary,
-			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync
-		FROM commits WHERE codebase_id = $1 AND is_user_commit = TRUE AND (summary IS NULL OR summary = '')
+			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync, is_signed, commit_type, is_bot, reverts_hash, parents
+		FROM commits WHERE codebase_id = $1 AND is_user_commit = TRUE AND is_bot = FALSE AND (summary IS NULL OR summary = '')
 		ORDER BY committed_at DESC`, codebaseID)
 	if err != nil {
 		return nil, fmt.Errorf("query commits missing summaries: %w", err)
@@ -489,17 +641,98 @@ func (r *SQLRepository) UpdateCommitSummary(ctx context.Context, commitID, summa
 	return nil
 }
 
+// GetCommitHashesMissingParents returns the hashes of commits ingested
+// before parent hashes were stored.
+func (r *SQLRepository) GetCommitHashesMissingParents(ctx context.Context, codebaseID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT hash FROM commits WHERE codebase_id = $1 AND parents IS NULL`, codebaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query commits missing parents: %w", err)
+	}
+	defer rows.Close()
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("scan commit hash: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, rows.Err()
+}
+
+// UpdateCommitParents records a commit's parent hashes.
+func (r *SQLRepository) UpdateCommitParents(ctx context.Context, codebaseID, hash string, parents []string) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE commits SET parents = $1 WHERE codebase_id = $2 AND hash = $3`, ToJSON(parents), codebaseID, hash); err != nil {
+		return fmt.Errorf("update commit parents: %w", err)
+	}
+	return nil
+}
+
+// GetCommitHashesMissingSignature returns the hashes of commits ingested
+// before signatures were recorded.
+func (r *SQLRepository) GetCommitHashesMissingSignature(ctx context.Context, codebaseID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT hash FROM commits WHERE codebase_id = $1 AND NOT COALESCE(signature_checked, FALSE)`, codebaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query commits missing signatures: %w", err)
+	}
+	defer rows.Close()
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("scan commit hash: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, rows.Err()
+}
+
+// UpdateCommitSigned records whether a commit carries a signature.
+func (r *SQLRepository) UpdateCommitSigned(ctx context.Context, codebaseID, hash string, signed bool) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE commits SET is_signed = $1, signature_checked = TRUE WHERE codebase_id = $2 AND hash = $3`, signed, codebaseID, hash); err != nil {
+		return fmt.Errorf("update commit signature: %w", err)
+	}
+	return nil
+}
+
+// GetBotCommits returns the hash, author and message of a codebase's
+// commits flagged as bot commits.
+func (r *SQLRepository) GetBotCommits(ctx context.Context, codebaseID string) ([]Commit, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT hash, author_email, message FROM commits WHERE codebase_id = $1 AND is_bot = TRUE`, codebaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query bot commits: %w", err)
+	}
+	defer rows.Close()
+	var commits []Commit
+	for rows.Next() {
+		c := Commit{CodebaseID: codebaseID, IsBot: true}
+		if err := rows.Scan(&c.Hash, &c.AuthorEmail, &c.Message); err != nil {
+			return nil, fmt.Errorf("scan bot commit: %w", err)
+		}
+		commits = append(commits, c)
+	}
+	return commits, rows.Err()
+}
+
+// ClearCommitBotFlag marks a commit as not written by a bot.
+func (r *SQLRepository) ClearCommitBotFlag(ctx context.Context, codebaseID, hash string) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE commits SET is_bot = FALSE WHERE codebase_id = $1 AND hash = $2`, codebaseID, hash); err != nil {
+		return fmt.Errorf("clear commit bot flag: %w", err)
+	}
+	return nil
+}
+
 // GetCommitByHash retrieves a commit by hash.
 func (r *SQLRepository) GetCommitByHash(ctx context.Context, codebaseID, hash string) (*Commit, error) {
 	row := r.db.QueryRowContext(ctx, `
 		SELECT id, hash, codebase_id, branch_id, author_email, message, summary,
-			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync
+			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync, is_signed, commit_type, is_bot, reverts_hash, parents
 		FROM commits WHERE codebase_id = $1 AND hash = $2`, codebaseID, hash)
 	c := &Commit{}
 	var branchID, summary sql.NullString
-	var stats any
+	var stats, parents any
 	err := row.Scan(&c.ID, &c.Hash, &c.CodebaseID, &branchID, &c.AuthorEmail, &c.Message, &summary,
-		&c.CommittedAt, &stats, &c.IsUserCommit, &c.IsOnDefaultBranch, &c.ParentCount, &c.IsMergeSync)
+		&c.CommittedAt, &stats, &c.IsUserCommit, &c.IsOnDefaultBranch, &c.ParentCount, &c.IsMergeSync, &c.IsSigned, &c.CommitType, &c.IsBot, &c.RevertsHash, &parents)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -509,22 +742,57 @@ func (r *SQLRepository) GetCommitByHash(ctx context.Context, codebaseID, hash st
 	c.BranchID = branchID.String
 	c.Summary = summary.String
 	c.Stats = convertToMap(stats)
+	c.Parents = convertToStringSlice(parents)
 	return c, nil
 }
 
+// FindCommitsByHashPrefix returns the commits whose hash starts with prefix,
+// newest first, in one codebase or, when codebaseID is "", in every codebase.
+func (r *SQLRepository) FindCommitsByHashPrefix(ctx context.Context, codebaseID, prefix string, limit int) ([]Commit, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, hash, codebase_id, branch_id, author_email, message, summary,
+			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync, is_signed, commit_type, is_bot, reverts_hash, parents
+		FROM commits WHERE ($1 = '' OR codebase_id = $1) AND starts_with(hash, $2)
+		ORDER BY committed_at DESC LIMIT $3`, codebaseID, strings.ToLower(prefix), limit)
+	if err != nil {
+		return nil, fmt.Errorf("query commits by hash prefix: %w", err)
+	}
+	defer rows.Close()
+	return r.scanCommits(rows)
+}
+
+// FindCommitHashBySubject returns the hash of the most recent commit at or
+// before the given time whose first message line equals subject, or "" if
+// there is none.
+func (r *SQLRepository) FindCommitHashBySubject(ctx context.Context, codebaseID, subject string, before time.Time) (string, error) {
+	var hash string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT hash FROM commits
+		WHERE codebase_id = $1 AND committed_at <= $2 AND (message = $3 OR starts_with(message, $3 || chr(10)))
+		ORDER BY committed_at DESC LIMIT 1`, codebaseID, before, subject).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("find commit by subject: %w", err)
+	}
+	return hash, nil
+}
+
 func (r *SQLRepository) scanCommits(rows *sql.Rows) ([]Commit, error) {
 	var commits []Commit
 	for rows.Next() {
 		c := Commit{}
 		var branchID, summary sql.NullString
-		var stats any
+		var stats, parents any
 		if err := rows.Scan(&c.ID, &c.Hash, &c.CodebaseID, &branchID, &c.AuthorEmail, &c.Message, &summary,
-			&c.CommittedAt, &stats, &c.IsUserCommit, &c.IsOnDefaultBranch, &c.ParentCount, &c.IsMergeSync); err != nil {
+			&c.CommittedAt, &stats, &c.IsUserCommit, &c.IsOnDefaultBranch, &c.ParentCount, &c.IsMergeSync, &c.IsSigned, &c.CommitType, &c.IsBot, &c.RevertsHash, &parents); err != nil {
 			return nil, fmt.Errorf("scan commit row: %w", err)
 		}
 		c.BranchID = branchID.String
 		c.Summary = summary.String
 		c.Stats = convertToMap(stats)
+		c.Parents = convertToStringSlice(parents)
 		commits = append(commits, c)
 	}
 	if err := rows.Err(); err != nil {
@@ -537,7 +805,7 @@ func (r *SQLRepository) scanCommits(rows *sql.Rows) ([]Commit, error) {
 func (r *SQLRepository) GetUserCommits(ctx context.Context, codebaseID string, since time.Time) ([]Commit, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, hash, codebase_id, branch_id, author_email, message, summary,
-			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync
+			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync, is_signed, commit_type, is_bot, reverts_hash, parents
 		FROM commits WHERE codebase_id = $1 AND is_user_commit = TRUE AND committed_at >= $2
 		ORDER BY committed_at DESC`, codebaseID, since)
 	if err != nil {
@@ -547,6 +815,48 @@ func (r *SQLRepository) GetUserCommits(ctx context.Context, codebaseID string, s
 	return r.scanCommits(rows)
 }
 
+// SearchCommits finds commits whose message or summary contains text,
+// case-insensitively, newest first. An empty codebaseID searches every
+// codebase; author matches part of the author email; a zero since and a
+// non-positive limit disable those filters.
+func (r *SQLRepository) SearchCommits(ctx context.Context, codebaseID, text, author string, since time.Time, limit int) ([]Commit, error) {
+	pattern := "%" + escapeLikePattern(text) + "%"
+	queryStr := `
+		SELECT id, hash, codebase_id, branch_id, author_email, message, summary,
+			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync, is_signed, commit_type, is_bot, reverts_hash, parents
+		FROM commits
+		WHERE (message ILIKE $1 ESCAPE '\' OR COALESCE(summary, '') ILIKE $1 ESCAPE '\')`
+	args := []any{pattern}
+	if codebaseID != "" {
+		args = append(args, codebaseID)
+		queryStr += fmt.Sprintf(" AND codebase_id = $%d", len(args))
+	}
+	if author != "" {
+		args = append(args, "%"+escapeLikePattern(author)+"%")
+		queryStr += fmt.Sprintf(" AND author_email ILIKE $%d ESCAPE '\\'", len(args))
+	}
+	if !since.IsZero() {
+		args = append(args, since)
+		queryStr += fmt.Sprintf(" AND committed_at >= $%d", len(args))
+	}
+	queryStr += " ORDER BY committed_at DESC"
+	if limit > 0 {
+		queryStr += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search commits: %w", err)
+	}
+	defer rows.Close()
+	return r.scanCommits(rows)
+}
+
+// escapeLikePattern escapes LIKE wildcards so text is matched literally.
+func escapeLikePattern(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+}
+
 // GetCommitCount returns the commit count for a codebase.
 func (r *SQLRepository) GetCommitCount(ctx context.Context, codebaseID string) (int64, error) {
 	var count int64
@@ -572,7 +882,7 @@ func (r *SQLRepository) GetCommitCountByPath(ctx context.Context, repoPath strin
 func (r *SQLRepository) GetCommitsBetweenDates(ctx context.Context, codebaseID string, startDate, endDate time.Time) ([]Commit, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, hash, codebase_id, branch_id, author_email, message, summary,
-			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync
+			committed_at, stats, is_user_commit, is_on_default_branch, parent_count, is_merge_sync, is_signed, commit_type, is_bot, reverts_hash, parents
 		FROM commits WHERE codebase_id = $1 AND committed_at >= $2 AND committed_at <= $3
 		ORDER BY committed_at DESC`, codebaseID, startDate, endDate)
 	if err != nil {
@@ -602,9 +912,9 @@ func (r *SQLRepository) GetEarliestCommitDate(ctx context.Context, codebaseID st
 // CreateFileChange creates a file change.
 func (r *SQLRepository) CreateFileChange(ctx context.Context, fc *FileChange) error {
 	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO file_changes (id, commit_id, file_path, change_type, additions, deletions, patch)
-		VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING`,
-		fc.ID, fc.CommitID, fc.FilePath, fc.ChangeType, fc.Additions, fc.Deletions, NullString(fc.Patch))
+		INSERT INTO file_changes (id, commit_id, file_path, change_type, additions, deletions, patch, content_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT DO NOTHING`,
+		fc.ID, fc.CommitID, fc.FilePath, fc.ChangeType, fc.Additions, fc.Deletions, NullString(fc.Patch), fc.ContentHash)
 	if err != nil {
 		return fmt.Errorf("create file change: %w", err)
 	}
@@ -614,7 +924,7 @@ func (r *SQLRepository) CreateFileChange(ctx context.Context, fc *FileChange) er
 // GetFileChangesByCommit retrieves file changes for a commit.
 func (r *SQLRepository) GetFileChangesByCommit(ctx context.Context, commitID string) ([]FileChange, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, commit_id, file_path, change_type, additions, deletions, patch
+		SELECT id, commit_id, file_path, change_type, additions, deletions, patch, content_hash
 		FROM file_changes WHERE commit_id = $1`, commitID)
 	if err != nil {
 		return nil, fmt.Errorf("query file changes: %w", err)
@@ -623,11 +933,12 @@ func (r *SQLRepository) GetFileChangesByCommit(ctx context.Context, commitID str
 	var changes []FileChange
 	for rows.Next() {
 		fc := FileChange{}
-		var patch sql.NullString
-		if err := rows.Scan(&fc.ID, &fc.CommitID, &fc.FilePath, &fc.ChangeType, &fc.Additions, &fc.Deletions, &patch); err != nil {
+		var patch, contentHash sql.NullString
+		if err := rows.Scan(&fc.ID, &fc.CommitID, &fc.FilePath, &fc.ChangeType, &fc.Additions, &fc.Deletions, &patch, &contentHash); err != nil {
 			return nil, fmt.Errorf("scan file change: %w", err)
 		}
 		fc.Patch = patch.String
+		fc.ContentHash = contentHash.String
 		changes = append(changes, fc)
 	}
 	if err := rows.Err(); err != nil {
@@ -822,6 +1133,58 @@ func (r *SQLRepository) UpsertFileIndex(ctx context.Context, file *FileIndex) er
 	return nil
 }
 
+// AddFileChurn adds a commit's changed lines to the churn recorded against
+// indexed files since their summaries were written. churn maps repo-relative
+// paths to added plus deleted lines. Files that are not indexed, or whose
+// summary was written after the commit, are left alone.
+func (r *SQLRepository) AddFileChurn(ctx context.Context, codebaseID string, committedAt time.Time, churn map[string]int) error {
+	for path, lines := range churn {
+		if lines <= 0 {
+			continue
+		}
+		if _, err := r.db.ExecContext(ctx, `
+			UPDATE file_indexes SET churn_since_summary = COALESCE(churn_since_summary, 0) + $1
+			WHERE codebase_id = $2 AND path = $3 AND (summarized_at IS NULL OR summarized_at < $4)`,
+			lines, codebaseID, path, committedAt); err != nil {
+			return fmt.Errorf("add file churn: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetFileChurnSinceSummary returns the lines changed in each indexed file
+// since its summary was written, for files with any.
+func (r *SQLRepository) GetFileChurnSinceSummary(ctx context.Context, codebaseID string) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT path, churn_since_summary FROM file_indexes
+		WHERE codebase_id = $1 AND churn_since_summary > 0`, codebaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query file churn: %w", err)
+	}
+	defer rows.Close()
+	churn := make(map[string]int)
+	for rows.Next() {
+		var path string
+		var lines int
+		if err := rows.Scan(&path, &lines); err != nil {
+			return nil, fmt.Errorf("scan file churn: %w", err)
+		}
+		churn[path] = lines
+	}
+	return churn, rows.Err()
+}
+
+// MarkFileSummarized records that a file's summary was just written,
+// clearing its churn.
+func (r *SQLRepository) MarkFileSummarized(ctx context.Context, codebaseID, path string) error {
+	if _, err := r.db.ExecContext(ctx, `
+		UPDATE file_indexes SET churn_since_summary = 0, summarized_at = $1
+		WHERE codebase_id = $2 AND path = $3`, time.Now(), codebaseID, path); err != nil {
+		return fmt.Errorf("mark file summarized: %w", err)
+	}
+	return nil
+}
+
 // GetFilesByCodebase retrieves all files for a codebase.
 func (r *SQLRepository) GetFilesByCodebase(ctx context.Context, codebaseID string) ([]FileIndex, error) {
 	rows, err := r.db.QueryContext(ctx, `
@@ -837,15 +1200,20 @@ func (r *SQLRepository) GetFilesByCodebase(ctx context.Context, codebaseID strin
 
 // ExistingFileInfo holds minimal info for incremental indexing.
 type ExistingFileInfo struct {
-	ID          string
-	Path        string
-	ContentHash string
-	Summary     string
+	ID           string
+	Path         string
+	ContentHash  string
+	Summary      string
+	Purpose      string
+	KeyExports   []string
+	HasEmbedding bool
 }
 
 // GetExistingFileHashes returns file hashes for change detection.
 func (r *SQLRepository) GetExistingFileHashes(ctx context.Context, codebaseID string) (map[string]ExistingFileInfo, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT id, path, content_hash, summary FROM file_indexes WHERE codebase_id = $1`, codebaseID)
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, path, content_hash, summary, purpose, key_exports, embedding IS NOT NULL
+		FROM file_indexes WHERE codebase_id = $1`, codebaseID)
 	if err != nil {
 		return nil, fmt.Errorf("query file hashes: %w", err)
 	}
@@ -853,12 +1221,15 @@ func (r *SQLRepository) GetExistingFileHashes(ctx context.Context, codebaseID st
 	result := make(map[string]ExistingFileInfo)
 	for rows.Next() {
 		var info ExistingFileInfo
-		var contentHash, summary sql.NullString
-		if err := rows.Scan(&info.ID, &info.Path, &contentHash, &summary); err != nil {
+		var contentHash, summary, purpose sql.NullString
+		var keyExports any
+		if err := rows.Scan(&info.ID, &info.Path, &contentHash, &summary, &purpose, &keyExports, &info.HasEmbedding); err != nil {
 			return nil, fmt.Errorf("scan file hash: %w", err)
 		}
 		info.ContentHash = contentHash.String
 		info.Summary = summary.String
+		info.Purpose = purpose.String
+		info.KeyExports = convertToStringSlice(keyExports)
 		result[info.Path] = info
 	}
 	if err := rows.Err(); err != nil {
@@ -867,6 +1238,35 @@ func (r *SQLRepository) GetExistingFileHashes(ctx context.Context, codebaseID st
 	return result, nil
 }
 
+// GetFilePurposes returns a one-line description per indexed file path: the
+// stored purpose, or the summary when no purpose was recorded.
+func (r *SQLRepository) GetFilePurposes(ctx context.Context, codebaseID string) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT path, purpose, summary FROM file_indexes
+		WHERE codebase_id = $1 AND (COALESCE(purpose, '') != '' OR COALESCE(summary, '') != '')`, codebaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query file purposes: %w", err)
+	}
+	defer rows.Close()
+	result := make(map[string]string)
+	for rows.Next() {
+		var path string
+		var purpose, summary sql.NullString
+		if err := rows.Scan(&path, &purpose, &summary); err != nil {
+			return nil, fmt.Errorf("scan file purpose: %w", err)
+		}
+		if purpose.String != "" {
+			result[path] = purpose.String
+		} else {
+			result[path] = summary.String
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate file purposes: %w", err)
+	}
+	return result, nil
+}
+
 // DeleteFileIndex deletes a file index.
 func (r *SQLRepository) DeleteFileIndex(ctx context.Context, codebaseID, path string) error {
 	if _, err := r.db.ExecContext(ctx, `DELETE FROM file_indexes WHERE codebase_id = $1 AND path = $2`, codebaseID, path); err != nil {
@@ -888,6 +1288,86 @@ func (r *SQLRepository) DeleteFileIndexesByPaths(ctx context.Context, codebaseID
 	return nil
 }
 
+// ReplaceTodoMarkers replaces a codebase's stored TODO/FIXME/HACK markers.
+func (r *SQLRepository) ReplaceTodoMarkers(ctx context.Context, codebaseID string, markers []TodoMarker) error {
+	return Transaction(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM todo_markers WHERE codebase_id = $1`, codebaseID); err != nil {
+			return fmt.Errorf("delete todo markers: %w", err)
+		}
+		for _, m := range markers {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO todo_markers (codebase_id, file_path, line_number, kind, text)
+				VALUES ($1, $2, $3, $4, $5)`,
+				codebaseID, m.FilePath, m.Line, m.Kind, m.Text); err != nil {
+				return fmt.Errorf("insert todo marker: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetTodoMarkers retrieves a codebase's markers ordered by file and line.
+func (r *SQLRepository) GetTodoMarkers(ctx context.Context, codebaseID string) ([]TodoMarker, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT codebase_id, file_path, line_number, kind, text
+		FROM todo_markers WHERE codebase_id = $1
+		ORDER BY file_path, line_number`, codebaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query todo markers: %w", err)
+	}
+	defer rows.Close()
+	var markers []TodoMarker
+	for rows.Next() {
+		var m TodoMarker
+		var text sql.NullString
+		if err := rows.Scan(&m.CodebaseID, &m.FilePath, &m.Line, &m.Kind, &text); err != nil {
+			return nil, fmt.Errorf("scan todo marker: %w", err)
+		}
+		m.Text = text.String
+		markers = append(markers, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate todo markers: %w", err)
+	}
+	return markers, nil
+}
+
+// AddTodoSnapshot records a codebase's marker totals.
+func (r *SQLRepository) AddTodoSnapshot(ctx context.Context, snapshot *TodoSnapshot) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO todo_snapshots (codebase_id, taken_at, todo_count, fixme_count, hack_count)
+		VALUES ($1, $2, $3, $4, $5)`,
+		snapshot.CodebaseID, snapshot.TakenAt, snapshot.Todo, snapshot.Fixme, snapshot.Hack)
+	if err != nil {
+		return fmt.Errorf("insert todo snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetTodoSnapshots retrieves a codebase's marker totals, oldest first.
+func (r *SQLRepository) GetTodoSnapshots(ctx context.Context, codebaseID string) ([]TodoSnapshot, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT codebase_id, taken_at, todo_count, fixme_count, hack_count
+		FROM todo_snapshots WHERE codebase_id = $1
+		ORDER BY taken_at`, codebaseID)
+	if err != nil {
+		return nil, fmt.Errorf("query todo snapshots: %w", err)
+	}
+	defer rows.Close()
+	var snapshots []TodoSnapshot
+	for rows.Next() {
+		var s TodoSnapshot
+		if err := rows.Scan(&s.CodebaseID, &s.TakenAt, &s.Todo, &s.Fixme, &s.Hack); err != nil {
+			return nil, fmt.Errorf("scan todo snapshot: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate todo snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
 // GetFilesByFolder retrieves files in a folder.
 func (r *SQLRepository) GetFilesByFolder(ctx context.Context, folderID string) ([]FileIndex, error) {
 	rows, err := r.db.QueryContext(ctx, `
@@ -901,6 +1381,153 @@ func (r *SQLRepository) GetFilesByFolder(ctx context.Context, folderID string) (
 	return r.scanFileIndexes(rows)
 }
 
+// GetFileIndexByPath retrieves a single file index, or nil if the path is not indexed.
+func (r *SQLRepository) GetFileIndexByPath(ctx context.Context, codebaseID, path string) (*FileIndex, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, codebase_id, folder_id, path, name, extension, language,
+			size_bytes, line_count, summary, purpose, key_exports, dependencies, content_hash, indexed_at
+		FROM file_indexes WHERE codebase_id = $1 AND path = $2`, codebaseID, path)
+	if err != nil {
+		return nil, fmt.Errorf("query file index: %w", err)
+	}
+	defer rows.Close()
+	files, err := r.scanFileIndexes(rows)
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	return &files[0], nil
+}
+
+// GetFileDependents returns the paths of indexed files whose stored
+// dependencies refer to path, sorted.
+func (r *SQLRepository) GetFileDependents(ctx context.Context, codebaseID, path string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT path, dependencies FROM file_indexes
+		WHERE codebase_id = $1 AND dependencies IS NOT NULL AND path != $2`, codebaseID, path)
+	if err != nil {
+		return nil, fmt.Errorf("query file dependencies: %w", err)
+	}
+	defer rows.Close()
+	var dependents []string
+	for rows.Next() {
+		var filePath string
+		var deps any
+		if err := rows.Scan(&filePath, &deps); err != nil {
+			return nil, fmt.Errorf("scan file dependencies: %w", err)
+		}
+		for _, dep := range convertToStringSlice(deps) {
+			if DependencyRefersTo(dep, path) {
+				dependents = append(dependents, filePath)
+				break
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate file dependencies: %w", err)
+	}
+	sort.Strings(dependents)
+	return dependents, nil
+}
+
+// DependencyRefersTo reports whether a stored dependency (as produced by
+// indexer.ExtractDependencies) points at the indexed file path: directly,
+// without its extension, through its package directory (Go packages,
+// index.js, __init__.py), or as a dotted module name (Python, JVM).
+func DependencyRefersTo(dep, path string) bool {
+	noExt := strings.TrimSuffix(path, pathpkg.Ext(path))
+	if dep == path || dep == noExt {
+		return true
+	}
+	dir := pathpkg.Dir(path)
+	base := pathpkg.Base(noExt)
+	isPackageFile := strings.HasSuffix(path, ".go") || base == "index" || base == "__init__"
+	if isPackageFile && dep == dir {
+		return true
+	}
+	if strings.Contains(dep, "/") || !strings.Contains(dep, ".") {
+		return false
+	}
+	slashed := strings.ReplaceAll(dep, ".", "/")
+	if noExt == slashed || strings.HasSuffix(noExt, "/"+slashed) {
+		return true
+	}
+	return base == "__init__" && (dir == slashed || strings.HasSuffix(dir, "/"+slashed))
+}
+
+// UpdateFileEmbedding stores the embedding vector for a file index.
+// Embeddings are kept out of UpsertFileIndex so re-indexing preserves them.
+func (r *SQLRepository) UpdateFileEmbedding(ctx context.Context, fileID string, embedding []float64) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE file_indexes SET embedding = $1 WHERE id = $2`,
+		ToJSON(embedding), fileID); err != nil {
+		return fmt.Errorf("update file embedding: %w", err)
+	}
+	return nil
+}
+
+// SemanticSearchFiles ranks a codebase's embedded files by cosine similarity
+// to the query embedding and returns the top limit results. Vectors of any
+// dimension are accepted; files embedded with a different dimension score 0.
+// The filter is applied in SQL, so excluded files are never scored.
+func (r *SQLRepository) SemanticSearchFiles(ctx context.Context, codebaseID string, query []float64, filter FileSearchFilter, limit int) ([]FileSearchResult, error) {
+	queryStr := `
+		SELECT id, codebase_id, folder_id, path, name, extension, language,
+			size_bytes, line_count, summary, purpose, key_exports, dependencies, content_hash, indexed_at, embedding
+		FROM file_indexes WHERE codebase_id = $1 AND embedding IS NOT NULL`
+	args := []any{codebaseID}
+	if language := strings.ToLower(strings.TrimSpace(filter.Language)); language != "" {
+		args = append(args, language, escapeLikePattern(language)+" (%")
+		queryStr += fmt.Sprintf(" AND (LOWER(language) = $%d OR LOWER(language) LIKE $%d ESCAPE '\\')", len(args)-1, len(args))
+	}
+	if prefix := strings.Trim(strings.TrimSpace(filter.PathPrefix), "/"); prefix != "" && prefix != "." {
+		args = append(args, prefix, escapeLikePattern(prefix)+"/%")
+		queryStr += fmt.Sprintf(" AND (path = $%d OR path LIKE $%d ESCAPE '\\')", len(args)-1, len(args))
+	}
+	rows, err := r.db.QueryContext(ctx, queryStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query file embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	var results []FileSearchResult
+	for rows.Next() {
+		f := FileIndex{}
+		var folderID, extension, language, summary, purpose, contentHash sql.NullString
+		var keyExports, deps, embedding any
+		var indexedAt sql.NullTime
+		if err := rows.Scan(&f.ID, &f.CodebaseID, &folderID, &f.Path, &f.Name, &extension, &language,
+			&f.SizeBytes, &f.LineCount, &summary, &purpose, &keyExports, &deps, &contentHash, &indexedAt, &embedding); err != nil {
+			return nil, fmt.Errorf("scan file embedding: %w", err)
+		}
+		f.FolderID = folderID.String
+		f.Extension = extension.String
+		f.Language = language.String
+		f.Summary = summary.String
+		f.Purpose = purpose.String
+		f.KeyExports = convertToStringSlice(keyExports)
+		f.Dependencies = convertToStringSlice(deps)
+		f.ContentHash = contentHash.String
+		if indexedAt.Valid {
+			f.IndexedAt = indexedAt.Time
+		}
+		score := cosineSimilarity(query, convertToFloatSlice(embedding))
+		if score <= 0 {
+			continue
+		}
+		results = append(results, FileSearchResult{FileIndex: f, Score: score})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate file embeddings: %w", err)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
 func (r *SQLRepository) scanFileIndexes(rows *sql.Rows) ([]FileIndex, error) {
 	var files []FileIndex
 	for rows.Next() {
@@ -1114,14 +1741,20 @@ func (r *SQLRepository) ListWorklogDates(ctx context.Context, codebaseID, profil
 	return dates, nil
 }
 
-// ListWorklogWeeks returns aggregated weekly stats for all weeks with cached entries.
-func (r *SQLRepository) ListWorklogWeeks(ctx context.Context, codebaseID, profile string) ([]WorklogWeekInfo, error) {
-	// Use Sunday as the start of the week to match worklog cache keys.
-	// DuckDB DATE_TRUNC('week', ...) is Monday-based, so shift by +1/-1 day.
+// weekTruncShift is how many days to shift a date before DuckDB's
+// Monday-based DATE_TRUNC('week', ...), and back after, so weeks start on
+// weekStart.
+func weekTruncShift(weekStart time.Weekday) int {
+	return (8 - int(weekStart)) % 7
+}
+
+// ListWorklogWeeks returns aggregated weekly stats for all weeks with cached
+// entries, with weeks starting on weekStart to match worklog cache keys.
+func (r *SQLRepository) ListWorklogWeeks(ctx context.Context, codebaseID, profile string, weekStart time.Weekday) ([]WorklogWeekInfo, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		WITH weekly_data AS (
 			SELECT 
-				(DATE_TRUNC('week', entry_date + INTERVAL '1 day') - INTERVAL '1 day')::DATE as week_start,
+				(DATE_TRUNC('week', entry_date + $3 * INTERVAL '1 day') - $3 * INTERVAL '1 day')::DATE as week_start,
 				COUNT(DISTINCT entry_date) as date_count,
 				COUNT(*) as entry_count,
 				COALESCE(SUM(commit_count), 0) as total_commits,
@@ -1140,7 +1773,7 @@ func (r *SQLRepository) ListWorklogWeeks(ctx context.Context, codebaseID, profil
 			total_additions,
 			total_deletions
 		FROM weekly_data
-		ORDER BY week_start DESC`, codebaseID, profile)
+		ORDER BY week_start DESC`, codebaseID, profile, weekTruncShift(weekStart))
 	if err != nil {
 		return nil, fmt.Errorf("query worklog weeks: %w", err)
 	}
@@ -1159,14 +1792,15 @@ func (r *SQLRepository) ListWorklogWeeks(ctx context.Context, codebaseID, profil
 	return weeks, nil
 }
 
-// ListWorklogMonths returns aggregated monthly stats for all months with cached entries.
-func (r *SQLRepository) ListWorklogMonths(ctx context.Context, codebaseID, profile string) ([]WorklogMonthInfo, error) {
+// ListWorklogMonths returns aggregated monthly stats for all months with
+// cached entries. Weeks are counted as starting on weekStart.
+func (r *SQLRepository) ListWorklogMonths(ctx context.Context, codebaseID, profile string, weekStart time.Weekday) ([]WorklogMonthInfo, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		WITH monthly_data AS (
 			SELECT 
 				DATE_TRUNC('month', entry_date)::DATE as month_start,
 				COUNT(DISTINCT entry_date) as date_count,
-				COUNT(DISTINCT (DATE_TRUNC('week', entry_date + INTERVAL '1 day') - INTERVAL '1 day')::DATE) as week_count,
+				COUNT(DISTINCT (DATE_TRUNC('week', entry_date + $3 * INTERVAL '1 day') - $3 * INTERVAL '1 day')::DATE) as week_count,
 				COUNT(*) as entry_count,
 				COALESCE(SUM(commit_count), 0) as total_commits,
 				COALESCE(SUM(additions), 0) as total_additions,
@@ -1185,7 +1819,7 @@ func (r *SQLRepository) ListWorklogMonths(ctx context.Context, codebaseID, profi
 			total_additions,
 			total_deletions
 		FROM monthly_data
-		ORDER BY month_start DESC`, codebaseID, profile)
+		ORDER BY month_start DESC`, codebaseID, profile, weekTruncShift(weekStart))
 	if err != nil {
 		return nil, fmt.Errorf("query worklog months: %w", err)
 	}
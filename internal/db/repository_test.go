@@ -0,0 +1,246 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestRepository opens a fresh DuckDB database with the full schema.
+func newTestRepository(t *testing.T) *SQLRepository {
+	t.Helper()
+	conn, err := sql.Open("duckdb", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() { conn.Close() })
+	if err := initializeSchema(conn); err != nil {
+		t.Fatalf("initialize schema: %v", err)
+	}
+	return NewRepository(conn)
+}
+
+func TestUpsertCommitWithFileChangesTwice(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	if err := repo.UpsertCodebase(ctx, &Codebase{ID: "cb", Path: "/tmp/repo", Name: "repo"}); err != nil {
+		t.Fatalf("upsert codebase: %v", err)
+	}
+	committedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	first := &Commit{ID: "c1", Hash: "abc123", CodebaseID: "cb", AuthorEmail: "me@x.com", Message: "feat: first", CommittedAt: committedAt, ParentCount: 1}
+	if err := repo.UpsertCommitWithFileChanges(ctx, first, []*FileChange{
+		{ID: "fc1", FilePath: "a.go", ChangeType: "added", Additions: 10},
+		{ID: "fc2", FilePath: "b.go", ChangeType: "added", Additions: 5},
+	}); err != nil {
+		t.Fatalf("first upsert: %v", err)
+	}
+
+	// Re-ingesting the same commit (new row IDs, as ingest generates them)
+	// replaces its details and file changes.
+	second := &Commit{ID: "c2", Hash: "abc123", CodebaseID: "cb", AuthorEmail: "me@x.com", Message: "feat: first (amended summary)", Summary: "Added a", CommittedAt: committedAt, ParentCount: 1, IsUserCommit: true}
+	if err := repo.UpsertCommitWithFileChanges(ctx, second, []*FileChange{
+		{ID: "fc3", FilePath: "a.go", ChangeType: "added", Additions: 12, Deletions: 1},
+	}); err != nil {
+		t.Fatalf("second upsert: %v", err)
+	}
+
+	stored, err := repo.GetCommitByHash(ctx, "cb", "abc123")
+	if err != nil || stored == nil {
+		t.Fatalf("get commit: %v, %v", stored, err)
+	}
+	if stored.Message != second.Message || stored.Summary != "Added a" || !stored.IsUserCommit {
+		t.Errorf("stored commit not updated: %+v", stored)
+	}
+	if second.ID != stored.ID {
+		t.Errorf("commit ID = %q, want the stored row's %q", second.ID, stored.ID)
+	}
+
+	var commits int
+	if err := repo.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM commits WHERE codebase_id = 'cb'`).Scan(&commits); err != nil {
+		t.Fatal(err)
+	}
+	if commits != 1 {
+		t.Errorf("got %d commit rows, want 1", commits)
+	}
+
+	changes, err := repo.GetFileChangesByCommit(ctx, stored.ID)
+	if err != nil {
+		t.Fatalf("get file changes: %v", err)
+	}
+	if len(changes) != 1 || changes[0].FilePath != "a.go" || changes[0].Additions != 12 {
+		t.Errorf("file changes = %+v, want only the re-ingested a.go", changes)
+	}
+}
+
+// indexEmbeddedFiles indexes files under codebase "cb" with fixed embedding
+// vectors so search rankings are predictable.
+func indexEmbeddedFiles(t *testing.T, repo *SQLRepository, files []FileIndex, vectors [][]float64) {
+	t.Helper()
+	ctx := context.Background()
+	if err := repo.UpsertCodebase(ctx, &Codebase{ID: "cb", Path: "/tmp/repo", Name: "repo"}); err != nil {
+		t.Fatalf("upsert codebase: %v", err)
+	}
+	for i := range files {
+		f := &files[i]
+		f.CodebaseID = "cb"
+		f.Name = filepath.Base(f.Path)
+		if err := repo.UpsertFileIndex(ctx, f); err != nil {
+			t.Fatalf("upsert %s: %v", f.Path, err)
+		}
+		if vectors[i] != nil {
+			if err := repo.UpdateFileEmbedding(ctx, f.ID, vectors[i]); err != nil {
+				t.Fatalf("embed %s: %v", f.Path, err)
+			}
+		}
+	}
+}
+
+func TestSemanticSearchFilesRanking(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	indexEmbeddedFiles(t, repo, []FileIndex{
+		{ID: "f1", Path: "exact.go"},
+		{ID: "f2", Path: "close.go"},
+		{ID: "f3", Path: "far.go"},
+		{ID: "f4", Path: "opposite.go"},
+		{ID: "f5", Path: "other_model.go"},
+		{ID: "f6", Path: "unembedded.go"},
+	}, [][]float64{
+		{1, 0, 0},
+		{0.8, 0.6, 0},
+		{0.1, 0, 1},
+		{-1, 0, 0},
+		{1, 0}, // a different dimension never matches
+		nil,
+	})
+
+	results, err := repo.SemanticSearchFiles(ctx, "cb", []float64{2, 0, 0}, FileSearchFilter{}, 0)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	var paths []string
+	for _, r := range results {
+		paths = append(paths, r.Path)
+	}
+	want := []string{"exact.go", "close.go", "far.go"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Fatalf("ranked %v, want %v", paths, want)
+	}
+	if math.Abs(results[0].Score-1) > 1e-9 || math.Abs(results[1].Score-0.8) > 1e-9 {
+		t.Errorf("scores = %v, %v; want 1 and 0.8", results[0].Score, results[1].Score)
+	}
+
+	top, err := repo.SemanticSearchFiles(ctx, "cb", []float64{2, 0, 0}, FileSearchFilter{}, 2)
+	if err != nil {
+		t.Fatalf("search with limit: %v", err)
+	}
+	if len(top) != 2 || top[0].Path != "exact.go" || top[1].Path != "close.go" {
+		t.Errorf("limit 2 returned %+v", top)
+	}
+}
+
+func TestSemanticSearchFilesFilter(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	// Every file matches the query equally, so only the filter decides.
+	same := []float64{1, 1}
+	indexEmbeddedFiles(t, repo, []FileIndex{
+		{ID: "f1", Path: "internal/cli/root.go", Language: "Go"},
+		{ID: "f2", Path: "internal/cli_old/root.go", Language: "Go"},
+		{ID: "f3", Path: "internal/db/db.go", Language: "Go"},
+		{ID: "f4", Path: "web/app.tsx", Language: "TypeScript (React)"},
+		{ID: "f5", Path: "web/api.ts", Language: "TypeScript"},
+		{ID: "f6", Path: "internal/cli/help.md", Language: "Markdown"},
+		{ID: "f7", Path: "web_100%/x.ts", Language: "TypeScript"},
+	}, [][]float64{same, same, same, same, same, same, same})
+
+	tests := []struct {
+		name   string
+		filter FileSearchFilter
+		want   []string
+	}{
+		{name: "language", filter: FileSearchFilter{Language: "go"}, want: []string{"internal/cli/root.go", "internal/cli_old/root.go", "internal/db/db.go"}},
+		{name: "language variants", filter: FileSearchFilter{Language: "TypeScript"}, want: []string{"web/api.ts", "web/app.tsx", "web_100%/x.ts"}},
+		{name: "path prefix", filter: FileSearchFilter{PathPrefix: "internal/cli/"}, want: []string{"internal/cli/help.md", "internal/cli/root.go"}},
+		{name: "path prefix without slash", filter: FileSearchFilter{PathPrefix: "internal/cli"}, want: []string{"internal/cli/help.md", "internal/cli/root.go"}},
+		{name: "single file", filter: FileSearchFilter{PathPrefix: "internal/db/db.go"}, want: []string{"internal/db/db.go"}},
+		{name: "like wildcards are literal", filter: FileSearchFilter{PathPrefix: "web_100%"}, want: []string{"web_100%/x.ts"}},
+		{name: "both", filter: FileSearchFilter{Language: "go", PathPrefix: "internal/cli"}, want: []string{"internal/cli/root.go"}},
+		{name: "no match", filter: FileSearchFilter{Language: "rust"}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.SemanticSearchFiles(ctx, "cb", same, tt.filter, 0)
+			if err != nil {
+				t.Fatalf("search: %v", err)
+			}
+			var paths []string
+			for _, r := range results {
+				paths = append(paths, r.Path)
+			}
+			sort.Strings(paths)
+			if strings.Join(paths, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", paths, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceCommitCoAuthors(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	stored := func() map[string]bool {
+		t.Helper()
+		rows, err := repo.db.QueryContext(ctx, `SELECT developer_id, is_user FROM commit_coauthors WHERE codebase_id = 'cb' AND commit_hash = 'abc'`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		got := make(map[string]bool)
+		for rows.Next() {
+			var id string
+			var isUser bool
+			if err := rows.Scan(&id, &isUser); err != nil {
+				t.Fatal(err)
+			}
+			got[id] = isUser
+		}
+		return got
+	}
+
+	steps := []struct {
+		name      string
+		coAuthors []CommitCoAuthor
+		want      map[string]bool
+	}{
+		{"first store", []CommitCoAuthor{{DeveloperID: "alice"}, {DeveloperID: "bob"}}, map[string]bool{"alice": false, "bob": false}},
+		{"same again", []CommitCoAuthor{{DeveloperID: "alice"}, {DeveloperID: "bob"}}, map[string]bool{"alice": false, "bob": false}},
+		{"flag changes", []CommitCoAuthor{{DeveloperID: "alice", IsUser: true}, {DeveloperID: "bob"}}, map[string]bool{"alice": true, "bob": false}},
+		{"one removed, one added", []CommitCoAuthor{{DeveloperID: "alice", IsUser: true}, {DeveloperID: "carol"}}, map[string]bool{"alice": true, "carol": false}},
+		{"all removed", nil, map[string]bool{}},
+	}
+	for _, step := range steps {
+		if err := repo.ReplaceCommitCoAuthors(ctx, "cb", "abc", step.coAuthors); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		got := stored()
+		if len(got) != len(step.want) {
+			t.Errorf("%s: got %v, want %v", step.name, got, step.want)
+			continue
+		}
+		for id, isUser := range step.want {
+			if v, ok := got[id]; !ok || v != isUser {
+				t.Errorf("%s: got %v, want %v", step.name, got, step.want)
+				break
+			}
+		}
+	}
+}
@@ -9,6 +9,19 @@ var Migrations = []string{
 	`ALTER TABLE codebases ADD COLUMN touch_activity JSON`,
 	`ALTER TABLE commits ADD COLUMN parent_count INTEGER DEFAULT 1`,
 	`ALTER TABLE commits ADD COLUMN is_merge_sync BOOLEAN DEFAULT FALSE`,
+	`ALTER TABLE file_indexes ADD COLUMN embedding JSON`,
+	`ALTER TABLE commits ADD COLUMN is_signed BOOLEAN DEFAULT FALSE`,
+	`ALTER TABLE commits ADD COLUMN commit_type VARCHAR DEFAULT ''`,
+	`ALTER TABLE commits ADD COLUMN is_bot BOOLEAN DEFAULT FALSE`,
+	`ALTER TABLE codebases ADD COLUMN remote_url VARCHAR DEFAULT ''`,
+	`ALTER TABLE commits ADD COLUMN reverts_hash VARCHAR DEFAULT ''`,
+	`ALTER TABLE file_changes ADD COLUMN content_hash VARCHAR DEFAULT ''`,
+	`ALTER TABLE commits ADD COLUMN parents JSON`,
+	`ALTER TABLE file_indexes ADD COLUMN churn_since_summary INTEGER DEFAULT 0`,
+	`ALTER TABLE file_indexes ADD COLUMN summarized_at TIMESTAMP`,
+	// Rows from before signatures were recorded stay unchecked until ingest
+	// reads their signature from git.
+	`ALTER TABLE commits ADD COLUMN signature_checked BOOLEAN DEFAULT FALSE`,
 }
 
 // Schema defines the DuckDB table schema
@@ -32,7 +45,8 @@ CREATE TABLE IF NOT EXISTS codebases (
     indexed_at TIMESTAMP,
     project_context VARCHAR DEFAULT '',
     longterm_context VARCHAR DEFAULT '',
-    touch_activity JSON
+    touch_activity JSON,
+    remote_url VARCHAR DEFAULT ''
 );
 
 -- Branches table
@@ -69,6 +83,12 @@ CREATE TABLE IF NOT EXISTS commits (
     is_on_default_branch BOOLEAN DEFAULT FALSE,
     parent_count INTEGER DEFAULT 1,
     is_merge_sync BOOLEAN DEFAULT FALSE,
+    is_signed BOOLEAN DEFAULT FALSE,
+    commit_type VARCHAR DEFAULT '',
+    is_bot BOOLEAN DEFAULT FALSE,
+    reverts_hash VARCHAR DEFAULT '',
+    parents JSON,
+    signature_checked BOOLEAN DEFAULT FALSE,
     UNIQUE(codebase_id, hash)
 );
 
@@ -80,7 +100,17 @@ CREATE TABLE IF NOT EXISTS file_changes (
     change_type VARCHAR NOT NULL,
     additions INTEGER DEFAULT 0,
     deletions INTEGER DEFAULT 0,
-    patch VARCHAR
+    patch VARCHAR,
+    content_hash VARCHAR DEFAULT ''
+);
+
+-- Co-authors named in Co-authored-by trailers
+CREATE TABLE IF NOT EXISTS commit_coauthors (
+    codebase_id VARCHAR NOT NULL,
+    commit_hash VARCHAR NOT NULL,
+    developer_id VARCHAR NOT NULL,
+    is_user BOOLEAN DEFAULT FALSE,
+    PRIMARY KEY (codebase_id, commit_hash, developer_id)
 );
 
 -- Folders table
@@ -115,6 +145,9 @@ CREATE TABLE IF NOT EXISTS file_indexes (
     dependencies JSON,
     content_hash VARCHAR,
     indexed_at TIMESTAMP,
+    embedding JSON,
+    churn_since_summary INTEGER DEFAULT 0,
+    summarized_at TIMESTAMP,
     UNIQUE(codebase_id, path)
 );
 
@@ -161,6 +194,24 @@ CREATE TABLE IF NOT EXISTS worklog_export_state (
     UNIQUE(codebase_id, profile_name, entry_type, entry_date, branch_id)
 );
 
+-- TODO/FIXME/HACK markers found in indexed files (replaced on each index)
+CREATE TABLE IF NOT EXISTS todo_markers (
+    codebase_id VARCHAR NOT NULL,
+    file_path VARCHAR NOT NULL,
+    line_number INTEGER NOT NULL,
+    kind VARCHAR NOT NULL,
+    text VARCHAR
+);
+
+-- Marker totals recorded at each index, for trends
+CREATE TABLE IF NOT EXISTS todo_snapshots (
+    codebase_id VARCHAR NOT NULL,
+    taken_at TIMESTAMP NOT NULL,
+    todo_count INTEGER DEFAULT 0,
+    fixme_count INTEGER DEFAULT 0,
+    hack_count INTEGER DEFAULT 0
+);
+
 -- Create indexes for better query performance
 CREATE INDEX IF NOT EXISTS idx_commits_codebase ON commits(codebase_id);
 CREATE INDEX IF NOT EXISTS idx_commits_branch ON commits(branch_id);
@@ -173,5 +224,7 @@ CREATE INDEX IF NOT EXISTS idx_branches_codebase ON branches(codebase_id);
 CREATE INDEX IF NOT EXISTS idx_folders_codebase ON folders(codebase_id);
 CREATE INDEX IF NOT EXISTS idx_file_indexes_codebase ON file_indexes(codebase_id);
 CREATE INDEX IF NOT EXISTS idx_worklog_entries_lookup ON worklog_entries(codebase_id, profile_name, entry_date, group_by);
+CREATE INDEX IF NOT EXISTS idx_todo_markers_codebase ON todo_markers(codebase_id);
+CREATE INDEX IF NOT EXISTS idx_todo_snapshots_codebase ON todo_snapshots(codebase_id, taken_at);
 CREATE INDEX IF NOT EXISTS idx_worklog_export_state_lookup ON worklog_export_state(codebase_id, profile_name, entry_type, entry_date);
 `
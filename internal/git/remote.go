@@ -0,0 +1,90 @@
+package git
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RemoteURL returns the first URL of the origin remote, with any credentials
+// embedded in an http(s) URL removed, or "" when the repository has no origin.
+func (r *Repository) RemoteURL() string {
+	remote, err := r.repo.Remote("origin")
+	if err != nil {
+		return ""
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return ""
+	}
+	return stripURLCredentials(urls[0])
+}
+
+func stripURLCredentials(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User == nil {
+		return raw
+	}
+	u.User = nil
+	return u.String()
+}
+
+// BrowseURL converts a git remote URL (https, ssh://, or scp-like
+// git@host:org/repo.git) into the https address of the repository's web
+// page. It returns "" for local paths and URLs it cannot interpret.
+func BrowseURL(remote string) string {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return ""
+	}
+
+	var host, repoPath string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		switch u.Scheme {
+		case "http", "https", "ssh", "git", "git+ssh", "ssh+git":
+		default:
+			return ""
+		}
+		host = u.Hostname()
+		if u.Scheme == "http" || u.Scheme == "https" {
+			host = u.Host
+		}
+		repoPath = u.Path
+	} else if at := strings.Index(remote, "@"); at >= 0 && strings.Contains(remote[at:], ":") {
+		// scp-like syntax: [user@]host:org/repo.git
+		rest := remote[at+1:]
+		colon := strings.Index(rest, ":")
+		host, repoPath = rest[:colon], rest[colon+1:]
+	} else {
+		return ""
+	}
+
+	repoPath = strings.Trim(strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git"), "/")
+	if host == "" || repoPath == "" {
+		return ""
+	}
+	return "https://" + host + "/" + repoPath
+}
+
+// CommitURL returns a permalink to a commit on a recognised hosting service
+// (GitHub, GitLab, Bitbucket, or a self-hosted instance with one of those
+// names in its host), or "" when browseURL is empty or the host is unknown.
+func CommitURL(browseURL, hash string) string {
+	if browseURL == "" || hash == "" {
+		return ""
+	}
+	u, err := url.Parse(browseURL)
+	if err != nil {
+		return ""
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case strings.Contains(host, "github"):
+		return browseURL + "/commit/" + hash
+	case strings.Contains(host, "gitlab"):
+		return browseURL + "/-/commit/" + hash
+	case strings.Contains(host, "bitbucket"):
+		return browseURL + "/commits/" + hash
+	default:
+		return ""
+	}
+}
@@ -3,10 +3,13 @@ package git
 import (
 	"errors"
 	"fmt"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -18,11 +21,92 @@ import (
 type Repository struct {
 	repo *git.Repository
 	path string
+
+	shallowOnce    sync.Once
+	shallowParents []plumbing.Hash
 }
 
 // Commit is an alias for the go-git commit object
 type Commit = object.Commit
 
+// CommitChanges returns the file changes a commit introduces relative to its
+// first parent. A root commit is compared with an empty tree, so every file
+// it adds counts as an insertion.
+func CommitChanges(commit *Commit) (object.Changes, error) {
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent commit: %w", err)
+		}
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent tree: %w", err)
+		}
+	}
+
+	commitTree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit tree: %w", err)
+	}
+
+	changes, err := object.DiffTree(parentTree, commitTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff trees: %w", err)
+	}
+	return changes, nil
+}
+
+// CombinedCommitChanges returns the first-parent changes of a merge commit
+// limited to files that differ from every parent, i.e. the edits the merge
+// itself made (conflict resolutions and the like) rather than the history it
+// brought in. For other commits it is the same as CommitChanges.
+func CombinedCommitChanges(commit *Commit) (object.Changes, error) {
+	changes, err := CommitChanges(commit)
+	if err != nil || commit.NumParents() < 2 {
+		return changes, err
+	}
+
+	commitTree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit tree: %w", err)
+	}
+	for i := 1; i < commit.NumParents(); i++ {
+		parent, err := commit.Parent(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent commit: %w", err)
+		}
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent tree: %w", err)
+		}
+		other, err := object.DiffTree(parentTree, commitTree)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff trees: %w", err)
+		}
+		changed := make(map[string]bool, len(other))
+		for _, c := range other {
+			changed[changePath(c)] = true
+		}
+		kept := changes[:0]
+		for _, c := range changes {
+			if changed[changePath(c)] {
+				kept = append(kept, c)
+			}
+		}
+		changes = kept
+	}
+	return changes, nil
+}
+
+// changePath returns the path a change applies to.
+func changePath(c *object.Change) string {
+	if c.To.Name != "" {
+		return c.To.Name
+	}
+	return c.From.Name
+}
+
 // BranchInfo holds information about a git branch
 type BranchInfo struct {
 	Name      string
@@ -37,10 +121,7 @@ type commitState struct {
 	done     bool
 }
 
-var (
-	errStopTraversal     = errors.New("stop traversal")
-	githubNoReplyEmailRE = regexp.MustCompile(`^(?:\d+\+)?([^@]+)@users\.noreply\.github\.com$`)
-)
+var githubNoReplyEmailRE = regexp.MustCompile(`^(?:\d+\+)?([^@]+)@users\.noreply\.github\.com$`)
 
 func OpenRepo(path string) (*Repository, error) {
 	absPath, err := filepath.Abs(path)
@@ -59,6 +140,82 @@ func OpenRepo(path string) (*Repository, error) {
 	}, nil
 }
 
+// CloneRepo makes a bare clone of url into dest and opens it. The git binary
+// is used rather than go-git so the user's credential helpers and SSH config
+// apply. When since is non-zero the clone is shallow and only holds history
+// after that date, on every branch.
+func CloneRepo(url, dest string, since time.Time) (*Repository, error) {
+	args := []string{"clone", "--bare", "--quiet"}
+	if !since.IsZero() {
+		args = append(args, "--no-single-branch", "--shallow-since="+since.Format("2006-01-02"))
+	}
+	args = append(args, "--", url, dest)
+
+	cmd := exec.Command("git", args...)
+	// Fail instead of hanging on an interactive credential prompt.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to clone %s: %w: %s", url, err, strings.TrimSpace(string(out)))
+	}
+
+	if !since.IsZero() {
+		// Fetch one more generation so the oldest commits in range still
+		// have a parent to diff against.
+		deepen := exec.Command("git", "-C", dest, "fetch", "--quiet", "--deepen=1", "origin", "+refs/heads/*:refs/heads/*")
+		deepen.Env = cmd.Env
+		if out, err := deepen.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("failed to deepen clone of %s: %w: %s", url, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return OpenRepo(dest)
+}
+
+// boundaryParents returns the parents of a shallow clone's boundary commits,
+// which are absent from the object store. It is empty for full clones.
+func (r *Repository) boundaryParents() []plumbing.Hash {
+	r.shallowOnce.Do(func() {
+		shallow, err := r.repo.Storer.Shallow()
+		if err != nil {
+			return
+		}
+		for _, h := range shallow {
+			c, err := r.repo.CommitObject(h)
+			if err != nil {
+				continue
+			}
+			r.shallowParents = append(r.shallowParents, c.ParentHashes...)
+		}
+	})
+	return r.shallowParents
+}
+
+// isBoundaryParent reports whether hash lies just past a shallow boundary.
+func (r *Repository) isBoundaryParent(hash plumbing.Hash) bool {
+	for _, h := range r.boundaryParents() {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// log iterates history from a commit like go-git's Log, but stops at the
+// boundary of a shallow clone instead of failing on missing parents.
+func (r *Repository) log(from plumbing.Hash, order git.LogOrder) (object.CommitIter, error) {
+	ignore := r.boundaryParents()
+	if len(ignore) == 0 {
+		return r.repo.Log(&git.LogOptions{From: from, Order: order})
+	}
+	c, err := r.repo.CommitObject(from)
+	if err != nil {
+		return nil, err
+	}
+	if order == git.LogOrderCommitterTime {
+		return object.NewCommitIterCTime(c, nil, ignore), nil
+	}
+	return object.NewCommitPreorderIter(c, nil, ignore), nil
+}
+
 func (r *Repository) Path() string {
 	return r.path
 }
@@ -108,34 +265,120 @@ func (r *Repository) GetCurrentBranch() (string, error) {
 	return r.CurrentBranch()
 }
 
-// GetDefaultBranch returns the default branch name (main or master)
+// Default branch detection methods, reported by DetectDefaultBranch so
+// misdetection can be debugged.
+const (
+	DefaultBranchFromRemoteHEAD  = "remote HEAD (origin/HEAD)"
+	DefaultBranchFromBareHEAD    = "bare repository HEAD"
+	DefaultBranchFromCommonName  = "common branch name"
+	DefaultBranchFromMostCommits = "branch with most commits"
+	DefaultBranchFromFallback    = "fallback"
+)
+
+// commonDefaultBranchNames are tried in order when the remote HEAD is unknown.
+var commonDefaultBranchNames = []string{"main", "master", "trunk", "develop"}
+
+// GetDefaultBranch returns the default branch name.
 func (r *Repository) GetDefaultBranch() (string, error) {
-	// Check for common default branch names
-	for _, name := range []string{"main", "master"} {
-		_, err := r.repo.Reference(plumbing.NewBranchReferenceName(name), true)
-		if err == nil {
-			return name, nil
+	name, _, err := r.DetectDefaultBranch()
+	return name, err
+}
+
+// DetectDefaultBranch returns the default branch name and the method used to
+// find it. The fallback chain is: remote HEAD (origin/HEAD) → HEAD of a bare
+// repository → common names (main, master, trunk, develop) that exist as
+// branches → the branch with the most commits. Detached-HEAD checkouts skip
+// straight past HEAD since it does not name a branch.
+func (r *Repository) DetectDefaultBranch() (string, string, error) {
+	if name := r.remoteHEADBranch(); name != "" {
+		return name, DefaultBranchFromRemoteHEAD, nil
+	}
+
+	if _, err := r.repo.Worktree(); errors.Is(err, git.ErrIsBareRepository) {
+		if head, err := r.repo.Reference(plumbing.HEAD, false); err == nil &&
+			head.Type() == plumbing.SymbolicReference && head.Target().IsBranch() {
+			if r.hasLocalBranch(head.Target().Short()) {
+				return head.Target().Short(), DefaultBranchFromBareHEAD, nil
+			}
+		}
+	}
+
+	for _, name := range commonDefaultBranchNames {
+		if r.hasLocalBranch(name) {
+			return name, DefaultBranchFromCommonName, nil
+		}
+	}
+
+	if name, err := r.branchWithMostCommits(); err != nil {
+		return "", "", err
+	} else if name != "" {
+		return name, DefaultBranchFromMostCommits, nil
+	}
+
+	return "main", DefaultBranchFromFallback, nil
+}
+
+// remoteHEADBranch resolves origin/HEAD, first from the local symbolic ref and
+// then by asking the remote. Returns "" when neither is available.
+func (r *Repository) remoteHEADBranch() string {
+	ref, err := r.repo.Reference(plumbing.NewRemoteHEADReferenceName("origin"), false)
+	if err == nil && ref.Type() == plumbing.SymbolicReference {
+		name := strings.TrimPrefix(ref.Target().Short(), "origin/")
+		if name != "" && name != "HEAD" {
+			return name
 		}
 	}
 
-	// Try to get from remote HEAD
 	remote, err := r.repo.Remote("origin")
-	if err == nil {
-		refs, err := remote.List(&git.ListOptions{})
-		if err == nil {
-			for _, ref := range refs {
-				if ref.Name() == plumbing.HEAD {
-					target := ref.Target()
-					if target.IsBranch() {
-						return target.Short(), nil
-					}
-				}
-			}
+	if err != nil {
+		return ""
+	}
+	refs, err := remote.List(&git.ListOptions{})
+	if err != nil {
+		return ""
+	}
+	for _, ref := range refs {
+		if ref.Name() == plumbing.HEAD && ref.Target().IsBranch() {
+			return ref.Target().Short()
 		}
 	}
+	return ""
+}
 
-	// Default to main
-	return "main", nil
+func (r *Repository) hasLocalBranch(name string) bool {
+	_, err := r.repo.Reference(plumbing.NewBranchReferenceName(name), true)
+	return err == nil
+}
+
+// branchWithMostCommits returns the local branch whose history is longest,
+// or "" if there are no local branches.
+func (r *Repository) branchWithMostCommits() (string, error) {
+	iter, err := r.repo.Branches()
+	if err != nil {
+		return "", fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	best, bestCount := "", -1
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		logIter, err := r.log(ref.Hash(), git.LogOrderDefault)
+		if err != nil {
+			return nil
+		}
+		count := 0
+		_ = logIter.ForEach(func(*object.Commit) error {
+			count++
+			return nil
+		})
+		name := ref.Name().Short()
+		if count > bestCount || (count == bestCount && name < best) {
+			best, bestCount = name, count
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to count branch commits: %w", err)
+	}
+	return best, nil
 }
 
 // ListBranches returns all local branches
@@ -209,7 +452,7 @@ func (r *Repository) GetMergeBase(branch1, branch2 string) (string, error) {
 	ancestors1 := make(map[string]bool)
 
 	// Collect all ancestors of commit1
-	iter1, err := r.repo.Log(&git.LogOptions{From: commit1.Hash})
+	iter1, err := r.log(commit1.Hash, git.LogOrderDefault)
 	if err != nil {
 		return "", err
 	}
@@ -222,7 +465,7 @@ func (r *Repository) GetMergeBase(branch1, branch2 string) (string, error) {
 	}
 
 	// Find first ancestor of commit2 that's also in commit1's ancestry
-	iter2, err := r.repo.Log(&git.LogOptions{From: commit2.Hash})
+	iter2, err := r.log(commit2.Hash, git.LogOrderDefault)
 	if err != nil {
 		return "", err
 	}
@@ -279,6 +522,54 @@ func (r *Repository) GetCommitsOnBranchSince(branchName, baseBranch string, sinc
 	return r.getAllCommitHashes(branchHash, mergeBase, sinceDate)
 }
 
+// ResolveRevision resolves a tag, branch, hash or other revision (such as
+// "HEAD~3") to a commit hash.
+func (r *Repository) ResolveRevision(rev string) (string, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return "", fmt.Errorf("revision '%s' not found: %w", rev, err)
+	}
+	return hash.String(), nil
+}
+
+// GetCommitsBetween returns the commits reachable from toRev but not from
+// fromRev, newest first, like 'git log fromRev..toRev'.
+func (r *Repository) GetCommitsBetween(fromRev, toRev string) ([]string, error) {
+	fromHash, err := r.ResolveRevision(fromRev)
+	if err != nil {
+		return nil, err
+	}
+	toHash, err := r.ResolveRevision(toRev)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded, err := r.getAllCommitHashes(fromHash, "", time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(excluded))
+	for _, h := range excluded {
+		seen[h] = true
+	}
+
+	iter, err := r.log(plumbing.NewHash(toHash), git.LogOrderCommitterTime)
+	if err != nil {
+		return nil, err
+	}
+	var hashes []string
+	err = iter.ForEach(func(c *object.Commit) error {
+		if hash := c.Hash.String(); !seen[hash] {
+			hashes = append(hashes, hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
 // GetCommitHashSet returns all commit hashes reachable from a branch head.
 func (r *Repository) GetCommitHashSet(branchName string) (map[string]bool, error) {
 	branchHash, err := r.GetBranchHash(branchName)
@@ -299,9 +590,7 @@ func (r *Repository) GetCommitHashSet(branchName string) (map[string]bool, error
 // getAllCommitHashes returns all commit hashes from start to stop (exclusive),
 // optionally stopping when commit dates are older than sinceDate.
 func (r *Repository) getAllCommitHashes(startHash, stopHash string, sinceDate time.Time) ([]string, error) {
-	iter, err := r.repo.Log(&git.LogOptions{
-		From: plumbing.NewHash(startHash),
-	})
+	iter, err := r.log(plumbing.NewHash(startHash), git.LogOrderDefault)
 	if err != nil {
 		return nil, err
 	}
@@ -343,7 +632,7 @@ func (r *Repository) IsBranchMerged(branchName, baseBranch string) (bool, error)
 		return false, err
 	}
 
-	iter, err := r.repo.Log(&git.LogOptions{From: baseCommit.Hash})
+	iter, err := r.log(baseCommit.Hash, git.LogOrderDefault)
 	if err != nil {
 		return false, err
 	}
@@ -463,6 +752,11 @@ func (r *Repository) commitContainsUserCommit(hash plumbing.Hash, userEmail, git
 		}
 	}
 
+	// History past a shallow clone's boundary is not available.
+	if r.isBoundaryParent(hash) {
+		return false, nil
+	}
+
 	state := memo[key]
 	if state == nil {
 		state = &commitState{}
@@ -482,20 +776,15 @@ func (r *Repository) commitContainsUserCommit(hash plumbing.Hash, userEmail, git
 		return true, nil
 	}
 
-	parentIter := commit.Parents()
-	err = parentIter.ForEach(func(parent *object.Commit) error {
-		parentHasUser, err := r.commitContainsUserCommit(parent.Hash, userEmail, githubUsername, memo)
+	for _, parentHash := range commit.ParentHashes {
+		parentHasUser, err := r.commitContainsUserCommit(parentHash, userEmail, githubUsername, memo)
 		if err != nil {
-			return err
+			return false, err
 		}
 		if parentHasUser {
 			state.hasUser = true
-			return errStopTraversal
+			break
 		}
-		return nil
-	})
-	if err != nil && !errors.Is(err, errStopTraversal) {
-		return false, err
 	}
 
 	state.done = true
@@ -0,0 +1,82 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+)
+
+func TestCommitChangesRootCommit(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+
+	files := map[string]string{
+		"README.md":       "# demo\n",
+		"main.go":         "package main\n",
+		"internal/x/x.go": "package x\n",
+	}
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Add(path); err != nil {
+			t.Fatalf("add %s: %v", path, err)
+		}
+	}
+	sig := &object.Signature{Name: "Dev", Email: "dev@example.com", When: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)}
+	hash, err := wt.Commit("init", &git.CommitOptions{Author: sig, Committer: sig})
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		t.Fatalf("commit object: %v", err)
+	}
+
+	changes, err := CommitChanges(commit)
+	if err != nil {
+		t.Fatalf("CommitChanges: %v", err)
+	}
+	if len(changes) != len(files) {
+		t.Fatalf("got %d changes, want %d", len(changes), len(files))
+	}
+	var paths []string
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			t.Fatalf("action: %v", err)
+		}
+		if action != merkletrie.Insert {
+			t.Errorf("%s: action %v, want Insert", change.To.Name, action)
+		}
+		if change.From.Name != "" {
+			t.Errorf("%s: has a from side %q, want none", change.To.Name, change.From.Name)
+		}
+		paths = append(paths, change.To.Name)
+	}
+	sort.Strings(paths)
+	want := []string{"README.md", "internal/x/x.go", "main.go"}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("paths = %v, want %v", paths, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ChangeSignatures describes a commit's diff against its first parent as a
+// canonical list of "path@blob -> path@blob" entries. inverse is the same
+// diff with before and after swapped, so a later commit whose forward
+// signature equals an earlier commit's inverse undoes it exactly. Both are ""
+// for root commits, merges, and commits that change no files.
+func ChangeSignatures(commit *object.Commit) (forward, inverse string, err error) {
+	if commit.NumParents() != 1 {
+		return "", "", nil
+	}
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get parent commit: %w", err)
+	}
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get parent tree: %w", err)
+	}
+	commitTree, err := commit.Tree()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get commit tree: %w", err)
+	}
+	changes, err := parentTree.Diff(commitTree)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to diff trees: %w", err)
+	}
+	if len(changes) == 0 {
+		return "", "", nil
+	}
+
+	fwd := make([]string, 0, len(changes))
+	inv := make([]string, 0, len(changes))
+	for _, change := range changes {
+		before := change.From.Name + "@" + change.From.TreeEntry.Hash.String()
+		after := change.To.Name + "@" + change.To.TreeEntry.Hash.String()
+		fwd = append(fwd, before+" -> "+after)
+		inv = append(inv, after+" -> "+before)
+	}
+	sort.Strings(fwd)
+	sort.Strings(inv)
+	return strings.Join(fwd, "\n"), strings.Join(inv, "\n"), nil
+}
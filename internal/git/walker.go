@@ -56,10 +56,7 @@ func WalkCommits(repo *Repository, opts WalkOptions) (int, error) {
 		return 0, fmt.Errorf("failed to get HEAD: %w", err)
 	}
 
-	iter, err := gitRepo.Log(&git.LogOptions{
-		From:  head.Hash(),
-		Order: git.LogOrderCommitterTime,
-	})
+	iter, err := repo.log(head.Hash(), git.LogOrderCommitterTime)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create log iterator: %w", err)
 	}
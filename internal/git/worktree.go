@@ -0,0 +1,184 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// WorkingChange describes an uncommitted change to a single file.
+type WorkingChange struct {
+	Path       string
+	ChangeType string // add, modify, delete
+	Additions  int
+	Deletions  int
+	Patch      string
+}
+
+// WorkingTreeChanges returns the uncommitted changes relative to HEAD.
+// When stagedOnly is true only the index is compared against HEAD; otherwise
+// the working tree (staged + unstaged, including untracked files) is used.
+func (r *Repository) WorkingTreeChanges(stagedOnly bool) ([]WorkingChange, error) {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree status: %w", err)
+	}
+
+	paths := make([]string, 0, len(status))
+	for path := range status {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var changes []WorkingChange
+	for _, path := range paths {
+		fs := status[path]
+		code := fs.Worktree
+		if stagedOnly || code == git.Unmodified {
+			code = fs.Staging
+		}
+		if stagedOnly && code == git.Untracked {
+			continue
+		}
+
+		var changeType string
+		switch code {
+		case git.Added, git.Untracked:
+			changeType = "add"
+		case git.Deleted:
+			changeType = "delete"
+		case git.Modified, git.Renamed, git.Copied, git.UpdatedButUnmerged:
+			changeType = "modify"
+		default:
+			continue
+		}
+
+		before, err := r.headFileContents(path)
+		if err != nil {
+			return nil, err
+		}
+		var after string
+		if changeType != "delete" {
+			if stagedOnly {
+				after, err = r.indexFileContents(path)
+			} else {
+				after, err = worktreeFileContents(wt.Filesystem.Root(), path)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isBinaryContent(before) || isBinaryContent(after) {
+			changes = append(changes, WorkingChange{Path: path, ChangeType: changeType})
+			continue
+		}
+
+		wc := WorkingChange{Path: path, ChangeType: changeType}
+		var patch strings.Builder
+		patch.WriteString(fmt.Sprintf("--- a/%s\n+++ b/%s\n", path, path))
+		for _, d := range diff.Do(before, after) {
+			var prefix string
+			switch d.Type {
+			case diffmatchpatch.DiffInsert:
+				prefix = "+"
+			case diffmatchpatch.DiffDelete:
+				prefix = "-"
+			default:
+				continue
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(d.Text, "\n"), "\n") {
+				patch.WriteString(prefix + line + "\n")
+				if prefix == "+" {
+					wc.Additions++
+				} else {
+					wc.Deletions++
+				}
+			}
+		}
+		if patch.Len() < 10000 {
+			wc.Patch = patch.String()
+		}
+		changes = append(changes, wc)
+	}
+
+	return changes, nil
+}
+
+// headFileContents returns a file's contents at HEAD, or "" if it does not exist there.
+func (r *Repository) headFileContents(path string) (string, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	commit, err := r.repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+	file, err := commit.File(path)
+	if err != nil {
+		return "", nil
+	}
+	return file.Contents()
+}
+
+// indexFileContents returns a file's staged contents, or "" if it is not in the index.
+func (r *Repository) indexFileContents(path string) (string, error) {
+	idx, err := r.repo.Storer.Index()
+	if err != nil {
+		return "", fmt.Errorf("failed to read index: %w", err)
+	}
+	entry, err := idx.Entry(path)
+	if err != nil {
+		return "", nil
+	}
+	blob, err := r.repo.BlobObject(entry.Hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to read staged blob for %s: %w", path, err)
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return "", fmt.Errorf("failed to read staged blob for %s: %w", path, err)
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read staged blob for %s: %w", path, err)
+	}
+	return string(content), nil
+}
+
+// worktreeFileContents returns a file's on-disk contents, or "" if it was removed.
+func worktreeFileContents(root, path string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(content), nil
+}
+
+func isBinaryContent(content string) bool {
+	sample := content
+	if len(sample) > 8000 {
+		sample = sample[:8000]
+	}
+	return strings.IndexByte(sample, 0) >= 0
+}
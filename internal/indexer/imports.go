@@ -0,0 +1,151 @@
+package indexer
+
+import (
+	"bufio"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	jsImportRE      = regexp.MustCompile(`(?m)(?:import|export)\s[^'"]*?\bfrom\s*['"]([^'"]+)['"]|import\s*['"]([^'"]+)['"]|(?:require|import)\s*\(\s*['"]([^'"]+)['"]\s*\)`)
+	pyImportRE      = regexp.MustCompile(`(?m)^\s*import\s+([\w.]+(?:\s+as\s+\w+)?(?:\s*,\s*[\w.]+(?:\s+as\s+\w+)?)*)`)
+	pyFromImportRE  = regexp.MustCompile(`(?m)^\s*from\s+(\.*)([\w.]*)\s+import\b`)
+	cIncludeRE      = regexp.MustCompile(`(?m)^\s*#\s*include\s*"([^"]+)"`)
+	jvmImportRE     = regexp.MustCompile(`(?m)^\s*import\s+(?:static\s+)?([\w.]+)(?:\.\*)?\s*;?\s*$`)
+	jsResolvableExt = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs", ".vue", ".svelte"}
+)
+
+// ReadGoModulePath returns the module path declared in root/go.mod, or "".
+func ReadGoModulePath(root string) string {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+	return ""
+}
+
+// ExtractDependencies parses the import statements of a source file.
+// Imports that point inside the repository are returned as repo-relative,
+// slash-separated paths: the package directory for Go, the target path
+// without extension for relative JS/TS and Python imports, and the header
+// path for C includes. Everything else (standard library, third-party
+// packages, dotted JVM names) is returned as written, in source order with
+// duplicates removed.
+func ExtractDependencies(file FileInfo, goModulePath string) []string {
+	if file.Content == "" {
+		return nil
+	}
+	filePath := filepath.ToSlash(file.Path)
+	dir := path.Dir(filePath)
+
+	var deps []string
+	switch file.Extension {
+	case ".go":
+		deps = goImports(file.Content, goModulePath)
+	case ".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs", ".vue", ".svelte":
+		for _, m := range jsImportRE.FindAllStringSubmatch(file.Content, -1) {
+			spec := firstNonEmpty(m[1:]...)
+			if strings.HasPrefix(spec, ".") {
+				spec = stripExtension(path.Join(dir, spec), jsResolvableExt)
+			}
+			deps = append(deps, spec)
+		}
+	case ".py":
+		for _, m := range pyImportRE.FindAllStringSubmatch(file.Content, -1) {
+			for _, part := range strings.Split(m[1], ",") {
+				deps = append(deps, strings.Fields(part)[0])
+			}
+		}
+		for _, m := range pyFromImportRE.FindAllStringSubmatch(file.Content, -1) {
+			dots, module := m[1], m[2]
+			if dots == "" {
+				deps = append(deps, module)
+				continue
+			}
+			base := dir
+			for i := 1; i < len(dots); i++ {
+				base = path.Dir(base)
+			}
+			deps = append(deps, path.Join(base, strings.ReplaceAll(module, ".", "/")))
+		}
+	case ".c", ".cc", ".cpp", ".h", ".hpp":
+		for _, m := range cIncludeRE.FindAllStringSubmatch(file.Content, -1) {
+			deps = append(deps, path.Join(dir, m[1]))
+		}
+	case ".java", ".kt", ".scala":
+		for _, m := range jvmImportRE.FindAllStringSubmatch(file.Content, -1) {
+			deps = append(deps, m[1])
+		}
+	}
+	return dedupe(deps)
+}
+
+// goImports parses a Go file's import block, mapping imports of the
+// repository's own module to repo-relative directories.
+func goImports(content, modulePath string) []string {
+	parsed, err := parser.ParseFile(token.NewFileSet(), "", content, parser.ImportsOnly)
+	if err != nil {
+		return nil
+	}
+	var deps []string
+	for _, imp := range parsed.Imports {
+		spec, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if modulePath != "" {
+			if spec == modulePath {
+				spec = "."
+			} else if rel, ok := strings.CutPrefix(spec, modulePath+"/"); ok {
+				spec = rel
+			}
+		}
+		deps = append(deps, spec)
+	}
+	return deps
+}
+
+func stripExtension(p string, exts []string) string {
+	for _, ext := range exts {
+		if strings.HasSuffix(p, ext) {
+			return strings.TrimSuffix(p, ext)
+		}
+	}
+	return p
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
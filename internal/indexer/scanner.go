@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -35,10 +36,11 @@ type FolderInfo struct {
 
 // ScanResult holds the complete scan result
 type ScanResult struct {
-	RootPath string
-	Name     string
-	Folders  map[string]*FolderInfo
-	Files    []FileInfo
+	RootPath   string
+	Name       string
+	ModulePath string // Go module path from go.mod, if any
+	Folders    map[string]*FolderInfo
+	Files      []FileInfo
 }
 
 var ignoredDirs = map[string]bool{
@@ -121,20 +123,29 @@ var languageMap = map[string]string{
 	".svelte":  "Svelte",
 }
 
+// LanguageForPath returns the language of a file from its extension, or ""
+// if it is not recognised.
+func LanguageForPath(filePath string) string {
+	return languageMap[strings.ToLower(filepath.Ext(filePath))]
+}
+
 // ScanCodebase scans a directory and returns information about all files and folders.
 // If includeFolders is non-nil, only scans those selected folders (supports nested paths).
 // Use "." or empty string in includeFolders to include root-level files. Nil = scan everything.
-func ScanCodebase(rootPath string, maxFileSize int64, includeFolders []string) (*ScanResult, error) {
+// Files and folders matching any of ignorePatterns (globs matched against the
+// name or the slash-separated relative path) are skipped.
+func ScanCodebase(rootPath string, maxFileSize int64, includeFolders []string, ignorePatterns []string) (*ScanResult, error) {
 	absPath, err := filepath.Abs(rootPath)
 	if err != nil {
 		return nil, err
 	}
 
 	result := &ScanResult{
-		RootPath: absPath,
-		Name:     filepath.Base(absPath),
-		Folders:  make(map[string]*FolderInfo),
-		Files:    []FileInfo{},
+		RootPath:   absPath,
+		Name:       filepath.Base(absPath),
+		ModulePath: ReadGoModulePath(absPath),
+		Folders:    make(map[string]*FolderInfo),
+		Files:      []FileInfo{},
 	}
 
 	// Add root folder
@@ -218,7 +229,7 @@ func ScanCodebase(rootPath string, maxFileSize int64, includeFolders []string) (
 			if !dirShouldVisit(relPath) {
 				return filepath.SkipDir
 			}
-			if ignoredDirs[d.Name()] {
+			if ignoredDirs[d.Name()] || matchesIgnorePattern(relPath, ignorePatterns) {
 				return filepath.SkipDir
 			}
 			// Skip hidden directories
@@ -265,6 +276,9 @@ func ScanCodebase(rootPath string, maxFileSize int64, includeFolders []string) (
 		if strings.HasPrefix(d.Name(), ".") {
 			return nil
 		}
+		if matchesIgnorePattern(relPath, ignorePatterns) {
+			return nil
+		}
 
 		info, err := d.Info()
 		if err != nil {
@@ -402,3 +416,24 @@ func DetectTechStack(files []FileInfo) map[string]int {
 
 	return stack
 }
+
+// matchesIgnorePattern reports whether relPath, or its base name, matches one
+// of the glob patterns. A trailing slash on a pattern is ignored, so "docs/"
+// and "docs" both skip the docs folder.
+func matchesIgnorePattern(relPath string, patterns []string) bool {
+	slashPath := filepath.ToSlash(relPath)
+	name := filepath.Base(relPath)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(filepath.ToSlash(strings.TrimSpace(pattern)), "/")
+		if pattern == "" {
+			continue
+		}
+		if ok, _ := path.Match(pattern, slashPath); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
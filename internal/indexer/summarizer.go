@@ -11,13 +11,31 @@ import (
 
 // Summarizer generates summaries for code files and folders.
 type Summarizer struct {
-	client  llm.Client
-	verbose bool
+	client     llm.Client
+	fileClient llm.Client
+	timeout    time.Duration
+	verbose    bool
+
+	fileMaxWords   int
+	folderMaxWords int
 }
 
-// NewSummarizer creates a new summarizer.
-func NewSummarizer(client llm.Client, verbose bool) *Summarizer {
-	return &Summarizer{client: client, verbose: verbose}
+// NewSummarizer creates a new summarizer. fileClient, if non-nil, is used
+// for per-file summaries (typically a cheaper model, since files dominate
+// token usage); folder and codebase summaries use client. timeout bounds
+// each individual LLM request.
+func NewSummarizer(client, fileClient llm.Client, timeout time.Duration, verbose bool) *Summarizer {
+	if fileClient == nil {
+		fileClient = client
+	}
+	return &Summarizer{client: client, fileClient: fileClient, timeout: timeout, verbose: verbose}
+}
+
+// SetMaxWords sets word budgets for file and folder summaries; 0 leaves
+// their length to the model.
+func (s *Summarizer) SetMaxWords(fileWords, folderWords int) {
+	s.fileMaxWords = fileWords
+	s.folderMaxWords = folderWords
 }
 
 // FileSummary holds the generated summary for a file
@@ -43,12 +61,12 @@ func (s *Summarizer) SummarizeFile(ctx context.Context, file FileInfo) (*FileSum
 		content = content[:2000]
 	}
 
-	prompt := prompts.BuildFileSummaryPrompt(file.Path, file.Language, content)
+	prompt := prompts.WithWordBudget(prompts.BuildFileSummaryPrompt(file.Path, file.Language, content), s.fileMaxWords)
 
-	ctx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	response, err := s.client.Complete(ctx, prompt)
+	response, err := s.fileClient.Complete(ctx, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -94,13 +112,13 @@ func (s *Summarizer) SummarizeFolder(ctx context.Context, folder *FolderInfo, to
 		touched = "None"
 	}
 
-	prompt := prompts.BuildFolderSummaryPrompt(
+	prompt := prompts.WithWordBudget(prompts.BuildFolderSummaryPrompt(
 		folder.Path,
 		files,
 		subfolders,
-		touched)
+		touched), s.folderMaxWords)
 
-	ctx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
 	response, err := s.client.Complete(ctx, prompt)
@@ -130,7 +148,7 @@ func (s *Summarizer) SummarizeCodebase(ctx context.Context, result *ScanResult,
 		len(result.Files),
 		readmeContent)
 
-	ctx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
 	response, err := s.client.Complete(ctx, prompt)
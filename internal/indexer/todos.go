@@ -0,0 +1,52 @@
+package indexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Technical-debt marker kinds, as they are written in code.
+const (
+	TodoKindTodo  = "TODO"
+	TodoKindFixme = "FIXME"
+	TodoKindHack  = "HACK"
+)
+
+// todoMarkerMaxText caps how much of a marker's comment is kept.
+const todoMarkerMaxText = 200
+
+// todoMarkerRE matches an upper-case TODO, FIXME or HACK word and the text
+// after it, e.g. "// TODO(alice): handle retries".
+var todoMarkerRE = regexp.MustCompile(`\b(TODO|FIXME|HACK)\b(?:\([^)]*\))?:?\s*(.*)`)
+
+// TodoMarker is a TODO, FIXME or HACK comment found in a file.
+type TodoMarker struct {
+	Path string
+	Line int // 1-based
+	Kind string
+	Text string
+}
+
+// FindTodoMarkers returns the debt markers in a file's content, at most
+// one per line.
+func FindTodoMarkers(filePath, content string) []TodoMarker {
+	if !strings.Contains(content, TodoKindTodo) && !strings.Contains(content, TodoKindFixme) && !strings.Contains(content, TodoKindHack) {
+		return nil
+	}
+	var markers []TodoMarker
+	for i, line := range strings.Split(content, "\n") {
+		m := todoMarkerRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		text := strings.TrimSpace(m[2])
+		for _, closer := range []string{"*/", "-->", "#}"} {
+			text = strings.TrimSpace(strings.TrimSuffix(text, closer))
+		}
+		if runes := []rune(text); len(runes) > todoMarkerMaxText {
+			text = string(runes[:todoMarkerMaxText]) + "..."
+		}
+		markers = append(markers, TodoMarker{Path: filePath, Line: i + 1, Kind: m[1], Text: text})
+	}
+	return markers
+}
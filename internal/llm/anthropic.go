@@ -32,6 +32,7 @@ type anthropicRequest struct {
 	MaxTokens int                `json:"max_tokens"`
 	Messages  []anthropicMessage `json:"messages"`
 	System    string             `json:"system,omitempty"`
+	Stream    bool               `json:"stream,omitempty"`
 }
 
 type anthropicMessage struct {
@@ -50,6 +51,20 @@ type anthropicResponse struct {
 	} `json:"error,omitempty"`
 }
 
+// anthropicStreamEvent is one server-sent event of a streamed message. Only
+// text deltas and errors are used.
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
 func (c *AnthropicClient) Complete(ctx context.Context, prompt string) (string, error) {
 	messages := []Message{
 		{Role: "user", Content: prompt},
@@ -92,7 +107,7 @@ func (c *AnthropicClient) ChatComplete(ctx context.Context, messages []Message)
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", requestError(err)
 	}
 	defer resp.Body.Close()
 
@@ -102,7 +117,7 @@ func (c *AnthropicClient) ChatComplete(ctx context.Context, messages []Message)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Anthropic API error (status %d): %s", resp.StatusCode, string(body))
+		return "", newAPIError("Anthropic", resp.StatusCode, string(body))
 	}
 
 	var result anthropicResponse
@@ -111,7 +126,7 @@ func (c *AnthropicClient) ChatComplete(ctx context.Context, messages []Message)
 	}
 
 	if result.Error != nil {
-		return "", fmt.Errorf("Anthropic API error: %s", result.Error.Message)
+		return "", newAPIError("Anthropic", 0, result.Error.Message)
 	}
 
 	if len(result.Content) == 0 {
@@ -127,3 +142,64 @@ func (c *AnthropicClient) ChatComplete(ctx context.Context, messages []Message)
 
 	return strings.TrimSpace(text), nil
 }
+
+func (c *AnthropicClient) CompleteStream(ctx context.Context, prompt string) (<-chan StreamChunk, error) {
+	reqBody := anthropicRequest{
+		Model:     c.model,
+		MaxTokens: 4096,
+		Messages:  []anthropicMessage{{Role: "user", Content: prompt}},
+		Stream:    true,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if err := checkStreamResponse(resp, "Anthropic"); err != nil {
+		return nil, err
+	}
+
+	return streamLines(ctx, resp.Body, extractAnthropicStreamChunk), nil
+}
+
+// extractAnthropicStreamChunk parses one SSE line of a Messages API stream.
+// Failures part-way through arrive as an "error" event.
+func extractAnthropicStreamChunk(line []byte) (string, bool, error) {
+	data := sseData(line)
+	if data == nil {
+		return "", false, nil
+	}
+	var event anthropicStreamEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return "", false, err
+	}
+	switch event.Type {
+	case "content_block_delta":
+		if event.Delta.Type == "text_delta" {
+			return event.Delta.Text, false, nil
+		}
+	case "message_stop":
+		return "", true, nil
+	case "error":
+		if event.Error != nil {
+			return "", false, newAPIError("Anthropic", 0, event.Error.Message)
+		}
+		return "", false, fmt.Errorf("Anthropic API error")
+	}
+	return "", false, nil
+}
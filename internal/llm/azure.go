@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AzureOpenAIClient talks to an Azure OpenAI deployment. Azure routes requests
+// by deployment name rather than model, and authenticates with an api-key header.
+type AzureOpenAIClient struct {
+	endpoint   string
+	deployment string
+	apiVersion string
+	apiKey     string
+	client     *http.Client
+}
+
+func NewAzureOpenAIClient(endpoint, deployment, apiVersion, apiKey string) *AzureOpenAIClient {
+	return &AzureOpenAIClient{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		deployment: deployment,
+		apiVersion: apiVersion,
+		apiKey:     apiKey,
+		client:     &http.Client{},
+	}
+}
+
+func (c *AzureOpenAIClient) Complete(ctx context.Context, prompt string) (string, error) {
+	messages := []Message{
+		{Role: "user", Content: prompt},
+	}
+	return c.ChatComplete(ctx, messages)
+}
+
+func (c *AzureOpenAIClient) ChatComplete(ctx context.Context, messages []Message) (string, error) {
+	azureMessages := make([]openAIMessage, len(messages))
+	for i, m := range messages {
+		azureMessages[i] = openAIMessage(m)
+	}
+
+	// The deployment determines the model; the field is ignored by Azure.
+	reqBody := openAIChatRequest{
+		Model:    c.deployment,
+		Messages: azureMessages,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+		c.endpoint, url.PathEscape(c.deployment), url.QueryEscape(c.apiVersion))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("api-key", c.apiKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", requestError(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result openAIChatResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return "", newAPIError("Azure OpenAI", resp.StatusCode, string(bytes.TrimSpace(body)))
+		}
+		return "", fmt.Errorf("failed to unmarshal response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if result.Error != nil {
+		return "", newAPIError("Azure OpenAI", resp.StatusCode, result.Error.Message)
+	}
+
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("no choices in response")
+	}
+
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
+
+func (c *AzureOpenAIClient) CompleteStream(ctx context.Context, prompt string) (<-chan StreamChunk, error) {
+	reqBody := openAIChatRequest{
+		Model:    c.deployment,
+		Messages: []openAIMessage{{Role: "user", Content: prompt}},
+		Stream:   true,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+		c.endpoint, url.PathEscape(c.deployment), url.QueryEscape(c.apiVersion))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("api-key", c.apiKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if err := checkStreamResponse(resp, "Azure OpenAI"); err != nil {
+		return nil, err
+	}
+	return streamLines(ctx, resp.Body, extractOpenAIStreamChunk), nil
+}
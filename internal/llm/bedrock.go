@@ -105,7 +105,7 @@ func (c *BedrockClient) ChatComplete(ctx context.Context, messages []Message) (s
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", requestError(err)
 	}
 	defer resp.Body.Close()
 
@@ -115,7 +115,7 @@ func (c *BedrockClient) ChatComplete(ctx context.Context, messages []Message) (s
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Bedrock API error (status %d): %s", resp.StatusCode, string(body))
+		return "", newAPIError("Bedrock", resp.StatusCode, string(body))
 	}
 
 	var result bedrockClaudeResponse
@@ -137,6 +137,13 @@ func (c *BedrockClient) ChatComplete(ctx context.Context, messages []Message) (s
 	return strings.TrimSpace(text), nil
 }
 
+// CompleteStream returns the whole completion as a single chunk; Bedrock's
+// streaming endpoint uses the binary AWS event-stream encoding, which is not
+// supported here.
+func (c *BedrockClient) CompleteStream(ctx context.Context, prompt string) (<-chan StreamChunk, error) {
+	return completeAsStream(ctx, c.Complete, prompt)
+}
+
 // signRequest signs the request using AWS Signature Version 4
 func (c *BedrockClient) signRequest(req *http.Request, payload []byte) error {
 	now := time.Now().UTC()
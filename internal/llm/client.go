@@ -15,28 +15,45 @@ type Message struct {
 type Client interface {
 	Complete(ctx context.Context, prompt string) (string, error)
 	ChatComplete(ctx context.Context, messages []Message) (string, error)
+	// CompleteStream sends a single-prompt completion and returns a channel of
+	// text chunks as they are generated. Request and HTTP status errors are
+	// returned directly; an error part-way through arrives as a final chunk
+	// with Err set. The channel is closed when generation finishes, the stream
+	// fails, or ctx is cancelled.
+	CompleteStream(ctx context.Context, prompt string) (<-chan StreamChunk, error)
+}
+
+// Embedder is implemented by clients that can turn text into a vector
+// embedding for semantic search.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float64, error)
 }
 
 type Provider = constants.Provider
 
 const (
-	ProviderOllama     = constants.ProviderOllama
-	ProviderOpenAI     = constants.ProviderOpenAI
-	ProviderChatGPT    = constants.ProviderChatGPT
-	ProviderAnthropic  = constants.ProviderAnthropic
-	ProviderBedrock    = constants.ProviderBedrock
-	ProviderOpenRouter = constants.ProviderOpenRouter
-	ProviderGemini     = constants.ProviderGemini
+	ProviderOllama      = constants.ProviderOllama
+	ProviderOpenAI      = constants.ProviderOpenAI
+	ProviderChatGPT     = constants.ProviderChatGPT
+	ProviderAnthropic   = constants.ProviderAnthropic
+	ProviderBedrock     = constants.ProviderBedrock
+	ProviderOpenRouter  = constants.ProviderOpenRouter
+	ProviderGemini      = constants.ProviderGemini
+	ProviderAzureOpenAI = constants.ProviderAzureOpenAI
 )
 
 type Config struct {
 	Provider           Provider
 	Model              string
+	EmbeddingModel     string
 	BaseURL            string
 	APIKey             string
 	AWSRegion          string
 	AWSAccessKeyID     string
 	AWSSecretAccessKey string
+	AzureEndpoint      string
+	AzureDeployment    string
+	AzureAPIVersion    string
 }
 
 type Option func(*Config)
@@ -141,7 +158,7 @@ func NewClient(cfg Config) (Client, error) {
 		if baseURL == "" {
 			baseURL = "http://localhost:11434"
 		}
-		return NewOllamaClient(baseURL, cfg.Model), nil
+		return NewOllamaClient(baseURL, cfg.Model).WithEmbeddingModel(cfg.EmbeddingModel), nil
 	case ProviderOpenAI:
 		if cfg.APIKey == "" {
 			return nil, fmt.Errorf("OpenAI API key is required")
@@ -187,6 +204,18 @@ func NewClient(cfg Config) (Client, error) {
 			baseURL = "https://generativelanguage.googleapis.com/v1beta"
 		}
 		return NewGeminiClient(baseURL, cfg.APIKey, cfg.Model), nil
+	case ProviderAzureOpenAI:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("Azure OpenAI API key is required")
+		}
+		if cfg.AzureEndpoint == "" || cfg.AzureDeployment == "" {
+			return nil, fmt.Errorf("Azure OpenAI endpoint and deployment are required; run 'devlog onboard' to configure")
+		}
+		apiVersion := cfg.AzureAPIVersion
+		if apiVersion == "" {
+			apiVersion = constants.GetDefaultAzureAPIVersion()
+		}
+		return NewAzureOpenAIClient(cfg.AzureEndpoint, cfg.AzureDeployment, apiVersion, cfg.APIKey), nil
 	default:
 		return nil, fmt.Errorf("unknown provider: %s", cfg.Provider)
 	}
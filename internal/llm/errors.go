@@ -0,0 +1,109 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Kinds of provider failure. Client errors wrap one of these when the cause
+// is recognised, so callers can tell them apart with errors.Is and decide
+// whether to retry, abort or explain.
+var (
+	// ErrAuth means the API key or credentials were rejected.
+	ErrAuth = errors.New("authentication failed")
+	// ErrRateLimit means the provider throttled the request or the quota
+	// ran out; retrying later may succeed.
+	ErrRateLimit = errors.New("rate limited")
+	// ErrContextLength means the prompt is larger than the model accepts.
+	ErrContextLength = errors.New("prompt exceeds the model's context length")
+	// ErrUnavailable means the provider could not be reached or failed on
+	// its side; retrying later may succeed.
+	ErrUnavailable = errors.New("provider unavailable")
+)
+
+// APIError is an error response from a provider's API.
+type APIError struct {
+	Provider   string
+	StatusCode int // 0 when the error came in a response body or stream
+	Message    string
+	Kind       error // ErrAuth, ErrRateLimit, ErrContextLength, ErrUnavailable or nil
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s API error: %s", e.Provider, e.Message)
+	}
+	return fmt.Sprintf("%s API error (status %d): %s", e.Provider, e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// newAPIError builds the error for a failed API call, classifying it from
+// its status code and message.
+func newAPIError(provider string, statusCode int, message string) error {
+	return &APIError{Provider: provider, StatusCode: statusCode, Message: message, Kind: classifyAPIError(statusCode, message)}
+}
+
+// contextLengthPhrases are fragments of the messages providers use when a
+// prompt is too long, since several report it as a plain 400.
+var contextLengthPhrases = []string{
+	"context length", "context_length", "context window", "maximum context",
+	"too many tokens", "prompt is too long", "input is too long", "token limit",
+	"reduce the length",
+}
+
+// classifyAPIError maps a status code and message to a kind of failure, or
+// nil when it is none of them.
+func classifyAPIError(statusCode int, message string) error {
+	lower := strings.ToLower(message)
+	for _, phrase := range contextLengthPhrases {
+		if strings.Contains(lower, phrase) {
+			return ErrContextLength
+		}
+	}
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrAuth
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimit
+	case statusCode == http.StatusRequestEntityTooLarge:
+		return ErrContextLength
+	case statusCode >= 500:
+		// Includes Anthropic's 529 "overloaded".
+		return ErrUnavailable
+	case statusCode == 0 && (strings.Contains(lower, "rate limit") || strings.Contains(lower, "overloaded")):
+		return ErrRateLimit
+	}
+	return nil
+}
+
+// requestError wraps a failure to send a request or get its response.
+// Cancellation and deadlines are passed through as they are; anything else
+// (refused connection, DNS, reset) means the provider is unavailable.
+func requestError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	return fmt.Errorf("failed to send request: %w: %w", ErrUnavailable, err)
+}
+
+// Describe returns a short hint for the user about a classified LLM error,
+// or "" when there is nothing more specific to say than the error itself.
+func Describe(err error) string {
+	switch {
+	case errors.Is(err, ErrAuth):
+		return "the provider rejected your credentials; check the API key with 'devlog models' or 'devlog onboard'"
+	case errors.Is(err, ErrRateLimit):
+		return "the provider is rate limiting requests; wait a moment and run the command again"
+	case errors.Is(err, ErrContextLength):
+		return "the prompt exceeded the model's context; use a shorter range or lower --max-commits, or pick a model with a larger context"
+	case errors.Is(err, ErrUnavailable):
+		return "the provider could not be reached or failed on its side; check that it is running and try again"
+	}
+	return ""
+}
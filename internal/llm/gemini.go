@@ -2,7 +2,9 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
 	"strings"
 
 	"google.golang.org/genai"
@@ -108,9 +110,61 @@ func (c *GeminiClient) ChatComplete(ctx context.Context, messages []Message) (st
 	// Call the GenerateContent method
 	result, err := c.client.Models.GenerateContent(ctx, c.model, contents, config)
 	if err != nil {
-		return "", fmt.Errorf("Gemini API error: %w", err)
+		return "", geminiError(err)
 	}
 
 	// Extract text from result
 	return result.Text(), nil
 }
+
+func (c *GeminiClient) CompleteStream(ctx context.Context, prompt string) (<-chan StreamChunk, error) {
+	if err := c.ensureClient(ctx); err != nil {
+		return nil, err
+	}
+
+	contents := []*genai.Content{
+		{Role: "user", Parts: []*genai.Part{genai.NewPartFromText(prompt)}},
+	}
+	config := &genai.GenerateContentConfig{}
+	if strings.Contains(c.model, "pro") {
+		config.ThinkingConfig = &genai.ThinkingConfig{
+			ThinkingLevel: "HIGH",
+		}
+	}
+
+	// The SDK only issues the request once iteration starts, so pull the
+	// first chunk here to surface request errors to the caller.
+	next, stop := iter.Pull2(c.client.Models.GenerateContentStream(ctx, c.model, contents, config))
+	resp, err, ok := next()
+	if ok && err != nil {
+		stop()
+		return nil, geminiError(err)
+	}
+
+	out := make(chan StreamChunk, streamBufferSize)
+	go func() {
+		defer close(out)
+		defer stop()
+		for ok {
+			if err != nil {
+				sendStreamError(ctx, out, geminiError(err))
+				return
+			}
+			if !sendChunk(ctx, out, resp.Text()) {
+				return
+			}
+			resp, err, ok = next()
+		}
+	}()
+	return out, nil
+}
+
+// geminiError classifies an error from the Gemini SDK like the HTTP clients'
+// errors.
+func geminiError(err error) error {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		return newAPIError("Gemini", apiErr.Code, apiErr.Message)
+	}
+	return fmt.Errorf("Gemini API error: %w", err)
+}
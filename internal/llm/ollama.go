@@ -8,12 +8,15 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/ishaan812/devlog/internal/constants"
 )
 
 type OllamaClient struct {
-	baseURL string
-	model   string
-	client  *http.Client
+	baseURL        string
+	model          string
+	embeddingModel string
+	client         *http.Client
 }
 
 func NewOllamaClient(baseURL, model string) *OllamaClient {
@@ -51,6 +54,22 @@ type ollamaChatResponse struct {
 	Done    bool          `json:"done"`
 }
 
+type ollamaEmbeddingRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+type ollamaEmbeddingResponse struct {
+	Embedding []float64 `json:"embedding"`
+}
+
+// WithEmbeddingModel sets the model used by Embed. Embedding models are
+// separate from chat models in Ollama (e.g. nomic-embed-text).
+func (c *OllamaClient) WithEmbeddingModel(model string) *OllamaClient {
+	c.embeddingModel = model
+	return c
+}
+
 func (c *OllamaClient) Complete(ctx context.Context, prompt string) (string, error) {
 	reqBody := ollamaGenerateRequest{
 		Model:  c.model,
@@ -71,13 +90,13 @@ func (c *OllamaClient) Complete(ctx context.Context, prompt string) (string, err
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", requestError(err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("ollama returned status %d: %s", resp.StatusCode, string(body))
+		return "", newAPIError("Ollama", resp.StatusCode, string(body))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -93,6 +112,42 @@ func (c *OllamaClient) Complete(ctx context.Context, prompt string) (string, err
 	return strings.TrimSpace(result.Response), nil
 }
 
+func (c *OllamaClient) CompleteStream(ctx context.Context, prompt string) (<-chan StreamChunk, error) {
+	reqBody := ollamaGenerateRequest{
+		Model:  c.model,
+		Prompt: prompt,
+		Stream: true,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/generate", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if err := checkStreamResponse(resp, "Ollama"); err != nil {
+		return nil, err
+	}
+
+	// Ollama streams newline-delimited JSON objects rather than SSE.
+	return streamLines(ctx, resp.Body, func(line []byte) (string, bool, error) {
+		var chunk ollamaGenerateResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return "", false, err
+		}
+		return chunk.Response, chunk.Done, nil
+	}), nil
+}
+
 func (c *OllamaClient) ChatComplete(ctx context.Context, messages []Message) (string, error) {
 	ollamaMessages := make([]ollamaMessage, len(messages))
 	for i, m := range messages {
@@ -118,13 +173,13 @@ func (c *OllamaClient) ChatComplete(ctx context.Context, messages []Message) (st
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", requestError(err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("ollama returned status %d: %s", resp.StatusCode, string(body))
+		return "", newAPIError("Ollama", resp.StatusCode, string(body))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -139,3 +194,42 @@ func (c *OllamaClient) ChatComplete(ctx context.Context, messages []Message) (st
 
 	return strings.TrimSpace(result.Message.Content), nil
 }
+
+func (c *OllamaClient) Embed(ctx context.Context, text string) ([]float64, error) {
+	model := c.embeddingModel
+	if model == "" {
+		model = constants.DefaultOllamaEmbeddingModel
+	}
+
+	jsonBody, err := json.Marshal(ollamaEmbeddingRequest{Model: model, Prompt: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/embeddings", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError("Ollama", resp.StatusCode, string(body))
+	}
+
+	var result ollamaEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if len(result.Embedding) == 0 {
+		return nil, fmt.Errorf("ollama returned an empty embedding; is %q an embedding model? Try: ollama pull %s", model, model)
+	}
+
+	return result.Embedding, nil
+}
@@ -29,6 +29,7 @@ func NewOpenAIClient(baseURL, apiKey, model string) *OpenAIClient {
 type openAIChatRequest struct {
 	Model    string          `json:"model"`
 	Messages []openAIMessage `json:"messages"`
+	Stream   bool            `json:"stream,omitempty"`
 }
 
 type openAIMessage struct {
@@ -45,6 +46,18 @@ type openAIChatResponse struct {
 	} `json:"error,omitempty"`
 }
 
+// openAIStreamChunk is one server-sent event of a streamed chat completion.
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
 func (c *OpenAIClient) Complete(ctx context.Context, prompt string) (string, error) {
 	messages := []Message{
 		{Role: "user", Content: prompt},
@@ -77,7 +90,7 @@ func (c *OpenAIClient) ChatComplete(ctx context.Context, messages []Message) (st
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", requestError(err)
 	}
 	defer resp.Body.Close()
 
@@ -88,11 +101,14 @@ func (c *OpenAIClient) ChatComplete(ctx context.Context, messages []Message) (st
 
 	var result openAIChatResponse
 	if err := json.Unmarshal(body, &result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return "", newAPIError("OpenAI", resp.StatusCode, string(bytes.TrimSpace(body)))
+		}
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	if result.Error != nil {
-		return "", fmt.Errorf("OpenAI API error: %s", result.Error.Message)
+		return "", newAPIError("OpenAI", resp.StatusCode, result.Error.Message)
 	}
 
 	if len(result.Choices) == 0 {
@@ -101,3 +117,56 @@ func (c *OpenAIClient) ChatComplete(ctx context.Context, messages []Message) (st
 
 	return strings.TrimSpace(result.Choices[0].Message.Content), nil
 }
+
+func (c *OpenAIClient) CompleteStream(ctx context.Context, prompt string) (<-chan StreamChunk, error) {
+	reqBody := openAIChatRequest{
+		Model:    c.model,
+		Messages: []openAIMessage{{Role: "user", Content: prompt}},
+		Stream:   true,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if err := checkStreamResponse(resp, "OpenAI"); err != nil {
+		return nil, err
+	}
+	return streamLines(ctx, resp.Body, extractOpenAIStreamChunk), nil
+}
+
+// extractOpenAIStreamChunk parses one SSE line of an OpenAI-compatible chat
+// completion stream. Azure OpenAI and OpenRouter use the same format.
+func extractOpenAIStreamChunk(line []byte) (string, bool, error) {
+	data := sseData(line)
+	if data == nil {
+		return "", false, nil
+	}
+	if string(data) == "[DONE]" {
+		return "", true, nil
+	}
+	var chunk openAIStreamChunk
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return "", false, err
+	}
+	if chunk.Error != nil {
+		return "", false, fmt.Errorf("stream error: %s", chunk.Error.Message)
+	}
+	if len(chunk.Choices) == 0 {
+		return "", false, nil
+	}
+	return chunk.Choices[0].Delta.Content, false, nil
+}
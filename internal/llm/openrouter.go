@@ -30,6 +30,7 @@ func NewOpenRouterClient(apiKey, model string) *OpenRouterClient {
 type openRouterChatRequest struct {
 	Model    string              `json:"model"`
 	Messages []openRouterMessage `json:"messages"`
+	Stream   bool                `json:"stream,omitempty"`
 }
 
 type openRouterMessage struct {
@@ -82,7 +83,7 @@ func (c *OpenRouterClient) ChatComplete(ctx context.Context, messages []Message)
 	resp, err := c.client.Do(req)
 	if err != nil {
 		printCurlCommand("POST", c.baseURL+"/chat/completions", req.Header, jsonBody)
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", requestError(err)
 	}
 	defer resp.Body.Close()
 
@@ -100,7 +101,7 @@ func (c *OpenRouterClient) ChatComplete(ctx context.Context, messages []Message)
 
 	if result.Error != nil {
 		printCurlCommand("POST", c.baseURL+"/chat/completions", req.Header, jsonBody)
-		return "", fmt.Errorf("OpenRouter API error: %s (code %d)", result.Error.Message, result.Error.Code)
+		return "", newAPIError("OpenRouter", result.Error.Code, result.Error.Message)
 	}
 
 	if len(result.Choices) == 0 {
@@ -110,6 +111,38 @@ func (c *OpenRouterClient) ChatComplete(ctx context.Context, messages []Message)
 	return strings.TrimSpace(result.Choices[0].Message.Content), nil
 }
 
+func (c *OpenRouterClient) CompleteStream(ctx context.Context, prompt string) (<-chan StreamChunk, error) {
+	reqBody := openRouterChatRequest{
+		Model:    c.model,
+		Messages: []openRouterMessage{{Role: "user", Content: prompt}},
+		Stream:   true,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("HTTP-Referer", "https://github.com/ishaan812/devlog")
+	req.Header.Set("X-Title", "DevLog")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if err := checkStreamResponse(resp, "OpenRouter"); err != nil {
+		return nil, err
+	}
+	return streamLines(ctx, resp.Body, extractOpenAIStreamChunk), nil
+}
+
 // printCurlCommand prints the equivalent curl command for debugging
 func printCurlCommand(method, url string, headers http.Header, body []byte) {
 	fmt.Fprintf(os.Stderr, "\n[DEBUG] Equivalent curl command:\n")
@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// streamBufferSize is the number of chunks buffered between the reader
+// goroutine and the consumer of a stream.
+const streamBufferSize = 64
+
+// maxStreamLineSize bounds a single SSE or NDJSON line.
+const maxStreamLineSize = 1024 * 1024
+
+// StreamChunk is one piece of a streamed completion. A chunk with a non-nil
+// Err is the last one sent: the stream failed part-way and Text is empty.
+type StreamChunk struct {
+	Text string
+	Err  error
+}
+
+// sendChunk delivers a text chunk unless the context is cancelled first.
+func sendChunk(ctx context.Context, out chan<- StreamChunk, text string) bool {
+	if text == "" {
+		return true
+	}
+	select {
+	case out <- StreamChunk{Text: text}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sendStreamError delivers the error that ended a stream. The channel is
+// buffered, but a consumer that has stopped reading must not block the
+// reader goroutine forever, so cancellation still wins.
+func sendStreamError(ctx context.Context, out chan<- StreamChunk, err error) {
+	select {
+	case out <- StreamChunk{Err: err}:
+	case <-ctx.Done():
+	}
+}
+
+// checkStreamResponse turns a non-200 streaming response into an error,
+// closing the body. name identifies the provider in the message.
+func checkStreamResponse(resp *http.Response, name string) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return newAPIError(name, resp.StatusCode, string(bytes.TrimSpace(body)))
+}
+
+// streamLines reads a response body line by line in a goroutine, passing each
+// non-empty line to extract and forwarding the returned text. extract reports
+// done=true to end the stream early. An extract or read error is sent as a
+// final chunk. The channel is closed when the body ends, extract fails, or
+// ctx is cancelled.
+func streamLines(ctx context.Context, body io.ReadCloser, extract func(line []byte) (text string, done bool, err error)) <-chan StreamChunk {
+	out := make(chan StreamChunk, streamBufferSize)
+	go func() {
+		defer close(out)
+		defer body.Close()
+
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			text, done, err := extract(line)
+			if err != nil {
+				sendStreamError(ctx, out, err)
+				return
+			}
+			if !sendChunk(ctx, out, text) || done {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil && ctx.Err() == nil {
+			sendStreamError(ctx, out, fmt.Errorf("failed to read stream: %w", err))
+		}
+	}()
+	return out
+}
+
+// sseData returns the payload of an SSE "data:" line, or nil for other lines
+// (event names, comments, ids).
+func sseData(line []byte) []byte {
+	if !bytes.HasPrefix(line, []byte("data:")) {
+		return nil
+	}
+	return bytes.TrimSpace(line[len("data:"):])
+}
+
+// completeAsStream adapts a non-streaming completion into a single-chunk
+// stream, for providers without native streaming support.
+func completeAsStream(ctx context.Context, complete func(context.Context, string) (string, error), prompt string) (<-chan StreamChunk, error) {
+	text, err := complete(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan StreamChunk, 1)
+	out <- StreamChunk{Text: text}
+	close(out)
+	return out, nil
+}
+
+// CollectStream drains a stream and returns the concatenated text, or the
+// error the stream ended with along with the text received before it.
+func CollectStream(stream <-chan StreamChunk) (string, error) {
+	var buf bytes.Buffer
+	for chunk := range stream {
+		if chunk.Err != nil {
+			return buf.String(), chunk.Err
+		}
+		buf.WriteString(chunk.Text)
+	}
+	return buf.String(), nil
+}
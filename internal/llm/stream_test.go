@@ -0,0 +1,93 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// sseServer serves body as a 200 event stream for every request.
+func sseServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOpenAICompleteStream(t *testing.T) {
+	srv := sseServer(t, `data: {"choices":[{"delta":{"content":"Hello"}}]}
+
+data: {"choices":[{"delta":{"content":", world"}}]}
+
+data: [DONE]
+
+`)
+	stream, err := NewOpenAIClient(srv.URL, "key", "gpt").CompleteStream(context.Background(), "hi")
+	if err != nil {
+		t.Fatalf("CompleteStream: %v", err)
+	}
+	text, err := CollectStream(stream)
+	if err != nil || text != "Hello, world" {
+		t.Errorf("CollectStream = %q, %v; want %q, nil", text, err, "Hello, world")
+	}
+}
+
+func TestOpenAICompleteStreamErrorEvent(t *testing.T) {
+	srv := sseServer(t, `data: {"choices":[{"delta":{"content":"Partial"}}]}
+
+data: {"error":{"message":"overloaded"}}
+
+`)
+	stream, err := NewOpenAIClient(srv.URL, "key", "gpt").CompleteStream(context.Background(), "hi")
+	if err != nil {
+		t.Fatalf("CompleteStream: %v", err)
+	}
+	text, err := CollectStream(stream)
+	if err == nil || !strings.Contains(err.Error(), "overloaded") {
+		t.Errorf("err = %v, want the stream's error event", err)
+	}
+	if text != "Partial" {
+		t.Errorf("text = %q, want the chunks before the error", text)
+	}
+}
+
+func TestAnthropicStreamErrorEvent(t *testing.T) {
+	// Anthropic reports failures mid-stream as an "error" event, which must
+	// reach the consumer rather than look like a normal end of stream.
+	body := io.NopCloser(strings.NewReader(`event: content_block_delta
+data: {"type":"content_block_delta","delta":{"type":"text_delta","text":"Hi"}}
+
+event: error
+data: {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}
+`))
+	stream := streamLines(context.Background(), body, extractAnthropicStreamChunk)
+
+	var chunks []StreamChunk
+	for chunk := range stream {
+		chunks = append(chunks, chunk)
+	}
+	if len(chunks) != 2 || chunks[0].Text != "Hi" {
+		t.Fatalf("chunks = %+v, want a text chunk then an error", chunks)
+	}
+	if last := chunks[1]; last.Err == nil || last.Text != "" || !strings.Contains(last.Err.Error(), "Overloaded") {
+		t.Errorf("final chunk = %+v, want the Anthropic error", last)
+	}
+}
+
+func TestOllamaCompleteStreamBadLine(t *testing.T) {
+	srv := sseServer(t, "{\"response\":\"ok\",\"done\":false}\nnot json\n")
+	stream, err := NewOllamaClient(srv.URL, "llama").CompleteStream(context.Background(), "hi")
+	if err != nil {
+		t.Fatalf("CompleteStream: %v", err)
+	}
+	if text, err := CollectStream(stream); err == nil || text != "ok" {
+		t.Errorf("CollectStream = %q, %v; want %q and a parse error", text, err, "ok")
+	}
+}
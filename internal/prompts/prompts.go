@@ -3,12 +3,34 @@ package prompts
 import (
 	_ "embed"
 	"fmt"
+	"path"
 	"strings"
 )
 
 //go:embed file_summary.md
 var fileSummaryPromptTemplate string
 
+//go:embed file_summary_go.md
+var fileSummaryGoFocus string
+
+//go:embed file_summary_sql.md
+var fileSummarySQLFocus string
+
+//go:embed file_summary_sql_migration.md
+var fileSummarySQLMigrationFocus string
+
+//go:embed file_summary_react.md
+var fileSummaryReactFocus string
+
+//go:embed file_summary_javascript.md
+var fileSummaryJavaScriptFocus string
+
+//go:embed file_summary_python.md
+var fileSummaryPythonFocus string
+
+//go:embed file_summary_config.md
+var fileSummaryConfigFocus string
+
 //go:embed folder_summary.md
 var folderSummaryPromptTemplate string
 
@@ -24,6 +46,12 @@ var commitSummarizerPromptTemplate string
 //go:embed worklog_overall_summary.md
 var worklogOverallSummaryPromptTemplate string
 
+//go:embed worklog_highlights.md
+var worklogHighlightsPromptTemplate string
+
+//go:embed worklog_highlights_nontechnical.md
+var worklogHighlightsNonTechnicalPromptTemplate string
+
 //go:embed worklog_day_updates.md
 var worklogDayUpdatesPromptTemplate string
 
@@ -51,11 +79,87 @@ var worklogMonthSummaryPromptTemplate string
 //go:embed worklog_month_summary_nontechnical.md
 var worklogMonthSummaryNonTechnicalPromptTemplate string
 
+//go:embed worklog_template_standup_updates.md
+var worklogTemplateStandupUpdatesPromptTemplate string
+
+//go:embed worklog_template_standup_summary.md
+var worklogTemplateStandupSummaryPromptTemplate string
+
+//go:embed worklog_template_review_updates.md
+var worklogTemplateReviewUpdatesPromptTemplate string
+
+//go:embed worklog_template_review_summary.md
+var worklogTemplateReviewSummaryPromptTemplate string
+
+//go:embed worklog_template_changelog_updates.md
+var worklogTemplateChangelogUpdatesPromptTemplate string
+
+//go:embed worklog_template_changelog_summary.md
+var worklogTemplateChangelogSummaryPromptTemplate string
+
 //go:embed commit_message.md
 var commitMessagePromptTemplate string
 
+//go:embed commit_suggestions.md
+var commitSuggestionsPromptTemplate string
+
+//go:embed commit_classify.md
+var commitClassifyPromptTemplate string
+
+//go:embed changelog.md
+var changelogPromptTemplate string
+
+//go:embed worklog_compare.md
+var worklogComparePromptTemplate string
+
+// WithWordBudget adds a length limit to a built prompt. The instruction goes
+// just above a closing cue line such as "Summary:" so the model reads it
+// last, or at the end when the prompt has none. maxWords <= 0 leaves the
+// prompt unchanged.
+func WithWordBudget(prompt string, maxWords int) string {
+	if maxWords <= 0 {
+		return prompt
+	}
+	budget := fmt.Sprintf("Keep the whole response under %d words.", maxWords)
+	if i := strings.LastIndex(prompt, "\n"); i >= 0 && strings.HasSuffix(prompt, ":") && len(prompt)-i <= 32 {
+		return prompt[:i+1] + budget + "\n\n" + prompt[i+1:]
+	}
+	return prompt + "\n\n" + budget
+}
+
+// BuildFileSummaryPrompt builds the per-file summary prompt, adding
+// questions tailored to the file's language or kind where there are any.
 func BuildFileSummaryPrompt(filePath, language, content string) string {
-	return fmt.Sprintf(strings.TrimSpace(fileSummaryPromptTemplate), filePath, language, content)
+	focus := fileSummaryFocus(filePath, language)
+	if focus != "" {
+		focus = "\n" + strings.TrimSpace(focus) + "\n"
+	}
+	return fmt.Sprintf(strings.TrimSpace(fileSummaryPromptTemplate), filePath, language, content, focus)
+}
+
+// fileSummaryFocus picks the language-specific guidance for a file from its
+// scanner language and path, or "" to use the generic prompt alone.
+func fileSummaryFocus(filePath, language string) string {
+	ext := strings.ToLower(path.Ext(filePath))
+	switch {
+	case language == "Go":
+		return fileSummaryGoFocus
+	case language == "SQL":
+		if strings.Contains(strings.ToLower(filePath), "migrat") {
+			return fileSummarySQLMigrationFocus
+		}
+		return fileSummarySQLFocus
+	case strings.HasSuffix(language, "(React)"), ext == ".vue", ext == ".svelte":
+		return fileSummaryReactFocus
+	case language == "JavaScript", language == "TypeScript":
+		return fileSummaryJavaScriptFocus
+	case language == "Python":
+		return fileSummaryPythonFocus
+	case language == "YAML", language == "JSON", language == "TOML", language == "INI", language == "Config":
+		return fileSummaryConfigFocus
+	default:
+		return ""
+	}
 }
 
 func BuildFolderSummaryPrompt(folderPath, files, subfolders, touchedFiles string) string {
@@ -90,6 +194,14 @@ func BuildWorklogOverallSummaryPromptNonTechnical(nameOfUser, projectContext, co
 	return fmt.Sprintf(strings.TrimSpace(worklogOverallSummaryNonTechnicalPromptTemplate), nameOfUser, projectContext, codebaseContext, commits, stats)
 }
 
+func BuildWorklogHighlightsPrompt(nameOfUser, projectContext, codebaseContext, commits, stats string) string {
+	return fmt.Sprintf(strings.TrimSpace(worklogHighlightsPromptTemplate), nameOfUser, projectContext, codebaseContext, commits, stats)
+}
+
+func BuildWorklogHighlightsPromptNonTechnical(nameOfUser, projectContext, codebaseContext, commits, stats string) string {
+	return fmt.Sprintf(strings.TrimSpace(worklogHighlightsNonTechnicalPromptTemplate), nameOfUser, projectContext, codebaseContext, commits, stats)
+}
+
 func BuildWorklogDayUpdatesPromptNonTechnical(nameOfUser, projectContext, branchContext, commits string) string {
 	return fmt.Sprintf(strings.TrimSpace(worklogDayUpdatesNonTechnicalPromptTemplate), nameOfUser, projectContext, branchContext, commits)
 }
@@ -102,6 +214,25 @@ func BuildCommitMessagePrompt(projectContext, diff string) string {
 	return fmt.Sprintf(strings.TrimSpace(commitMessagePromptTemplate), projectContext, diff)
 }
 
+func BuildCommitSuggestionsPrompt(count int, projectContext, changeType, diff string) string {
+	return fmt.Sprintf(strings.TrimSpace(commitSuggestionsPromptTemplate), count, projectContext, changeType, diff)
+}
+
+func BuildCommitClassifyPrompt(commitContent string) string {
+	return fmt.Sprintf(strings.TrimSpace(commitClassifyPromptTemplate), commitContent)
+}
+
+// BuildChangelogPrompt builds the release-notes prompt for 'devlog changelog'.
+func BuildChangelogPrompt(projectContext, release, commits string) string {
+	return fmt.Sprintf(strings.TrimSpace(changelogPromptTemplate), projectContext, release, commits)
+}
+
+// BuildWorklogComparePrompt builds the prompt that narrates how a worklog
+// period compares with the one before it.
+func BuildWorklogComparePrompt(nameOfUser, projectContext, periods, metrics, previousCommits, currentCommits string) string {
+	return fmt.Sprintf(strings.TrimSpace(worklogComparePromptTemplate), nameOfUser, projectContext, periods, metrics, previousCommits, currentCommits)
+}
+
 func BuildWorklogWeekSummaryPrompt(nameOfUser, projectContext, codebaseContext, periodContext, dailySummaries, stats string) string {
 	return fmt.Sprintf(strings.TrimSpace(worklogWeekSummaryPromptTemplate), nameOfUser, projectContext, codebaseContext, periodContext, dailySummaries, stats)
 }
@@ -117,3 +248,39 @@ func BuildWorklogMonthSummaryPrompt(nameOfUser, projectContext, codebaseContext,
 func BuildWorklogMonthSummaryPromptNonTechnical(nameOfUser, projectContext, codebaseContext, periodContext, weeklySummaries, stats string) string {
 	return fmt.Sprintf(strings.TrimSpace(worklogMonthSummaryNonTechnicalPromptTemplate), nameOfUser, projectContext, codebaseContext, periodContext, weeklySummaries, stats)
 }
+
+// WorklogTemplates lists the worklog presets accepted by
+// BuildWorklogTemplateUpdatesPrompt and BuildWorklogTemplateSummaryPrompt.
+var WorklogTemplates = []string{"standup", "review", "changelog"}
+
+var worklogTemplateUpdatesPrompts = map[string]*string{
+	"standup":   &worklogTemplateStandupUpdatesPromptTemplate,
+	"review":    &worklogTemplateReviewUpdatesPromptTemplate,
+	"changelog": &worklogTemplateChangelogUpdatesPromptTemplate,
+}
+
+var worklogTemplateSummaryPrompts = map[string]*string{
+	"standup":   &worklogTemplateStandupSummaryPromptTemplate,
+	"review":    &worklogTemplateReviewSummaryPromptTemplate,
+	"changelog": &worklogTemplateChangelogSummaryPromptTemplate,
+}
+
+// BuildWorklogTemplateUpdatesPrompt builds the per-day (or per-branch-day)
+// prompt for a worklog template. It returns "" for an unknown template.
+func BuildWorklogTemplateUpdatesPrompt(template, nameOfUser, projectContext, branchContext, commits string) string {
+	tmpl, ok := worklogTemplateUpdatesPrompts[template]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(strings.TrimSpace(*tmpl), nameOfUser, projectContext, branchContext, commits)
+}
+
+// BuildWorklogTemplateSummaryPrompt builds the period or branch summary
+// prompt for a worklog template. It returns "" for an unknown template.
+func BuildWorklogTemplateSummaryPrompt(template, nameOfUser, projectContext, context, commits, stats string) string {
+	tmpl, ok := worklogTemplateSummaryPrompts[template]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(strings.TrimSpace(*tmpl), nameOfUser, projectContext, context, commits, stats)
+}
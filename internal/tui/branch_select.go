@@ -62,6 +62,7 @@ var (
 type BranchSelection struct {
 	MainBranch       string
 	SelectedBranches []string
+	BaseBranches     map[string]string // branch -> base, for branches not cut from main
 	Canceled         bool
 }
 
@@ -71,6 +72,7 @@ type BranchSelectModel struct {
 	filtered        []int // indices into branches that match filter
 	cursor          int   // cursor in filtered list
 	selected        map[int]bool
+	bases           map[int]int // branch index -> base branch index, when not main
 	mainBranchIdx   int
 	detectedDefault string
 
@@ -129,6 +131,7 @@ func NewBranchSelectModel(branches []git.BranchInfo, detectedDefault string) Bra
 		filtered:        filtered,
 		cursor:          cursorPos,
 		selected:        make(map[int]bool),
+		bases:           make(map[int]int),
 		mainBranchIdx:   -1,
 		detectedDefault: detectedDefault,
 		step:            0,
@@ -148,6 +151,7 @@ type keyMap struct {
 	All    key.Binding
 	None   key.Binding
 	Search key.Binding
+	Base   key.Binding
 }
 
 var keys = keyMap{
@@ -183,6 +187,10 @@ var keys = keyMap{
 		key.WithKeys("/"),
 		key.WithHelp("/", "search"),
 	),
+	Base: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "cycle base branch"),
+	),
 }
 
 func (m BranchSelectModel) Init() tea.Cmd {
@@ -213,6 +221,37 @@ func (m *BranchSelectModel) filterBranches() {
 	m.viewportStart = 0
 }
 
+// cycleBase moves a branch's base to the next selected branch, in list
+// order, wrapping back to the main branch. Stacked branches use this to
+// point at the branch they were cut from.
+func (m *BranchSelectModel) cycleBase(idx int) {
+	if idx == m.mainBranchIdx {
+		return
+	}
+	candidates := []int{m.mainBranchIdx}
+	for i := range m.branches {
+		if i != idx && i != m.mainBranchIdx && m.selected[i] {
+			candidates = append(candidates, i)
+		}
+	}
+	current, ok := m.bases[idx]
+	if !ok {
+		current = m.mainBranchIdx
+	}
+	next := candidates[0]
+	for i, c := range candidates {
+		if c == current && i+1 < len(candidates) {
+			next = candidates[i+1]
+			break
+		}
+	}
+	if next == m.mainBranchIdx {
+		delete(m.bases, idx)
+	} else {
+		m.bases[idx] = next
+	}
+}
+
 func (m *BranchSelectModel) ensureCursorVisible() {
 	if m.cursor < m.viewportStart {
 		m.viewportStart = m.cursor
@@ -316,9 +355,13 @@ func (m BranchSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.done = true
 				m.result.MainBranch = m.branches[m.mainBranchIdx].Name
 				m.result.SelectedBranches = []string{m.result.MainBranch}
+				m.result.BaseBranches = make(map[string]string)
 				for i, b := range m.branches {
 					if m.selected[i] && i != m.mainBranchIdx {
 						m.result.SelectedBranches = append(m.result.SelectedBranches, b.Name)
+						if base, ok := m.bases[i]; ok {
+							m.result.BaseBranches[b.Name] = m.branches[base].Name
+						}
 					}
 				}
 				return m, tea.Quit
@@ -331,6 +374,11 @@ func (m BranchSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		case key.Matches(msg, keys.Base):
+			if m.step == 1 && len(m.filtered) > 0 {
+				m.cycleBase(m.filtered[m.cursor])
+			}
+
 		case key.Matches(msg, keys.None):
 			if m.step == 1 {
 				for i := range m.branches {
@@ -433,6 +481,8 @@ func (m BranchSelectModel) View() string {
 				name := branch.Name
 				if branchIdx == m.mainBranchIdx {
 					name = bsMainBranchStyle.Render(name + " (main)")
+				} else if base, ok := m.bases[branchIdx]; ok {
+					name += bsUncheckedStyle.Render(" (base: " + m.branches[base].Name + ")")
 				}
 
 				line := fmt.Sprintf("%s%s %s", cursor, checkbox, name)
@@ -459,7 +509,7 @@ func (m BranchSelectModel) View() string {
 	} else if m.step == 0 {
 		b.WriteString(bsHelpStyle.Render("↑/↓: navigate • /: search • enter/space: select • q: cancel"))
 	} else {
-		b.WriteString(bsHelpStyle.Render("↑/↓: navigate • /: search • space: toggle • a: all • n: none • enter: confirm"))
+		b.WriteString(bsHelpStyle.Render("↑/↓: navigate • /: search • space: toggle • a: all • n: none • b: base branch • enter: confirm"))
 	}
 
 	return b.String()
@@ -495,8 +545,8 @@ func RunBranchSelection(branches []git.BranchInfo, detectedDefault string) (*Bra
 }
 
 // RunBranchSelectionWithPreselected runs the TUI with pre-selected branches for modification
-func RunBranchSelectionWithPreselected(branches []git.BranchInfo, mainBranch string, selectedBranches []string) (*BranchSelection, error) {
-	model := NewBranchSelectModelWithPreselected(branches, mainBranch, selectedBranches)
+func RunBranchSelectionWithPreselected(branches []git.BranchInfo, mainBranch string, selectedBranches []string, baseBranches map[string]string) (*BranchSelection, error) {
+	model := NewBranchSelectModelWithPreselected(branches, mainBranch, selectedBranches, baseBranches)
 
 	// Don't use alternate screen - stay in same terminal
 	p := tea.NewProgram(model)
@@ -514,7 +564,7 @@ func RunBranchSelectionWithPreselected(branches []git.BranchInfo, mainBranch str
 }
 
 // NewBranchSelectModelWithPreselected creates a model with pre-selected branches (for modify mode)
-func NewBranchSelectModelWithPreselected(branches []git.BranchInfo, mainBranch string, selectedBranches []string) BranchSelectModel {
+func NewBranchSelectModelWithPreselected(branches []git.BranchInfo, mainBranch string, selectedBranches []string, baseBranches map[string]string) BranchSelectModel {
 	// Find the main branch index
 	mainIdx := 0
 	for i, b := range branches {
@@ -536,11 +586,21 @@ func NewBranchSelectModelWithPreselected(branches []git.BranchInfo, mainBranch s
 	for _, b := range selectedBranches {
 		selectedSet[b] = true
 	}
+	indexByName := make(map[string]int, len(branches))
 	for i, b := range branches {
+		indexByName[b.Name] = i
 		if selectedSet[b.Name] {
 			selectedMap[i] = true
 		}
 	}
+	bases := make(map[int]int)
+	for branch, base := range baseBranches {
+		i, ok := indexByName[branch]
+		baseIdx, baseOK := indexByName[base]
+		if ok && baseOK && baseIdx != mainIdx {
+			bases[i] = baseIdx
+		}
+	}
 
 	// Search input
 	ti := textinput.New()
@@ -553,6 +613,7 @@ func NewBranchSelectModelWithPreselected(branches []git.BranchInfo, mainBranch s
 		filtered:        filtered,
 		cursor:          0,
 		selected:        selectedMap,
+		bases:           bases,
 		mainBranchIdx:   mainIdx,
 		detectedDefault: mainBranch,
 		step:            1, // Start at step 1 (modify mode) - skip main branch selection
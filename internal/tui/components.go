@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/ishaan812/devlog/internal/auth"
+	"github.com/ishaan812/devlog/internal/config"
 	"github.com/ishaan812/devlog/internal/constants"
 )
 
@@ -107,6 +108,33 @@ func testProvider(provider, apiKey, baseURL string) tea.Cmd {
 	}
 }
 
+// azureConfigFields are the values collected, one per Enter, when configuring
+// Azure OpenAI. The API key comes last so it goes through the usual key test.
+var azureConfigFields = []struct {
+	Label       string
+	Placeholder string
+}{
+	{"endpoint", "https://my-resource.openai.azure.com"},
+	{"deployment name", "gpt-4o"},
+	{"API key", "Azure OpenAI API key"},
+}
+
+// setAzureConfigField stores the idx-th Azure OpenAI field on cfg.
+func setAzureConfigField(cfg *config.Config, idx int, value string) {
+	switch idx {
+	case 0:
+		cfg.AzureEndpoint = strings.TrimRight(value, "/")
+	case 1:
+		cfg.AzureDeployment = value
+		cfg.DefaultModel = value
+	case 2:
+		cfg.AzureOpenAIAPIKey = value
+		if cfg.AzureAPIVersion == "" {
+			cfg.AzureAPIVersion = constants.GetDefaultAzureAPIVersion()
+		}
+	}
+}
+
 // ── Helpers ────────────────────────────────────────────────────────────────
 
 // maskKey masks an API key for display (first 4 + last 4 chars).
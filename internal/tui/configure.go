@@ -50,6 +50,9 @@ type ConfigModel struct {
 	// ChatGPT OAuth login state
 	chatGPTLoggingIn bool
 	chatGPTLoginErr  string
+
+	// Azure OpenAI needs several values; index into azureConfigFields
+	azureFieldIdx int
 }
 
 type menuOption struct {
@@ -137,7 +140,7 @@ func (m ConfigModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedIdx++
 			} else if m.step == configStepTimezone && m.selectedIdx < len(getTimezoneOptions())-1 {
 				m.selectedIdx++
-			} else if m.step == configStepAPIKeys && m.selectedIdx < 7 {
+			} else if m.step == configStepAPIKeys && m.selectedIdx < 8 {
 				m.selectedIdx++
 			}
 		case "esc":
@@ -267,6 +270,18 @@ func (m ConfigModel) handleEnter() (tea.Model, tea.Cmd) {
 
 	case configStepLLMConfig:
 		value := strings.TrimSpace(m.textInput.Value())
+		if constants.Provider(m.config.DefaultProvider) == constants.ProviderAzureOpenAI && m.azureFieldIdx < len(azureConfigFields)-1 {
+			if value != "" {
+				setAzureConfigField(m.config, m.azureFieldIdx, value)
+			}
+			m.azureFieldIdx++
+			m.textInput.Reset()
+			m.textInput.Placeholder = m.azureFieldPlaceholder()
+			if m.azureFieldIdx == len(azureConfigFields)-1 {
+				m.textInput.EchoMode = textinput.EchoPassword
+			}
+			return m, nil
+		}
 		switch constants.Provider(m.config.DefaultProvider) {
 		case constants.ProviderOllama:
 			if value != "" {
@@ -303,6 +318,10 @@ func (m ConfigModel) handleEnter() (tea.Model, tea.Cmd) {
 			if value != "" {
 				m.config.AWSAccessKeyID = value
 			}
+		case constants.ProviderAzureOpenAI:
+			if value != "" {
+				setAzureConfigField(m.config, m.azureFieldIdx, value)
+			}
 		}
 
 		// Test the configuration if API key was provided
@@ -381,6 +400,8 @@ func (m ConfigModel) handleEnter() (tea.Model, tea.Cmd) {
 				m.config.AWSAccessKeyID = value
 			case 6:
 				m.config.AWSSecretAccessKey = value
+			case 7:
+				m.config.AzureOpenAIAPIKey = value
 			}
 		}
 		m.step = configStepMenu
@@ -411,7 +432,11 @@ func (m *ConfigModel) prepareStep() {
 		provider := constants.Provider(m.config.DefaultProvider)
 		setupInfo := constants.GetProviderSetupInfo(provider)
 		m.textInput.Placeholder = setupInfo.Placeholder
-		if setupInfo.NeedsAPIKey {
+		m.azureFieldIdx = 0
+		if provider == constants.ProviderAzureOpenAI {
+			m.textInput.EchoMode = textinput.EchoNormal
+			m.textInput.Placeholder = m.azureFieldPlaceholder()
+		} else if setupInfo.NeedsAPIKey {
 			m.textInput.EchoMode = textinput.EchoPassword
 			// Show masked existing key if available
 			existingKey := m.getExistingAPIKey(provider)
@@ -443,11 +468,33 @@ func (m ConfigModel) getExistingAPIKey(provider constants.Provider) string {
 		return m.config.GeminiAPIKey
 	case constants.ProviderBedrock:
 		return m.config.AWSAccessKeyID
+	case constants.ProviderAzureOpenAI:
+		return m.config.AzureOpenAIAPIKey
 	default:
 		return ""
 	}
 }
 
+// azureFieldPlaceholder shows the current value of the Azure OpenAI field being
+// edited, falling back to an example value.
+func (m ConfigModel) azureFieldPlaceholder() string {
+	var current string
+	switch m.azureFieldIdx {
+	case 0:
+		current = m.config.AzureEndpoint
+	case 1:
+		current = m.config.AzureDeployment
+	case 2:
+		if m.config.AzureOpenAIAPIKey != "" {
+			current = maskKey(m.config.AzureOpenAIAPIKey)
+		}
+	}
+	if current != "" {
+		return current
+	}
+	return azureConfigFields[m.azureFieldIdx].Placeholder
+}
+
 func (m ConfigModel) finishConfiguration() (tea.Model, tea.Cmd) {
 	// Copy the (potentially modified) global LLM fields back into the active profile.
 	// The TUI uses global Config fields as temporary storage during configuration.
@@ -564,6 +611,9 @@ func (m ConfigModel) viewLLMConfig() string {
 		} else {
 			body = successStyle.Render("  Signed in with ChatGPT!") + "\n"
 		}
+	} else if constants.Provider(m.config.DefaultProvider) == constants.ProviderAzureOpenAI {
+		field := azureConfigFields[m.azureFieldIdx]
+		body = normalStyle.Render(fmt.Sprintf("Azure OpenAI %s (%d/%d, leave empty to keep current):", field.Label, m.azureFieldIdx+1, len(azureConfigFields)))
 	} else if setupInfo.NeedsAPIKey {
 		body = normalStyle.Render(fmt.Sprintf("%s API key (leave empty to keep current):", providerName))
 		if setupInfo.APIKeyURL != "" {
@@ -701,6 +751,7 @@ func (m ConfigModel) viewAPIKeys() string {
 		{"Gemini", m.config.GeminiAPIKey},
 		{"AWS Access Key", m.config.AWSAccessKeyID},
 		{"AWS Secret Key", m.config.AWSSecretAccessKey},
+		{"Azure OpenAI", m.config.AzureOpenAIAPIKey},
 	}
 
 	for i, opt := range apiKeyOptions {
@@ -765,7 +816,8 @@ func (m ConfigModel) viewReview() string {
 	// API Keys (masked)
 	hasAPIKeys := m.config.AnthropicAPIKey != "" || m.config.OpenAIAPIKey != "" ||
 		m.config.ChatGPTAccessToken != "" ||
-		m.config.OpenRouterAPIKey != "" || m.config.GeminiAPIKey != "" || m.config.AWSAccessKeyID != ""
+		m.config.OpenRouterAPIKey != "" || m.config.GeminiAPIKey != "" || m.config.AWSAccessKeyID != "" ||
+		m.config.AzureOpenAIAPIKey != ""
 
 	if hasAPIKeys {
 		s.WriteString(successStyle.Render("API Keys:"))
@@ -794,6 +846,10 @@ func (m ConfigModel) viewReview() string {
 			s.WriteString(dimStyle.Render(fmt.Sprintf("  AWS: %s", maskKey(m.config.AWSAccessKeyID))))
 			s.WriteString("\n")
 		}
+		if m.config.AzureOpenAIAPIKey != "" {
+			s.WriteString(dimStyle.Render(fmt.Sprintf("  Azure OpenAI: %s", maskKey(m.config.AzureOpenAIAPIKey))))
+			s.WriteString("\n")
+		}
 		s.WriteString("\n")
 	}
 
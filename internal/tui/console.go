@@ -1,18 +1,24 @@
 package tui
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/ishaan812/devlog/internal/config"
 	"github.com/ishaan812/devlog/internal/db"
 )
 
@@ -27,8 +33,10 @@ type ConsoleCodebase struct {
 	Dates       []ConsoleDate
 	Weeks       []ConsoleWeek
 	Months      []ConsoleMonth
-	CommitCount int  // Total commits ingested
-	IsIngested  bool // Whether ingest has been run
+	CommitCount int    // Total commits ingested
+	IsIngested  bool   // Whether ingest has been run
+	Missing     bool   // Whether the repo path no longer exists on disk
+	RemoteURL   string // URL of the origin remote, if any
 }
 
 // ConsoleDate holds date info for the console TUI.
@@ -177,11 +185,32 @@ type DateItem struct {
 
 // ── Operation messages ─────────────────────────────────────────────────────
 
+// operationLineMsg carries one line of live subprocess output.
+type operationLineMsg string
+
+// maxOperationLogLines bounds the live output kept in memory.
+const maxOperationLogLines = 2000
+
+// waitForOperationLine reads the next live output line from a running operation.
+func waitForOperationLine(lines <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-lines
+		if !ok {
+			return nil
+		}
+		return operationLineMsg(line)
+	}
+}
+
+// clearCopyStatusMsg hides the help-bar copy notice.
+type clearCopyStatusMsg struct{}
+
 type operationCompleteMsg struct {
 	opType string
 	repoID string
 	err    error
 	output string
+	item   *DateItem // Timeline item to show again once data is reloaded
 }
 
 // ConsoleModel is the Bubbletea model for the full-screen console TUI.
@@ -209,19 +238,30 @@ type ConsoleModel struct {
 	expandedMonths map[string]bool
 	expandedWeeks  map[string]bool
 
+	// Filtering: "/" in the repos or dates pane fuzzy-filters that list.
+	filterInput textinput.Model
+	filtering   bool // typing into filterInput
+	repoFilter  string
+	dateFilter  string
+
 	// Content pane (read-only, no focus)
 	viewport      viewport.Model
 	contentReady  bool
 	selectedRepo  int
 	selectedDate  int
 	contentHeader string
+	contentMD     string // raw markdown of the displayed content, for copying
+	copyStatus    string // transient help-bar notice after a copy
 
 	// Operation state
 	operationRunning bool
 	operationType    string // "ingest", "worklog", or "ingest+worklog"
 	operationRepo    string // repo ID
 	operationError   string
-	operationOutput  string // Full output from operation
+	operationOutput  string      // Full output from operation
+	operationLines   chan string // Live output lines while the operation runs
+	operationLog     []string    // Lines received so far
+	reloadItem       *DateItem   // Timeline item to reopen after the next data reload
 
 	// State
 	quitting bool
@@ -232,6 +272,11 @@ func NewConsoleModel(codebases []ConsoleCodebase, profileName string, dbRepo *db
 	vp := viewport.New(0, 0)
 	vp.SetContent("")
 
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "filter"
+	ti.CharLimit = 50
+
 	selectedRepo := -1
 	if len(codebases) > 0 {
 		selectedRepo = 0
@@ -248,6 +293,7 @@ func NewConsoleModel(codebases []ConsoleCodebase, profileName string, dbRepo *db
 		viewport:       vp,
 		expandedMonths: make(map[string]bool),
 		expandedWeeks:  make(map[string]bool),
+		filterInput:    ti,
 	}
 }
 
@@ -275,9 +321,21 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.operationError = ""
 		}
+		m.reloadItem = msg.item
 		// Always reconnect and reload data (db was closed before operation)
 		return m, reloadConsoleDataCmd(m.profileName)
 
+	case operationLineMsg:
+		m.operationLog = append(m.operationLog, string(msg))
+		if len(m.operationLog) > maxOperationLogLines {
+			m.operationLog = m.operationLog[len(m.operationLog)-maxOperationLogLines:]
+		}
+		return m, waitForOperationLine(m.operationLines)
+
+	case clearCopyStatusMsg:
+		m.copyStatus = ""
+		return m, nil
+
 	case reloadDataMsg:
 		m.codebases = msg.codebases
 		if msg.dbRepo != nil {
@@ -290,6 +348,10 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.repoCursor < 0 {
 			m.repoCursor = 0
 		}
+		if m.reloadItem != nil {
+			m.reopenDateItem(*m.reloadItem)
+			m.reloadItem = nil
+		}
 		return m, nil
 
 	case tea.WindowSizeMsg:
@@ -306,7 +368,41 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.filtering {
+			switch msg.String() {
+			case "ctrl+c":
+				// fall through to quit below
+			case "esc":
+				m.filtering = false
+				m.filterInput.Blur()
+				m.filterInput.SetValue("")
+				m.setActiveFilter("")
+				return m, nil
+			case "enter":
+				m.filtering = false
+				m.filterInput.Blur()
+				return m, nil
+			case "up", "down":
+				// navigate the filtered list while typing
+			default:
+				var cmd tea.Cmd
+				m.filterInput, cmd = m.filterInput.Update(msg)
+				m.setActiveFilter(m.filterInput.Value())
+				return m, cmd
+			}
+		}
+
 		switch msg.String() {
+		case "/":
+			if m.activePane == paneRepos || m.activePane == paneDates {
+				m.filtering = true
+				m.filterInput.SetValue(m.activeFilter())
+				m.filterInput.CursorEnd()
+				m.filterInput.Focus()
+				return m, textinput.Blink
+			}
+			return m, nil
+
 		case "q", "ctrl+c":
 			m.quitting = true
 			// Close database connection on exit
@@ -317,6 +413,10 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "G": // Shift+G - Run ingest + worklog on selected repo
 			if m.activePane == paneRepos && m.repoCursor >= 0 && m.repoCursor < len(m.codebases) && !m.operationRunning {
 				repo := m.codebases[m.repoCursor]
+				if repo.Missing {
+					m.operationError = missingRepoMessage(repo)
+					return m, nil
+				}
 				m.operationRunning = true
 				m.operationType = "ingest+worklog"
 				m.operationRepo = repo.ID
@@ -325,9 +425,11 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Capture current dbRepo and nil it out (persists via returned m)
 				oldDB := m.dbRepo
 				m.dbRepo = nil
+				lines := m.startOperationLog()
 				return m, tea.Batch(
 					tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg { return tickMsg(t) }),
-					runIngestAndWorklogCmd(repo, oldDB, m.profileName),
+					waitForOperationLine(lines),
+					runIngestAndWorklogCmd(repo, oldDB, m.profileName, lines),
 				)
 			}
 			return m, nil
@@ -335,6 +437,10 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "I": // Shift+I - Run ingest on selected repo
 			if m.activePane == paneRepos && m.repoCursor >= 0 && m.repoCursor < len(m.codebases) && !m.operationRunning {
 				repo := m.codebases[m.repoCursor]
+				if repo.Missing {
+					m.operationError = missingRepoMessage(repo)
+					return m, nil
+				}
 				m.operationRunning = true
 				m.operationType = "ingest"
 				m.operationRepo = repo.ID
@@ -343,9 +449,11 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Capture current dbRepo and nil it out (persists via returned m)
 				oldDB := m.dbRepo
 				m.dbRepo = nil
+				lines := m.startOperationLog()
 				return m, tea.Batch(
 					tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg { return tickMsg(t) }),
-					runIngestCmd(repo, oldDB, m.profileName),
+					waitForOperationLine(lines),
+					runIngestCmd(repo, oldDB, m.profileName, lines),
 				)
 			}
 			return m, nil
@@ -353,6 +461,10 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "W": // Shift+W - Generate worklog on selected repo
 			if m.activePane == paneRepos && m.repoCursor >= 0 && m.repoCursor < len(m.codebases) && !m.operationRunning {
 				repo := m.codebases[m.repoCursor]
+				if repo.Missing {
+					m.operationError = missingRepoMessage(repo)
+					return m, nil
+				}
 				m.operationRunning = true
 				m.operationType = "worklog"
 				m.operationRepo = repo.ID
@@ -361,9 +473,66 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Capture current dbRepo and nil it out (persists via returned m)
 				oldDB := m.dbRepo
 				m.dbRepo = nil
+				lines := m.startOperationLog()
+				return m, tea.Batch(
+					tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg { return tickMsg(t) }),
+					waitForOperationLine(lines),
+					runWorklogCmd(repo, oldDB, m.profileName, lines),
+				)
+			}
+			return m, nil
+
+		case "R": // Shift+R - Regenerate worklog for the selected day/week/month only
+			if m.activePane == paneDates && m.selectedRepo >= 0 && m.selectedRepo < len(m.codebases) &&
+				m.dateCursor >= 0 && m.dateCursor < len(m.dateItems) && !m.operationRunning {
+				repo := m.codebases[m.selectedRepo]
+				since, until := dateItemRange(m.dateItems[m.dateCursor])
+				if repo.Missing {
+					m.operationError = missingRepoMessage(repo)
+					return m, nil
+				}
+				m.operationRunning = true
+				m.operationType = "worklog"
+				m.operationRepo = repo.ID
+				m.operationError = ""
+				m.operationOutput = ""
+				oldDB := m.dbRepo
+				m.dbRepo = nil
+				lines := m.startOperationLog()
+				return m, tea.Batch(
+					tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg { return tickMsg(t) }),
+					waitForOperationLine(lines),
+					runWorklogCmd(repo, oldDB, m.profileName, lines,
+						"--since", since.Format("2006-01-02"), "--until", until.Format("2006-01-02"), "--no-cache"),
+				)
+			}
+			return m, nil
+
+		case "r": // Regenerate the weekly/monthly summary shown in the content pane
+			if m.activePane == paneContent && m.contentReady && !m.operationRunning &&
+				m.selectedRepo >= 0 && m.selectedRepo < len(m.codebases) &&
+				m.selectedDate >= 0 && m.selectedDate < len(m.dateItems) {
+				item := m.dateItems[m.selectedDate]
+				if item.Type != "week" && item.Type != "month" {
+					return m, nil
+				}
+				repo := m.codebases[m.selectedRepo]
+				if repo.Missing {
+					m.operationError = missingRepoMessage(repo)
+					return m, nil
+				}
+				m.operationRunning = true
+				m.operationType = "worklog"
+				m.operationRepo = repo.ID
+				m.operationError = ""
+				m.operationOutput = ""
+				oldDB := m.dbRepo
+				m.dbRepo = nil
+				lines := m.startOperationLog()
 				return m, tea.Batch(
 					tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg { return tickMsg(t) }),
-					runWorklogCmd(repo, oldDB, m.profileName),
+					waitForOperationLine(lines),
+					runRollupRefreshCmd(repo, oldDB, m.profileName, item, lines),
 				)
 			}
 			return m, nil
@@ -419,15 +588,9 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "up", "k":
 			switch m.activePane {
 			case paneRepos:
-				if m.repoCursor > 0 {
-					m.repoCursor--
-					m.ensureRepoVisible()
-				}
+				m.moveRepoCursor(-1)
 			case paneDates:
-				if m.dateCursor > 0 {
-					m.dateCursor--
-					m.ensureDateVisible()
-				}
+				m.moveDateCursor(-1)
 			case paneContent:
 				var cmd tea.Cmd
 				m.viewport.LineUp(1)
@@ -438,18 +601,12 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "down", "j":
 			switch m.activePane {
 			case paneRepos:
-				if m.repoCursor < len(m.codebases)-1 {
-					m.repoCursor++
-					m.ensureRepoVisible()
-				}
+				m.moveRepoCursor(1)
 			case paneDates:
 				if len(m.dateItems) == 0 {
 					m.dateItems = m.buildDateHierarchy()
 				}
-				if m.dateCursor < len(m.dateItems)-1 {
-					m.dateCursor++
-					m.ensureDateVisible()
-				}
+				m.moveDateCursor(1)
 			case paneContent:
 				var cmd tea.Cmd
 				m.viewport.LineDown(1)
@@ -464,6 +621,7 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.selectedRepo = m.repoCursor
 					m.dateCursor = 0
 					m.dateScroll = 0
+					m.dateFilter = ""
 					m.selectedDate = -1
 					m.contentReady = false
 					m.viewport.SetContent("")
@@ -511,6 +669,10 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "esc":
+			if m.activeFilter() != "" {
+				m.setActiveFilter("")
+				return m, nil
+			}
 			switch m.activePane {
 			case paneDates:
 				if m.dateCursor >= 0 && m.dateCursor < len(m.dateItems) {
@@ -555,6 +717,17 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case "y":
+			if m.activePane == paneContent && m.contentReady && m.contentMD != "" {
+				if err := clipboard.WriteAll(m.contentMD); err != nil {
+					m.copyStatus = "copy failed"
+				} else {
+					m.copyStatus = "copied"
+				}
+				return m, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return clearCopyStatusMsg{} })
+			}
+			return m, nil
+
 		// ── Content scrolling (works from any pane) ────────────────
 
 		case "pgup":
@@ -590,15 +763,16 @@ func (m ConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // runIngestCmd creates a command that closes the db, runs ingest, and returns the result.
 // Uses standalone function (not pointer receiver) to avoid Bubbletea value-receiver issues.
-func runIngestCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, profileName string) tea.Cmd {
+func runIngestCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, profileName string, lines chan<- string) tea.Cmd {
 	repoPath := repo.Path
 	repoID := repo.ID
 
 	return func() tea.Msg {
+		defer close(lines)
 		// Close read-only connection so subprocess can get write lock
 		closeReadOnlyConnection(currentDB)
 
-		output, err := executeIngest(repoPath, profileName)
+		output, err := executeIngest(repoPath, profileName, lines)
 
 		return operationCompleteMsg{
 			opType: "ingest",
@@ -610,15 +784,17 @@ func runIngestCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, profileName
 }
 
 // runWorklogCmd creates a command that closes the db, runs worklog, and returns the result.
-func runWorklogCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, profileName string) tea.Cmd {
+// Extra args are passed through to 'devlog worklog'.
+func runWorklogCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, profileName string, lines chan<- string, args ...string) tea.Cmd {
 	repoPath := repo.Path
 	repoID := repo.ID
 
 	return func() tea.Msg {
+		defer close(lines)
 		// Close read-only connection so subprocess can get write lock
 		closeReadOnlyConnection(currentDB)
 
-		output, err := executeWorklog(repoPath)
+		output, err := executeWorklog(repoPath, lines, args...)
 
 		return operationCompleteMsg{
 			opType: "worklog",
@@ -629,17 +805,33 @@ func runWorklogCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, profileNam
 	}
 }
 
+// runRollupRefreshCmd creates a command that regenerates the weekly or
+// monthly summary for item, reusing the cached days in its range, and asks
+// for item to be shown again afterwards.
+func runRollupRefreshCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, profileName string, item DateItem, lines chan<- string) tea.Cmd {
+	since, until := dateItemRange(item)
+	reload := runWorklogCmd(repo, currentDB, profileName, lines,
+		"--since", since.Format("2006-01-02"), "--until", until.Format("2006-01-02"), "--refresh-rollups")
+
+	return func() tea.Msg {
+		msg := reload().(operationCompleteMsg)
+		msg.item = &item
+		return msg
+	}
+}
+
 // runIngestAndWorklogCmd creates a command that runs ingest then worklog sequentially.
-func runIngestAndWorklogCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, profileName string) tea.Cmd {
+func runIngestAndWorklogCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, profileName string, lines chan<- string) tea.Cmd {
 	repoPath := repo.Path
 	repoID := repo.ID
 
 	return func() tea.Msg {
+		defer close(lines)
 		// Close read-only connection so subprocess can get write lock
 		closeReadOnlyConnection(currentDB)
 
 		// Run ingest first
-		ingestOutput, err := executeIngest(repoPath, profileName)
+		ingestOutput, err := executeIngest(repoPath, profileName, lines)
 		if err != nil {
 			return operationCompleteMsg{
 				opType: "ingest+worklog",
@@ -650,7 +842,7 @@ func runIngestAndWorklogCmd(repo ConsoleCodebase, currentDB *db.SQLRepository, p
 		}
 
 		// Run worklog after successful ingest
-		worklogOutput, err := executeWorklog(repoPath)
+		worklogOutput, err := executeWorklog(repoPath, lines)
 
 		// Combine outputs
 		combinedOutput := fmt.Sprintf("=== Ingest ===\n%s\n\n=== Worklog ===\n%s", ingestOutput, worklogOutput)
@@ -676,110 +868,139 @@ func reloadConsoleDataCmd(profileName string) tea.Cmd {
 			return reloadDataMsg{} // empty; will be handled gracefully
 		}
 
-		// Reload codebases
-		codebases, err := newRepo.GetAllCodebases(ctx)
+		newCodebases, err := LoadConsoleCodebases(ctx, newRepo, profileName)
 		if err != nil {
 			return reloadDataMsg{dbRepo: newRepo}
 		}
 
-		// Rebuild console data
-		var newCodebases []ConsoleCodebase
-		for _, cb := range codebases {
-			dates, err := newRepo.ListWorklogDates(ctx, cb.ID, profileName)
-			if err != nil {
-				continue
-			}
+		// Return message with both new data AND the new db connection
+		return reloadDataMsg{codebases: newCodebases, dbRepo: newRepo}
+	}
+}
 
-			tuiDates := make([]ConsoleDate, len(dates))
-			for i, d := range dates {
-				tuiDates[i] = ConsoleDate{
-					EntryDate:   d.EntryDate,
-					EntryCount:  d.EntryCount,
-					CommitCount: d.CommitCount,
-					Additions:   d.Additions,
-					Deletions:   d.Deletions,
-				}
-			}
+// IsRepoPathMissing reports whether a codebase's path no longer exists on disk.
+func IsRepoPathMissing(path string) bool {
+	_, err := os.Stat(path)
+	return os.IsNotExist(err)
+}
 
-			// Load weeks
-			weeks, err := newRepo.ListWorklogWeeks(ctx, cb.ID, profileName)
-			if err != nil {
-				weeks = nil
+// missingRepoMessage explains why operations are disabled for a repo whose
+// directory was moved or deleted.
+func missingRepoMessage(repo ConsoleCodebase) string {
+	return fmt.Sprintf("%s no longer exists at %s.\n\nIf it moved, run 'devlog ingest <new path>'. To forget it, run 'devlog repos remove %s --prune'.", repo.Name, repo.Path, repo.Path)
+}
+
+// LoadConsoleCodebases loads every codebase with its cached worklog dates,
+// weeks and months, as shown in the console.
+func LoadConsoleCodebases(ctx context.Context, dbRepo *db.SQLRepository, profileName string) ([]ConsoleCodebase, error) {
+	codebases, err := dbRepo.GetAllCodebases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Weeks follow the profile's week_start, as worklog's weekly summaries do.
+	weekStart := time.Sunday
+	if cfg, err := config.Load(); err == nil {
+		weekStart = cfg.GetWeekStart()
+	}
+
+	// Rebuild console data
+	var newCodebases []ConsoleCodebase
+	for _, cb := range codebases {
+		dates, err := dbRepo.ListWorklogDates(ctx, cb.ID, profileName)
+		if err != nil {
+			continue
+		}
+
+		tuiDates := make([]ConsoleDate, len(dates))
+		for i, d := range dates {
+			tuiDates[i] = ConsoleDate{
+				EntryDate:   d.EntryDate,
+				EntryCount:  d.EntryCount,
+				CommitCount: d.CommitCount,
+				Additions:   d.Additions,
+				Deletions:   d.Deletions,
 			}
+		}
 
-			tuiWeeks := make([]ConsoleWeek, len(weeks))
-			for i, w := range weeks {
-				// Find dates that belong to this week
-				var weekDates []ConsoleDate
-				for _, d := range tuiDates {
-					if !d.EntryDate.Before(w.WeekStart) && !d.EntryDate.After(w.WeekEnd) {
-						weekDates = append(weekDates, d)
-					}
-				}
+		// Load weeks
+		weeks, err := dbRepo.ListWorklogWeeks(ctx, cb.ID, profileName, weekStart)
+		if err != nil {
+			weeks = nil
+		}
 
-				tuiWeeks[i] = ConsoleWeek{
-					WeekStart:   w.WeekStart,
-					WeekEnd:     w.WeekEnd,
-					DateCount:   w.DateCount,
-					EntryCount:  w.EntryCount,
-					CommitCount: w.CommitCount,
-					Additions:   w.Additions,
-					Deletions:   w.Deletions,
-					Dates:       weekDates,
+		tuiWeeks := make([]ConsoleWeek, len(weeks))
+		for i, w := range weeks {
+			// Find dates that belong to this week
+			var weekDates []ConsoleDate
+			for _, d := range tuiDates {
+				if !d.EntryDate.Before(w.WeekStart) && !d.EntryDate.After(w.WeekEnd) {
+					weekDates = append(weekDates, d)
 				}
 			}
 
-			// Load months
-			months, err := newRepo.ListWorklogMonths(ctx, cb.ID, profileName)
-			if err != nil {
-				months = nil
+			tuiWeeks[i] = ConsoleWeek{
+				WeekStart:   w.WeekStart,
+				WeekEnd:     w.WeekEnd,
+				DateCount:   w.DateCount,
+				EntryCount:  w.EntryCount,
+				CommitCount: w.CommitCount,
+				Additions:   w.Additions,
+				Deletions:   w.Deletions,
+				Dates:       weekDates,
 			}
+		}
 
-			tuiMonths := make([]ConsoleMonth, len(months))
-			for i, m := range months {
-				// Find weeks that belong to this month
-				var monthWeeks []ConsoleWeek
-				for _, w := range tuiWeeks {
-					if !w.WeekStart.Before(m.MonthStart) && !w.WeekStart.After(m.MonthEnd) {
-						monthWeeks = append(monthWeeks, w)
-					}
-				}
+		// Load months
+		months, err := dbRepo.ListWorklogMonths(ctx, cb.ID, profileName, weekStart)
+		if err != nil {
+			months = nil
+		}
 
-				tuiMonths[i] = ConsoleMonth{
-					MonthStart:  m.MonthStart,
-					MonthEnd:    m.MonthEnd,
-					DateCount:   m.DateCount,
-					WeekCount:   m.WeekCount,
-					EntryCount:  m.EntryCount,
-					CommitCount: m.CommitCount,
-					Additions:   m.Additions,
-					Deletions:   m.Deletions,
-					Weeks:       monthWeeks,
+		tuiMonths := make([]ConsoleMonth, len(months))
+		for i, m := range months {
+			// Find weeks that belong to this month
+			var monthWeeks []ConsoleWeek
+			for _, w := range tuiWeeks {
+				if !w.WeekStart.Before(m.MonthStart) && !w.WeekStart.After(m.MonthEnd) {
+					monthWeeks = append(monthWeeks, w)
 				}
 			}
 
-			// Check if repository has been ingested
-			commitCount, err := newRepo.GetCommitCount(ctx, cb.ID)
-			if err != nil {
-				commitCount = 0
+			tuiMonths[i] = ConsoleMonth{
+				MonthStart:  m.MonthStart,
+				MonthEnd:    m.MonthEnd,
+				DateCount:   m.DateCount,
+				WeekCount:   m.WeekCount,
+				EntryCount:  m.EntryCount,
+				CommitCount: m.CommitCount,
+				Additions:   m.Additions,
+				Deletions:   m.Deletions,
+				Weeks:       monthWeeks,
 			}
+		}
 
-			newCodebases = append(newCodebases, ConsoleCodebase{
-				ID:          cb.ID,
-				Name:        cb.Name,
-				Path:        cb.Path,
-				DateCount:   len(dates),
-				Dates:       tuiDates,
-				Weeks:       tuiWeeks,
-				Months:      tuiMonths,
-				CommitCount: int(commitCount),
-				IsIngested:  commitCount > 0,
-			})
+		// Check if repository has been ingested
+		commitCount, err := dbRepo.GetCommitCount(ctx, cb.ID)
+		if err != nil {
+			commitCount = 0
 		}
 
-		// Return message with both new data AND the new db connection
-		return reloadDataMsg{codebases: newCodebases, dbRepo: newRepo}
-	}
+		newCodebases = append(newCodebases, ConsoleCodebase{
+			ID:          cb.ID,
+			Name:        cb.Name,
+			Path:        cb.Path,
+			DateCount:   len(dates),
+			Dates:       tuiDates,
+			Weeks:       tuiWeeks,
+			Months:      tuiMonths,
+			CommitCount: int(commitCount),
+			IsIngested:  commitCount > 0,
+			Missing:     IsRepoPathMissing(cb.Path),
+			RemoteURL:   cb.RemoteURL,
+		})
+	}
+	return newCodebases, nil
 }
 
 type reloadDataMsg struct {
@@ -789,6 +1010,40 @@ type reloadDataMsg struct {
 
 // ── Helpers ────────────────────────────────────────────────────────────────
 
+// startOperationLog resets the live output and returns the channel the
+// operation should stream its lines into.
+func (m *ConsoleModel) startOperationLog() chan string {
+	lines := make(chan string, 256)
+	m.operationLines = lines
+	m.operationLog = nil
+	return lines
+}
+
+// dateItemRange returns the first and last day covered by a timeline item.
+func dateItemRange(item DateItem) (time.Time, time.Time) {
+	switch item.Type {
+	case "month":
+		return item.Date, item.Date.AddDate(0, 1, -1)
+	case "week":
+		return item.Date, item.Date.AddDate(0, 0, 6)
+	default:
+		return item.Date, item.Date
+	}
+}
+
+// reopenDateItem rebuilds the timeline after a data reload and shows item
+// in the content pane again, if it is still listed.
+func (m *ConsoleModel) reopenDateItem(item DateItem) {
+	m.dateItems = m.buildDateHierarchy()
+	for i, di := range m.dateItems {
+		if di.Type == item.Type && di.Date.Equal(item.Date) {
+			m.selectedDate = i
+			m.loadContent()
+			return
+		}
+	}
+}
+
 func (m *ConsoleModel) currentDates() []ConsoleDate {
 	if m.selectedRepo >= 0 && m.selectedRepo < len(m.codebases) {
 		return m.codebases[m.selectedRepo].Dates
@@ -928,24 +1183,144 @@ func (m *ConsoleModel) updateViewportSize() {
 	}
 }
 
+// Scroll offsets are positions in the filtered list, not raw indices.
 func (m *ConsoleModel) ensureRepoVisible() {
 	maxVis := m.maxVisibleRepos()
-	if m.repoCursor < m.repoScroll {
-		m.repoScroll = m.repoCursor
+	pos := indexPosition(m.visibleRepoIndices(), m.repoCursor)
+	if pos < 0 {
+		return
+	}
+	if pos < m.repoScroll {
+		m.repoScroll = pos
 	}
-	if m.repoCursor >= m.repoScroll+maxVis {
-		m.repoScroll = m.repoCursor - maxVis + 1
+	if pos >= m.repoScroll+maxVis {
+		m.repoScroll = pos - maxVis + 1
 	}
 }
 
 func (m *ConsoleModel) ensureDateVisible() {
 	maxVis := m.maxVisibleDates()
-	if m.dateCursor < m.dateScroll {
-		m.dateScroll = m.dateCursor
+	pos := indexPosition(m.visibleDateIndices(), m.dateCursor)
+	if pos < 0 {
+		// The cursor item was filtered out (e.g. after collapsing); snap to a match.
+		if visible := m.visibleDateIndices(); len(visible) > 0 {
+			m.dateCursor = visible[0]
+			pos = 0
+		} else {
+			return
+		}
+	}
+	if pos < m.dateScroll {
+		m.dateScroll = pos
+	}
+	if pos >= m.dateScroll+maxVis {
+		m.dateScroll = pos - maxVis + 1
+	}
+}
+
+// fuzzyMatch reports whether the runes of query appear in text in order,
+// ignoring case.
+func fuzzyMatch(query, text string) bool {
+	text = strings.ToLower(text)
+	for _, r := range strings.ToLower(query) {
+		i := strings.IndexRune(text, r)
+		if i < 0 {
+			return false
+		}
+		text = text[i+len(string(r)):]
+	}
+	return true
+}
+
+// indexPosition returns the position of idx in indices, or -1.
+func indexPosition(indices []int, idx int) int {
+	for pos, i := range indices {
+		if i == idx {
+			return pos
+		}
+	}
+	return -1
+}
+
+// visibleRepoIndices returns the indices of repos matching the repo filter.
+func (m ConsoleModel) visibleRepoIndices() []int {
+	indices := make([]int, 0, len(m.codebases))
+	for i, cb := range m.codebases {
+		if m.repoFilter == "" || fuzzyMatch(m.repoFilter, cb.Name) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// visibleDateIndices returns the indices of timeline items matching the date filter.
+func (m ConsoleModel) visibleDateIndices() []int {
+	indices := make([]int, 0, len(m.dateItems))
+	for i, item := range m.dateItems {
+		if m.dateFilter == "" || fuzzyMatch(m.dateFilter, item.DisplayText) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func (m *ConsoleModel) moveRepoCursor(delta int) {
+	visible := m.visibleRepoIndices()
+	pos := indexPosition(visible, m.repoCursor) + delta
+	if pos >= 0 && pos < len(visible) {
+		m.repoCursor = visible[pos]
+		m.ensureRepoVisible()
+	}
+}
+
+func (m *ConsoleModel) moveDateCursor(delta int) {
+	visible := m.visibleDateIndices()
+	pos := indexPosition(visible, m.dateCursor) + delta
+	if pos >= 0 && pos < len(visible) {
+		m.dateCursor = visible[pos]
+		m.ensureDateVisible()
+	}
+}
+
+// activeFilter returns the filter for the focused list pane.
+func (m ConsoleModel) activeFilter() string {
+	if m.activePane == paneDates {
+		return m.dateFilter
 	}
-	if m.dateCursor >= m.dateScroll+maxVis {
-		m.dateScroll = m.dateCursor - maxVis + 1
+	return m.repoFilter
+}
+
+// setActiveFilter updates the focused pane's filter and moves the cursor
+// onto the first match if the current item no longer matches.
+func (m *ConsoleModel) setActiveFilter(query string) {
+	if m.activePane == paneDates {
+		m.dateFilter = query
+		m.dateScroll = 0
+		visible := m.visibleDateIndices()
+		if indexPosition(visible, m.dateCursor) < 0 && len(visible) > 0 {
+			m.dateCursor = visible[0]
+		}
+		m.ensureDateVisible()
+		return
 	}
+	m.repoFilter = query
+	m.repoScroll = 0
+	visible := m.visibleRepoIndices()
+	if indexPosition(visible, m.repoCursor) < 0 && len(visible) > 0 {
+		m.repoCursor = visible[0]
+	}
+	m.ensureRepoVisible()
+}
+
+// renderFilterLine shows the filter input or the applied filter for a pane.
+func (m ConsoleModel) renderFilterLine(pane int, filter string) string {
+	if m.filtering && m.activePane == pane {
+		return " " + m.filterInput.View() + "\n"
+	}
+	if filter != "" {
+		return consoleDimStyle.Render(fmt.Sprintf(" filter: %s (esc to clear)", filter)) + "\n"
+	}
+	return ""
 }
 
 func (m *ConsoleModel) maxVisibleRepos() int {
@@ -994,6 +1369,7 @@ func (m *ConsoleModel) loadContent() {
 		summary, err := m.dbRepo.GetMonthlySummary(ctx, cb.ID, m.profileName, item.Date)
 		if err != nil || summary == nil {
 			m.contentReady = true
+			m.contentMD = ""
 			m.contentHeader = item.Date.Format("January 2006") + " - Monthly Summary"
 			m.viewport.SetContent(consoleEmptyStyle.Render("No monthly summary available.\nGenerate worklogs to create summaries."))
 			return
@@ -1006,6 +1382,7 @@ func (m *ConsoleModel) loadContent() {
 		summary, err := m.dbRepo.GetWeeklySummary(ctx, cb.ID, m.profileName, item.Date)
 		if err != nil || summary == nil {
 			m.contentReady = true
+			m.contentMD = ""
 			m.contentHeader = fmt.Sprintf("Week of %s - Weekly Summary", item.Date.Format("Jan 2"))
 			m.viewport.SetContent(consoleEmptyStyle.Render("No weekly summary available.\nGenerate worklogs spanning >7 days to create weekly summaries."))
 			return
@@ -1019,11 +1396,12 @@ func (m *ConsoleModel) loadContent() {
 		entries, err := m.dbRepo.ListWorklogEntriesByDate(ctx, cb.ID, m.profileName, item.Date)
 		if err != nil || len(entries) == 0 {
 			m.contentReady = true
+			m.contentMD = ""
 			m.contentHeader = item.Date.Format("Monday, January 2, 2006")
 			m.viewport.SetContent(consoleEmptyStyle.Render("No worklog entries for this date.\nRun 'devlog worklog' to generate worklogs."))
 			return
 		}
-		md = renderDayMarkdown(entries, item.Date)
+		md = RenderDayMarkdown(entries, item.Date)
 		header = fmt.Sprintf("%s  -  %s", item.Date.Format("Monday, January 2, 2006"), cb.Name)
 
 	default:
@@ -1050,11 +1428,13 @@ func (m *ConsoleModel) loadContent() {
 
 	m.contentReady = true
 	m.contentHeader = header
+	m.contentMD = md
 	m.viewport.SetContent(rendered)
 	m.viewport.GotoTop()
 }
 
-func renderDayMarkdown(entries []db.WorklogEntry, date time.Time) string {
+// RenderDayMarkdown combines a day's cached entries into one markdown document.
+func RenderDayMarkdown(entries []db.WorklogEntry, date time.Time) string {
 	var md strings.Builder
 	md.WriteString(fmt.Sprintf("# %s\n\n", date.Format("Monday, January 2, 2006")))
 	for _, e := range entries {
@@ -1156,11 +1536,19 @@ func (m ConsoleModel) renderReposSection(width int) string {
 		return b.String()
 	}
 
+	b.WriteString(m.renderFilterLine(paneRepos, m.repoFilter))
+	visible := m.visibleRepoIndices()
+	if len(visible) == 0 {
+		b.WriteString(consoleDimStyle.Render(" No repos match the filter"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
 	maxVis := m.maxVisibleRepos()
 	start := m.repoScroll
 	end := start + maxVis
-	if end > len(m.codebases) {
-		end = len(m.codebases)
+	if end > len(visible) {
+		end = len(visible)
 	}
 
 	if start > 0 {
@@ -1168,7 +1556,7 @@ func (m ConsoleModel) renderReposSection(width int) string {
 		b.WriteString("\n")
 	}
 
-	for i := start; i < end; i++ {
+	for _, i := range visible[start:end] {
 		cb := m.codebases[i]
 		isSelected := i == m.selectedRepo
 		isCursor := i == m.repoCursor && m.activePane == paneRepos
@@ -1197,7 +1585,9 @@ func (m ConsoleModel) renderReposSection(width int) string {
 		}
 
 		badge := ""
-		if cb.DateCount > 0 {
+		if cb.Missing {
+			badge = consoleDimStyle.Render(" missing")
+		} else if cb.DateCount > 0 {
 			badge = consoleStatStyle.Render(fmt.Sprintf(" %dd", cb.DateCount))
 		}
 
@@ -1214,7 +1604,7 @@ func (m ConsoleModel) renderReposSection(width int) string {
 		b.WriteString("\n")
 	}
 
-	if end < len(m.codebases) {
+	if end < len(visible) {
 		b.WriteString(consoleDimStyle.Render(" ↓ more"))
 		b.WriteString("\n")
 	}
@@ -1254,11 +1644,19 @@ func (m ConsoleModel) renderDatesSection(width int) string {
 		return b.String()
 	}
 
+	b.WriteString(m.renderFilterLine(paneDates, m.dateFilter))
+	visible := m.visibleDateIndices()
+	if len(visible) == 0 {
+		b.WriteString(consoleDimStyle.Render(" No dates match the filter"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
 	maxVis := m.maxVisibleDates()
 	start := m.dateScroll
 	end := start + maxVis
-	if end > len(items) {
-		end = len(items)
+	if end > len(visible) {
+		end = len(visible)
 	}
 
 	if start > 0 {
@@ -1266,7 +1664,7 @@ func (m ConsoleModel) renderDatesSection(width int) string {
 		b.WriteString("\n")
 	}
 
-	for i := start; i < end; i++ {
+	for _, i := range visible[start:end] {
 		item := items[i]
 		isSelected := i == m.selectedDate
 		isCursor := i == m.dateCursor && m.activePane == paneDates
@@ -1324,7 +1722,7 @@ func (m ConsoleModel) renderDatesSection(width int) string {
 		b.WriteString("\n")
 	}
 
-	if end < len(items) {
+	if end < len(visible) {
 		b.WriteString(consoleDimStyle.Render(" ↓ more"))
 		b.WriteString("\n")
 	}
@@ -1550,9 +1948,9 @@ func wrapOutput(text string, maxWidth int) []string {
 func (m ConsoleModel) renderOperationStatus(width, height int) string {
 	var b strings.Builder
 
-	// Center vertically
+	// Center vertically until live output starts arriving
 	topPad := (height - 10) / 2
-	if topPad < 2 {
+	if topPad < 2 || len(m.operationLog) > 0 {
 		topPad = 2
 	}
 
@@ -1605,14 +2003,33 @@ func (m ConsoleModel) renderOperationStatus(width, height int) string {
 		}
 	}
 
-	b.WriteString("\n\n")
-	hint := "Please wait, this may take a few moments..."
-	hintPad := (width - len(hint)) / 2
-	if hintPad < 0 {
-		hintPad = 0
+	b.WriteString("\n")
+	if len(m.operationLog) == 0 {
+		b.WriteString("\n")
+		hint := "Please wait, this may take a few moments..."
+		hintPad := (width - len(hint)) / 2
+		if hintPad < 0 {
+			hintPad = 0
+		}
+		b.WriteString(strings.Repeat(" ", hintPad))
+		b.WriteString(consoleDimStyle.Italic(true).Render(hint))
+		return b.String()
+	}
+
+	// Live output, auto-scrolled to the latest lines
+	outputLines := wrapOutput(strings.Join(m.operationLog, "\n"), width-4)
+	maxLines := height - strings.Count(b.String(), "\n") - 1
+	if maxLines < 3 {
+		maxLines = 3
+	}
+	if len(outputLines) > maxLines {
+		outputLines = outputLines[len(outputLines)-maxLines:]
+	}
+	for _, line := range outputLines {
+		b.WriteString("  ")
+		b.WriteString(consoleDimStyle.Render(line))
+		b.WriteString("\n")
 	}
-	b.WriteString(strings.Repeat(" ", hintPad))
-	b.WriteString(consoleDimStyle.Italic(true).Render(hint))
 
 	return b.String()
 }
@@ -1734,8 +2151,15 @@ func (m ConsoleModel) renderHelpBar() string {
 	sep := helpSepStyle.Render(" ")
 
 	var items []string
-	switch m.activePane {
-	case paneRepos:
+	switch {
+	case m.filtering:
+		items = []string{
+			helpItem("type", "filter"),
+			helpItem("↑↓", "navigate"),
+			helpItem("enter", "apply"),
+			helpItem("esc", "clear"),
+		}
+	case m.activePane == paneRepos:
 		if m.operationRunning {
 			items = []string{
 				helpItem("...", "operation in progress"),
@@ -1745,6 +2169,7 @@ func (m ConsoleModel) renderHelpBar() string {
 			items = []string{
 				helpItem("↑↓", "navigate"),
 				helpItem("enter", "select"),
+				helpItem("/", "filter"),
 				helpItem("Shift+G", "ingest+log"),
 				helpItem("Shift+I", "ingest"),
 				helpItem("Shift+W", "worklog"),
@@ -1753,25 +2178,36 @@ func (m ConsoleModel) renderHelpBar() string {
 				helpItem("q", "quit"),
 			}
 		}
-	case paneDates:
+	case m.activePane == paneDates:
 		items = []string{
 			helpItem("↑↓", "navigate"),
 			helpItem("enter", "view/expand"),
+			helpItem("/", "filter"),
+			helpItem("Shift+R", "regenerate"),
 			helpItem("esc", "up"),
 			helpItem("tab", "repos"),
 			helpItem("→", "content"),
 			helpItem("pgup/dn", "scroll"),
 			helpItem("q", "quit"),
 		}
-	case paneContent:
+	case m.activePane == paneContent:
 		items = []string{
 			helpItem("↑↓", "scroll"),
 			helpItem("pgup/dn", "page"),
+			helpItem("y", "copy"),
+			helpItem("r", "regenerate week/month"),
 			helpItem("←", "back"),
 			helpItem("tab", "panels"),
 			helpItem("q", "quit"),
 		}
 	}
+	if m.copyStatus != "" {
+		icon := "✓"
+		if m.copyStatus != "copied" {
+			icon = "!"
+		}
+		items = append([]string{helpItem(icon, m.copyStatus)}, items...)
+	}
 
 	bar := strings.Join(items, sep)
 	barWidth := lipgloss.Width(bar)
@@ -1797,6 +2233,59 @@ func RunConsole(codebases []ConsoleCodebase, profileName string, dbRepo *db.SQLR
 
 // ── External command executors ─────────────────────────────────────────────
 
+var ansiEscapeRE = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// runStreaming runs cmd, sending each stdout/stderr line to lines as it is
+// produced, and returns the combined output once the command exits.
+func runStreaming(cmd *exec.Cmd, lines chan<- string) (string, error) {
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	var output strings.Builder
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		scanner.Split(scanOutputLines)
+		for scanner.Scan() {
+			line := ansiEscapeRE.ReplaceAllString(scanner.Text(), "")
+			output.WriteString(line)
+			output.WriteString("\n")
+			if strings.TrimSpace(line) != "" {
+				lines <- line
+			}
+		}
+		// Drain anything left so the subprocess never blocks on a full pipe.
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+
+	if err := cmd.Start(); err != nil {
+		pw.Close()
+		<-done
+		return "", err
+	}
+	err := cmd.Wait()
+	pw.Close()
+	<-done
+	return output.String(), err
+}
+
+// scanOutputLines splits on \n or \r so spinner and progress-bar redraws
+// show up as separate lines.
+func scanOutputLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	for i, b := range data {
+		if b == '\n' || b == '\r' {
+			return i + 1, data[:i], nil
+		}
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
 // closeReadOnlyConnection safely closes a read-only database connection
 func closeReadOnlyConnection(repo *db.SQLRepository) {
 	if repo != nil {
@@ -1805,7 +2294,7 @@ func closeReadOnlyConnection(repo *db.SQLRepository) {
 }
 
 // executeIngest runs the ingest command on a repository
-func executeIngest(repoPath, profileName string) (string, error) {
+func executeIngest(repoPath, profileName string, lines chan<- string) (string, error) {
 	// Get the path to the current devlog executable
 	devlogPath, err := os.Executable()
 	if err != nil {
@@ -1816,9 +2305,7 @@ func executeIngest(repoPath, profileName string) (string, error) {
 	cmd := exec.Command(devlogPath, "ingest", repoPath, "--all-branches", "--skip-worklog")
 	cmd.Dir = repoPath
 
-	// Capture output
-	output, err := cmd.CombinedOutput()
-	outputStr := string(output)
+	outputStr, err := runStreaming(cmd, lines)
 
 	if err != nil {
 		return outputStr, fmt.Errorf("ingest failed: %w", err)
@@ -1827,8 +2314,8 @@ func executeIngest(repoPath, profileName string) (string, error) {
 	return outputStr, nil
 }
 
-// executeWorklog runs the worklog command on a repository
-func executeWorklog(repoPath string) (string, error) {
+// executeWorklog runs the worklog command on a repository with optional extra args
+func executeWorklog(repoPath string, lines chan<- string, args ...string) (string, error) {
 	// Get the path to the current devlog executable
 	devlogPath, err := os.Executable()
 	if err != nil {
@@ -1836,12 +2323,10 @@ func executeWorklog(repoPath string) (string, error) {
 	}
 
 	// Run devlog worklog
-	cmd := exec.Command(devlogPath, "worklog")
+	cmd := exec.Command(devlogPath, append([]string{"worklog"}, args...)...)
 	cmd.Dir = repoPath
 
-	// Capture output
-	output, err := cmd.CombinedOutput()
-	outputStr := string(output)
+	outputStr, err := runStreaming(cmd, lines)
 
 	if err != nil {
 		return outputStr, fmt.Errorf("worklog failed: %w", err)
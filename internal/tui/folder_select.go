@@ -446,9 +446,45 @@ func (m FolderSelectModel) Done() bool {
 	return m.done
 }
 
+// preselect marks the given folders (and their subtrees) as selected and
+// expands their ancestors so the existing selection is visible.
+func (m *FolderSelectModel) preselect(paths []string) {
+	for _, path := range paths {
+		path = filepath.Clean(strings.TrimSpace(path))
+		node := findFolderNode(m.root, path)
+		if node == nil {
+			continue
+		}
+		m.setSubtreeSelected(node, true)
+		for p := node.Parent; p != nil; p = p.Parent {
+			m.expanded[p.Path] = true
+		}
+	}
+	m.rebuildVisible()
+}
+
+func findFolderNode(n *folderNode, path string) *folderNode {
+	if n.Path == path {
+		return n
+	}
+	for _, c := range n.Children {
+		if found := findFolderNode(c, path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
 // RunFolderSelection runs the interactive folder selection TUI
 func RunFolderSelection(folders []FolderInfo) (*FolderSelection, error) {
+	return RunFolderSelectionWithSelected(folders, nil)
+}
+
+// RunFolderSelectionWithSelected runs the folder selection TUI with the given
+// folders already selected, for editing a saved selection.
+func RunFolderSelectionWithSelected(folders []FolderInfo, selected []string) (*FolderSelection, error) {
 	model := NewFolderSelectModel(folders)
+	model.preselect(selected)
 	p := tea.NewProgram(model)
 	finalModel, err := p.Run()
 	if err != nil {
@@ -57,6 +57,9 @@ type Model struct {
 	// ChatGPT OAuth login state
 	chatGPTLoggingIn bool   // OAuth flow is in progress
 	chatGPTLoginErr  string // Error from the OAuth flow
+
+	// Azure OpenAI needs several values; index into azureConfigFields
+	azureFieldIdx int
 }
 
 // NewModel creates a new onboarding model
@@ -301,6 +304,22 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		value := strings.TrimSpace(m.textInput.Value())
+		if m.config.DefaultProvider == "azure" && m.azureFieldIdx < len(azureConfigFields)-1 {
+			if value == "" {
+				m.testResult = fmt.Sprintf("Azure OpenAI %s is required", azureConfigFields[m.azureFieldIdx].Label)
+				m.testSuccess = false
+				return m, nil
+			}
+			setAzureConfigField(m.config, m.azureFieldIdx, value)
+			m.azureFieldIdx++
+			m.textInput.Reset()
+			m.textInput.Placeholder = azureConfigFields[m.azureFieldIdx].Placeholder
+			m.testResult = ""
+			if m.azureFieldIdx == len(azureConfigFields)-1 {
+				m.textInput.EchoMode = textinput.EchoPassword
+			}
+			return m, nil
+		}
 		switch m.config.DefaultProvider {
 		case "ollama":
 			if value != "" {
@@ -318,6 +337,8 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			m.config.GeminiAPIKey = value
 		case "bedrock":
 			m.config.AWSAccessKeyID = value
+		case "azure":
+			setAzureConfigField(m.config, m.azureFieldIdx, value)
 		}
 		// Test the configuration
 		m.testing = true
@@ -385,7 +406,11 @@ func (m *Model) prepareStep() {
 	case stepProviderConfig:
 		setupInfo := constants.GetProviderSetupInfo(constants.Provider(m.config.DefaultProvider))
 		m.textInput.Placeholder = setupInfo.Placeholder
-		if setupInfo.NeedsAPIKey {
+		m.azureFieldIdx = 0
+		if constants.Provider(m.config.DefaultProvider) == constants.ProviderAzureOpenAI {
+			m.textInput.Placeholder = azureConfigFields[0].Placeholder
+			m.textInput.EchoMode = textinput.EchoNormal
+		} else if setupInfo.NeedsAPIKey {
 			m.textInput.EchoMode = textinput.EchoPassword
 		}
 	case stepGitHubUsername:
@@ -620,6 +645,10 @@ func (m Model) viewProviderConfig() string {
 		} else {
 			body = successStyle.Render("  Signed in with ChatGPT!") + "\n"
 		}
+	} else if constants.Provider(m.config.DefaultProvider) == constants.ProviderAzureOpenAI {
+		field := azureConfigFields[m.azureFieldIdx]
+		body = normalStyle.Render(fmt.Sprintf("Enter your Azure OpenAI %s (%d/%d):", field.Label, m.azureFieldIdx+1, len(azureConfigFields)))
+		body += "\n" + dimStyle.Render(setupInfo.SetupHint)
 	} else if setupInfo.NeedsAPIKey {
 		body = normalStyle.Render(fmt.Sprintf("Enter your %s API key:", providerName))
 		if setupInfo.APIKeyURL != "" {